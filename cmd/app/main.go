@@ -13,12 +13,19 @@ import (
 	"dealls-technical-test-dating-service/internal/infrastructure/auth"
 	"dealls-technical-test-dating-service/internal/infrastructure/config"
 	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/imaging"
 	"dealls-technical-test-dating-service/internal/infrastructure/log"
+	"dealls-technical-test-dating-service/internal/infrastructure/mail"
+	"dealls-technical-test-dating-service/internal/infrastructure/password"
+	"dealls-technical-test-dating-service/internal/infrastructure/payment"
 	"dealls-technical-test-dating-service/internal/infrastructure/repository"
 	"dealls-technical-test-dating-service/internal/infrastructure/server"
+	"dealls-technical-test-dating-service/internal/infrastructure/sms"
+	"dealls-technical-test-dating-service/internal/infrastructure/storage"
 	"dealls-technical-test-dating-service/internal/interface/controller"
+	"dealls-technical-test-dating-service/internal/interface/filter"
+	"dealls-technical-test-dating-service/internal/interface/response"
 	"dealls-technical-test-dating-service/internal/interface/routes"
-	"dealls-technical-test-dating-service/pkg/util"
 
 	"github.com/sirupsen/logrus"
 )
@@ -55,6 +62,10 @@ func main() {
 	if server == nil {
 		logrus.Fatal("Failed to initialize server")
 	}
+	server.Container.Filter(filter.RequestID)
+	server.Container.Filter(filter.NewClientIPFilter(cfg.TrustedClientIPHeader))
+	server.Container.Filter(filter.UserAgent)
+	server.Container.ServiceErrorHandler(response.WriteServiceError)
 
 	userRepo := repository.NewUserRepository(postgres.Client)
 	userService := service.NewUserService(userRepo)
@@ -62,10 +73,112 @@ func main() {
 	profileRepo := repository.NewProfileRepository(postgres.Client)
 	profileService := service.NewProfileService(profileRepo)
 
-	jwt := auth.NewJWTClaims(cfg.JWTExpiration)
-	userUsecase := usecase.NewUserUsecase(userService, profileService, cfg, jwt, util.HashPassword, util.IsValidPasswordHash)
+	refreshTokenRepo := repository.NewRefreshTokenRepository(postgres.Client)
+	refreshTokenService := service.NewRefreshTokenService(refreshTokenRepo)
+
+	tokenRevocationRepo := repository.NewCachedTokenRevocationRepository(repository.NewTokenRevocationRepository(postgres.Client), cfg.TokenRevocationCacheTTL)
+	tokenRevocationService := service.NewTokenRevocationService(tokenRevocationRepo)
+
+	userTokenRepo := repository.NewUserTokenRepository(postgres.Client)
+	userTokenService := service.NewUserTokenService(userTokenRepo)
+
+	loginThrottleRepo := repository.NewLoginThrottleRepository(postgres.Client)
+	loginLockoutRepo := repository.NewLoginLockoutRepository(postgres.Client)
+	loginThrottleService := service.NewLoginThrottleService(loginThrottleRepo, loginLockoutRepo, cfg.GetLoginAccountThrottlePolicy(), cfg.GetLoginIPThrottlePolicy())
+	twoFactorRepo := repository.NewTwoFactorRepository(postgres.Client)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(postgres.Client)
+	twoFactorService := service.NewTwoFactorService(twoFactorRepo, recoveryCodeRepo)
+
+	sessionRepo := repository.NewSessionRepository(postgres.Client)
+	sessionService := service.NewSessionService(sessionRepo)
+
+	phoneOTPRepo := repository.NewPhoneOTPRepository(postgres.Client)
+	phoneOTPService := service.NewPhoneOTPService(phoneOTPRepo, cfg.GetPhoneOTPPolicy())
+
+	userIdentityRepo := repository.NewUserIdentityRepository(postgres.Client)
+	userIdentityService := service.NewUserIdentityService(userIdentityRepo)
+
+	unitOfWork := database.NewUnitOfWork(postgres.Client)
+
+	mailer, err := mail.NewMailer(cfg)
+	if err != nil {
+		logrus.Fatalf("Failed to initialize mailer: %s", err.Error())
+	}
+
+	smsSender, err := sms.NewSMSSender(cfg)
+	if err != nil {
+		logrus.Fatalf("Failed to initialize SMS sender: %s", err.Error())
+	}
+
+	blobStore, err := storage.NewLocalBlobStore(cfg.BlobStoreDir, cfg.BlobBaseURL, cfg.BlobURLSigningKey, cfg.BlobAllowEphemeralKey)
+	if err != nil {
+		logrus.Fatalf("Failed to initialize blob store: %s", err.Error())
+	}
+
+	photoService := service.NewPhotoService(blobStore, imaging.NewProcessor(), cfg.GetPhotoPolicy())
+
+	keySet, err := auth.LoadKeySet(cfg.JWTSigningKeyFile, cfg.JWTVerificationKeyFiles, cfg.JWTAllowEphemeralKey)
+	if err != nil {
+		logrus.Fatalf("Failed to load JWT keys: %s", err.Error())
+	}
+
+	jwt := auth.NewJWTClaims(cfg.JWTExpiration, keySet)
+	passwordHasher := password.NewPasswordHasher(cfg)
+	userUsecase := usecase.NewUserUsecase(
+		userService, profileService, refreshTokenService, tokenRevocationService, userTokenService,
+		loginThrottleService, twoFactorService, sessionService, phoneOTPService, userIdentityService,
+		photoService, unitOfWork, cfg, jwt, mailer, smsSender, passwordHasher, auth.NewOIDCProviders(cfg),
+	)
 	userController := controller.NewUserController(userUsecase)
-	routes.RegisterUserRoutes(server.Container, cfg.BasePath, userController)
+	keyUsecase := usecase.NewKeyUsecase(jwt)
+	keyController := controller.NewKeyController(keyUsecase)
+	routes.RegisterKeyRoutes(server.Container, keyController)
+	blobUsecase := usecase.NewBlobUsecase(blobStore, blobStore)
+	blobController := controller.NewBlobController(blobUsecase)
+	routes.RegisterBlobRoutes(server.Container, cfg.BasePath, blobController)
+
+	authFilter := filter.NewAuthFilter(jwt, userService, tokenRevocationService, sessionService)
+	routes.RegisterUserRoutes(server.Container, cfg.BasePath, authFilter.Authenticate, userController)
+
+	entitlementRepo := repository.NewEntitlementRepository(postgres.Client)
+	entitlementService := service.NewEntitlementService(entitlementRepo)
+
+	activityRepo := repository.NewActivityRepository(postgres.Client)
+	activityService := service.NewActivityService(activityRepo)
+	activityCounterRepo := repository.NewActivityCounterRepository(postgres.Client)
+	activityCounterService := service.NewActivityCounterService(activityCounterRepo)
+	swipeUsecase := usecase.NewSwipeUsecase(profileService, activityService, activityCounterService, entitlementService, unitOfWork, cfg)
+	swipeController := controller.NewSwipeController(swipeUsecase)
+	routes.RegisterSwipeRoutes(server.Container, cfg.BasePath, authFilter.Authenticate, swipeController)
+
+	discoveryUsecase := usecase.NewDiscoveryUsecase(profileService, entitlementService, photoService, cfg)
+	profileUsecase := usecase.NewProfileUsecase(userService, profileService, entitlementService, photoService, unitOfWork)
+	profileController := controller.NewProfileController(discoveryUsecase, profileUsecase)
+	routes.RegisterProfileRoutes(server.Container, cfg.BasePath, authFilter.Authenticate, profileController)
+
+	matchRepo := repository.NewMatchRepository(postgres.Client)
+	matchService := service.NewMatchService(matchRepo)
+	matchUsecase := usecase.NewMatchUsecase(matchService, photoService)
+	matchController := controller.NewMatchController(matchUsecase)
+	routes.RegisterMatchRoutes(server.Container, cfg.BasePath, authFilter.Authenticate, matchController)
+
+	subscriptionPackageRepo := repository.NewSubscriptionPackageRepository(postgres.Client)
+	subscriptionPackageService := service.NewSubscriptionPackageService(subscriptionPackageRepo)
+
+	subscriptionRepo := repository.NewSubscriptionRepository(postgres.Client)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo)
+
+	paymentGateway := payment.NewFakePaymentGateway()
+	subscriptionUsecase := usecase.NewSubscriptionUsecase(subscriptionPackageService, subscriptionService, paymentGateway)
+	subscriptionController := controller.NewSubscriptionController(subscriptionUsecase)
+	routes.RegisterSubscriptionRoutes(server.Container, cfg.BasePath, authFilter.Authenticate, subscriptionController)
+
+	roleRepo := repository.NewRoleRepository(postgres.Client)
+	roleService := service.NewRoleService(roleRepo)
+	authorizationFilter := filter.NewAuthorizationFilter(roleService)
+	adminUsecase := usecase.NewAdminUsecase(userService, photoService)
+	adminController := controller.NewAdminController(adminUsecase)
+	routes.RegisterAdminRoutes(server.Container, cfg.BasePath, authFilter.Authenticate, authorizationFilter.Require, adminController)
 
 	defer func() {
 		r := recover()
@@ -0,0 +1,121 @@
+// Package apperror contains the typed errors raised by the domain, independent of the transport and the database.
+package apperror
+
+import "strings"
+
+// Kind is a type that represents the category of a domain error.
+type Kind string
+
+// Error kinds.
+const (
+	KindValidation      Kind = "VALIDATION"
+	KindUnauthorized    Kind = "UNAUTHORIZED"
+	KindForbidden       Kind = "FORBIDDEN"
+	KindNotFound        Kind = "NOT_FOUND"
+	KindConflict        Kind = "CONFLICT"
+	KindQuotaExceeded   Kind = "QUOTA_EXCEEDED"
+	KindPaymentRequired Kind = "PAYMENT_REQUIRED"
+)
+
+// Sentinel errors used with errors.Is to match any error of the same kind.
+var (
+	ErrValidation      = &Error{Kind: KindValidation}
+	ErrUnauthorized    = &Error{Kind: KindUnauthorized}
+	ErrForbidden       = &Error{Kind: KindForbidden}
+	ErrNotFound        = &Error{Kind: KindNotFound}
+	ErrConflict        = &Error{Kind: KindConflict}
+	ErrQuotaExceeded   = &Error{Kind: KindQuotaExceeded}
+	ErrPaymentRequired = &Error{Kind: KindPaymentRequired}
+)
+
+// FieldError is a struct that represents an invalid field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// FieldErrors is a type that collects every invalid field of a request.
+type FieldErrors []FieldError
+
+// Add is a method for adding an invalid field.
+func (f *FieldErrors) Add(field, message string) {
+	*f = append(*f, Field(field, message))
+}
+
+// Err is a method for getting a validation error with every collected field, or nil when no field is invalid.
+func (f FieldErrors) Err(message string) error {
+	if len(f) == 0 {
+		return nil
+	}
+
+	return Validation(message, f...)
+}
+
+// Error is a struct that represents a typed domain error.
+type Error struct {
+	Kind    Kind
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// New is a function used to initialize a domain error of the kind.
+func New(kind Kind, message string) *Error {
+	return &Error{
+		Kind:    kind,
+		Message: message,
+	}
+}
+
+// Wrap is a function used to initialize a domain error of the kind caused by another error.
+func Wrap(kind Kind, message string, err error) *Error {
+	return &Error{
+		Kind:    kind,
+		Message: message,
+		Err:     err,
+	}
+}
+
+// Validation is a function used to initialize a validation error with the invalid fields.
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{
+		Kind:    KindValidation,
+		Message: message,
+		Fields:  fields,
+	}
+}
+
+// Field is a function used to initialize the field error struct.
+func Field(field, message string) FieldError {
+	return FieldError{
+		Field:   field,
+		Message: message,
+	}
+}
+
+// Error is a method for getting the error message followed by the messages of the invalid fields.
+// The cause is left out so that errors of the database or other dependencies never reach the clients.
+func (e *Error) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+
+	messages := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		messages[i] = field.Message
+	}
+
+	return e.Message + ": " + strings.Join(messages, ", ")
+}
+
+// Unwrap is a method for getting the cause of the error.
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is is a method for matching the error against a sentinel error of the same kind.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+
+	return ok && t.Message == "" && t.Kind == e.Kind
+}
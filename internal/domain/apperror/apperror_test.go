@@ -0,0 +1,91 @@
+package apperror_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+)
+
+func TestError_Error(t *testing.T) {
+	cause := errors.New("cause")
+	tests := []struct {
+		name string
+		err  *apperror.Error
+		want string
+	}{
+		{
+			name: "Message only",
+			err:  apperror.New(apperror.KindNotFound, "User not found"),
+			want: "User not found",
+		},
+		{
+			name: "With fields",
+			err:  apperror.Validation("Invalid request body", apperror.Field("email", "email is required"), apperror.Field("name", "name is required")),
+			want: "Invalid request body: email is required, name is required",
+		},
+		{
+			name: "With cause",
+			err:  apperror.Wrap(apperror.KindValidation, "Invalid request body", cause),
+			want: "Invalid request body",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.err.Error(); got != tt.want {
+				t.Errorf("Error.Error() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestError_Is(t *testing.T) {
+	cause := errors.New("cause")
+	err := fmt.Errorf("wrapped: %w", apperror.Wrap(apperror.KindNotFound, "User not found", cause))
+	tests := []struct {
+		name   string
+		target error
+		want   bool
+	}{
+		{
+			name:   "Same kind",
+			target: apperror.ErrNotFound,
+			want:   true,
+		},
+		{
+			name:   "Other kind",
+			target: apperror.ErrConflict,
+			want:   false,
+		},
+		{
+			name:   "Cause",
+			target: cause,
+			want:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := errors.Is(err, tt.target); got != tt.want {
+				t.Errorf("errors.Is() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFieldErrors_Err(t *testing.T) {
+	fields := apperror.FieldErrors{}
+	if err := fields.Err("Invalid request body"); err != nil {
+		t.Fatalf("FieldErrors.Err() = %v, want nil", err)
+	}
+
+	fields.Add("email", "email is required")
+	fields.Add("name", "name is required")
+	err := fields.Err("Invalid request body")
+	if !errors.Is(err, apperror.ErrValidation) {
+		t.Fatalf("FieldErrors.Err() = %v, want a validation error", err)
+	}
+	if err.Error() != "Invalid request body: email is required, name is required" {
+		t.Errorf("FieldErrors.Err() = %v", err)
+	}
+}
@@ -1,7 +1,17 @@
 // Package domain contains domain-specific logic and entities.
 package domain
 
+import (
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
 // Auth is an interface that represents the authentication functionality needed by the domain.
 type Auth interface {
-	GenerateToken(email, key string) (string, error)
+	GenerateToken(userID int, email, role string) (*entity.TokenClaims, string, error)
+	ValidateToken(token string) (*entity.TokenClaims, error)
+	GenerateActionToken(purpose string, userID int, email string, expiration time.Duration) (*entity.ActionTokenClaims, string, error)
+	ValidateActionToken(purpose, token string) (*entity.ActionTokenClaims, error)
+	JWKS() *entity.JSONWebKeySet
 }
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// BlobStore is an interface that represents the file storage needed by the domain, such as for photos.
+// SignedURL returns a URL that the file can be downloaded from without other credentials until the expiry time.
+type BlobStore interface {
+	Put(ctx context.Context, key, contentType string, data []byte) error
+	Get(ctx context.Context, key string) ([]byte, error)
+	Delete(ctx context.Context, key string) error
+	SignedURL(ctx context.Context, key string, expiresAt time.Time) (string, error)
+}
+
+// SignedURLVerifier is an interface that represents the checking of the URLs signed by a blob store whose files the service serves itself.
+type SignedURLVerifier interface {
+	VerifySignedURL(key string, expiresAt time.Time, signature string) bool
+}
@@ -1,6 +1,24 @@
 package domain
 
+import "time"
+
 // Config is an interface that represents the configuration requirements of the domain.
 type Config interface {
-	GetJWTKey() string
+	GetRefreshTokenExpiration() time.Duration
+	GetDailySwipeLimit() int
+	GetSwipeResetLocation() *time.Location
+	GetEmailVerificationTokenExpiration() time.Duration
+	GetEmailVerificationResendInterval() time.Duration
+	GetEmailVerificationURL() string
+	GetPasswordResetTokenExpiration() time.Duration
+	GetPasswordResetRequestInterval() time.Duration
+	GetPasswordResetURL() string
+	GetEmailChangeTokenExpiration() time.Duration
+	GetEmailChangeURL() string
+	GetTwoFactorIssuer() string
+	GetTwoFactorChallengeExpiration() time.Duration
+	GetOIDCNonceExpiration() time.Duration
+	GetOIDCNonceMaxPerIP() int
+	IsVerifiedEmailRequiredForLogin() bool
+	IsVerifiedEmailRequiredForDiscovery() bool
 }
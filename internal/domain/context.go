@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+type contextKey string
+
+const (
+	userContextKey        contextKey = "user"
+	tokenClaimsContextKey contextKey = "token_claims"
+	requestIDContextKey   contextKey = "request_id"
+	clientIPContextKey    contextKey = "client_ip"
+	userAgentContextKey   contextKey = "user_agent"
+)
+
+// WithUser is a function used to store the authenticated user in the context.
+func WithUser(ctx context.Context, user *entity.User) context.Context {
+	return context.WithValue(ctx, userContextKey, user)
+}
+
+// UserFromContext is a function used to get the authenticated user from the context.
+func UserFromContext(ctx context.Context) (*entity.User, bool) {
+	user, ok := ctx.Value(userContextKey).(*entity.User)
+
+	return user, ok && user != nil
+}
+
+// WithTokenClaims is a function used to store the claims of the access token of the authenticated user in the context.
+func WithTokenClaims(ctx context.Context, claims *entity.TokenClaims) context.Context {
+	return context.WithValue(ctx, tokenClaimsContextKey, claims)
+}
+
+// TokenClaimsFromContext is a function used to get the claims of the access token of the authenticated user from the context.
+func TokenClaimsFromContext(ctx context.Context) (*entity.TokenClaims, bool) {
+	claims, ok := ctx.Value(tokenClaimsContextKey).(*entity.TokenClaims)
+
+	return claims, ok && claims != nil
+}
+
+// WithRequestID is a function used to store the ID of the current request in the context.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDContextKey, requestID)
+}
+
+// RequestIDFromContext is a function used to get the ID of the current request from the context.
+func RequestIDFromContext(ctx context.Context) string {
+	requestID, _ := ctx.Value(requestIDContextKey).(string)
+
+	return requestID
+}
+
+// WithClientIP is a function used to store the IP address of the client of the current request in the context.
+func WithClientIP(ctx context.Context, clientIP string) context.Context {
+	return context.WithValue(ctx, clientIPContextKey, clientIP)
+}
+
+// ClientIPFromContext is a function used to get the IP address of the client of the current request from the context, which is empty when unknown.
+func ClientIPFromContext(ctx context.Context) string {
+	clientIP, _ := ctx.Value(clientIPContextKey).(string)
+
+	return clientIP
+}
+
+// WithUserAgent is a function used to store the User-Agent header of the current request in the context.
+func WithUserAgent(ctx context.Context, userAgent string) context.Context {
+	return context.WithValue(ctx, userAgentContextKey, userAgent)
+}
+
+// UserAgentFromContext is a function used to get the User-Agent header of the current request from the context, which is empty when unknown.
+func UserAgentFromContext(ctx context.Context) string {
+	userAgent, _ := ctx.Value(userAgentContextKey).(string)
+
+	return userAgent
+}
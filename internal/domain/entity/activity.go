@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// Activity actions.
+const (
+	ActionLike = "LIKE"
+	ActionPass = "PASS"
+)
+
+var actions = []string{ActionLike, ActionPass}
+
+// Activity is a struct that represents activity attributes.
+// ActivityDate is the day the swipe counts for, on which a user can swipe on a profile only once.
+type Activity struct {
+	ID           int       `json:"id"`
+	UserID       int       `json:"user_id"`
+	ProfileID    int       `json:"profile_id"`
+	Action       string    `json:"action"`
+	ActivityDate time.Time `json:"-"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// NewActivity is a function used to initialize the activity struct.
+func NewActivity(userID, profileID int, action string, activityDate, createdAt time.Time) *Activity {
+	return &Activity{
+		UserID:       userID,
+		ProfileID:    profileID,
+		Action:       action,
+		ActivityDate: activityDate,
+		CreatedAt:    createdAt,
+	}
+}
+
+// SwipeRequest is a struct that represents swipe request body.
+type SwipeRequest struct {
+	ProfileID int    `json:"profile_id"`
+	Action    string `json:"action"`
+}
+
+// Validate is a method for validating the attributes in the swipe request body.
+func (s *SwipeRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if s.ProfileID <= 0 {
+		fields.Add("profile_id", "profile_id is required")
+	}
+
+	if s.Action == "" || !slices.Contains(actions, strings.ToUpper(s.Action)) {
+		fields.Add("action", "action must be \"LIKE\" or \"PASS\"")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// SwipeResponse is a struct that represents swipe response body.
+// RemainingSwipes is null when the user has no daily swipe quota, and Match is null unless the swipe formed a match.
+type SwipeResponse struct {
+	Activity        *Activity `json:"activity"`
+	RemainingSwipes *int      `json:"remaining_swipes"`
+	Matched         bool      `json:"matched"`
+	Match           *Match    `json:"match"`
+}
+
+// NewSwipeResponse is a function used to initialize the swipe response struct.
+func NewSwipeResponse(activity *Activity, remainingSwipes *int, match *Match) *SwipeResponse {
+	return &SwipeResponse{
+		Activity:        activity,
+		RemainingSwipes: remainingSwipes,
+		Matched:         match != nil,
+		Match:           match,
+	}
+}
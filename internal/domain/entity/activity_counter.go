@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// ActivityCounter is a struct that represents activity counter attributes.
+type ActivityCounter struct {
+	ID        int       `json:"id"`
+	UserID    int       `json:"user_id"`
+	Count     int       `json:"count"`
+	Date      time.Time `json:"date"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
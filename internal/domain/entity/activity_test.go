@@ -0,0 +1,62 @@
+package entity_test
+
+import (
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestSwipeRequest_Validate(t *testing.T) {
+	type fields struct {
+		profileID int
+		action    string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "Failed: Empty profile ID",
+			fields: fields{
+				profileID: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Empty action",
+			fields: fields{
+				profileID: 1,
+				action:    "",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Invalid action value",
+			fields: fields{
+				profileID: 1,
+				action:    "INVALID",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				profileID: 1,
+				action:    "like",
+			},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &entity.SwipeRequest{
+				ProfileID: test.fields.profileID,
+				Action:    test.fields.action,
+			}
+			if err := req.Validate(); (err != nil) != test.wantErr {
+				t.Errorf("SwipeRequest.Validate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
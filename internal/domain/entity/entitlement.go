@@ -0,0 +1,14 @@
+package entity
+
+// Entitlement features.
+const (
+	FeatureNoSwipeQuota  = "NO_SWIPE_QUOTA"
+	FeatureVerifiedLabel = "VERIFIED_LABEL"
+)
+
+// Entitlement is a struct that represents the attributes of a feature unlocked by a subscription package.
+type Entitlement struct {
+	ID                    int    `json:"id"`
+	SubscriptionPackageID int    `json:"subscription_package_id"`
+	Feature               string `json:"feature"`
+}
@@ -0,0 +1,18 @@
+package entity
+
+// JSONWebKey is a struct that represents the public part of a key used to sign access tokens, as defined by RFC 7517.
+type JSONWebKey struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+}
+
+// JSONWebKeySet is a struct that represents JSON web key set response body.
+type JSONWebKeySet struct {
+	Keys []*JSONWebKey `json:"keys"`
+}
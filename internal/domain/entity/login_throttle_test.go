@@ -0,0 +1,59 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestLoginThrottlePolicy_Lockout(t *testing.T) {
+	policy := entity.NewLoginThrottlePolicy(5, time.Minute, 10*time.Minute, time.Hour)
+	tests := []struct {
+		name           string
+		failedAttempts int
+		want           time.Duration
+	}{
+		{
+			name:           "Below the maximum",
+			failedAttempts: 4,
+			want:           0,
+		},
+		{
+			name:           "At the maximum",
+			failedAttempts: 5,
+			want:           time.Minute,
+		},
+		{
+			name:           "Past the maximum",
+			failedAttempts: 7,
+			want:           4 * time.Minute,
+		},
+		{
+			name:           "Capped",
+			failedAttempts: 50,
+			want:           10 * time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := policy.Lockout(tt.failedAttempts); got != tt.want {
+				t.Errorf("LoginThrottlePolicy.Lockout() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoginThrottle_IsLocked(t *testing.T) {
+	now := time.Now()
+	lockedUntil := now.Add(time.Minute)
+	if !(&entity.LoginThrottle{LockedUntil: &lockedUntil}).IsLocked(now) {
+		t.Errorf("LoginThrottle.IsLocked() = false before the lockout ends")
+	}
+	if (&entity.LoginThrottle{LockedUntil: &lockedUntil}).IsLocked(lockedUntil) {
+		t.Errorf("LoginThrottle.IsLocked() = true once the lockout ends")
+	}
+	if (&entity.LoginThrottle{}).IsLocked(now) {
+		t.Errorf("LoginThrottle.IsLocked() = true without a lockout")
+	}
+}
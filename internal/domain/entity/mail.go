@@ -0,0 +1,54 @@
+package entity
+
+import "fmt"
+
+// Mail is a struct that represents a plain text email message.
+type Mail struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// NewMail is a function used to initialize the mail struct.
+func NewMail(to, subject, body string) *Mail {
+	return &Mail{
+		To:      to,
+		Subject: subject,
+		Body:    body,
+	}
+}
+
+// NewEmailVerificationMail is a function used to initialize the mail asking a user to verify the email address with the link.
+func NewEmailVerificationMail(to, link string) *Mail {
+	body := fmt.Sprintf("Welcome to the dating service!\n\n"+
+		"Please confirm that this is your email address by opening the link below:\n\n%s\n\n"+
+		"If you did not sign up, you can ignore this email.\n", link)
+
+	return NewMail(to, "Verify your email address", body)
+}
+
+// NewEmailChangeMail is a function used to initialize the mail asking a user to confirm the new email address with the link.
+func NewEmailChangeMail(to, link string) *Mail {
+	body := fmt.Sprintf("You asked to use this email address for your dating service account.\n\n"+
+		"To confirm the change, open the link below:\n\n%s\n\n"+
+		"Until then, you keep logging in with your current email address. If it was not you, you can ignore this email.\n", link)
+
+	return NewMail(to, "Confirm your new email address", body)
+}
+
+// NewEmailChangedMail is a function used to initialize the mail telling the previous email address of a user that it was replaced.
+func NewEmailChangedMail(to, newEmail string) *Mail {
+	body := fmt.Sprintf("The email address of your dating service account was changed to %s.\n\n"+
+		"You can no longer log in with this address. If you did not make this change, please contact us right away.\n", newEmail)
+
+	return NewMail(to, "Your email address was changed", body)
+}
+
+// NewPasswordResetMail is a function used to initialize the mail with the link that lets a user choose a new password.
+func NewPasswordResetMail(to, link string) *Mail {
+	body := fmt.Sprintf("Someone asked to reset the password of your dating service account.\n\n"+
+		"To choose a new password, open the link below:\n\n%s\n\n"+
+		"If it was not you, you can ignore this email and your password will stay the same.\n", link)
+
+	return NewMail(to, "Reset your password", body)
+}
@@ -0,0 +1,55 @@
+package entity
+
+import "time"
+
+// Match is a struct that represents match attributes.
+// A match always stores the lower user ID as UserOneID so that a pair of users has at most one current match.
+type Match struct {
+	ID          int        `json:"id"`
+	UserOneID   int        `json:"user_one_id"`
+	UserTwoID   int        `json:"user_two_id"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UnmatchedAt *time.Time `json:"-"`
+}
+
+// NewMatch is a function used to initialize the match struct between two users in any order.
+func NewMatch(userID, otherUserID int, createdAt time.Time) *Match {
+	return &Match{
+		UserOneID: min(userID, otherUserID),
+		UserTwoID: max(userID, otherUserID),
+		CreatedAt: createdAt,
+	}
+}
+
+// MatchDetail is a struct that represents a match together with the matched user's public attributes.
+type MatchDetail struct {
+	ID                int        `json:"id"`
+	UserID            int        `json:"user_id"`
+	ProfileID         int        `json:"profile_id"`
+	Name              string     `json:"name"`
+	ProfilePictureKey string     `json:"-"`
+	ProfilePictureURL string     `json:"profile_picture_url"`
+	ProfilePicture    *PhotoURLs `json:"profile_picture"    gorm:"-"`
+	CreatedAt         time.Time  `json:"created_at"`
+}
+
+// SetProfilePicture is a method for showing the profile picture of the matched user with the URLs signed for it.
+func (m *MatchDetail) SetProfilePicture(urls *PhotoURLs) {
+	m.ProfilePicture = urls
+	m.ProfilePictureURL = urls.LargeURL()
+}
+
+// MatchesResponse is a struct that represents matches response body.
+// NextCursor is null when there are no more matches.
+type MatchesResponse struct {
+	Matches    []*MatchDetail `json:"matches"`
+	NextCursor *int           `json:"next_cursor"`
+}
+
+// NewMatchesResponse is a function used to initialize the matches response struct.
+func NewMatchesResponse(matches []*MatchDetail, nextCursor *int) *MatchesResponse {
+	return &MatchesResponse{
+		Matches:    matches,
+		NextCursor: nextCursor,
+	}
+}
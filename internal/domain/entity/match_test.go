@@ -0,0 +1,42 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestNewMatch(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		userID        int
+		otherUserID   int
+		wantUserOneID int
+		wantUserTwoID int
+	}{
+		{
+			name:          "Lower user ID first",
+			userID:        1,
+			otherUserID:   2,
+			wantUserOneID: 1,
+			wantUserTwoID: 2,
+		},
+		{
+			name:          "Higher user ID first",
+			userID:        2,
+			otherUserID:   1,
+			wantUserOneID: 1,
+			wantUserTwoID: 2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := entity.NewMatch(tt.userID, tt.otherUserID, createdAt)
+			if got.UserOneID != tt.wantUserOneID || got.UserTwoID != tt.wantUserTwoID {
+				t.Errorf("NewMatch() = (%d, %d), want (%d, %d)", got.UserOneID, got.UserTwoID, tt.wantUserOneID, tt.wantUserTwoID)
+			}
+		})
+	}
+}
@@ -0,0 +1,42 @@
+package entity
+
+import (
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// Page sizes.
+const (
+	DefaultPageLimit = 10
+	MaxPageLimit     = 50
+)
+
+// PageRequest is a struct that represents cursor-based pagination query parameters.
+// Cursor is the ID of the last item of the previous page, or zero for the first page.
+type PageRequest struct {
+	Cursor int `json:"cursor"`
+	Limit  int `json:"limit"`
+}
+
+// Validate is a method for validating the attributes in the page request query parameters.
+func (p *PageRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if p.Cursor < 0 {
+		fields.Add("cursor", "cursor must not be negative")
+	}
+
+	if p.Limit < 0 {
+		fields.Add("limit", "limit must not be negative")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// PageSize is a method for getting the page size, falling back to the default and capped at the maximum.
+func (p *PageRequest) PageSize() int {
+	if p.Limit == 0 {
+		return DefaultPageLimit
+	}
+
+	return min(p.Limit, MaxPageLimit)
+}
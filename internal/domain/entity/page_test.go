@@ -0,0 +1,84 @@
+package entity_test
+
+import (
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestPageRequest_Validate(t *testing.T) {
+	type fields struct {
+		cursor int
+		limit  int
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "Failed: Negative cursor",
+			fields: fields{
+				cursor: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Negative limit",
+			fields: fields{
+				limit: -1,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			fields:  fields{},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &entity.PageRequest{
+				Cursor: test.fields.cursor,
+				Limit:  test.fields.limit,
+			}
+			if err := req.Validate(); (err != nil) != test.wantErr {
+				t.Errorf("PageRequest.Validate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestPageRequest_PageSize(t *testing.T) {
+	tests := []struct {
+		name  string
+		limit int
+		want  int
+	}{
+		{
+			name:  "Success: Default",
+			limit: 0,
+			want:  entity.DefaultPageLimit,
+		},
+		{
+			name:  "Success: Requested",
+			limit: 5,
+			want:  5,
+		},
+		{
+			name:  "Success: Capped",
+			limit: 1000,
+			want:  entity.MaxPageLimit,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &entity.PageRequest{
+				Limit: test.limit,
+			}
+			if got := req.PageSize(); got != test.want {
+				t.Errorf("PageRequest.PageSize() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
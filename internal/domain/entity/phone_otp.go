@@ -0,0 +1,89 @@
+package entity
+
+import (
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// PhoneOTP is a struct that represents the attributes of a one-time password sent by SMS to prove owning a phone number.
+// Only the hash of the code is stored, along with the number of wrong codes given for it and the IP address that requested it.
+type PhoneOTP struct {
+	ID        int
+	Phone     string
+	IPAddress string `gorm:"default:null"`
+	CodeHash  string
+	Attempts  int
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
+
+// NewPhoneOTP is a function used to initialize the phone OTP struct.
+func NewPhoneOTP(phone, ipAddress, codeHash string, expiresAt, createdAt time.Time) *PhoneOTP {
+	return &PhoneOTP{
+		Phone:     phone,
+		IPAddress: ipAddress,
+		CodeHash:  codeHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: createdAt,
+	}
+}
+
+// IsUsable is a method for checking whether a code can still be given for the phone OTP at the given time.
+func (p *PhoneOTP) IsUsable(now time.Time, maxAttempts int) bool {
+	return p.UsedAt == nil && now.Before(p.ExpiresAt) && p.Attempts < maxAttempts
+}
+
+// PhoneOTPPolicy is a struct that represents how long a phone OTP lasts, how many wrong codes it allows and how often one can be sent.
+// The daily maximums count the phone OTPs sent in the last 24 hours, and a maximum of 0 means no limit.
+type PhoneOTPPolicy struct {
+	Expiration         time.Duration
+	MaxAttempts        int
+	ResendInterval     time.Duration
+	MaxDailySends      int
+	MaxDailySendsPerIP int
+}
+
+// NewPhoneOTPPolicy is a function used to initialize the phone OTP policy struct.
+func NewPhoneOTPPolicy(expiration time.Duration, maxAttempts int, resendInterval time.Duration, maxDailySends, maxDailySendsPerIP int) *PhoneOTPPolicy {
+	return &PhoneOTPPolicy{
+		Expiration:         expiration,
+		MaxAttempts:        maxAttempts,
+		ResendInterval:     resendInterval,
+		MaxDailySends:      maxDailySends,
+		MaxDailySendsPerIP: maxDailySendsPerIP,
+	}
+}
+
+// PhoneOTPRequest is a struct that represents phone OTP request body.
+type PhoneOTPRequest struct {
+	Phone string `json:"phone"`
+}
+
+// Validate is a method for validating the attributes in the phone OTP request body.
+func (p *PhoneOTPRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	validatePhone(&fields, p.Phone)
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// PhoneLoginRequest is a struct that represents phone login request body.
+type PhoneLoginRequest struct {
+	Phone string `json:"phone"`
+	Code  string `json:"code"`
+}
+
+// Validate is a method for validating the attributes in the phone login request body.
+func (p *PhoneLoginRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	validatePhone(&fields, p.Phone)
+
+	if p.Code == "" {
+		fields.Add("code", "code is required")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
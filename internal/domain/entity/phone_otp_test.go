@@ -0,0 +1,77 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestPhoneOTP_IsUsable(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	usedAt := now.Add(-time.Minute)
+	tests := []struct {
+		name string
+		otp  *entity.PhoneOTP
+		want bool
+	}{
+		{
+			name: "Usable",
+			otp:  &entity.PhoneOTP{Attempts: 4, ExpiresAt: now.Add(time.Minute)},
+			want: true,
+		},
+		{
+			name: "Expired",
+			otp:  &entity.PhoneOTP{ExpiresAt: now},
+			want: false,
+		},
+		{
+			name: "Used",
+			otp:  &entity.PhoneOTP{ExpiresAt: now.Add(time.Minute), UsedAt: &usedAt},
+			want: false,
+		},
+		{
+			name: "Too many attempts",
+			otp:  &entity.PhoneOTP{Attempts: 5, ExpiresAt: now.Add(time.Minute)},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.otp.IsUsable(now, 5); got != tt.want {
+				t.Errorf("PhoneOTP.IsUsable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPhoneLoginRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *entity.PhoneLoginRequest
+		wantErr bool
+	}{
+		{
+			name:    "Failed: Invalid phone",
+			req:     &entity.PhoneLoginRequest{Phone: "12345", Code: "123456"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed: Code empty",
+			req:     &entity.PhoneLoginRequest{Phone: "+6281234567890"},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			req:     &entity.PhoneLoginRequest{Phone: "+6281234567890", Code: "123456"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("PhoneLoginRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package entity
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// Names of the sizes every photo is stored in.
+const (
+	PhotoSizeLarge  = "large"
+	PhotoSizeMedium = "medium"
+	PhotoSizeSmall  = "small"
+)
+
+// photoContentTypes are the content types of the photos users can upload.
+var photoContentTypes = []string{"image/jpeg", "image/png"}
+
+// PhotoPolicy is a struct that represents how large an uploaded photo may be and how long the URLs of photos last.
+type PhotoPolicy struct {
+	MaxSize       int
+	URLExpiration time.Duration
+}
+
+// NewPhotoPolicy is a function used to initialize the photo policy struct.
+func NewPhotoPolicy(maxSize int, urlExpiration time.Duration) *PhotoPolicy {
+	return &PhotoPolicy{
+		MaxSize:       maxSize,
+		URLExpiration: urlExpiration,
+	}
+}
+
+// PhotoUpload is a struct that represents a photo uploaded by a user, with the content type declared by the client.
+type PhotoUpload struct {
+	ContentType string
+	Content     io.Reader
+}
+
+// NewPhotoUpload is a function used to initialize the photo upload struct.
+func NewPhotoUpload(contentType string, content io.Reader) *PhotoUpload {
+	return &PhotoUpload{
+		ContentType: contentType,
+		Content:     content,
+	}
+}
+
+// Read is a method for reading the uploaded photo, which must be at most maxSize bytes and a JPEG or PNG image both as declared and by its content.
+func (p *PhotoUpload) Read(maxSize int) ([]byte, error) {
+	data, err := io.ReadAll(io.LimitReader(p.Content, int64(maxSize)+1))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(data) == 0 {
+		return nil, apperror.Validation(constant.InvalidRequestBody, apperror.Field("photo", "photo is required"))
+	}
+
+	if len(data) > maxSize {
+		return nil, apperror.Validation(constant.InvalidRequestBody, apperror.Field("photo", fmt.Sprintf("photo must be at most %d bytes", maxSize)))
+	}
+
+	declared := p.ContentType == "" || slices.Contains(photoContentTypes, p.ContentType)
+	if !declared || !slices.Contains(photoContentTypes, http.DetectContentType(data)) {
+		return nil, apperror.Validation(constant.InvalidRequestBody, apperror.Field("photo", "photo must be a JPEG or PNG image"))
+	}
+
+	return data, nil
+}
+
+// PhotoURLs is a struct that represents the signed URLs of the sizes of a photo, which stop working at the expiry time.
+type PhotoURLs struct {
+	Large     string    `json:"large"`
+	Medium    string    `json:"medium"`
+	Small     string    `json:"small"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// LargeURL is a method for getting the URL of the large size, which is empty when there is no photo.
+func (p *PhotoURLs) LargeURL() string {
+	if p == nil {
+		return ""
+	}
+
+	return p.Large
+}
+
+// Blob is a struct that represents a stored file served to clients.
+type Blob struct {
+	ContentType string
+	Data        []byte
+	ExpiresAt   time.Time
+}
@@ -1,7 +1,24 @@
 // Package entity holds the core entities (models) of the application.
 package entity
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+// Length limits of the profile attributes that users write themselves, counted in characters.
+const (
+	maxNameLength      = 100
+	maxLocationLength  = 100
+	maxBioLength       = 500
+	maxInterestsLength = 255
+)
 
 // Profile is a struct that represents profile attributes.
 type Profile struct {
@@ -25,3 +42,151 @@ func NewProfile(userID int, bio, interests string, verified bool, createdAt, upd
 		UpdatedAt: updatedAt,
 	}
 }
+
+// ProfileCandidate is a struct that represents a profile shown in the discovery feed.
+type ProfileCandidate struct {
+	ProfileID         int        `json:"profile_id"`
+	UserID            int        `json:"user_id"`
+	Name              string     `json:"name"`
+	BirthDate         time.Time  `json:"-"`
+	Age               int        `json:"age"`
+	Gender            string     `json:"gender"`
+	Location          string     `json:"location"`
+	ProfilePictureKey string     `json:"-"`
+	ProfilePictureURL string     `json:"profile_picture_url"`
+	ProfilePicture    *PhotoURLs `json:"profile_picture"    gorm:"-"`
+	Bio               string     `json:"bio"`
+	Interests         string     `json:"interests"`
+	Verified          bool       `json:"verified"`
+}
+
+// SetProfilePicture is a method for showing the profile picture with the URLs signed for it.
+func (p *ProfileCandidate) SetProfilePicture(urls *PhotoURLs) {
+	p.ProfilePicture = urls
+	p.ProfilePictureURL = urls.LargeURL()
+}
+
+// DiscoverResponse is a struct that represents discover response body.
+// NextCursor is null when there are no more profiles to discover.
+type DiscoverResponse struct {
+	Profiles   []*ProfileCandidate `json:"profiles"`
+	NextCursor *int                `json:"next_cursor"`
+}
+
+// NewDiscoverResponse is a function used to initialize the discover response struct.
+func NewDiscoverResponse(profiles []*ProfileCandidate, nextCursor *int) *DiscoverResponse {
+	return &DiscoverResponse{
+		Profiles:   profiles,
+		NextCursor: nextCursor,
+	}
+}
+
+// ProfileResponse is a struct that represents the profile of the current user, including the attributes only the user may see.
+type ProfileResponse struct {
+	ProfileID         int        `json:"profile_id"`
+	UserID            int        `json:"user_id"`
+	Email             string     `json:"email"`
+	Phone             string     `json:"phone"`
+	Name              string     `json:"name"`
+	BirthDate         time.Time  `json:"birth_date"`
+	Age               int        `json:"age"`
+	Gender            string     `json:"gender"`
+	Location          string     `json:"location"`
+	ProfilePictureURL string     `json:"profile_picture_url"`
+	ProfilePicture    *PhotoURLs `json:"profile_picture"`
+	Bio               string     `json:"bio"`
+	Interests         string     `json:"interests"`
+	Verified          bool       `json:"verified"`
+}
+
+// NewProfileResponse is a function used to initialize the profile response struct.
+func NewProfileResponse(user *User, profile *Profile, currentTime time.Time) *ProfileResponse {
+	return &ProfileResponse{
+		ProfileID: profile.ID,
+		UserID:    user.ID,
+		Email:     user.Email,
+		Phone:     user.Phone,
+		Name:      user.Name,
+		BirthDate: user.BirthDate,
+		Age:       util.Age(user.BirthDate, currentTime),
+		Gender:    user.Gender,
+		Location:  user.Location,
+		Bio:       profile.Bio,
+		Interests: profile.Interests,
+		Verified:  profile.Verified,
+	}
+}
+
+// SetProfilePicture is a method for showing the profile picture with the URLs signed for it.
+func (p *ProfileResponse) SetProfilePicture(urls *PhotoURLs) {
+	p.ProfilePicture = urls
+	p.ProfilePictureURL = urls.LargeURL()
+}
+
+// UpdateProfileRequest is a struct that represents update profile request body.
+// Attributes left out of the request body are kept as they are.
+type UpdateProfileRequest struct {
+	Name      *string `json:"name"`
+	Location  *string `json:"location"`
+	Bio       *string `json:"bio"`
+	Interests *string `json:"interests"`
+}
+
+// Validate is a method for validating the attributes in the update profile request body.
+func (u *UpdateProfileRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if u.Name != nil {
+		validateRequired(&fields, "name", *u.Name, maxNameLength)
+	}
+
+	if u.Location != nil {
+		validateRequired(&fields, "location", *u.Location, maxLocationLength)
+	}
+
+	if u.Bio != nil {
+		validateLength(&fields, "bio", *u.Bio, maxBioLength)
+	}
+
+	if u.Interests != nil {
+		validateLength(&fields, "interests", *u.Interests, maxInterestsLength)
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// Apply is a method for copying the attributes in the update profile request body to the user and the profile, without surrounding spaces.
+func (u *UpdateProfileRequest) Apply(user *User, profile *Profile) {
+	if u.Name != nil {
+		user.Name = strings.TrimSpace(*u.Name)
+	}
+
+	if u.Location != nil {
+		user.Location = strings.TrimSpace(*u.Location)
+	}
+
+	if u.Bio != nil {
+		profile.Bio = strings.TrimSpace(*u.Bio)
+	}
+
+	if u.Interests != nil {
+		profile.Interests = strings.TrimSpace(*u.Interests)
+	}
+}
+
+// validateRequired is a function to add the field to the invalid fields when the value is blank or longer than the limit.
+func validateRequired(fields *apperror.FieldErrors, field, value string, maxLength int) {
+	if strings.TrimSpace(value) == "" {
+		fields.Add(field, field+" is required")
+
+		return
+	}
+
+	validateLength(fields, field, value, maxLength)
+}
+
+// validateLength is a function to add the field to the invalid fields when the value without surrounding spaces is longer than the limit.
+func validateLength(fields *apperror.FieldErrors, field, value string, maxLength int) {
+	if utf8.RuneCountInString(strings.TrimSpace(value)) > maxLength {
+		fields.Add(field, fmt.Sprintf("%s must be at most %d characters", field, maxLength))
+	}
+}
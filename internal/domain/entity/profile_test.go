@@ -0,0 +1,83 @@
+package entity_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestUpdateProfileRequest_Validate(t *testing.T) {
+	blank := "  "
+	longName := strings.Repeat("a", 101)
+	longBio := strings.Repeat("é", 501)
+	bio := strings.Repeat("é", 500)
+	name := "Name"
+	tests := []struct {
+		name    string
+		req     *entity.UpdateProfileRequest
+		wantErr error
+	}{
+		{
+			name:    "Failed: Name blank",
+			req:     &entity.UpdateProfileRequest{Name: &blank},
+			wantErr: apperror.ErrValidation,
+		},
+		{
+			name:    "Failed: Name too long",
+			req:     &entity.UpdateProfileRequest{Name: &longName},
+			wantErr: apperror.ErrValidation,
+		},
+		{
+			name:    "Failed: Bio too long",
+			req:     &entity.UpdateProfileRequest{Bio: &longBio},
+			wantErr: apperror.ErrValidation,
+		},
+		{
+			name: "Success with nothing to update",
+			req:  &entity.UpdateProfileRequest{},
+		},
+		{
+			name: "Success with the longest bio",
+			req:  &entity.UpdateProfileRequest{Name: &name, Bio: &bio},
+		},
+		{
+			name: "Success with a blank bio",
+			req:  &entity.UpdateProfileRequest{Bio: &blank},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("UpdateProfileRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestUpdateProfileRequest_Apply(t *testing.T) {
+	name := " New Name "
+	bio := " New bio "
+	user := &entity.User{Name: "Name", Location: "Indonesia"}
+	profile := &entity.Profile{Bio: "Bio", Interests: "Hiking"}
+
+	req := &entity.UpdateProfileRequest{Name: &name, Bio: &bio}
+	req.Apply(user, profile)
+	if user.Name != "New Name" || user.Location != "Indonesia" || profile.Bio != "New bio" || profile.Interests != "Hiking" {
+		t.Errorf("UpdateProfileRequest.Apply() = %+v, %+v, want only the attributes in the request replaced without surrounding spaces", user, profile)
+	}
+}
+
+func TestNewProfileResponse(t *testing.T) {
+	currentTime := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	user := &entity.User{ID: 1, Email: "user@email.com", BirthDate: time.Date(2000, 6, 2, 0, 0, 0, 0, time.UTC)}
+	profile := &entity.Profile{ID: 2, UserID: 1, Bio: "Bio"}
+
+	resp := entity.NewProfileResponse(user, profile, currentTime)
+	if resp.ProfileID != 2 || resp.UserID != 1 || resp.Email != "user@email.com" || resp.Age != 23 || resp.Bio != "Bio" {
+		t.Errorf("NewProfileResponse() = %+v", resp)
+	}
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// RefreshToken is a struct that represents refresh token attributes.
+// Only the hash of the token is stored, and every token issued by rotating another one shares its family.
+type RefreshToken struct {
+	ID        int
+	UserID    int
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+}
+
+// NewRefreshToken is a function used to initialize the refresh token struct.
+func NewRefreshToken(userID int, familyID, tokenHash string, expiresAt, createdAt time.Time) *RefreshToken {
+	return &RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: tokenHash,
+		ExpiresAt: expiresAt,
+		CreatedAt: createdAt,
+	}
+}
+
+// IsUsable is a method for checking whether the refresh token can still be exchanged at the given time.
+func (r *RefreshToken) IsUsable(now time.Time) bool {
+	return r.RotatedAt == nil && r.RevokedAt == nil && now.Before(r.ExpiresAt)
+}
+
+// RefreshTokenRequest is a struct that represents refresh token request body.
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Validate is a method for validating the attributes in the refresh token request body.
+func (r *RefreshTokenRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if r.RefreshToken == "" {
+		fields.Add("refresh_token", "refresh_token is required")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
@@ -0,0 +1,55 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestRefreshToken_IsUsable(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	tests := []struct {
+		name         string
+		refreshToken *entity.RefreshToken
+		want         bool
+	}{
+		{
+			name:         "Usable",
+			refreshToken: &entity.RefreshToken{ExpiresAt: now.Add(time.Hour)},
+			want:         true,
+		},
+		{
+			name:         "Expired",
+			refreshToken: &entity.RefreshToken{ExpiresAt: now},
+			want:         false,
+		},
+		{
+			name:         "Rotated",
+			refreshToken: &entity.RefreshToken{ExpiresAt: now.Add(time.Hour), RotatedAt: &past},
+			want:         false,
+		},
+		{
+			name:         "Revoked",
+			refreshToken: &entity.RefreshToken{ExpiresAt: now.Add(time.Hour), RevokedAt: &past},
+			want:         false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.refreshToken.IsUsable(now); got != tt.want {
+				t.Errorf("RefreshToken.IsUsable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefreshTokenRequest_Validate(t *testing.T) {
+	if err := (&entity.RefreshTokenRequest{}).Validate(); err == nil {
+		t.Error("RefreshTokenRequest.Validate() error = nil, want an error for a missing refresh token")
+	}
+	if err := (&entity.RefreshTokenRequest{RefreshToken: "token"}).Validate(); err != nil {
+		t.Errorf("RefreshTokenRequest.Validate() error = %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package entity
+
+import (
+	"slices"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// Roles of users, which grant the permissions stored for them in the role_permissions table.
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
+// Permissions that routes can require.
+const (
+	PermissionReadUsers   = "users:read"
+	PermissionManageRoles = "users:manage_roles"
+)
+
+var roles = []string{RoleUser, RoleModerator, RoleAdmin}
+
+// RolePermission is a struct that represents a permission granted to every user with a role.
+type RolePermission struct {
+	Role       string
+	Permission string
+}
+
+// ChangeRoleRequest is a struct that represents change role request body.
+type ChangeRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// Validate is a method for validating the attributes in the change role request body.
+func (c *ChangeRoleRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if !slices.Contains(roles, c.Role) {
+		fields.Add("role", "role must be \"user\", \"moderator\", or \"admin\"")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
@@ -0,0 +1,69 @@
+package entity
+
+import "time"
+
+// Session is a struct that represents the attributes of a device a user is logged in on.
+// It follows the access token currently issued to the device by its ID, and the refresh token family the device refreshes it with.
+type Session struct {
+	ID              int
+	UserID          int
+	TokenID         string
+	RefreshFamilyID string
+	DeviceName      string
+	IPAddress       string
+	CreatedAt       time.Time
+	LastSeenAt      time.Time
+}
+
+// NewSession is a function used to initialize the session struct.
+func NewSession(userID int, tokenID, refreshFamilyID, deviceName, ipAddress string, createdAt time.Time) *Session {
+	return &Session{
+		UserID:          userID,
+		TokenID:         tokenID,
+		RefreshFamilyID: refreshFamilyID,
+		DeviceName:      deviceName,
+		IPAddress:       ipAddress,
+		CreatedAt:       createdAt,
+		LastSeenAt:      createdAt,
+	}
+}
+
+// IsSeenWithin is a method for checking whether the session was last seen less than the interval before the given time.
+func (s *Session) IsSeenWithin(now time.Time, interval time.Duration) bool {
+	return now.Sub(s.LastSeenAt) < interval
+}
+
+// SessionResponse is a struct that represents a session in the sessions response body.
+// Current is true for the session of the access token the sessions were listed with.
+type SessionResponse struct {
+	ID         int       `json:"id"`
+	DeviceName string    `json:"device_name"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	Current    bool      `json:"current"`
+}
+
+// SessionsResponse is a struct that represents sessions response body.
+type SessionsResponse struct {
+	Sessions []*SessionResponse `json:"sessions"`
+}
+
+// NewSessionsResponse is a function used to initialize the sessions response struct, marking the session of the current access token.
+func NewSessionsResponse(sessions []*Session, currentTokenID string) *SessionsResponse {
+	resp := &SessionsResponse{
+		Sessions: make([]*SessionResponse, 0, len(sessions)),
+	}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, &SessionResponse{
+			ID:         session.ID,
+			DeviceName: session.DeviceName,
+			IPAddress:  session.IPAddress,
+			CreatedAt:  session.CreatedAt,
+			LastSeenAt: session.LastSeenAt,
+			Current:    session.TokenID == currentTokenID,
+		})
+	}
+
+	return resp
+}
@@ -0,0 +1,55 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestSession_IsSeenWithin(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name       string
+		lastSeenAt time.Time
+		want       bool
+	}{
+		{
+			name:       "Seen within the interval",
+			lastSeenAt: now.Add(-30 * time.Second),
+			want:       true,
+		},
+		{
+			name:       "Seen before the interval",
+			lastSeenAt: now.Add(-time.Minute),
+			want:       false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &entity.Session{LastSeenAt: tt.lastSeenAt}
+			if got := s.IsSeenWithin(now, time.Minute); got != tt.want {
+				t.Errorf("Session.IsSeenWithin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNewSessionsResponse(t *testing.T) {
+	sessions := []*entity.Session{
+		{ID: 1, TokenID: "current", DeviceName: "Chrome on Windows"},
+		{ID: 2, TokenID: "other", DeviceName: "Safari on iPhone"},
+	}
+
+	got := entity.NewSessionsResponse(sessions, "current")
+	if len(got.Sessions) != 2 || !got.Sessions[0].Current || got.Sessions[1].Current {
+		t.Errorf("NewSessionsResponse() = %+v, want only the first session marked as current", got.Sessions)
+	}
+	if got.Sessions[1].ID != 2 || got.Sessions[1].DeviceName != "Safari on iPhone" {
+		t.Errorf("NewSessionsResponse() = %+v, want the attributes of the sessions", got.Sessions[1])
+	}
+
+	if got := entity.NewSessionsResponse(nil, "current"); got.Sessions == nil {
+		t.Error("NewSessionsResponse() sessions = nil, want an empty list")
+	}
+}
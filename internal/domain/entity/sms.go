@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// SMS is a struct that represents a text message sent to a phone number.
+type SMS struct {
+	To   string
+	Body string
+}
+
+// NewSMS is a function used to initialize the SMS struct.
+func NewSMS(to, body string) *SMS {
+	return &SMS{
+		To:   to,
+		Body: body,
+	}
+}
+
+// NewPhoneOTPSMS is a function used to initialize the SMS with the one-time password that proves a user owns the phone number.
+func NewPhoneOTPSMS(to, code string, expiration time.Duration) *SMS {
+	body := fmt.Sprintf("%s is your dating service code. It expires in %d minutes. Do not share it with anyone.", code, int(expiration.Minutes()))
+
+	return NewSMS(to, body)
+}
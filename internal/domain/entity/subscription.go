@@ -0,0 +1,79 @@
+package entity
+
+import (
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// SubscriptionPackage is a struct that represents subscription package attributes.
+type SubscriptionPackage struct {
+	ID           int    `json:"id"`
+	Name         string `json:"name"`
+	LifetimeDays int    `json:"lifetime_days"`
+	Price        int64  `json:"price"`
+}
+
+// Subscription is a struct that represents subscription attributes.
+type Subscription struct {
+	ID                    int       `json:"id"`
+	SubscriptionPackageID int       `json:"subscription_package_id"`
+	UserID                int       `json:"user_id"`
+	StartDate             time.Time `json:"start_date"`
+	EndDate               time.Time `json:"end_date"`
+	Active                bool      `json:"active"`
+	PaymentReference      string    `json:"-"`
+}
+
+// NewSubscription is a function used to initialize an active subscription of a package starting on the start date.
+func NewSubscription(subscriptionPackage *SubscriptionPackage, userID int, startDate time.Time, paymentReference string) *Subscription {
+	return &Subscription{
+		SubscriptionPackageID: subscriptionPackage.ID,
+		UserID:                userID,
+		StartDate:             startDate,
+		EndDate:               startDate.AddDate(0, 0, subscriptionPackage.LifetimeDays),
+		Active:                true,
+		PaymentReference:      paymentReference,
+	}
+}
+
+// PurchaseSubscriptionRequest is a struct that represents purchase subscription request body.
+type PurchaseSubscriptionRequest struct {
+	SubscriptionPackageID int    `json:"subscription_package_id"`
+	PaymentToken          string `json:"payment_token"`
+}
+
+// Validate is a method for validating the attributes in the purchase subscription request body.
+func (p *PurchaseSubscriptionRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if p.SubscriptionPackageID <= 0 {
+		fields.Add("subscription_package_id", "subscription_package_id is required")
+	}
+
+	if p.PaymentToken == "" {
+		fields.Add("payment_token", "payment_token is required")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// SubscriptionResponse is a struct that represents subscription response body.
+type SubscriptionResponse struct {
+	ID                  int                  `json:"id"`
+	SubscriptionPackage *SubscriptionPackage `json:"subscription_package"`
+	StartDate           time.Time            `json:"start_date"`
+	EndDate             time.Time            `json:"end_date"`
+	Active              bool                 `json:"active"`
+}
+
+// NewSubscriptionResponse is a function used to initialize the subscription response struct.
+func NewSubscriptionResponse(subscription *Subscription, subscriptionPackage *SubscriptionPackage) *SubscriptionResponse {
+	return &SubscriptionResponse{
+		ID:                  subscription.ID,
+		SubscriptionPackage: subscriptionPackage,
+		StartDate:           subscription.StartDate,
+		EndDate:             subscription.EndDate,
+		Active:              subscription.Active,
+	}
+}
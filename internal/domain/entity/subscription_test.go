@@ -0,0 +1,71 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestNewSubscription(t *testing.T) {
+	startDate := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	subscriptionPackage := &entity.SubscriptionPackage{
+		ID:           1,
+		LifetimeDays: 30,
+	}
+
+	subscription := entity.NewSubscription(subscriptionPackage, 2, startDate, "reference")
+	if !subscription.EndDate.Equal(time.Date(2024, 5, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("NewSubscription() end date = %v, want 2024-05-31", subscription.EndDate)
+	}
+	if !subscription.Active || subscription.SubscriptionPackageID != 1 || subscription.UserID != 2 {
+		t.Errorf("NewSubscription() = %+v", subscription)
+	}
+}
+
+func TestPurchaseSubscriptionRequest_Validate(t *testing.T) {
+	type fields struct {
+		subscriptionPackageID int
+		paymentToken          string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "Failed: Empty subscription package ID",
+			fields: fields{
+				subscriptionPackageID: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Empty payment token",
+			fields: fields{
+				subscriptionPackageID: 1,
+				paymentToken:          "",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				subscriptionPackageID: 1,
+				paymentToken:          "token",
+			},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := &entity.PurchaseSubscriptionRequest{
+				SubscriptionPackageID: test.fields.subscriptionPackageID,
+				PaymentToken:          test.fields.paymentToken,
+			}
+			if err := req.Validate(); (err != nil) != test.wantErr {
+				t.Errorf("PurchaseSubscriptionRequest.Validate() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// TokenClaims is a struct that represents the verified claims of an access token.
+type TokenClaims struct {
+	ID        string
+	UserID    int
+	Email     string
+	Role      string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
+
+// ActionTokenClaims is a struct that represents the verified claims of a token that lets a user perform a single action, such as verifying an email address.
+type ActionTokenClaims struct {
+	ID        string
+	Purpose   string
+	UserID    int
+	Email     string
+	IssuedAt  time.Time
+	ExpiresAt time.Time
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "time"
+
+// RevokedToken is a struct that represents revoked access token attributes.
+// The expiration is kept so that the revocation can be purged once the token would no longer be accepted anyway.
+type RevokedToken struct {
+	ID        string
+	UserID    int
+	ExpiresAt time.Time
+	RevokedAt time.Time
+}
+
+// NewRevokedToken is a function used to initialize the revoked token struct from the claims of an access token.
+func NewRevokedToken(userID int, claims *TokenClaims, revokedAt time.Time) *RevokedToken {
+	return &RevokedToken{
+		ID:        claims.ID,
+		UserID:    userID,
+		ExpiresAt: claims.ExpiresAt,
+		RevokedAt: revokedAt,
+	}
+}
+
+// UserTokenRevocation is a struct that represents the time before which every access token of a user is revoked.
+type UserTokenRevocation struct {
+	UserID        int `gorm:"primaryKey"`
+	RevokedBefore time.Time
+}
+
+// NewUserTokenRevocation is a function used to initialize the user token revocation struct.
+func NewUserTokenRevocation(userID int, revokedBefore time.Time) *UserTokenRevocation {
+	return &UserTokenRevocation{
+		UserID:        userID,
+		RevokedBefore: revokedBefore,
+	}
+}
+
+// LogoutRequest is a struct that represents logout request body.
+// The refresh token is optional and, when given, is revoked together with the access token.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
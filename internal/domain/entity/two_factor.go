@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// TwoFactor is a struct that represents the TOTP two-factor authentication attributes of a user.
+// It is enabled once the user has confirmed the enrollment with a first code, and the last time step a code was used for is kept so that a code cannot be used twice.
+type TwoFactor struct {
+	UserID       int `gorm:"primaryKey"`
+	Secret       string
+	LastUsedStep int64
+	ConfirmedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// NewTwoFactor is a function used to initialize the two-factor struct of an enrollment that is not confirmed yet.
+func NewTwoFactor(userID int, secret string, createdAt, updatedAt time.Time) *TwoFactor {
+	return &TwoFactor{
+		UserID:    userID,
+		Secret:    secret,
+		CreatedAt: createdAt,
+		UpdatedAt: updatedAt,
+	}
+}
+
+// IsEnabled is a method for checking whether the user has confirmed the enrollment, so that logging in requires a code.
+func (t *TwoFactor) IsEnabled() bool {
+	return t.ConfirmedAt != nil
+}
+
+// RecoveryCode is a struct that represents the attributes of a single-use code that replaces a TOTP code when the authenticator is lost.
+// Only the hash of the code is stored.
+type RecoveryCode struct {
+	ID        int
+	UserID    int
+	CodeHash  string
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
+
+// NewRecoveryCode is a function used to initialize the recovery code struct.
+func NewRecoveryCode(userID int, codeHash string, createdAt time.Time) *RecoveryCode {
+	return &RecoveryCode{
+		UserID:    userID,
+		CodeHash:  codeHash,
+		CreatedAt: createdAt,
+	}
+}
+
+// TwoFactorEnrollmentResponse is a struct that represents two-factor enrollment response body.
+type TwoFactorEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+// NewTwoFactorEnrollmentResponse is a function used to initialize the two-factor enrollment response struct.
+func NewTwoFactorEnrollmentResponse(secret, otpAuthURI string) *TwoFactorEnrollmentResponse {
+	return &TwoFactorEnrollmentResponse{
+		Secret:     secret,
+		OTPAuthURI: otpAuthURI,
+	}
+}
+
+// ConfirmTwoFactorRequest is a struct that represents confirm two-factor request body.
+type ConfirmTwoFactorRequest struct {
+	Code string `json:"code"`
+}
+
+// Validate is a method for validating the attributes in the confirm two-factor request body.
+func (c *ConfirmTwoFactorRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if c.Code == "" {
+		fields.Add("code", "code is required")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// RecoveryCodesResponse is a struct that represents recovery codes response body.
+// The codes are only ever shown in this response.
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// NewRecoveryCodesResponse is a function used to initialize the recovery codes response struct.
+func NewRecoveryCodesResponse(recoveryCodes []string) *RecoveryCodesResponse {
+	return &RecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	}
+}
+
+// TwoFactorLoginRequest is a struct that represents two-factor login request body.
+// Either a TOTP code or a recovery code is given along with the challenge token returned by the login.
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+// Validate is a method for validating the attributes in the two-factor login request body.
+func (t *TwoFactorLoginRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if t.ChallengeToken == "" {
+		fields.Add("challenge_token", "challenge_token is required")
+	}
+
+	if t.Code == "" && t.RecoveryCode == "" {
+		fields.Add("code", "code or recovery_code is required")
+	} else if t.Code != "" && t.RecoveryCode != "" {
+		fields.Add("code", "only one of code and recovery_code can be given")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
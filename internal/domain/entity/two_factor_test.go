@@ -0,0 +1,60 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestTwoFactor_IsEnabled(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	twoFactor := entity.NewTwoFactor(1, "SECRET", now, now)
+	if twoFactor.IsEnabled() {
+		t.Errorf("TwoFactor.IsEnabled() = true before the enrollment is confirmed")
+	}
+
+	twoFactor.ConfirmedAt = &now
+	if !twoFactor.IsEnabled() {
+		t.Errorf("TwoFactor.IsEnabled() = false after the enrollment is confirmed")
+	}
+}
+
+func TestTwoFactorLoginRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *entity.TwoFactorLoginRequest
+		wantErr bool
+	}{
+		{
+			name:    "Failed: Missing challenge token",
+			req:     &entity.TwoFactorLoginRequest{Code: "123456"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed: Missing code",
+			req:     &entity.TwoFactorLoginRequest{ChallengeToken: "token"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed: Both codes",
+			req:     &entity.TwoFactorLoginRequest{ChallengeToken: "token", Code: "123456", RecoveryCode: "code"},
+			wantErr: true,
+		},
+		{
+			name: "Success: Code",
+			req:  &entity.TwoFactorLoginRequest{ChallengeToken: "token", Code: "123456"},
+		},
+		{
+			name: "Success: Recovery code",
+			req:  &entity.TwoFactorLoginRequest{ChallengeToken: "token", RecoveryCode: "code"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("TwoFactorLoginRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
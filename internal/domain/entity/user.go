@@ -1,8 +1,9 @@
 package entity
 
 import (
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
 	"dealls-technical-test-dating-service/pkg/util"
-	"errors"
 	"slices"
 	"strings"
 	"time"
@@ -12,20 +13,24 @@ var genders = []string{"MALE", "FEMALE", "OTHER"}
 
 // User is a struct that represents user attributes.
 type User struct {
-	ID                int       `json:"id"`
-	Email             string    `json:"email"`
-	Password          string    `json:"password"`
-	Name              string    `json:"name"`
-	BirthDate         time.Time `json:"birth_date"`
-	Gender            string    `json:"gender"`
-	Location          string    `json:"location"`
-	ProfilePictureURL string    `json:"profile_picture_url"`
-	CreatedAt         time.Time `json:"created_at"`
-	UpdatedAt         time.Time `json:"updated_at"`
+	ID                int        `json:"id"`
+	Email             string     `json:"email"              gorm:"default:null"`
+	Phone             string     `json:"phone"              gorm:"default:null"`
+	Password          string     `json:"password"`
+	Name              string     `json:"name"`
+	BirthDate         time.Time  `json:"birth_date"`
+	Gender            string     `json:"gender"`
+	Location          string     `json:"location"`
+	ProfilePictureKey string     `json:"-"`
+	EmailVerifiedAt   *time.Time `json:"email_verified_at"`
+	PhoneVerifiedAt   *time.Time `json:"phone_verified_at"`
+	Role              string     `json:"role"`
+	CreatedAt         time.Time  `json:"created_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
 }
 
 // NewUser is a function used to initialize the user struct.
-func NewUser(email, password, name, birthDate, gender, location, profilePictureURL string, createdAt, updatedAt time.Time) *User {
+func NewUser(email, password, name, birthDate, gender, location, profilePictureKey string, createdAt, updatedAt time.Time) *User {
 	birthDateParsed, _ := time.Parse("2006-01-02", birthDate)
 
 	return &User{
@@ -35,12 +40,41 @@ func NewUser(email, password, name, birthDate, gender, location, profilePictureU
 		BirthDate:         birthDateParsed,
 		Gender:            gender,
 		Location:          location,
-		ProfilePictureURL: profilePictureURL,
+		ProfilePictureKey: profilePictureKey,
+		Role:              RoleUser,
 		CreatedAt:         createdAt,
 		UpdatedAt:         updatedAt,
 	}
 }
 
+// NewPhoneUser is a function used to initialize the user struct of a user who signs up with a verified phone number and no password.
+func NewPhoneUser(phone, name, birthDate, gender, location string, createdAt time.Time) *User {
+	user := NewUser("", "", name, birthDate, gender, location, "", createdAt, createdAt)
+	user.Phone = phone
+	user.PhoneVerifiedAt = &createdAt
+
+	return user
+}
+
+// HasPassword is a method for checking whether the user has a password, which users who signed up with a phone number have not.
+func (u *User) HasPassword() bool {
+	return u.Password != ""
+}
+
+// Identifier is a method for getting the email address of the user, or the phone number of a user without one.
+func (u *User) Identifier() string {
+	if u.Email != "" {
+		return u.Email
+	}
+
+	return u.Phone
+}
+
+// IsEmailVerified is a method for checking whether the user has confirmed owning the email address.
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
 // UserSignupRequest is a struct that represents user signup request body.
 type UserSignupRequest struct {
 	Email     string `json:"email"`
@@ -52,45 +86,44 @@ type UserSignupRequest struct {
 }
 
 // Validate is a method for validating the attributes in the user signup request body.
+// Every invalid attribute is reported at once so that clients can highlight all of them in one round trip.
 func (u *UserSignupRequest) Validate() error {
+	fields := apperror.FieldErrors{}
 	if u.Email == "" {
-		return errors.New("email is required")
-	}
-
-	if !util.IsValidEmail(u.Email) {
-		return errors.New("invalid email format")
-	}
-
-	if u.Password == "" {
-		return errors.New("password is required")
+		fields.Add("email", "email is required")
+	} else if !util.IsValidEmail(u.Email) {
+		fields.Add("email", "invalid email format")
 	}
 
-	if len(u.Password) < 8 {
-		return errors.New("password must be at least 8 characters long")
-	}
+	validatePassword(&fields, "password", u.Password)
+	validateProfile(&fields, u.Name, u.BirthDate, u.Gender, u.Location)
 
-	if u.Name == "" {
-		return errors.New("name is required")
-	}
+	return fields.Err(constant.InvalidRequestBody)
+}
 
-	if u.BirthDate == "" {
-		return errors.New("birth_date is required")
-	}
+// PhoneSignupRequest is a struct that represents phone signup request body.
+// The code is the one-time password sent to the phone number, which proves that the user owns it.
+type PhoneSignupRequest struct {
+	Phone     string `json:"phone"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	BirthDate string `json:"birth_date"`
+	Gender    string `json:"gender"`
+	Location  string `json:"location"`
+}
 
-	_, err := time.Parse("2006-01-02", u.BirthDate)
-	if err != nil {
-		return errors.New("invalid birth_date format. Format must be YYYY-MM-DD")
-	}
+// Validate is a method for validating the attributes in the phone signup request body.
+func (p *PhoneSignupRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	validatePhone(&fields, p.Phone)
 
-	if u.Gender == "" || !slices.Contains(genders, strings.ToUpper(u.Gender)) {
-		return errors.New("gender must be \"MALE\", \"FEMALE\", or \"OTHER\"")
+	if p.Code == "" {
+		fields.Add("code", "code is required")
 	}
 
-	if u.Location == "" {
-		return errors.New("location is required")
-	}
+	validateProfile(&fields, p.Name, p.BirthDate, p.Gender, p.Location)
 
-	return nil
+	return fields.Err(constant.InvalidRequestBody)
 }
 
 // UserLoginRequest is a struct that represents user login request body.
@@ -101,29 +134,185 @@ type UserLoginRequest struct {
 
 // Validate is a method for validating the attributes in the user login request body.
 func (u *UserLoginRequest) Validate() error {
+	fields := apperror.FieldErrors{}
 	if u.Email == "" {
-		return errors.New("email is required")
+		fields.Add("email", "email is required")
+	} else if !util.IsValidEmail(u.Email) {
+		fields.Add("email", "invalid email format")
 	}
 
-	if !util.IsValidEmail(u.Email) {
-		return errors.New("invalid email format")
+	if u.Password == "" {
+		fields.Add("password", "password is required")
 	}
 
-	if u.Password == "" {
-		return errors.New("password is required")
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// ForgotPasswordRequest is a struct that represents forgot password request body.
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// Validate is a method for validating the attributes in the forgot password request body.
+func (f *ForgotPasswordRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if f.Email == "" {
+		fields.Add("email", "email is required")
+	} else if !util.IsValidEmail(f.Email) {
+		fields.Add("email", "invalid email format")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// ResetPasswordRequest is a struct that represents reset password request body.
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// Validate is a method for validating the attributes in the reset password request body.
+// The new password follows the same rules as the one given on sign up.
+func (r *ResetPasswordRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if r.Token == "" {
+		fields.Add("token", "token is required")
 	}
 
-	return nil
+	validatePassword(&fields, "password", r.Password)
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// ChangePasswordRequest is a struct that represents change password request body.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// Validate is a method for validating the attributes in the change password request body.
+func (c *ChangePasswordRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if c.CurrentPassword == "" {
+		fields.Add("current_password", "current_password is required")
+	}
+
+	validatePassword(&fields, "new_password", c.NewPassword)
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// ChangeEmailRequest is a struct that represents change email request body.
+type ChangeEmailRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Validate is a method for validating the attributes in the change email request body.
+// The password is checked by the use case, since users who signed up with a phone number have none to give.
+func (c *ChangeEmailRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if c.Email == "" {
+		fields.Add("email", "email is required")
+	} else if !util.IsValidEmail(c.Email) {
+		fields.Add("email", "invalid email format")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
 }
 
 // UserLoginResponse is a struct that represents user login response body.
+// Users with two-factor authentication get a challenge token instead of the tokens, to exchange along with a code for them.
 type UserLoginResponse struct {
-	Token string `json:"token"`
+	Token          string `json:"token,omitempty"`
+	RefreshToken   string `json:"refresh_token,omitempty"`
+	ChallengeToken string `json:"challenge_token,omitempty"`
 }
 
 // NewUserLoginResponse is a function used to initialize the user login response struct.
-func NewUserLoginResponse(token string) *UserLoginResponse {
+func NewUserLoginResponse(token, refreshToken string) *UserLoginResponse {
+	return &UserLoginResponse{
+		Token:        token,
+		RefreshToken: refreshToken,
+	}
+}
+
+// NewTwoFactorChallengeResponse is a function used to initialize the user login response struct with a two-factor authentication challenge.
+func NewTwoFactorChallengeResponse(challengeToken string) *UserLoginResponse {
 	return &UserLoginResponse{
-		Token: token,
+		ChallengeToken: challengeToken,
+	}
+}
+
+// UserResponse is a struct that represents user response body.
+type UserResponse struct {
+	ID                int        `json:"id"`
+	Email             string     `json:"email"`
+	Phone             string     `json:"phone"`
+	Name              string     `json:"name"`
+	BirthDate         time.Time  `json:"birth_date"`
+	Gender            string     `json:"gender"`
+	Location          string     `json:"location"`
+	ProfilePictureURL string     `json:"profile_picture_url"`
+	ProfilePicture    *PhotoURLs `json:"profile_picture"`
+	EmailVerified     bool       `json:"email_verified"`
+	PhoneVerified     bool       `json:"phone_verified"`
+	Role              string     `json:"role"`
+}
+
+// NewUserResponse is a function used to initialize the user response struct.
+func NewUserResponse(user *User) *UserResponse {
+	return &UserResponse{
+		ID:            user.ID,
+		Email:         user.Email,
+		Phone:         user.Phone,
+		Name:          user.Name,
+		BirthDate:     user.BirthDate,
+		Gender:        user.Gender,
+		Location:      user.Location,
+		EmailVerified: user.IsEmailVerified(),
+		PhoneVerified: user.PhoneVerifiedAt != nil,
+		Role:          user.Role,
+	}
+}
+
+// SetProfilePicture is a method for showing the profile picture with the URLs signed for it, the large size being the profile picture URL.
+func (u *UserResponse) SetProfilePicture(urls *PhotoURLs) {
+	u.ProfilePicture = urls
+	u.ProfilePictureURL = urls.LargeURL()
+}
+
+// validatePassword is a function to add the field to the invalid fields when the password does not meet the password rules.
+func validatePassword(fields *apperror.FieldErrors, field, password string) {
+	if password == "" {
+		fields.Add(field, field+" is required")
+	} else if len(password) < 8 {
+		fields.Add(field, field+" must be at least 8 characters long")
+	}
+}
+
+// validateProfile is a function to add the fields of the profile given on sign up that are missing or invalid to the invalid fields.
+func validateProfile(fields *apperror.FieldErrors, name, birthDate, gender, location string) {
+	validateRequired(fields, "name", name, maxNameLength)
+
+	if birthDate == "" {
+		fields.Add("birth_date", "birth_date is required")
+	} else if _, err := time.Parse("2006-01-02", birthDate); err != nil {
+		fields.Add("birth_date", "invalid birth_date format. Format must be YYYY-MM-DD")
+	}
+
+	if gender == "" || !slices.Contains(genders, strings.ToUpper(gender)) {
+		fields.Add("gender", "gender must be \"MALE\", \"FEMALE\", or \"OTHER\"")
+	}
+
+	validateRequired(fields, "location", location, maxLocationLength)
+}
+
+// validatePhone is a function to add the phone field to the invalid fields when the phone number is missing or not a number with its country code.
+func validatePhone(fields *apperror.FieldErrors, phone string) {
+	if phone == "" {
+		fields.Add("phone", "phone is required")
+	} else if _, ok := util.NormalizePhone(phone); !ok {
+		fields.Add("phone", "invalid phone format. Format must be a number with its country code, such as +6281234567890")
 	}
 }
@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// UserIdentity is a struct that represents the account of a user at an identity provider, identified by its subject there.
+// The email is the one the provider vouched for when the account was linked, kept for reference only.
+type UserIdentity struct {
+	ID        int
+	UserID    int
+	Provider  string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+}
+
+// NewUserIdentity is a function used to initialize the user identity struct.
+func NewUserIdentity(userID int, provider, subject, email string, createdAt time.Time) *UserIdentity {
+	return &UserIdentity{
+		UserID:    userID,
+		Provider:  provider,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: createdAt,
+	}
+}
+
+// ExternalIdentity is a struct that represents the claims of an ID token verified by an identity provider.
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// IdentityProviderLoginRequest is a struct that represents identity provider login request body.
+// The nonce is one the service issued, which the client put in the authentication request and the ID token must carry back.
+// The profile attributes are only needed when no user is linked to the account or its email address yet.
+type IdentityProviderLoginRequest struct {
+	Provider  string `json:"provider"`
+	IDToken   string `json:"id_token"`
+	Nonce     string `json:"nonce"`
+	Name      string `json:"name"`
+	BirthDate string `json:"birth_date"`
+	Gender    string `json:"gender"`
+	Location  string `json:"location"`
+}
+
+// Validate is a method for validating the attributes in the identity provider login request body.
+func (i *IdentityProviderLoginRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if i.Provider == "" {
+		fields.Add("provider", "provider is required")
+	}
+	if i.IDToken == "" {
+		fields.Add("id_token", "id_token is required")
+	}
+	if i.Nonce == "" {
+		fields.Add("nonce", "nonce is required")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// ValidateSignup is a method for validating the profile attributes needed to sign up a new user, named after the identity provider account when no name is given.
+func (i *IdentityProviderLoginRequest) ValidateSignup(providerName string) error {
+	fields := apperror.FieldErrors{}
+	validateProfile(&fields, i.signupName(providerName), i.BirthDate, i.Gender, i.Location)
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// signupName is a method for getting the name a new user signs up with, which is the name at the identity provider unless another one is given.
+func (i *IdentityProviderLoginRequest) signupName(providerName string) string {
+	if i.Name != "" {
+		return i.Name
+	}
+
+	return providerName
+}
+
+// NewIdentityProviderUser is a function used to initialize the user struct of a user who signs up with an identity provider account.
+// The provider vouched for the email address, so it counts as verified, and the user has no password.
+func NewIdentityProviderUser(identity *ExternalIdentity, req *IdentityProviderLoginRequest, createdAt time.Time) *User {
+	user := NewUser(identity.Email, "", req.signupName(identity.Name), req.BirthDate, req.Gender, req.Location, "", createdAt, createdAt)
+	user.EmailVerifiedAt = &createdAt
+
+	return user
+}
+
+// IdentityProviderNonceResponse is a struct that represents identity provider nonce response body.
+// The nonce can be used for a single login with the provider until it expires.
+type IdentityProviderNonceResponse struct {
+	Nonce     string    `json:"nonce"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// NewIdentityProviderNonceResponse is a function used to initialize the identity provider nonce response struct.
+func NewIdentityProviderNonceResponse(nonce string, expiresAt time.Time) *IdentityProviderNonceResponse {
+	return &IdentityProviderNonceResponse{
+		Nonce:     nonce,
+		ExpiresAt: expiresAt,
+	}
+}
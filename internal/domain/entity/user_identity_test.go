@@ -0,0 +1,59 @@
+package entity_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestIdentityProviderLoginRequest_ValidateSignup(t *testing.T) {
+	tests := []struct {
+		name         string
+		req          *entity.IdentityProviderLoginRequest
+		providerName string
+		wantErr      error
+	}{
+		{
+			name:         "Failed: Name missing",
+			req:          &entity.IdentityProviderLoginRequest{BirthDate: "2000-01-01", Gender: "MALE", Location: "Indonesia"},
+			providerName: "",
+			wantErr:      apperror.ErrValidation,
+		},
+		{
+			name:         "Failed: Profile missing",
+			req:          &entity.IdentityProviderLoginRequest{},
+			providerName: "User",
+			wantErr:      apperror.ErrValidation,
+		},
+		{
+			name:         "Success with the name at the provider",
+			req:          &entity.IdentityProviderLoginRequest{BirthDate: "2000-01-01", Gender: "MALE", Location: "Indonesia"},
+			providerName: "User",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.ValidateSignup(tt.providerName); !errors.Is(err, tt.wantErr) {
+				t.Errorf("IdentityProviderLoginRequest.ValidateSignup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewIdentityProviderUser(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	identity := &entity.ExternalIdentity{Provider: "test", Subject: "subject", Email: "user@email.com", EmailVerified: true, Name: "User"}
+
+	user := entity.NewIdentityProviderUser(identity, &entity.IdentityProviderLoginRequest{Name: "Nickname", BirthDate: "2000-01-01"}, createdAt)
+	if user.Email != "user@email.com" || user.Name != "Nickname" || user.HasPassword() || !user.IsEmailVerified() {
+		t.Errorf("NewIdentityProviderUser() = %+v, want a user named as requested with a verified email and no password", user)
+	}
+
+	user = entity.NewIdentityProviderUser(identity, &entity.IdentityProviderLoginRequest{BirthDate: "2000-01-01"}, createdAt)
+	if user.Name != "User" {
+		t.Errorf("NewIdentityProviderUser() name = %q, want the name at the provider", user.Name)
+	}
+}
@@ -1,8 +1,11 @@
 package entity_test
 
 import (
+	"errors"
+	"reflect"
 	"testing"
 
+	"dealls-technical-test-dating-service/internal/domain/apperror"
 	"dealls-technical-test-dating-service/internal/domain/entity"
 )
 
@@ -142,6 +145,29 @@ func TestUserSignupRequest_Validate(t *testing.T) {
 	}
 }
 
+func TestUserSignupRequest_Validate_Reports_Every_Invalid_Field(t *testing.T) {
+	req := &entity.UserSignupRequest{
+		Email:     "invalid@email",
+		Password:  "short",
+		BirthDate: "01-01-2000",
+	}
+	err := req.Validate()
+
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) {
+		t.Fatalf("UserSignupRequest.Validate() error = %v, want a validation error", err)
+	}
+
+	got := make([]string, len(appErr.Fields))
+	for i, field := range appErr.Fields {
+		got[i] = field.Field
+	}
+	want := []string{"email", "password", "name", "birth_date", "gender", "location"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("UserSignupRequest.Validate() fields = %v, want %v", got, want)
+	}
+}
+
 func TestUserLoginRequest_Validate(t *testing.T) {
 	type fields struct {
 		email    string
@@ -195,3 +221,153 @@ func TestUserLoginRequest_Validate(t *testing.T) {
 		})
 	}
 }
+
+func TestResetPasswordRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *entity.ResetPasswordRequest
+		wantErr bool
+	}{
+		{
+			name:    "Failed: Token empty",
+			req:     &entity.ResetPasswordRequest{Password: "password"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed: Password too short",
+			req:     &entity.ResetPasswordRequest{Token: "token", Password: "pass"},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			req:     &entity.ResetPasswordRequest{Token: "token", Password: "password"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ResetPasswordRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChangePasswordRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *entity.ChangePasswordRequest
+		wantErr bool
+	}{
+		{
+			name:    "Failed: Current password empty",
+			req:     &entity.ChangePasswordRequest{NewPassword: "new-password"},
+			wantErr: true,
+		},
+		{
+			name:    "Failed: New password too short",
+			req:     &entity.ChangePasswordRequest{CurrentPassword: "password", NewPassword: "pass"},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			req:     &entity.ChangePasswordRequest{CurrentPassword: "password", NewPassword: "new-password"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ChangePasswordRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChangeEmailRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     *entity.ChangeEmailRequest
+		wantErr bool
+	}{
+		{
+			name:    "Failed: Invalid email",
+			req:     &entity.ChangeEmailRequest{Email: "email", Password: "password"},
+			wantErr: true,
+		},
+		{
+			name:    "Success without password",
+			req:     &entity.ChangeEmailRequest{Email: "user@email.com"},
+			wantErr: false,
+		},
+		{
+			name:    "Success",
+			req:     &entity.ChangeEmailRequest{Email: "user@email.com", Password: "password"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("ChangeEmailRequest.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPhoneSignupRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name       string
+		req        *entity.PhoneSignupRequest
+		wantFields []string
+	}{
+		{
+			name:       "Failed: Every field missing",
+			req:        &entity.PhoneSignupRequest{},
+			wantFields: []string{"phone", "code", "name", "birth_date", "gender", "location"},
+		},
+		{
+			name:       "Failed: Phone without country code",
+			req:        &entity.PhoneSignupRequest{Phone: "081234567890", Code: "123456", Name: "User", BirthDate: "2000-01-01", Gender: "MALE", Location: "Jakarta"},
+			wantFields: []string{"phone"},
+		},
+		{
+			name: "Success",
+			req:  &entity.PhoneSignupRequest{Phone: "+62 812 3456 7890", Code: "123456", Name: "User", BirthDate: "2000-01-01", Gender: "male", Location: "Jakarta"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if tt.wantFields == nil {
+				if err != nil {
+					t.Errorf("PhoneSignupRequest.Validate() error = %v, want nil", err)
+				}
+
+				return
+			}
+
+			var appErr *apperror.Error
+			if !errors.As(err, &appErr) {
+				t.Fatalf("PhoneSignupRequest.Validate() error = %v, want a validation error", err)
+			}
+
+			var got []string
+			for _, field := range appErr.Fields {
+				got = append(got, field.Field)
+			}
+			if !reflect.DeepEqual(got, tt.wantFields) {
+				t.Errorf("PhoneSignupRequest.Validate() fields = %v, want %v", got, tt.wantFields)
+			}
+		})
+	}
+}
+
+func TestUser_Identifier(t *testing.T) {
+	if got := (&entity.User{Email: "user@email.com", Phone: "+6281234567890"}).Identifier(); got != "user@email.com" {
+		t.Errorf("User.Identifier() = %q, want the email address", got)
+	}
+	if got := (&entity.User{Phone: "+6281234567890"}).Identifier(); got != "+6281234567890" {
+		t.Errorf("User.Identifier() = %q, want the phone number", got)
+	}
+}
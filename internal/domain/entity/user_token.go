@@ -0,0 +1,98 @@
+package entity
+
+import (
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+// User token purposes.
+const (
+	UserTokenPurposeEmailVerification = "EMAIL_VERIFICATION"
+	UserTokenPurposePasswordReset     = "PASSWORD_RESET"
+	UserTokenPurposeEmailChange       = "EMAIL_CHANGE"
+	UserTokenPurposeTwoFactorLogin    = "TWO_FACTOR_LOGIN"
+	UserTokenPurposeOIDCNonce         = "OIDC_NONCE"
+)
+
+// UserToken is a struct that represents the attributes of a single-use token sent to a user, such as an email verification token.
+// Only the hash of the token is stored, along with the email address it was sent to.
+// Tokens issued before anyone logs in, such as OpenID Connect nonces, belong to no user and are sent to no email address,
+// but record the IP address that requested them instead.
+type UserToken struct {
+	ID        int
+	UserID    int `gorm:"default:null"`
+	Purpose   string
+	TokenHash string
+	Email     string
+	IPAddress string `gorm:"default:null"`
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+}
+
+// NewUserToken is a function used to initialize the user token struct.
+func NewUserToken(userID int, purpose, tokenHash, email string, expiresAt, createdAt time.Time) *UserToken {
+	return &UserToken{
+		UserID:    userID,
+		Purpose:   purpose,
+		TokenHash: tokenHash,
+		Email:     email,
+		ExpiresAt: expiresAt,
+		CreatedAt: createdAt,
+	}
+}
+
+// IsUsable is a method for checking whether the user token can still be used at the given time.
+func (u *UserToken) IsUsable(now time.Time) bool {
+	return u.UsedAt == nil && now.Before(u.ExpiresAt)
+}
+
+// VerifyEmailRequest is a struct that represents verify email request body.
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// Validate is a method for validating the attributes in the verify email request body.
+func (v *VerifyEmailRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if v.Token == "" {
+		fields.Add("token", "token is required")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// ResendVerificationEmailRequest is a struct that represents resend verification email request body.
+type ResendVerificationEmailRequest struct {
+	Email string `json:"email"`
+}
+
+// Validate is a method for validating the attributes in the resend verification email request body.
+func (r *ResendVerificationEmailRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if r.Email == "" {
+		fields.Add("email", "email is required")
+	} else if !util.IsValidEmail(r.Email) {
+		fields.Add("email", "invalid email format")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
+
+// ConfirmEmailChangeRequest is a struct that represents confirm email change request body.
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token"`
+}
+
+// Validate is a method for validating the attributes in the confirm email change request body.
+func (c *ConfirmEmailChangeRequest) Validate() error {
+	fields := apperror.FieldErrors{}
+	if c.Token == "" {
+		fields.Add("token", "token is required")
+	}
+
+	return fields.Err(constant.InvalidRequestBody)
+}
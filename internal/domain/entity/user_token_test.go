@@ -0,0 +1,52 @@
+package entity_test
+
+import (
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func TestUserToken_IsUsable(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	past := now.Add(-time.Minute)
+	tests := []struct {
+		name      string
+		userToken *entity.UserToken
+		want      bool
+	}{
+		{
+			name:      "Usable",
+			userToken: &entity.UserToken{ExpiresAt: now.Add(time.Hour)},
+			want:      true,
+		},
+		{
+			name:      "Expired",
+			userToken: &entity.UserToken{ExpiresAt: now},
+			want:      false,
+		},
+		{
+			name:      "Used",
+			userToken: &entity.UserToken{ExpiresAt: now.Add(time.Hour), UsedAt: &past},
+			want:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.userToken.IsUsable(now); got != tt.want {
+				t.Errorf("UserToken.IsUsable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResendVerificationEmailRequest_Validate(t *testing.T) {
+	for _, email := range []string{"", "email"} {
+		if err := (&entity.ResendVerificationEmailRequest{Email: email}).Validate(); err == nil {
+			t.Errorf("ResendVerificationEmailRequest.Validate() error = nil for email %q", email)
+		}
+	}
+	if err := (&entity.ResendVerificationEmailRequest{Email: "user@email.com"}).Validate(); err != nil {
+		t.Errorf("ResendVerificationEmailRequest.Validate() error = %v", err)
+	}
+}
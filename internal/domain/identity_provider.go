@@ -0,0 +1,14 @@
+package domain
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// IdentityProvider is an interface that represents an OpenID Connect provider that users can log in with.
+// A token that is invalid, expired, issued for another client or with another nonce is rejected with an unauthorized error.
+type IdentityProvider interface {
+	Name() string
+	VerifyIDToken(ctx context.Context, idToken, nonce string) (*entity.ExternalIdentity, error)
+}
@@ -0,0 +1,8 @@
+package domain
+
+// ImageProcessor is an interface that represents the image functionality needed by the domain.
+// Resize decodes an image, turns it upright according to its EXIF orientation, and encodes one JPEG copy fitting in a square of each size.
+// The copies carry no metadata, so that the EXIF GPS position and camera details of uploaded photos are never served.
+type ImageProcessor interface {
+	Resize(data []byte, sizes []int) ([][]byte, error)
+}
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// Mailer is an interface that represents the email functionality needed by the domain.
+type Mailer interface {
+	Send(ctx context.Context, mail *entity.Mail) error
+}
@@ -0,0 +1,9 @@
+package domain
+
+// PasswordHasher is an interface that represents the password hashing functionality needed by the domain.
+// NeedsRehash reports whether a hash was made with another algorithm or other parameters than the ones new hashes are made with.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password, hashedPassword string) bool
+	NeedsRehash(hashedPassword string) bool
+}
@@ -0,0 +1,9 @@
+package domain
+
+import "context"
+
+// PaymentGateway is an interface that represents the payment functionality needed by the domain.
+type PaymentGateway interface {
+	Charge(ctx context.Context, userID int, amount int64, paymentToken string) (string, error)
+	Refund(ctx context.Context, reference string) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// ActivityCounterRepository is the activity counter repository interface.
+type ActivityCounterRepository interface {
+	Increment(ctx context.Context, userID int, date time.Time, limit int) (*entity.ActivityCounter, error)
+}
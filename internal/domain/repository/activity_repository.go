@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// ActivityRepository is the activity repository interface.
+type ActivityRepository interface {
+	Insert(ctx context.Context, activity *entity.Activity) error
+	InsertLike(ctx context.Context, activity *entity.Activity, likedUserID int) (*entity.Match, error)
+}
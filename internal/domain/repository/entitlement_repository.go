@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// EntitlementRepository is the entitlement repository interface.
+type EntitlementRepository interface {
+	ExistsByUserIDAndFeature(ctx context.Context, userID int, feature string, date time.Time) (bool, error)
+	FindUserIDsByFeature(ctx context.Context, userIDs []int, feature string, date time.Time) ([]int, error)
+}
@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// LoginLockoutRepository is the login lockout repository interface.
+type LoginLockoutRepository interface {
+	Insert(ctx context.Context, lockout *entity.LoginLockout) error
+}
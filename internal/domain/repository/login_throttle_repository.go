@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// LoginThrottleRepository is the login throttle repository interface.
+type LoginThrottleRepository interface {
+	Find(ctx context.Context, scope, subject string) (*entity.LoginThrottle, error)
+	RecordFailure(ctx context.Context, scope, subject string, failedAt, resetBefore time.Time) (*entity.LoginThrottle, error)
+	Lock(ctx context.Context, id int, lockedUntil time.Time) error
+	Delete(ctx context.Context, scope, subject string) error
+}
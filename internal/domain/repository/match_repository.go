@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// MatchRepository is the match repository interface.
+type MatchRepository interface {
+	FindByUserID(ctx context.Context, userID, cursor, limit int) ([]*entity.MatchDetail, error)
+	Unmatch(ctx context.Context, id, userID int, unmatchedAt time.Time) error
+}
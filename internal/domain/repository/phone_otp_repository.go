@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// PhoneOTPRepository is the phone OTP repository interface.
+type PhoneOTPRepository interface {
+	Insert(ctx context.Context, phoneOTP *entity.PhoneOTP) error
+	Lock(ctx context.Context, phone, ipAddress string) error
+	FindLatest(ctx context.Context, phone string) (*entity.PhoneOTP, error)
+	CountByPhoneSince(ctx context.Context, phone string, since time.Time) (int, error)
+	CountByIPAddressSince(ctx context.Context, ipAddress string, since time.Time) (int, error)
+	IncrementAttempts(ctx context.Context, id, maxAttempts int) error
+	MarkUsed(ctx context.Context, id int, usedAt time.Time) error
+}
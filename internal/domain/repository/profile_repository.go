@@ -3,6 +3,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"dealls-technical-test-dating-service/internal/domain/entity"
 )
@@ -10,4 +11,9 @@ import (
 // ProfileRepository is the profile repository interface.
 type ProfileRepository interface {
 	Insert(ctx context.Context, profile *entity.Profile) error
+	FindByID(ctx context.Context, id int) (*entity.Profile, error)
+	FindByUserID(ctx context.Context, userID int) (*entity.Profile, error)
+	FindCandidateByID(ctx context.Context, id int) (*entity.ProfileCandidate, error)
+	Update(ctx context.Context, id int, bio, interests string, updatedAt time.Time) error
+	FindDiscoverable(ctx context.Context, userID int, swipeDate time.Time, cursor, limit int, verifiedEmailOnly bool) ([]*entity.ProfileCandidate, error)
 }
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// RecoveryCodeRepository is the recovery code repository interface.
+type RecoveryCodeRepository interface {
+	InsertBatch(ctx context.Context, recoveryCodes []*entity.RecoveryCode) error
+	DeleteByUserID(ctx context.Context, userID int) error
+	MarkUsed(ctx context.Context, userID int, codeHash string, usedAt time.Time) error
+}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// RefreshTokenRepository is the refresh token repository interface.
+type RefreshTokenRepository interface {
+	Insert(ctx context.Context, refreshToken *entity.RefreshToken) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	MarkRotated(ctx context.Context, id int, rotatedAt time.Time) error
+	RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error
+	RevokeByUserID(ctx context.Context, userID int, revokedAt time.Time) error
+}
@@ -0,0 +1,8 @@
+package repository
+
+import "context"
+
+// RoleRepository is the role repository interface.
+type RoleRepository interface {
+	FindPermissions(ctx context.Context, role string) ([]string, error)
+}
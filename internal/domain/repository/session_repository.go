@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// SessionRepository is the session repository interface.
+type SessionRepository interface {
+	Insert(ctx context.Context, session *entity.Session) error
+	FindByTokenID(ctx context.Context, tokenID string) (*entity.Session, error)
+	FindByUserID(ctx context.Context, userID int, seenSince time.Time) ([]*entity.Session, error)
+	UpdateTokenID(ctx context.Context, refreshFamilyID, tokenID string, seenAt time.Time) error
+	UpdateLastSeen(ctx context.Context, id int, seenAt time.Time) error
+	Delete(ctx context.Context, id, userID int) (*entity.Session, error)
+	DeleteByTokenID(ctx context.Context, tokenID string) error
+	DeleteByUserID(ctx context.Context, userID int) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// SubscriptionPackageRepository is the subscription package repository interface.
+type SubscriptionPackageRepository interface {
+	FindAll(ctx context.Context) ([]*entity.SubscriptionPackage, error)
+	FindByID(ctx context.Context, id int) (*entity.SubscriptionPackage, error)
+}
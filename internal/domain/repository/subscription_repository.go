@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// SubscriptionRepository is the subscription repository interface.
+type SubscriptionRepository interface {
+	Insert(ctx context.Context, subscription *entity.Subscription) error
+	FindActiveByUserID(ctx context.Context, userID int, date time.Time) (*entity.Subscription, error)
+	DeactivateExpired(ctx context.Context, userID int, date time.Time) error
+	Deactivate(ctx context.Context, id int) error
+}
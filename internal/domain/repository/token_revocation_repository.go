@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// TokenRevocationRepository is the token revocation repository interface.
+type TokenRevocationRepository interface {
+	InsertRevokedToken(ctx context.Context, revokedToken *entity.RevokedToken) error
+	ExistsRevokedToken(ctx context.Context, id string) (bool, error)
+	UpsertUserTokenRevocation(ctx context.Context, revocation *entity.UserTokenRevocation) error
+	FindRevokedBefore(ctx context.Context, userID int) (time.Time, error)
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// TwoFactorRepository is the two-factor repository interface.
+type TwoFactorRepository interface {
+	Find(ctx context.Context, userID int) (*entity.TwoFactor, error)
+	Upsert(ctx context.Context, twoFactor *entity.TwoFactor) error
+	Confirm(ctx context.Context, userID int, step int64, confirmedAt time.Time) error
+	UseStep(ctx context.Context, userID int, step int64) error
+}
@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// UserIdentityRepository is the user identity repository interface.
+type UserIdentityRepository interface {
+	Insert(ctx context.Context, userIdentity *entity.UserIdentity) error
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+}
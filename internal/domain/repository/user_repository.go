@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"dealls-technical-test-dating-service/internal/domain/entity"
 )
@@ -10,4 +11,12 @@ import (
 type UserRepository interface {
 	Insert(ctx context.Context, user *entity.User) (int, error)
 	FindByEmail(ctx context.Context, email string) (*entity.User, error)
+	FindByPhone(ctx context.Context, phone string) (*entity.User, error)
+	FindByID(ctx context.Context, id int) (*entity.User, error)
+	UpdateEmailVerifiedAt(ctx context.Context, id int, verifiedAt time.Time) error
+	UpdatePassword(ctx context.Context, id int, password string, updatedAt time.Time) error
+	UpdateEmail(ctx context.Context, id int, email string, verifiedAt time.Time) error
+	UpdateRole(ctx context.Context, id int, role string, updatedAt time.Time) error
+	UpdateProfile(ctx context.Context, id int, name, location string, updatedAt time.Time) error
+	UpdateProfilePictureKey(ctx context.Context, id int, key string, updatedAt time.Time) (string, error)
 }
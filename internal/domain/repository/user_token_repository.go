@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// UserTokenRepository is the user token repository interface.
+type UserTokenRepository interface {
+	Insert(ctx context.Context, userToken *entity.UserToken) error
+	FindByTokenHash(ctx context.Context, purpose, tokenHash string) (*entity.UserToken, error)
+	FindLatest(ctx context.Context, userID int, purpose string) (*entity.UserToken, error)
+	MarkUsed(ctx context.Context, id int, usedAt time.Time) error
+	MarkUsedByUserID(ctx context.Context, userID int, purpose string, usedAt time.Time) error
+	LockIPAddress(ctx context.Context, purpose, ipAddress string) error
+	CountUsableByIPAddress(ctx context.Context, purpose, ipAddress string, now time.Time) (int, error)
+	DeleteUnusable(ctx context.Context, purpose string, now time.Time) error
+}
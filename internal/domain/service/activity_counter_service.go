@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// ActivityCounterService is the interface used for the activity counter service.
+type ActivityCounterService interface {
+	ConsumeSwipe(ctx context.Context, userID int, date time.Time, limit int) (int, error)
+}
+
+type activityCounterService struct {
+	repo repository.ActivityCounterRepository
+}
+
+// NewActivityCounterService is a function used to initialize the activity counter service implementation.
+func NewActivityCounterService(repo repository.ActivityCounterRepository) ActivityCounterService {
+	return &activityCounterService{
+		repo: repo,
+	}
+}
+
+// ConsumeSwipe is a method for consuming one swipe of the quota of a user on the date and returning the remaining swipes.
+func (a *activityCounterService) ConsumeSwipe(ctx context.Context, userID int, date time.Time, limit int) (int, error) {
+	counter, err := a.repo.Increment(ctx, userID, date, limit)
+	if errors.Is(err, apperror.ErrQuotaExceeded) {
+		return 0, apperror.New(apperror.KindQuotaExceeded, constant.SwipeQuotaExceeded)
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return max(limit-counter.Count, 0), nil
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/pkg/constant"
+
+	"gorm.io/gorm/schema"
+)
+
+type fakeActivityCounterRepository struct {
+	counter *entity.ActivityCounter
+	err     error
+}
+
+func (f *fakeActivityCounterRepository) Increment(context.Context, int, time.Time, int) (*entity.ActivityCounter, error) {
+	return f.counter, f.err
+}
+
+func TestActivityCounterService_ConsumeSwipe(t *testing.T) {
+	type fields struct {
+		repo repository.ActivityCounterRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    int
+		wantErr string
+	}{
+		{
+			name: "Failed: Increment failed",
+			fields: fields{
+				repo: &fakeActivityCounterRepository{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			want:    0,
+			wantErr: schema.ErrUnsupportedDataType.Error(),
+		},
+		{
+			name: "Failed: Quota exceeded",
+			fields: fields{
+				repo: &fakeActivityCounterRepository{
+					err: apperror.ErrQuotaExceeded,
+				},
+			},
+			want:    0,
+			wantErr: constant.SwipeQuotaExceeded,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeActivityCounterRepository{
+					counter: &entity.ActivityCounter{
+						Count: 3,
+					},
+				},
+			},
+			want:    7,
+			wantErr: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewActivityCounterService(tt.fields.repo)
+			got, err := a.ConsumeSwipe(context.Background(), 1, time.Now(), 10)
+			if (err != nil && err.Error() != tt.wantErr) || (err == nil && tt.wantErr != "") {
+				t.Errorf("ActivityCounterService.ConsumeSwipe() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if got != tt.want {
+				t.Errorf("ActivityCounterService.ConsumeSwipe() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+)
+
+// ActivityService is the interface used for the activity service.
+type ActivityService interface {
+	CreateActivity(ctx context.Context, activity *entity.Activity) error
+	CreateLike(ctx context.Context, activity *entity.Activity, likedUserID int) (*entity.Match, error)
+}
+
+type activityService struct {
+	repo repository.ActivityRepository
+}
+
+// NewActivityService is a function used to initialize the activity service implementation.
+func NewActivityService(repo repository.ActivityRepository) ActivityService {
+	return &activityService{
+		repo: repo,
+	}
+}
+
+// CreateActivity is a method for creating an activity.
+func (a *activityService) CreateActivity(ctx context.Context, activity *entity.Activity) error {
+	return a.repo.Insert(ctx, activity)
+}
+
+// CreateLike is a method for creating a like activity, returning the match formed when the liked user has liked back.
+func (a *activityService) CreateLike(ctx context.Context, activity *entity.Activity, likedUserID int) (*entity.Match, error) {
+	return a.repo.InsertLike(ctx, activity, likedUserID)
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+
+	"gorm.io/gorm/schema"
+)
+
+type fakeActivityRepository struct {
+	match *entity.Match
+	err   error
+}
+
+func (f *fakeActivityRepository) Insert(context.Context, *entity.Activity) error {
+	return f.err
+}
+
+func (f *fakeActivityRepository) InsertLike(context.Context, *entity.Activity, int) (*entity.Match, error) {
+	return f.match, f.err
+}
+
+func TestActivityService_CreateActivity(t *testing.T) {
+	type fields struct {
+		repo repository.ActivityRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeActivityRepository{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeActivityRepository{
+					err: nil,
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewActivityService(tt.fields.repo)
+			if err := a.CreateActivity(context.Background(), &entity.Activity{}); (err != nil) != tt.wantErr {
+				t.Errorf("ActivityService.CreateActivity() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestActivityService_CreateLike(t *testing.T) {
+	type fields struct {
+		repo repository.ActivityRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *entity.Match
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeActivityRepository{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success: Not matched",
+			fields: fields{
+				repo: &fakeActivityRepository{},
+			},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name: "Success: Matched",
+			fields: fields{
+				repo: &fakeActivityRepository{
+					match: &entity.Match{ID: 1, UserOneID: 1, UserTwoID: 2},
+				},
+			},
+			want:    &entity.Match{ID: 1, UserOneID: 1, UserTwoID: 2},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := NewActivityService(tt.fields.repo)
+			got, err := a.CreateLike(context.Background(), &entity.Activity{}, 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ActivityService.CreateLike() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ActivityService.CreateLike() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
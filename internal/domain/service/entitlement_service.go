@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+// EntitlementService is the interface used for the entitlement service.
+type EntitlementService interface {
+	HasFeature(ctx context.Context, userID int, feature string) (bool, error)
+	GetUsersWithFeature(ctx context.Context, userIDs []int, feature string) (map[int]bool, error)
+}
+
+type entitlementService struct {
+	repo repository.EntitlementRepository
+}
+
+// NewEntitlementService is a function used to initialize the entitlement service implementation.
+func NewEntitlementService(repo repository.EntitlementRepository) EntitlementService {
+	return &entitlementService{
+		repo: repo,
+	}
+}
+
+// HasFeature is a method for checking whether a user has a feature through a subscription that is active today.
+func (e *entitlementService) HasFeature(ctx context.Context, userID int, feature string) (bool, error) {
+	return e.repo.ExistsByUserIDAndFeature(ctx, userID, feature, util.StartOfDay(time.Now()))
+}
+
+// GetUsersWithFeature is a method for getting which of the users have a feature through a subscription that is active today.
+func (e *entitlementService) GetUsersWithFeature(ctx context.Context, userIDs []int, feature string) (map[int]bool, error) {
+	entitledUserIDs, err := e.repo.FindUserIDsByFeature(ctx, userIDs, feature, util.StartOfDay(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+
+	users := make(map[int]bool, len(entitledUserIDs))
+	for _, userID := range entitledUserIDs {
+		users[userID] = true
+	}
+
+	return users, nil
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+
+	"gorm.io/gorm/schema"
+)
+
+type fakeEntitlementRepository struct {
+	exists  bool
+	userIDs []int
+	err     error
+}
+
+func (f *fakeEntitlementRepository) ExistsByUserIDAndFeature(context.Context, int, string, time.Time) (bool, error) {
+	return f.exists, f.err
+}
+
+func (f *fakeEntitlementRepository) FindUserIDsByFeature(context.Context, []int, string, time.Time) ([]int, error) {
+	return f.userIDs, f.err
+}
+
+func TestEntitlementService_HasFeature(t *testing.T) {
+	type fields struct {
+		repo repository.EntitlementRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeEntitlementRepository{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeEntitlementRepository{
+					exists: true,
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEntitlementService(tt.fields.repo)
+			got, err := e.HasFeature(context.Background(), 1, entity.FeatureNoSwipeQuota)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EntitlementService.HasFeature() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if got != tt.want {
+				t.Errorf("EntitlementService.HasFeature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEntitlementService_GetUsersWithFeature(t *testing.T) {
+	type fields struct {
+		repo repository.EntitlementRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    map[int]bool
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeEntitlementRepository{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeEntitlementRepository{
+					userIDs: []int{2, 3},
+				},
+			},
+			want:    map[int]bool{2: true, 3: true},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := NewEntitlementService(tt.fields.repo)
+			got, err := e.GetUsersWithFeature(context.Background(), []int{1, 2, 3}, entity.FeatureVerifiedLabel)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("EntitlementService.GetUsersWithFeature() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EntitlementService.GetUsersWithFeature() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
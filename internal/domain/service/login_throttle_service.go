@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// LoginThrottleService is the interface used for the login throttle service.
+type LoginThrottleService interface {
+	CheckLogin(ctx context.Context, email, ipAddress string) error
+	RecordFailedLogin(ctx context.Context, email, ipAddress string, userID *int) error
+	ResetFailedLogins(ctx context.Context, email string) error
+}
+
+type loginThrottleService struct {
+	throttleRepo  repository.LoginThrottleRepository
+	lockoutRepo   repository.LoginLockoutRepository
+	accountPolicy *entity.LoginThrottlePolicy
+	ipPolicy      *entity.LoginThrottlePolicy
+}
+
+// loginThrottleSubject is a struct that represents an email address or an IP address whose failed login attempts are counted.
+type loginThrottleSubject struct {
+	scope   string
+	subject string
+	policy  *entity.LoginThrottlePolicy
+}
+
+// NewLoginThrottleService is a function used to initialize the login throttle service implementation.
+// Failed attempts are counted against the email address with the account policy and against the IP address with the IP policy.
+func NewLoginThrottleService(
+	throttleRepo repository.LoginThrottleRepository, lockoutRepo repository.LoginLockoutRepository, accountPolicy, ipPolicy *entity.LoginThrottlePolicy,
+) LoginThrottleService {
+	return &loginThrottleService{
+		throttleRepo:  throttleRepo,
+		lockoutRepo:   lockoutRepo,
+		accountPolicy: accountPolicy,
+		ipPolicy:      ipPolicy,
+	}
+}
+
+// CheckLogin is a method for refusing a login attempt while the email address or the IP address is locked out.
+func (l *loginThrottleService) CheckLogin(ctx context.Context, email, ipAddress string) error {
+	now := time.Now().UTC()
+	for _, s := range l.subjects(email, ipAddress) {
+		throttle, err := l.throttleRepo.Find(ctx, s.scope, s.subject)
+		if errors.Is(err, apperror.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if throttle.IsLocked(now) {
+			return apperror.New(apperror.KindQuotaExceeded, constant.TooManyLoginAttempts)
+		}
+	}
+
+	return nil
+}
+
+// RecordFailedLogin is a method for counting a failed login attempt against the email address and the IP address.
+// Reaching the maximum number of failed attempts locks the address out and records the lockout for auditing, the user ID being nil for unknown emails.
+func (l *loginThrottleService) RecordFailedLogin(ctx context.Context, email, ipAddress string, userID *int) error {
+	now := time.Now().UTC()
+	for _, s := range l.subjects(email, ipAddress) {
+		throttle, err := l.throttleRepo.RecordFailure(ctx, s.scope, s.subject, now, now.Add(-s.policy.FailedAttemptWindow))
+		if err != nil {
+			return err
+		}
+
+		lockout := s.policy.Lockout(throttle.FailedAttempts)
+		if lockout == 0 {
+			continue
+		}
+
+		lockedUntil := now.Add(lockout)
+		err = l.throttleRepo.Lock(ctx, throttle.ID, lockedUntil)
+		if err != nil {
+			return err
+		}
+
+		throttle.LockedUntil = &lockedUntil
+		err = l.lockoutRepo.Insert(ctx, entity.NewLoginLockout(throttle, userID, ipAddress, now))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ResetFailedLogins is a method for forgetting the failed login attempts of an email address after a successful login.
+// Those of the IP address are kept, so that logging in to an account of one's own does not allow guessing more passwords of others.
+func (l *loginThrottleService) ResetFailedLogins(ctx context.Context, email string) error {
+	return l.throttleRepo.Delete(ctx, entity.LoginThrottleScopeAccount, email)
+}
+
+// subjects is a method for getting the addresses a login attempt is counted against, the IP address being skipped when it is unknown.
+func (l *loginThrottleService) subjects(email, ipAddress string) []loginThrottleSubject {
+	subjects := []loginThrottleSubject{{scope: entity.LoginThrottleScopeAccount, subject: email, policy: l.accountPolicy}}
+	if ipAddress != "" {
+		subjects = append(subjects, loginThrottleSubject{scope: entity.LoginThrottleScopeIP, subject: ipAddress, policy: l.ipPolicy})
+	}
+
+	return subjects
+}
@@ -0,0 +1,169 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+
+	"gorm.io/gorm/schema"
+)
+
+type fakeLoginThrottleRepository struct {
+	throttles map[string]*entity.LoginThrottle
+	err       error
+}
+
+func (f *fakeLoginThrottleRepository) Find(_ context.Context, scope, subject string) (*entity.LoginThrottle, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	throttle, ok := f.throttles[scope+":"+subject]
+	if !ok {
+		return &entity.LoginThrottle{}, apperror.ErrNotFound
+	}
+
+	return throttle, nil
+}
+
+func (f *fakeLoginThrottleRepository) RecordFailure(_ context.Context, scope, subject string, failedAt, resetBefore time.Time) (*entity.LoginThrottle, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	if f.throttles == nil {
+		f.throttles = map[string]*entity.LoginThrottle{}
+	}
+	throttle, ok := f.throttles[scope+":"+subject]
+	if !ok {
+		throttle = &entity.LoginThrottle{ID: len(f.throttles) + 1, Scope: scope, Subject: subject}
+		f.throttles[scope+":"+subject] = throttle
+	}
+	if throttle.LastFailedAt.Before(resetBefore) {
+		throttle.FailedAttempts = 0
+		throttle.LockedUntil = nil
+	}
+	throttle.FailedAttempts++
+	throttle.LastFailedAt = failedAt
+
+	return throttle, nil
+}
+
+func (f *fakeLoginThrottleRepository) Lock(_ context.Context, id int, lockedUntil time.Time) error {
+	for _, throttle := range f.throttles {
+		if throttle.ID == id {
+			throttle.LockedUntil = &lockedUntil
+		}
+	}
+
+	return f.err
+}
+
+func (f *fakeLoginThrottleRepository) Delete(_ context.Context, scope, subject string) error {
+	delete(f.throttles, scope+":"+subject)
+
+	return f.err
+}
+
+type fakeLoginLockoutRepository struct {
+	lockouts []*entity.LoginLockout
+}
+
+func (f *fakeLoginLockoutRepository) Insert(_ context.Context, lockout *entity.LoginLockout) error {
+	f.lockouts = append(f.lockouts, lockout)
+
+	return nil
+}
+
+func TestLoginThrottleService_CheckLogin(t *testing.T) {
+	lockedUntil := time.Now().UTC().Add(time.Minute)
+	tests := []struct {
+		name    string
+		repo    *fakeLoginThrottleRepository
+		wantErr error
+	}{
+		{
+			name:    "Failed: Repository error",
+			repo:    &fakeLoginThrottleRepository{err: schema.ErrUnsupportedDataType},
+			wantErr: schema.ErrUnsupportedDataType,
+		},
+		{
+			name: "Failed: Account locked",
+			repo: &fakeLoginThrottleRepository{throttles: map[string]*entity.LoginThrottle{
+				entity.LoginThrottleScopeAccount + ":user@email.com": {LockedUntil: &lockedUntil},
+			}},
+			wantErr: apperror.ErrQuotaExceeded,
+		},
+		{
+			name: "Failed: IP address locked",
+			repo: &fakeLoginThrottleRepository{throttles: map[string]*entity.LoginThrottle{
+				entity.LoginThrottleScopeIP + ":192.0.2.1": {LockedUntil: &lockedUntil},
+			}},
+			wantErr: apperror.ErrQuotaExceeded,
+		},
+		{
+			name: "Success: Failed attempts below the maximum",
+			repo: &fakeLoginThrottleRepository{throttles: map[string]*entity.LoginThrottle{
+				entity.LoginThrottleScopeAccount + ":user@email.com": {FailedAttempts: 2},
+			}},
+		},
+		{
+			name: "Success: Never failed",
+			repo: &fakeLoginThrottleRepository{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := NewLoginThrottleService(test.repo, &fakeLoginLockoutRepository{}, &entity.LoginThrottlePolicy{}, &entity.LoginThrottlePolicy{})
+			err := l.CheckLogin(context.Background(), "user@email.com", "192.0.2.1")
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("LoginThrottleService.CheckLogin() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestLoginThrottleService_RecordFailedLogin(t *testing.T) {
+	throttleRepo := &fakeLoginThrottleRepository{}
+	lockoutRepo := &fakeLoginLockoutRepository{}
+	accountPolicy := entity.NewLoginThrottlePolicy(3, time.Minute, time.Hour, time.Hour)
+	ipPolicy := entity.NewLoginThrottlePolicy(10, time.Minute, time.Hour, time.Hour)
+	l := NewLoginThrottleService(throttleRepo, lockoutRepo, accountPolicy, ipPolicy)
+
+	userID := 1
+	for i := 0; i < 2; i++ {
+		err := l.RecordFailedLogin(context.Background(), "user@email.com", "192.0.2.1", &userID)
+		if err != nil {
+			t.Fatalf("LoginThrottleService.RecordFailedLogin() error = %v", err)
+		}
+	}
+	if err := l.CheckLogin(context.Background(), "user@email.com", "192.0.2.1"); err != nil {
+		t.Fatalf("LoginThrottleService.CheckLogin() error = %v below the maximum", err)
+	}
+
+	err := l.RecordFailedLogin(context.Background(), "user@email.com", "192.0.2.1", &userID)
+	if err != nil {
+		t.Fatalf("LoginThrottleService.RecordFailedLogin() error = %v", err)
+	}
+	if err := l.CheckLogin(context.Background(), "user@email.com", "192.0.2.2"); !errors.Is(err, apperror.ErrQuotaExceeded) {
+		t.Errorf("LoginThrottleService.CheckLogin() error = %v once locked, want %v", err, apperror.ErrQuotaExceeded)
+	}
+	if err := l.CheckLogin(context.Background(), "other@email.com", "192.0.2.1"); err != nil {
+		t.Errorf("LoginThrottleService.CheckLogin() error = %v for another account from the same IP address", err)
+	}
+	if len(lockoutRepo.lockouts) != 1 || lockoutRepo.lockouts[0].Scope != entity.LoginThrottleScopeAccount || *lockoutRepo.lockouts[0].UserID != userID {
+		t.Errorf("LoginThrottleService.RecordFailedLogin() recorded lockouts %+v, want one of the account", lockoutRepo.lockouts)
+	}
+
+	err = l.ResetFailedLogins(context.Background(), "user@email.com")
+	if err != nil {
+		t.Fatalf("LoginThrottleService.ResetFailedLogins() error = %v", err)
+	}
+	if err := l.CheckLogin(context.Background(), "user@email.com", "192.0.2.1"); err != nil {
+		t.Errorf("LoginThrottleService.CheckLogin() error = %v after a reset", err)
+	}
+}
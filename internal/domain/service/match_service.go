@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+)
+
+// MatchService is the interface used for the match service.
+type MatchService interface {
+	GetMatches(ctx context.Context, userID, cursor, limit int) ([]*entity.MatchDetail, error)
+	Unmatch(ctx context.Context, id, userID int) error
+}
+
+type matchService struct {
+	repo repository.MatchRepository
+}
+
+// NewMatchService is a function used to initialize the match service implementation.
+func NewMatchService(repo repository.MatchRepository) MatchService {
+	return &matchService{
+		repo: repo,
+	}
+}
+
+// GetMatches is a method for getting the current matches of a user before the cursor, newest first.
+func (m *matchService) GetMatches(ctx context.Context, userID, cursor, limit int) ([]*entity.MatchDetail, error) {
+	return m.repo.FindByUserID(ctx, userID, cursor, limit)
+}
+
+// Unmatch is a method for unmatching a current match of a user.
+func (m *matchService) Unmatch(ctx context.Context, id, userID int) error {
+	return m.repo.Unmatch(ctx, id, userID, time.Now().UTC())
+}
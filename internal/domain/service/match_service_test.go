@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+
+	"gorm.io/gorm/schema"
+)
+
+type fakeMatchRepository struct {
+	matches []*entity.MatchDetail
+	err     error
+}
+
+func (f *fakeMatchRepository) FindByUserID(context.Context, int, int, int) ([]*entity.MatchDetail, error) {
+	return f.matches, f.err
+}
+
+func (f *fakeMatchRepository) Unmatch(context.Context, int, int, time.Time) error {
+	return f.err
+}
+
+func TestMatchService_GetMatches(t *testing.T) {
+	type fields struct {
+		repo repository.MatchRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []*entity.MatchDetail
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeMatchRepository{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeMatchRepository{
+					matches: []*entity.MatchDetail{{ID: 1, UserID: 2, ProfileID: 2}},
+				},
+			},
+			want:    []*entity.MatchDetail{{ID: 1, UserID: 2, ProfileID: 2}},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatchService(tt.fields.repo)
+			got, err := m.GetMatches(context.Background(), 1, 0, 10)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("MatchService.GetMatches() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchService.GetMatches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchService_Unmatch(t *testing.T) {
+	type fields struct {
+		repo repository.MatchRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeMatchRepository{
+					err: apperror.ErrNotFound,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeMatchRepository{},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := NewMatchService(tt.fields.repo)
+			if err := m.Unmatch(context.Background(), 1, 1); (err != nil) != tt.wantErr {
+				t.Errorf("MatchService.Unmatch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+// phoneOTPDigits is the number of digits of the one-time passwords sent by SMS.
+const phoneOTPDigits = 6
+
+// PhoneOTPService is the interface used for the phone OTP service.
+type PhoneOTPService interface {
+	IssueOTP(ctx context.Context, phone, ipAddress string) (*entity.PhoneOTP, string, error)
+	VerifyOTP(ctx context.Context, phone, code string) error
+}
+
+type phoneOTPService struct {
+	repo   repository.PhoneOTPRepository
+	policy *entity.PhoneOTPPolicy
+}
+
+// NewPhoneOTPService is a function used to initialize the phone OTP service implementation.
+func NewPhoneOTPService(repo repository.PhoneOTPRepository, policy *entity.PhoneOTPPolicy) PhoneOTPService {
+	return &phoneOTPService{
+		repo:   repo,
+		policy: policy,
+	}
+}
+
+// IssueOTP is a method for generating a one-time password for a phone number and storing its hash, returning it along with the code to send.
+// Another code is refused within the resend interval or past the daily maximums of the phone number and the IP address, so that the endpoint
+// cannot be used to flood a phone number with text messages or to send them at our expense. It must be called in a transaction,
+// which holds the lock taken on the phone number and the IP address so that concurrent requests cannot all pass the checks.
+func (p *phoneOTPService) IssueOTP(ctx context.Context, phone, ipAddress string) (*entity.PhoneOTP, string, error) {
+	err := p.repo.Lock(ctx, phone, ipAddress)
+	if err != nil {
+		return nil, "", err
+	}
+
+	currentTime := time.Now().UTC()
+	latest, err := p.repo.FindLatest(ctx, phone)
+	if err != nil && !errors.Is(err, apperror.ErrNotFound) {
+		return nil, "", err
+	}
+	if err == nil && currentTime.Sub(latest.CreatedAt) < p.policy.ResendInterval {
+		return nil, "", apperror.New(apperror.KindQuotaExceeded, constant.OTPRecentlySent)
+	}
+
+	since := currentTime.Add(-24 * time.Hour)
+	if p.policy.MaxDailySends > 0 {
+		count, err := p.repo.CountByPhoneSince(ctx, phone, since)
+		if err != nil {
+			return nil, "", err
+		}
+		if count >= p.policy.MaxDailySends {
+			return nil, "", apperror.New(apperror.KindQuotaExceeded, constant.OTPDailyLimitReached)
+		}
+	}
+	if p.policy.MaxDailySendsPerIP > 0 && ipAddress != "" {
+		count, err := p.repo.CountByIPAddressSince(ctx, ipAddress, since)
+		if err != nil {
+			return nil, "", err
+		}
+		if count >= p.policy.MaxDailySendsPerIP {
+			return nil, "", apperror.New(apperror.KindQuotaExceeded, constant.OTPDailyLimitReached)
+		}
+	}
+
+	code, err := randomPhoneOTPCode()
+	if err != nil {
+		return nil, "", err
+	}
+
+	phoneOTP := entity.NewPhoneOTP(phone, ipAddress, util.HashToken(code), currentTime.Add(p.policy.Expiration), currentTime)
+	err = p.repo.Insert(ctx, phoneOTP)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return phoneOTP, code, nil
+}
+
+// VerifyOTP is a method for consuming the latest one-time password sent to a phone number, so that it cannot be used again.
+// Every code given counts as an attempt before it is compared, and the one-time password cannot be used anymore once they run out.
+func (p *phoneOTPService) VerifyOTP(ctx context.Context, phone, code string) error {
+	phoneOTP, err := p.repo.FindLatest(ctx, phone)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.Wrap(apperror.KindUnauthorized, constant.InvalidOTP, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	currentTime := time.Now().UTC()
+	if !phoneOTP.IsUsable(currentTime, p.policy.MaxAttempts) {
+		return apperror.New(apperror.KindUnauthorized, constant.InvalidOTP)
+	}
+
+	err = p.repo.IncrementAttempts(ctx, phoneOTP.ID, p.policy.MaxAttempts)
+	if errors.Is(err, apperror.ErrConflict) {
+		return apperror.Wrap(apperror.KindUnauthorized, constant.InvalidOTP, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	if subtle.ConstantTimeCompare([]byte(util.HashToken(code)), []byte(phoneOTP.CodeHash)) != 1 {
+		return apperror.New(apperror.KindUnauthorized, constant.InvalidOTP)
+	}
+
+	err = p.repo.MarkUsed(ctx, phoneOTP.ID, currentTime)
+	if errors.Is(err, apperror.ErrConflict) {
+		return apperror.Wrap(apperror.KindUnauthorized, constant.InvalidOTP, err)
+	}
+
+	return err
+}
+
+// randomPhoneOTPCode is a function to generate a numeric one-time password, keeping its leading zeros.
+func randomPhoneOTPCode() (string, error) {
+	limit := big.NewInt(1)
+	for range phoneOTPDigits {
+		limit.Mul(limit, big.NewInt(10))
+	}
+
+	n, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", phoneOTPDigits, n), nil
+}
@@ -0,0 +1,202 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+type fakePhoneOTPRepository struct {
+	phoneOTPs []*entity.PhoneOTP
+	locked    [][2]string
+}
+
+func (f *fakePhoneOTPRepository) Insert(_ context.Context, phoneOTP *entity.PhoneOTP) error {
+	phoneOTP.ID = len(f.phoneOTPs) + 1
+	f.phoneOTPs = append(f.phoneOTPs, phoneOTP)
+
+	return nil
+}
+
+func (f *fakePhoneOTPRepository) Lock(_ context.Context, phone, ipAddress string) error {
+	f.locked = append(f.locked, [2]string{phone, ipAddress})
+
+	return nil
+}
+
+func (f *fakePhoneOTPRepository) CountByPhoneSince(_ context.Context, phone string, since time.Time) (int, error) {
+	count := 0
+	for _, phoneOTP := range f.phoneOTPs {
+		if phoneOTP.Phone == phone && phoneOTP.CreatedAt.After(since) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (f *fakePhoneOTPRepository) CountByIPAddressSince(_ context.Context, ipAddress string, since time.Time) (int, error) {
+	count := 0
+	for _, phoneOTP := range f.phoneOTPs {
+		if phoneOTP.IPAddress == ipAddress && phoneOTP.CreatedAt.After(since) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (f *fakePhoneOTPRepository) FindLatest(_ context.Context, phone string) (*entity.PhoneOTP, error) {
+	for i := len(f.phoneOTPs) - 1; i >= 0; i-- {
+		if f.phoneOTPs[i].Phone == phone {
+			return f.phoneOTPs[i], nil
+		}
+	}
+
+	return &entity.PhoneOTP{}, apperror.ErrNotFound
+}
+
+func (f *fakePhoneOTPRepository) IncrementAttempts(_ context.Context, id, maxAttempts int) error {
+	phoneOTP := f.phoneOTPs[id-1]
+	if phoneOTP.Attempts >= maxAttempts || phoneOTP.UsedAt != nil {
+		return apperror.New(apperror.KindConflict, "Phone OTP has no attempts left")
+	}
+	phoneOTP.Attempts++
+
+	return nil
+}
+
+func (f *fakePhoneOTPRepository) MarkUsed(_ context.Context, id int, usedAt time.Time) error {
+	phoneOTP := f.phoneOTPs[id-1]
+	if phoneOTP.UsedAt != nil {
+		return apperror.New(apperror.KindConflict, "Phone OTP already used")
+	}
+	phoneOTP.UsedAt = &usedAt
+
+	return nil
+}
+
+func newTestPhoneOTPService(repo *fakePhoneOTPRepository) PhoneOTPService {
+	return NewPhoneOTPService(repo, entity.NewPhoneOTPPolicy(5*time.Minute, 3, time.Minute, 3, 5))
+}
+
+func TestPhoneOTPService_IssueOTP(t *testing.T) {
+	repo := &fakePhoneOTPRepository{}
+	p := newTestPhoneOTPService(repo)
+	phoneOTP, code, err := p.IssueOTP(context.Background(), "+6281234567890", "203.0.113.1")
+	if err != nil {
+		t.Fatalf("PhoneOTPService.IssueOTP() error = %v", err)
+	}
+	if len(code) != phoneOTPDigits || phoneOTP.CodeHash == code {
+		t.Errorf("PhoneOTPService.IssueOTP() code = %q, hash = %q, want a %d digit code stored hashed", code, phoneOTP.CodeHash, phoneOTPDigits)
+	}
+	if got := phoneOTP.ExpiresAt.Sub(phoneOTP.CreatedAt); got != 5*time.Minute {
+		t.Errorf("PhoneOTPService.IssueOTP() expires after %v, want %v", got, 5*time.Minute)
+	}
+
+	_, _, err = p.IssueOTP(context.Background(), "+6281234567890", "203.0.113.1")
+	if !errors.Is(err, apperror.ErrQuotaExceeded) {
+		t.Errorf("PhoneOTPService.IssueOTP() error = %v within the resend interval, want %v", err, apperror.ErrQuotaExceeded)
+	}
+
+	_, _, err = p.IssueOTP(context.Background(), "+6281234567891", "203.0.113.1")
+	if err != nil {
+		t.Errorf("PhoneOTPService.IssueOTP() error = %v for another phone number", err)
+	}
+	if len(repo.locked) != 3 || repo.locked[0] != [2]string{"+6281234567890", "203.0.113.1"} {
+		t.Errorf("PhoneOTPService.IssueOTP() locked %v, want the phone number and the IP address on every call", repo.locked)
+	}
+}
+
+func TestPhoneOTPService_IssueOTP_Daily_Limit(t *testing.T) {
+	repo := &fakePhoneOTPRepository{}
+	p := NewPhoneOTPService(repo, entity.NewPhoneOTPPolicy(5*time.Minute, 3, 0, 3, 5))
+	for range 3 {
+		_, _, err := p.IssueOTP(context.Background(), "+6281234567890", "203.0.113.1")
+		if err != nil {
+			t.Fatalf("PhoneOTPService.IssueOTP() error = %v", err)
+		}
+	}
+
+	_, _, err := p.IssueOTP(context.Background(), "+6281234567890", "203.0.113.2")
+	if !errors.Is(err, apperror.ErrQuotaExceeded) {
+		t.Errorf("PhoneOTPService.IssueOTP() error = %v past the daily limit of the phone number, want %v", err, apperror.ErrQuotaExceeded)
+	}
+
+	for _, phone := range []string{"+6281234567891", "+6281234567892"} {
+		_, _, err = p.IssueOTP(context.Background(), phone, "203.0.113.1")
+		if err != nil {
+			t.Fatalf("PhoneOTPService.IssueOTP() error = %v for another phone number", err)
+		}
+	}
+
+	_, _, err = p.IssueOTP(context.Background(), "+6281234567893", "203.0.113.1")
+	if !errors.Is(err, apperror.ErrQuotaExceeded) {
+		t.Errorf("PhoneOTPService.IssueOTP() error = %v past the daily limit of the IP address, want %v", err, apperror.ErrQuotaExceeded)
+	}
+
+	// Sends older than a day no longer count.
+	for _, phoneOTP := range repo.phoneOTPs {
+		phoneOTP.CreatedAt = phoneOTP.CreatedAt.Add(-25 * time.Hour)
+	}
+	_, _, err = p.IssueOTP(context.Background(), "+6281234567890", "203.0.113.1")
+	if err != nil {
+		t.Errorf("PhoneOTPService.IssueOTP() error = %v a day later", err)
+	}
+}
+
+func TestPhoneOTPService_VerifyOTP(t *testing.T) {
+	repo := &fakePhoneOTPRepository{}
+	p := newTestPhoneOTPService(repo)
+	_, code, _ := p.IssueOTP(context.Background(), "+6281234567890", "203.0.113.1")
+
+	err := p.VerifyOTP(context.Background(), "+6281234567891", code)
+	if !errors.Is(err, apperror.ErrUnauthorized) {
+		t.Errorf("PhoneOTPService.VerifyOTP() error = %v for another phone number, want %v", err, apperror.ErrUnauthorized)
+	}
+
+	err = p.VerifyOTP(context.Background(), "+6281234567890", "wrong")
+	if !errors.Is(err, apperror.ErrUnauthorized) {
+		t.Errorf("PhoneOTPService.VerifyOTP() error = %v for a wrong code, want %v", err, apperror.ErrUnauthorized)
+	}
+
+	err = p.VerifyOTP(context.Background(), "+6281234567890", code)
+	if err != nil {
+		t.Fatalf("PhoneOTPService.VerifyOTP() error = %v for the right code", err)
+	}
+
+	err = p.VerifyOTP(context.Background(), "+6281234567890", code)
+	if !errors.Is(err, apperror.ErrUnauthorized) {
+		t.Errorf("PhoneOTPService.VerifyOTP() error = %v for a used code, want %v", err, apperror.ErrUnauthorized)
+	}
+}
+
+func TestPhoneOTPService_VerifyOTP_Attempts_Exhausted(t *testing.T) {
+	repo := &fakePhoneOTPRepository{}
+	p := newTestPhoneOTPService(repo)
+	_, code, _ := p.IssueOTP(context.Background(), "+6281234567890", "203.0.113.1")
+	for range 3 {
+		_ = p.VerifyOTP(context.Background(), "+6281234567890", "wrong")
+	}
+
+	err := p.VerifyOTP(context.Background(), "+6281234567890", code)
+	if !errors.Is(err, apperror.ErrUnauthorized) {
+		t.Errorf("PhoneOTPService.VerifyOTP() error = %v after the attempts ran out, want %v", err, apperror.ErrUnauthorized)
+	}
+}
+
+func TestPhoneOTPService_VerifyOTP_Expired(t *testing.T) {
+	repo := &fakePhoneOTPRepository{}
+	p := newTestPhoneOTPService(repo)
+	phoneOTP, code, _ := p.IssueOTP(context.Background(), "+6281234567890", "203.0.113.1")
+	phoneOTP.ExpiresAt = time.Now().UTC().Add(-time.Second)
+
+	err := p.VerifyOTP(context.Background(), "+6281234567890", code)
+	if !errors.Is(err, apperror.ErrUnauthorized) {
+		t.Errorf("PhoneOTPService.VerifyOTP() error = %v for an expired code, want %v", err, apperror.ErrUnauthorized)
+	}
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+// photoSizes are the sizes every photo is stored in, each fitting in a square of the given number of pixels.
+// The large size keeps the photo close to its original for the full screen, while the others are thumbnails for lists.
+var photoSizes = []struct {
+	name   string
+	pixels int
+}{
+	{entity.PhotoSizeLarge, 1280},
+	{entity.PhotoSizeMedium, 480},
+	{entity.PhotoSizeSmall, 160},
+}
+
+// PhotoService is the interface used for the photo service.
+type PhotoService interface {
+	StorePhoto(ctx context.Context, userID int, upload *entity.PhotoUpload) (string, error)
+	DeletePhoto(ctx context.Context, key string) error
+	GetPhotoURLs(ctx context.Context, key string) (*entity.PhotoURLs, error)
+}
+
+type photoService struct {
+	blobStore      domain.BlobStore
+	imageProcessor domain.ImageProcessor
+	policy         *entity.PhotoPolicy
+}
+
+// NewPhotoService is a function used to initialize the photo service implementation.
+func NewPhotoService(bs domain.BlobStore, ip domain.ImageProcessor, policy *entity.PhotoPolicy) PhotoService {
+	return &photoService{
+		blobStore:      bs,
+		imageProcessor: ip,
+		policy:         policy,
+	}
+}
+
+// StorePhoto is a method for storing every size of an uploaded photo under a new key, which is returned.
+func (p *photoService) StorePhoto(ctx context.Context, userID int, upload *entity.PhotoUpload) (string, error) {
+	data, err := upload.Read(p.policy.MaxSize)
+	if err != nil {
+		return "", err
+	}
+
+	pixels := make([]int, len(photoSizes))
+	for i, size := range photoSizes {
+		pixels[i] = size.pixels
+	}
+
+	images, err := p.imageProcessor.Resize(data, pixels)
+	if err != nil {
+		return "", err
+	}
+
+	// A new key for every upload keeps URLs signed for a previous photo from showing the new one.
+	name, err := util.RandomToken(12)
+	if err != nil {
+		return "", err
+	}
+
+	key := fmt.Sprintf("photos/%d/%s", userID, name)
+	for i, size := range photoSizes {
+		err = p.blobStore.Put(ctx, photoBlobKey(key, size.name), "image/jpeg", images[i])
+		if err != nil {
+			_ = p.DeletePhoto(ctx, key)
+
+			return "", err
+		}
+	}
+
+	return key, nil
+}
+
+// DeletePhoto is a method for deleting every size of a photo.
+func (p *photoService) DeletePhoto(ctx context.Context, key string) error {
+	for _, size := range photoSizes {
+		err := p.blobStore.Delete(ctx, photoBlobKey(key, size.name))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetPhotoURLs is a method for getting the signed URLs of every size of a photo, or nil when there is no photo.
+func (p *photoService) GetPhotoURLs(ctx context.Context, key string) (*entity.PhotoURLs, error) {
+	if key == "" {
+		return nil, nil
+	}
+
+	expiresAt := time.Now().Add(p.policy.URLExpiration).Truncate(time.Second)
+	urls := make(map[string]string, len(photoSizes))
+	for _, size := range photoSizes {
+		url, err := p.blobStore.SignedURL(ctx, photoBlobKey(key, size.name), expiresAt)
+		if err != nil {
+			return nil, err
+		}
+
+		urls[size.name] = url
+	}
+
+	return &entity.PhotoURLs{
+		Large:     urls[entity.PhotoSizeLarge],
+		Medium:    urls[entity.PhotoSizeMedium],
+		Small:     urls[entity.PhotoSizeSmall],
+		ExpiresAt: expiresAt,
+	}, nil
+}
+
+// photoBlobKey is a function to get the key of the file of a size of a photo.
+func photoBlobKey(key, size string) string {
+	return key + "/" + size + ".jpg"
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// pngHeader is the start of a PNG file, which is enough for its content type to be detected.
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+type fakeBlobStore struct {
+	blobs  map[string][]byte
+	putErr error
+}
+
+func (f *fakeBlobStore) Put(_ context.Context, key, _ string, data []byte) error {
+	if f.putErr != nil {
+		return f.putErr
+	}
+
+	f.blobs[key] = data
+
+	return nil
+}
+
+func (f *fakeBlobStore) Get(_ context.Context, key string) ([]byte, error) {
+	return f.blobs[key], nil
+}
+
+func (f *fakeBlobStore) Delete(_ context.Context, key string) error {
+	delete(f.blobs, key)
+
+	return nil
+}
+
+func (f *fakeBlobStore) SignedURL(_ context.Context, key string, expiresAt time.Time) (string, error) {
+	return "https://blobs/" + key + "?expires=" + expiresAt.Format(time.RFC3339), nil
+}
+
+type fakeImageProcessor struct {
+	err error
+}
+
+func (f *fakeImageProcessor) Resize(_ []byte, sizes []int) ([][]byte, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	images := make([][]byte, len(sizes))
+	for i := range sizes {
+		images[i] = []byte{byte(i)}
+	}
+
+	return images, nil
+}
+
+func TestPhotoService_StorePhoto(t *testing.T) {
+	tests := []struct {
+		name           string
+		upload         *entity.PhotoUpload
+		blobStore      *fakeBlobStore
+		imageProcessor *fakeImageProcessor
+		wantErr        error
+	}{
+		{
+			name:           "Failed: Too large",
+			upload:         entity.NewPhotoUpload("image/png", bytes.NewReader(append(pngHeader, make([]byte, 100)...))),
+			blobStore:      &fakeBlobStore{blobs: map[string][]byte{}},
+			imageProcessor: &fakeImageProcessor{},
+			wantErr:        apperror.ErrValidation,
+		},
+		{
+			name:           "Failed: Declared content type not allowed",
+			upload:         entity.NewPhotoUpload("image/gif", bytes.NewReader(pngHeader)),
+			blobStore:      &fakeBlobStore{blobs: map[string][]byte{}},
+			imageProcessor: &fakeImageProcessor{},
+			wantErr:        apperror.ErrValidation,
+		},
+		{
+			name:           "Failed: Content not an image",
+			upload:         entity.NewPhotoUpload("image/png", strings.NewReader("<html></html>")),
+			blobStore:      &fakeBlobStore{blobs: map[string][]byte{}},
+			imageProcessor: &fakeImageProcessor{},
+			wantErr:        apperror.ErrValidation,
+		},
+		{
+			name:           "Failed: Put failed",
+			upload:         entity.NewPhotoUpload("image/png", bytes.NewReader(pngHeader)),
+			blobStore:      &fakeBlobStore{blobs: map[string][]byte{}, putErr: errors.New("disk full")},
+			imageProcessor: &fakeImageProcessor{},
+			wantErr:        errors.New("disk full"),
+		},
+		{
+			name:           "Success",
+			upload:         entity.NewPhotoUpload("", bytes.NewReader(pngHeader)),
+			blobStore:      &fakeBlobStore{blobs: map[string][]byte{}},
+			imageProcessor: &fakeImageProcessor{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewPhotoService(tt.blobStore, tt.imageProcessor, entity.NewPhotoPolicy(64, time.Hour))
+			key, err := p.StorePhoto(context.Background(), 1, tt.upload)
+			if tt.wantErr != nil {
+				if err == nil || (!errors.Is(err, tt.wantErr) && err.Error() != tt.wantErr.Error()) {
+					t.Errorf("PhotoService.StorePhoto() error = %v, wantErr %v", err, tt.wantErr)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("PhotoService.StorePhoto() error = %v", err)
+			}
+			if !strings.HasPrefix(key, "photos/1/") || len(tt.blobStore.blobs) != 3 {
+				t.Errorf("PhotoService.StorePhoto() = %q with %d files, want three sizes under the key", key, len(tt.blobStore.blobs))
+			}
+			if _, ok := tt.blobStore.blobs[key+"/small.jpg"]; !ok {
+				t.Errorf("PhotoService.StorePhoto() stored %v, want the small size", tt.blobStore.blobs)
+			}
+		})
+	}
+}
+
+func TestPhotoService_DeletePhoto(t *testing.T) {
+	blobStore := &fakeBlobStore{blobs: map[string][]byte{
+		"photos/1/key/large.jpg":   {0},
+		"photos/1/key/medium.jpg":  {1},
+		"photos/1/key/small.jpg":   {2},
+		"photos/1/other/large.jpg": {0},
+	}}
+	p := NewPhotoService(blobStore, &fakeImageProcessor{}, entity.NewPhotoPolicy(64, time.Hour))
+	if err := p.DeletePhoto(context.Background(), "photos/1/key"); err != nil {
+		t.Fatalf("PhotoService.DeletePhoto() error = %v", err)
+	}
+	if len(blobStore.blobs) != 1 {
+		t.Errorf("PhotoService.DeletePhoto() left %v, want only the other photo", blobStore.blobs)
+	}
+}
+
+func TestPhotoService_GetPhotoURLs(t *testing.T) {
+	p := NewPhotoService(&fakeBlobStore{}, &fakeImageProcessor{}, entity.NewPhotoPolicy(64, time.Hour))
+
+	urls, err := p.GetPhotoURLs(context.Background(), "")
+	if err != nil || urls != nil {
+		t.Errorf("PhotoService.GetPhotoURLs() = %v, %v, want nil without a photo", urls, err)
+	}
+
+	urls, err = p.GetPhotoURLs(context.Background(), "photos/1/key")
+	if err != nil {
+		t.Fatalf("PhotoService.GetPhotoURLs() error = %v", err)
+	}
+	if !strings.HasPrefix(urls.Large, "https://blobs/photos/1/key/large.jpg") || !strings.HasPrefix(urls.Small, "https://blobs/photos/1/key/small.jpg") {
+		t.Errorf("PhotoService.GetPhotoURLs() = %+v", urls)
+	}
+	if until := time.Until(urls.ExpiresAt); until <= 59*time.Minute || until > time.Hour {
+		t.Errorf("PhotoService.GetPhotoURLs() expires in %v, want an hour", until)
+	}
+}
@@ -3,14 +3,21 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"dealls-technical-test-dating-service/internal/domain/entity"
 	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/pkg/util"
 )
 
 // ProfileService is the interface used for the profile service.
 type ProfileService interface {
 	CreateProfile(ctx context.Context, profile *entity.Profile) error
+	GetProfileByID(ctx context.Context, id int) (*entity.Profile, error)
+	GetProfileByUserID(ctx context.Context, userID int) (*entity.Profile, error)
+	GetProfileCandidate(ctx context.Context, id int) (*entity.ProfileCandidate, error)
+	UpdateProfile(ctx context.Context, profile *entity.Profile) error
+	GetDiscoverableProfiles(ctx context.Context, userID int, swipeDate time.Time, cursor, limit int, verifiedEmailOnly bool) ([]*entity.ProfileCandidate, error)
 }
 
 type profileService struct {
@@ -28,3 +35,47 @@ func NewProfileService(repo repository.ProfileRepository) ProfileService {
 func (p *profileService) CreateProfile(ctx context.Context, profile *entity.Profile) error {
 	return p.repo.Insert(ctx, profile)
 }
+
+// GetProfileByID is a method for getting profile based on ID.
+func (p *profileService) GetProfileByID(ctx context.Context, id int) (*entity.Profile, error) {
+	return p.repo.FindByID(ctx, id)
+}
+
+// GetProfileByUserID is a method for getting the profile of a user.
+func (p *profileService) GetProfileByUserID(ctx context.Context, userID int) (*entity.Profile, error) {
+	return p.repo.FindByUserID(ctx, userID)
+}
+
+// GetProfileCandidate is a method for getting a profile as other users see it, with the age of its user instead of the birth date.
+func (p *profileService) GetProfileCandidate(ctx context.Context, id int) (*entity.ProfileCandidate, error) {
+	candidate, err := p.repo.FindCandidateByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate.Age = util.Age(candidate.BirthDate, time.Now())
+
+	return candidate, nil
+}
+
+// UpdateProfile is a method for saving the bio and interests of a profile.
+func (p *profileService) UpdateProfile(ctx context.Context, profile *entity.Profile) error {
+	profile.UpdatedAt = time.Now().UTC()
+
+	return p.repo.Update(ctx, profile.ID, profile.Bio, profile.Interests, profile.UpdatedAt)
+}
+
+// GetDiscoverableProfiles is a method for getting the profiles after the cursor that a user has not liked or passed on the swipe date.
+func (p *profileService) GetDiscoverableProfiles(ctx context.Context, userID int, swipeDate time.Time, cursor, limit int, verifiedEmailOnly bool) ([]*entity.ProfileCandidate, error) {
+	currentTime := time.Now()
+	candidates, err := p.repo.FindDiscoverable(ctx, userID, swipeDate, cursor, limit, verifiedEmailOnly)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		candidate.Age = util.Age(candidate.BirthDate, currentTime)
+	}
+
+	return candidates, nil
+}
@@ -2,22 +2,53 @@ package service
 
 import (
 	"context"
+	"reflect"
 	"testing"
+	"time"
 
+	"dealls-technical-test-dating-service/internal/domain/apperror"
 	"dealls-technical-test-dating-service/internal/domain/entity"
 	"dealls-technical-test-dating-service/internal/domain/repository"
 
-	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
 )
 
+var mockSuccessProfile = &entity.Profile{
+	ID:     1,
+	UserID: 1,
+}
+
 type fakeProfileRepository struct {
-	err error
+	profile    *entity.Profile
+	candidate  *entity.ProfileCandidate
+	candidates []*entity.ProfileCandidate
+	err        error
 }
 
 func (f *fakeProfileRepository) Insert(context.Context, *entity.Profile) error {
 	return f.err
 }
 
+func (f *fakeProfileRepository) FindByID(context.Context, int) (*entity.Profile, error) {
+	return f.profile, f.err
+}
+
+func (f *fakeProfileRepository) FindByUserID(context.Context, int) (*entity.Profile, error) {
+	return f.profile, f.err
+}
+
+func (f *fakeProfileRepository) FindCandidateByID(context.Context, int) (*entity.ProfileCandidate, error) {
+	return f.candidate, f.err
+}
+
+func (f *fakeProfileRepository) Update(context.Context, int, string, string, time.Time) error {
+	return f.err
+}
+
+func (f *fakeProfileRepository) FindDiscoverable(context.Context, int, time.Time, int, int, bool) ([]*entity.ProfileCandidate, error) {
+	return f.candidates, f.err
+}
+
 func TestProfileService_CreateProfile(t *testing.T) {
 	type fields struct {
 		repo repository.ProfileRepository
@@ -31,7 +62,7 @@ func TestProfileService_CreateProfile(t *testing.T) {
 			name: "Failed",
 			fields: fields{
 				repo: &fakeProfileRepository{
-					err: gorm.ErrDuplicatedKey,
+					err: apperror.ErrConflict,
 				},
 			},
 			wantErr: true,
@@ -55,3 +86,172 @@ func TestProfileService_CreateProfile(t *testing.T) {
 		})
 	}
 }
+
+func TestProfileService_GetProfileByID(t *testing.T) {
+	type fields struct {
+		repo repository.ProfileRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *entity.Profile
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeProfileRepository{
+					profile: &entity.Profile{},
+					err:     apperror.ErrNotFound,
+				},
+			},
+			want:    &entity.Profile{},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeProfileRepository{
+					profile: mockSuccessProfile,
+					err:     nil,
+				},
+			},
+			want:    mockSuccessProfile,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProfileService(tt.fields.repo)
+			got, err := p.GetProfileByID(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProfileService.GetProfileByID() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ProfileService.GetProfileByID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestProfileService_GetDiscoverableProfiles(t *testing.T) {
+	type fields struct {
+		repo repository.ProfileRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantLen int
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeProfileRepository{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			wantLen: 0,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeProfileRepository{
+					candidates: []*entity.ProfileCandidate{
+						{
+							ProfileID: 2,
+							BirthDate: time.Now().AddDate(-20, 0, -1),
+						},
+					},
+				},
+			},
+			wantLen: 1,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProfileService(tt.fields.repo)
+			got, err := p.GetDiscoverableProfiles(context.Background(), 1, time.Now(), 0, 10, false)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProfileService.GetDiscoverableProfiles() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if len(got) != tt.wantLen {
+				t.Errorf("ProfileService.GetDiscoverableProfiles() len = %v, want %v", len(got), tt.wantLen)
+			}
+			for _, candidate := range got {
+				if candidate.Age != 20 {
+					t.Errorf("ProfileService.GetDiscoverableProfiles() age = %v, want 20", candidate.Age)
+				}
+			}
+		})
+	}
+}
+
+func TestProfileService_GetProfileCandidate(t *testing.T) {
+	birthDate := time.Now().AddDate(-30, 0, -1)
+	tests := []struct {
+		name    string
+		repo    repository.ProfileRepository
+		wantAge int
+		wantErr bool
+	}{
+		{
+			name:    "Failed",
+			repo:    &fakeProfileRepository{err: apperror.ErrNotFound},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			repo:    &fakeProfileRepository{candidate: &entity.ProfileCandidate{ProfileID: 1, BirthDate: birthDate}},
+			wantAge: 30,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProfileService(tt.repo)
+			got, err := p.GetProfileCandidate(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ProfileService.GetProfileCandidate() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if err == nil && got.Age != tt.wantAge {
+				t.Errorf("ProfileService.GetProfileCandidate() age = %v, want %v", got.Age, tt.wantAge)
+			}
+		})
+	}
+}
+
+func TestProfileService_UpdateProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    repository.ProfileRepository
+		wantErr bool
+	}{
+		{
+			name:    "Failed",
+			repo:    &fakeProfileRepository{err: apperror.ErrNotFound},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			repo:    &fakeProfileRepository{},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := NewProfileService(tt.repo)
+			if err := p.UpdateProfile(context.Background(), &entity.Profile{ID: 1, Bio: "Bio"}); (err != nil) != tt.wantErr {
+				t.Errorf("ProfileService.UpdateProfile() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+const (
+	refreshTokenSize = 32
+	familyIDSize     = 16
+)
+
+// RefreshTokenService is the interface used for the refresh token service.
+type RefreshTokenService interface {
+	CreateRefreshToken(ctx context.Context, userID int, expiration time.Duration) (*entity.RefreshToken, string, error)
+	RotateRefreshToken(ctx context.Context, token string, expiration time.Duration) (*entity.RefreshToken, string, error)
+	RevokeRefreshToken(ctx context.Context, userID int, token string) error
+	RevokeRefreshTokenFamily(ctx context.Context, familyID string) error
+	RevokeUserRefreshTokens(ctx context.Context, userID int) error
+}
+
+type refreshTokenService struct {
+	repo repository.RefreshTokenRepository
+}
+
+// NewRefreshTokenService is a function used to initialize the refresh token service implementation.
+func NewRefreshTokenService(repo repository.RefreshTokenRepository) RefreshTokenService {
+	return &refreshTokenService{
+		repo: repo,
+	}
+}
+
+// CreateRefreshToken is a method for creating a refresh token that starts a new family for a user, returning the stored refresh token along with it.
+func (r *refreshTokenService) CreateRefreshToken(ctx context.Context, userID int, expiration time.Duration) (*entity.RefreshToken, string, error) {
+	familyID, err := util.RandomToken(familyIDSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return r.create(ctx, userID, familyID, expiration)
+}
+
+// RotateRefreshToken is a method for exchanging a refresh token for a new one of the same family, returning the exchanged token.
+// Presenting a token that was already rotated means it was stolen, so the whole family is revoked.
+// The revocation is reported with an unauthorized error, so a caller running it in a unit of work still has to commit to keep it.
+func (r *refreshTokenService) RotateRefreshToken(ctx context.Context, token string, expiration time.Duration) (*entity.RefreshToken, string, error) {
+	refreshToken, err := r.repo.FindByTokenHash(ctx, util.HashToken(token))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil, "", apperror.Wrap(apperror.KindUnauthorized, constant.InvalidRefreshToken, err)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	currentTime := time.Now().UTC()
+	if refreshToken.RotatedAt != nil {
+		return nil, "", r.revokeFamily(ctx, refreshToken.FamilyID, currentTime)
+	}
+	if !refreshToken.IsUsable(currentTime) {
+		return nil, "", apperror.New(apperror.KindUnauthorized, constant.InvalidRefreshToken)
+	}
+
+	err = r.repo.MarkRotated(ctx, refreshToken.ID, currentTime)
+	if errors.Is(err, apperror.ErrConflict) {
+		return nil, "", r.revokeFamily(ctx, refreshToken.FamilyID, currentTime)
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	_, newToken, err := r.create(ctx, refreshToken.UserID, refreshToken.FamilyID, expiration)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return refreshToken, newToken, nil
+}
+
+// RevokeRefreshToken is a method for revoking the family of a refresh token of a user.
+// A token that is unknown or that belongs to another user is ignored, so that it cannot be used to log someone else out.
+func (r *refreshTokenService) RevokeRefreshToken(ctx context.Context, userID int, token string) error {
+	refreshToken, err := r.repo.FindByTokenHash(ctx, util.HashToken(token))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if refreshToken.UserID != userID {
+		return nil
+	}
+
+	return r.repo.RevokeFamily(ctx, refreshToken.FamilyID, time.Now().UTC())
+}
+
+// RevokeRefreshTokenFamily is a method for revoking every refresh token of a family, ending the session it belongs to.
+func (r *refreshTokenService) RevokeRefreshTokenFamily(ctx context.Context, familyID string) error {
+	return r.repo.RevokeFamily(ctx, familyID, time.Now().UTC())
+}
+
+// RevokeUserRefreshTokens is a method for revoking every refresh token of a user.
+func (r *refreshTokenService) RevokeUserRefreshTokens(ctx context.Context, userID int) error {
+	return r.repo.RevokeByUserID(ctx, userID, time.Now().UTC())
+}
+
+// create is a method for creating a refresh token in a family and returning the token, of which only the hash is stored.
+func (r *refreshTokenService) create(ctx context.Context, userID int, familyID string, expiration time.Duration) (*entity.RefreshToken, string, error) {
+	token, err := util.RandomToken(refreshTokenSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	currentTime := time.Now().UTC()
+	refreshToken := entity.NewRefreshToken(userID, familyID, util.HashToken(token), currentTime.Add(expiration), currentTime)
+	err = r.repo.Insert(ctx, refreshToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return refreshToken, token, nil
+}
+
+// revokeFamily is a method for revoking a refresh token family after a token reuse and returning the error reported to the client.
+func (r *refreshTokenService) revokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	err := r.repo.RevokeFamily(ctx, familyID, revokedAt)
+	if err != nil {
+		return err
+	}
+
+	return apperror.New(apperror.KindUnauthorized, constant.InvalidRefreshToken)
+}
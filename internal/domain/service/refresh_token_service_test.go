@@ -0,0 +1,253 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/pkg/util"
+
+	"gorm.io/gorm/schema"
+)
+
+type fakeRefreshTokenRepository struct {
+	refreshTokens  map[string]*entity.RefreshToken
+	insertErr      error
+	markRotatedErr error
+	revokedFamily  string
+	revokedUserID  int
+}
+
+func (f *fakeRefreshTokenRepository) Insert(_ context.Context, refreshToken *entity.RefreshToken) error {
+	if f.insertErr != nil {
+		return f.insertErr
+	}
+
+	if f.refreshTokens == nil {
+		f.refreshTokens = map[string]*entity.RefreshToken{}
+	}
+	refreshToken.ID = len(f.refreshTokens) + 1
+	f.refreshTokens[refreshToken.TokenHash] = refreshToken
+
+	return nil
+}
+
+func (f *fakeRefreshTokenRepository) FindByTokenHash(_ context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	refreshToken, ok := f.refreshTokens[tokenHash]
+	if !ok {
+		return &entity.RefreshToken{}, apperror.ErrNotFound
+	}
+
+	return refreshToken, nil
+}
+
+func (f *fakeRefreshTokenRepository) MarkRotated(_ context.Context, id int, rotatedAt time.Time) error {
+	if f.markRotatedErr != nil {
+		return f.markRotatedErr
+	}
+
+	for _, refreshToken := range f.refreshTokens {
+		if refreshToken.ID == id {
+			refreshToken.RotatedAt = &rotatedAt
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeRefreshTokenRepository) RevokeFamily(_ context.Context, familyID string, revokedAt time.Time) error {
+	f.revokedFamily = familyID
+	for _, refreshToken := range f.refreshTokens {
+		if refreshToken.FamilyID == familyID {
+			refreshToken.RevokedAt = &revokedAt
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeRefreshTokenRepository) RevokeByUserID(_ context.Context, userID int, _ time.Time) error {
+	f.revokedUserID = userID
+
+	return nil
+}
+
+func TestRefreshTokenService_CreateRefreshToken(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    *fakeRefreshTokenRepository
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			repo: &fakeRefreshTokenRepository{
+				insertErr: schema.ErrUnsupportedDataType,
+			},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			repo:    &fakeRefreshTokenRepository{},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRefreshTokenService(tt.repo)
+			refreshToken, got, err := r.CreateRefreshToken(context.Background(), 1, time.Hour)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RefreshTokenService.CreateRefreshToken() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if tt.repo.refreshTokens[util.HashToken(got)] != refreshToken || refreshToken.FamilyID == "" {
+				t.Errorf("RefreshTokenService.CreateRefreshToken() = %+v, want the stored refresh token of a new family", refreshToken)
+			}
+		})
+	}
+}
+
+func TestRefreshTokenService_RotateRefreshToken(t *testing.T) {
+	expiredAt := time.Now().UTC().Add(-time.Minute)
+	tests := []struct {
+		name          string
+		refreshToken  *entity.RefreshToken
+		markRotateErr error
+		wantErr       error
+		wantRevoked   bool
+	}{
+		{
+			name:    "Failed: Unknown token",
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name:         "Failed: Expired token",
+			refreshToken: &entity.RefreshToken{TokenHash: util.HashToken("token"), UserID: 1, FamilyID: "family", ExpiresAt: expiredAt},
+			wantErr:      apperror.ErrUnauthorized,
+		},
+		{
+			name:         "Failed: Reused rotated token revokes the family",
+			refreshToken: &entity.RefreshToken{TokenHash: util.HashToken("token"), UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour), RotatedAt: &expiredAt},
+			wantErr:      apperror.ErrUnauthorized,
+			wantRevoked:  true,
+		},
+		{
+			name:          "Failed: Concurrently rotated token revokes the family",
+			refreshToken:  &entity.RefreshToken{TokenHash: util.HashToken("token"), UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)},
+			markRotateErr: apperror.New(apperror.KindConflict, "Refresh token already rotated"),
+			wantErr:       apperror.ErrUnauthorized,
+			wantRevoked:   true,
+		},
+		{
+			name:          "Failed: Mark rotated failed",
+			refreshToken:  &entity.RefreshToken{TokenHash: util.HashToken("token"), UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)},
+			markRotateErr: schema.ErrUnsupportedDataType,
+			wantErr:       schema.ErrUnsupportedDataType,
+		},
+		{
+			name:         "Success",
+			refreshToken: &entity.RefreshToken{TokenHash: util.HashToken("token"), UserID: 1, FamilyID: "family", ExpiresAt: time.Now().Add(time.Hour)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRefreshTokenRepository{markRotatedErr: tt.markRotateErr}
+			if tt.refreshToken != nil {
+				_ = repo.Insert(context.Background(), tt.refreshToken)
+			}
+
+			r := NewRefreshTokenService(repo)
+			got, newToken, err := r.RotateRefreshToken(context.Background(), "token", time.Hour)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("RefreshTokenService.RotateRefreshToken() error = %v, want %v", err, tt.wantErr)
+				}
+				if (repo.revokedFamily == "family") != tt.wantRevoked {
+					t.Errorf("RefreshTokenService.RotateRefreshToken() revoked family = %v, want revoked %v", repo.revokedFamily, tt.wantRevoked)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("RefreshTokenService.RotateRefreshToken() error = %v", err)
+			}
+			if got.UserID != 1 || got.RotatedAt == nil {
+				t.Errorf("RefreshTokenService.RotateRefreshToken() = %v, want the rotated token of user 1", got)
+			}
+			successor, ok := repo.refreshTokens[util.HashToken(newToken)]
+			if !ok || successor.FamilyID != "family" {
+				t.Errorf("RefreshTokenService.RotateRefreshToken() did not issue a token in the same family")
+			}
+		})
+	}
+}
+
+func TestRefreshTokenService_RevokeRefreshToken(t *testing.T) {
+	tests := []struct {
+		name        string
+		userID      int
+		token       string
+		wantRevoked bool
+	}{
+		{
+			name:        "Unknown token is ignored",
+			userID:      1,
+			token:       "unknown",
+			wantRevoked: false,
+		},
+		{
+			name:        "Token of another user is ignored",
+			userID:      2,
+			token:       "token",
+			wantRevoked: false,
+		},
+		{
+			name:        "Token of the user revokes its family",
+			userID:      1,
+			token:       "token",
+			wantRevoked: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeRefreshTokenRepository{}
+			_ = repo.Insert(context.Background(), &entity.RefreshToken{TokenHash: util.HashToken("token"), UserID: 1, FamilyID: "family"})
+
+			r := NewRefreshTokenService(repo)
+			if err := r.RevokeRefreshToken(context.Background(), tt.userID, tt.token); err != nil {
+				t.Fatalf("RefreshTokenService.RevokeRefreshToken() error = %v", err)
+			}
+			if (repo.revokedFamily == "family") != tt.wantRevoked {
+				t.Errorf("RefreshTokenService.RevokeRefreshToken() revoked family = %v, want revoked %v", repo.revokedFamily, tt.wantRevoked)
+			}
+		})
+	}
+}
+
+func TestRefreshTokenService_RevokeRefreshTokenFamily(t *testing.T) {
+	repo := &fakeRefreshTokenRepository{}
+	r := NewRefreshTokenService(repo)
+	if err := r.RevokeRefreshTokenFamily(context.Background(), "family"); err != nil {
+		t.Fatalf("RefreshTokenService.RevokeRefreshTokenFamily() error = %v", err)
+	}
+	if repo.revokedFamily != "family" {
+		t.Errorf("RefreshTokenService.RevokeRefreshTokenFamily() revoked family = %v, want family", repo.revokedFamily)
+	}
+}
+
+func TestRefreshTokenService_RevokeUserRefreshTokens(t *testing.T) {
+	repo := &fakeRefreshTokenRepository{}
+	r := NewRefreshTokenService(repo)
+	if err := r.RevokeUserRefreshTokens(context.Background(), 1); err != nil {
+		t.Fatalf("RefreshTokenService.RevokeUserRefreshTokens() error = %v", err)
+	}
+	if repo.revokedUserID != 1 {
+		t.Errorf("RefreshTokenService.RevokeUserRefreshTokens() revoked user = %v, want 1", repo.revokedUserID)
+	}
+}
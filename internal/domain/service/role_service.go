@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"slices"
+
+	"dealls-technical-test-dating-service/internal/domain/repository"
+)
+
+// RoleService is the interface used for the role service.
+type RoleService interface {
+	HasPermission(ctx context.Context, role, permission string) (bool, error)
+}
+
+type roleService struct {
+	repo repository.RoleRepository
+}
+
+// NewRoleService is a function used to initialize the role service implementation.
+func NewRoleService(repo repository.RoleRepository) RoleService {
+	return &roleService{
+		repo: repo,
+	}
+}
+
+// HasPermission is a method for checking whether a role grants a permission.
+func (r *roleService) HasPermission(ctx context.Context, role, permission string) (bool, error) {
+	permissions, err := r.repo.FindPermissions(ctx, role)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(permissions, permission), nil
+}
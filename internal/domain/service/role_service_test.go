@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+
+	"gorm.io/gorm/schema"
+)
+
+type fakeRoleRepository struct {
+	permissions map[string][]string
+	err         error
+}
+
+func (f *fakeRoleRepository) FindPermissions(_ context.Context, role string) ([]string, error) {
+	return f.permissions[role], f.err
+}
+
+func TestRoleService_HasPermission(t *testing.T) {
+	repo := &fakeRoleRepository{permissions: map[string][]string{
+		entity.RoleModerator: {entity.PermissionReadUsers},
+		entity.RoleAdmin:     {entity.PermissionManageRoles, entity.PermissionReadUsers},
+	}}
+	tests := []struct {
+		name       string
+		repo       *fakeRoleRepository
+		role       string
+		permission string
+		want       bool
+		wantErr    error
+	}{
+		{
+			name:       "Failed",
+			repo:       &fakeRoleRepository{err: schema.ErrUnsupportedDataType},
+			role:       entity.RoleAdmin,
+			permission: entity.PermissionReadUsers,
+			wantErr:    schema.ErrUnsupportedDataType,
+		},
+		{
+			name:       "Role without permissions",
+			repo:       repo,
+			role:       entity.RoleUser,
+			permission: entity.PermissionReadUsers,
+			want:       false,
+		},
+		{
+			name:       "Role without the permission",
+			repo:       repo,
+			role:       entity.RoleModerator,
+			permission: entity.PermissionManageRoles,
+			want:       false,
+		},
+		{
+			name:       "Role with the permission",
+			repo:       repo,
+			role:       entity.RoleAdmin,
+			permission: entity.PermissionManageRoles,
+			want:       true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewRoleService(tt.repo)
+			got, err := s.HasPermission(context.Background(), tt.role, tt.permission)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("RoleService.HasPermission() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("RoleService.HasPermission() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
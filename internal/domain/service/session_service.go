@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+// sessionTouchInterval is how long the last seen time of a session is kept before a request updates it,
+// so that a burst of requests does not write to the database each time.
+const sessionTouchInterval = time.Minute
+
+// SessionService is the interface used for the session service.
+type SessionService interface {
+	CreateSession(ctx context.Context, userID int, tokenID, refreshFamilyID, userAgent, ipAddress string) error
+	RefreshSession(ctx context.Context, refreshFamilyID, tokenID string) error
+	TouchSession(ctx context.Context, userID int, tokenID string) error
+	GetUserSessions(ctx context.Context, userID int, seenSince time.Time) ([]*entity.Session, error)
+	DeleteSession(ctx context.Context, id, userID int) (*entity.Session, error)
+	DeleteSessionByTokenID(ctx context.Context, tokenID string) error
+	DeleteUserSessions(ctx context.Context, userID int) error
+}
+
+type sessionService struct {
+	repo repository.SessionRepository
+}
+
+// NewSessionService is a function used to initialize the session service implementation.
+func NewSessionService(repo repository.SessionRepository) SessionService {
+	return &sessionService{
+		repo: repo,
+	}
+}
+
+// CreateSession is a method for recording a login of a user, naming its device after the User-Agent header.
+func (s *sessionService) CreateSession(ctx context.Context, userID int, tokenID, refreshFamilyID, userAgent, ipAddress string) error {
+	session := entity.NewSession(userID, tokenID, refreshFamilyID, util.DeviceName(userAgent), ipAddress, time.Now().UTC())
+
+	return s.repo.Insert(ctx, session)
+}
+
+// RefreshSession is a method for moving the session of a refresh token family to the access token issued by refreshing it.
+// The previous access token of the session stops being accepted, and a family whose session was deleted cannot be refreshed.
+func (s *sessionService) RefreshSession(ctx context.Context, refreshFamilyID, tokenID string) error {
+	err := s.repo.UpdateTokenID(ctx, refreshFamilyID, tokenID, time.Now().UTC())
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.Wrap(apperror.KindUnauthorized, constant.InvalidRefreshToken, err)
+	}
+
+	return err
+}
+
+// TouchSession is a method for checking that an access token of a user still has a session and updating the time the session was last seen.
+func (s *sessionService) TouchSession(ctx context.Context, userID int, tokenID string) error {
+	session, err := s.repo.FindByTokenID(ctx, tokenID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.Wrap(apperror.KindUnauthorized, constant.InvalidToken, err)
+	}
+	if err != nil {
+		return err
+	}
+	if session.UserID != userID {
+		return apperror.New(apperror.KindUnauthorized, constant.InvalidToken)
+	}
+
+	currentTime := time.Now().UTC()
+	if session.IsSeenWithin(currentTime, sessionTouchInterval) {
+		return nil
+	}
+
+	return s.repo.UpdateLastSeen(ctx, session.ID, currentTime)
+}
+
+// GetUserSessions is a method for getting the sessions of a user seen since the given time.
+func (s *sessionService) GetUserSessions(ctx context.Context, userID int, seenSince time.Time) ([]*entity.Session, error) {
+	return s.repo.FindByUserID(ctx, userID, seenSince)
+}
+
+// DeleteSession is a method for deleting a session of a user and returning it.
+func (s *sessionService) DeleteSession(ctx context.Context, id, userID int) (*entity.Session, error) {
+	return s.repo.Delete(ctx, id, userID)
+}
+
+// DeleteSessionByTokenID is a method for deleting the session of an access token.
+func (s *sessionService) DeleteSessionByTokenID(ctx context.Context, tokenID string) error {
+	return s.repo.DeleteByTokenID(ctx, tokenID)
+}
+
+// DeleteUserSessions is a method for deleting every session of a user.
+func (s *sessionService) DeleteUserSessions(ctx context.Context, userID int) error {
+	return s.repo.DeleteByUserID(ctx, userID)
+}
@@ -0,0 +1,238 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+
+	"gorm.io/gorm/schema"
+)
+
+type fakeSessionRepository struct {
+	sessions      map[string]*entity.Session
+	err           error
+	touchedID     int
+	deletedUserID int
+}
+
+func (f *fakeSessionRepository) Insert(_ context.Context, session *entity.Session) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	if f.sessions == nil {
+		f.sessions = map[string]*entity.Session{}
+	}
+	session.ID = len(f.sessions) + 1
+	f.sessions[session.TokenID] = session
+
+	return nil
+}
+
+func (f *fakeSessionRepository) FindByTokenID(_ context.Context, tokenID string) (*entity.Session, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	session, ok := f.sessions[tokenID]
+	if !ok {
+		return &entity.Session{}, apperror.ErrNotFound
+	}
+
+	return session, nil
+}
+
+func (f *fakeSessionRepository) FindByUserID(_ context.Context, userID int, seenSince time.Time) ([]*entity.Session, error) {
+	sessions := []*entity.Session{}
+	for _, session := range f.sessions {
+		if session.UserID == userID && !session.LastSeenAt.Before(seenSince) {
+			sessions = append(sessions, session)
+		}
+	}
+
+	return sessions, f.err
+}
+
+func (f *fakeSessionRepository) UpdateTokenID(_ context.Context, refreshFamilyID, tokenID string, seenAt time.Time) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	for oldTokenID, session := range f.sessions {
+		if session.RefreshFamilyID == refreshFamilyID {
+			delete(f.sessions, oldTokenID)
+			session.TokenID = tokenID
+			session.LastSeenAt = seenAt
+			f.sessions[tokenID] = session
+
+			return nil
+		}
+	}
+
+	return apperror.ErrNotFound
+}
+
+func (f *fakeSessionRepository) UpdateLastSeen(_ context.Context, id int, _ time.Time) error {
+	f.touchedID = id
+
+	return f.err
+}
+
+func (f *fakeSessionRepository) Delete(_ context.Context, id, userID int) (*entity.Session, error) {
+	for tokenID, session := range f.sessions {
+		if session.ID == id && session.UserID == userID {
+			delete(f.sessions, tokenID)
+
+			return session, nil
+		}
+	}
+
+	return nil, apperror.ErrNotFound
+}
+
+func (f *fakeSessionRepository) DeleteByTokenID(_ context.Context, tokenID string) error {
+	delete(f.sessions, tokenID)
+
+	return f.err
+}
+
+func (f *fakeSessionRepository) DeleteByUserID(_ context.Context, userID int) error {
+	f.deletedUserID = userID
+
+	return f.err
+}
+
+func TestSessionService_CreateSession(t *testing.T) {
+	repo := &fakeSessionRepository{}
+	s := NewSessionService(repo)
+	err := s.CreateSession(context.Background(), 1, "token", "family", "curl/8.4.0", "127.0.0.1")
+	if err != nil {
+		t.Fatalf("SessionService.CreateSession() error = %v", err)
+	}
+
+	session := repo.sessions["token"]
+	if session == nil || session.UserID != 1 || session.RefreshFamilyID != "family" || session.DeviceName != "curl" || session.IPAddress != "127.0.0.1" {
+		t.Errorf("SessionService.CreateSession() stored %+v, want the session of the login", session)
+	}
+}
+
+func TestSessionService_RefreshSession(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    *fakeSessionRepository
+		wantErr error
+	}{
+		{
+			name: "Failed",
+			repo: &fakeSessionRepository{
+				err: schema.ErrUnsupportedDataType,
+			},
+			wantErr: schema.ErrUnsupportedDataType,
+		},
+		{
+			name:    "Failed: Deleted session",
+			repo:    &fakeSessionRepository{},
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name: "Success",
+			repo: &fakeSessionRepository{
+				sessions: map[string]*entity.Session{"old": {ID: 1, TokenID: "old", RefreshFamilyID: "family"}},
+			},
+			wantErr: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSessionService(tt.repo)
+			err := s.RefreshSession(context.Background(), "family", "new")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SessionService.RefreshSession() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if tt.wantErr == nil && tt.repo.sessions["new"] == nil {
+				t.Error("SessionService.RefreshSession() did not move the session to the new token")
+			}
+		})
+	}
+}
+
+func TestSessionService_TouchSession(t *testing.T) {
+	currentTime := time.Now().UTC()
+	tests := []struct {
+		name        string
+		repo        *fakeSessionRepository
+		wantErr     error
+		wantTouched bool
+	}{
+		{
+			name: "Failed",
+			repo: &fakeSessionRepository{
+				err: schema.ErrUnsupportedDataType,
+			},
+			wantErr: schema.ErrUnsupportedDataType,
+		},
+		{
+			name:    "Failed: Deleted session",
+			repo:    &fakeSessionRepository{},
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name: "Failed: Session of another user",
+			repo: &fakeSessionRepository{
+				sessions: map[string]*entity.Session{"token": {ID: 1, UserID: 2, LastSeenAt: currentTime}},
+			},
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name: "Success: Recently seen",
+			repo: &fakeSessionRepository{
+				sessions: map[string]*entity.Session{"token": {ID: 1, UserID: 1, LastSeenAt: currentTime}},
+			},
+			wantErr:     nil,
+			wantTouched: false,
+		},
+		{
+			name: "Success",
+			repo: &fakeSessionRepository{
+				sessions: map[string]*entity.Session{"token": {ID: 1, UserID: 1, LastSeenAt: currentTime.Add(-time.Hour)}},
+			},
+			wantErr:     nil,
+			wantTouched: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSessionService(tt.repo)
+			err := s.TouchSession(context.Background(), 1, "token")
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("SessionService.TouchSession() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if touched := tt.repo.touchedID == 1; touched != tt.wantTouched {
+				t.Errorf("SessionService.TouchSession() touched = %v, want %v", touched, tt.wantTouched)
+			}
+		})
+	}
+}
+
+func TestSessionService_DeleteSession(t *testing.T) {
+	repo := &fakeSessionRepository{
+		sessions: map[string]*entity.Session{"token": {ID: 1, UserID: 1, RefreshFamilyID: "family"}},
+	}
+	s := NewSessionService(repo)
+	if _, err := s.DeleteSession(context.Background(), 1, 2); !errors.Is(err, apperror.ErrNotFound) {
+		t.Errorf("SessionService.DeleteSession() error = %v for a session of another user, want %v", err, apperror.ErrNotFound)
+	}
+
+	session, err := s.DeleteSession(context.Background(), 1, 1)
+	if err != nil || session.RefreshFamilyID != "family" {
+		t.Errorf("SessionService.DeleteSession() = %+v, %v, want the deleted session", session, err)
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+)
+
+// SubscriptionPackageService is the interface used for the subscription package service.
+type SubscriptionPackageService interface {
+	GetSubscriptionPackages(ctx context.Context) ([]*entity.SubscriptionPackage, error)
+	GetSubscriptionPackageByID(ctx context.Context, id int) (*entity.SubscriptionPackage, error)
+}
+
+type subscriptionPackageService struct {
+	repo repository.SubscriptionPackageRepository
+}
+
+// NewSubscriptionPackageService is a function used to initialize the subscription package service implementation.
+func NewSubscriptionPackageService(repo repository.SubscriptionPackageRepository) SubscriptionPackageService {
+	return &subscriptionPackageService{
+		repo: repo,
+	}
+}
+
+// GetSubscriptionPackages is a method for getting all subscription packages.
+func (s *subscriptionPackageService) GetSubscriptionPackages(ctx context.Context) ([]*entity.SubscriptionPackage, error) {
+	return s.repo.FindAll(ctx)
+}
+
+// GetSubscriptionPackageByID is a method for getting subscription package based on ID.
+func (s *subscriptionPackageService) GetSubscriptionPackageByID(ctx context.Context, id int) (*entity.SubscriptionPackage, error) {
+	return s.repo.FindByID(ctx, id)
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+
+	"gorm.io/gorm/schema"
+)
+
+var mockSubscriptionPackage = &entity.SubscriptionPackage{
+	ID:           1,
+	Name:         "PREMIUM_MONTHLY",
+	LifetimeDays: 30,
+	Price:        99000,
+}
+
+type fakeSubscriptionPackageRepository struct {
+	subscriptionPackages []*entity.SubscriptionPackage
+	subscriptionPackage  *entity.SubscriptionPackage
+	err                  error
+}
+
+func (f *fakeSubscriptionPackageRepository) FindAll(context.Context) ([]*entity.SubscriptionPackage, error) {
+	return f.subscriptionPackages, f.err
+}
+
+func (f *fakeSubscriptionPackageRepository) FindByID(context.Context, int) (*entity.SubscriptionPackage, error) {
+	return f.subscriptionPackage, f.err
+}
+
+func TestSubscriptionPackageService_GetSubscriptionPackages(t *testing.T) {
+	type fields struct {
+		repo repository.SubscriptionPackageRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    []*entity.SubscriptionPackage
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeSubscriptionPackageRepository{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeSubscriptionPackageRepository{
+					subscriptionPackages: []*entity.SubscriptionPackage{mockSubscriptionPackage},
+				},
+			},
+			want:    []*entity.SubscriptionPackage{mockSubscriptionPackage},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSubscriptionPackageService(tt.fields.repo)
+			got, err := s.GetSubscriptionPackages(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionPackageService.GetSubscriptionPackages() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SubscriptionPackageService.GetSubscriptionPackages() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionPackageService_GetSubscriptionPackageByID(t *testing.T) {
+	type fields struct {
+		repo repository.SubscriptionPackageRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *entity.SubscriptionPackage
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeSubscriptionPackageRepository{
+					err: apperror.ErrNotFound,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeSubscriptionPackageRepository{
+					subscriptionPackage: mockSubscriptionPackage,
+				},
+			},
+			want:    mockSubscriptionPackage,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSubscriptionPackageService(tt.fields.repo)
+			got, err := s.GetSubscriptionPackageByID(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionPackageService.GetSubscriptionPackageByID() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SubscriptionPackageService.GetSubscriptionPackageByID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+// SubscriptionService is the interface used for the subscription service.
+type SubscriptionService interface {
+	CreateSubscription(ctx context.Context, subscription *entity.Subscription) error
+	GetActiveSubscription(ctx context.Context, userID int) (*entity.Subscription, error)
+	HasActiveSubscription(ctx context.Context, userID int) (bool, error)
+	DeactivateExpiredSubscriptions(ctx context.Context, userID int) error
+	CancelSubscription(ctx context.Context, id int) error
+}
+
+type subscriptionService struct {
+	repo repository.SubscriptionRepository
+}
+
+// NewSubscriptionService is a function used to initialize the subscription service implementation.
+func NewSubscriptionService(repo repository.SubscriptionRepository) SubscriptionService {
+	return &subscriptionService{
+		repo: repo,
+	}
+}
+
+// CreateSubscription is a method for creating a subscription.
+func (s *subscriptionService) CreateSubscription(ctx context.Context, subscription *entity.Subscription) error {
+	return s.repo.Insert(ctx, subscription)
+}
+
+// GetActiveSubscription is a method for getting the subscription of a user that is active today.
+func (s *subscriptionService) GetActiveSubscription(ctx context.Context, userID int) (*entity.Subscription, error) {
+	return s.repo.FindActiveByUserID(ctx, userID, util.StartOfDay(time.Now()))
+}
+
+// HasActiveSubscription is a method for checking whether a user has a subscription that is active today.
+func (s *subscriptionService) HasActiveSubscription(ctx context.Context, userID int) (bool, error) {
+	_, err := s.GetActiveSubscription(ctx, userID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// DeactivateExpiredSubscriptions is a method for deactivating the subscriptions of a user that have ended.
+func (s *subscriptionService) DeactivateExpiredSubscriptions(ctx context.Context, userID int) error {
+	return s.repo.DeactivateExpired(ctx, userID, util.StartOfDay(time.Now()))
+}
+
+// CancelSubscription is a method for cancelling a subscription.
+func (s *subscriptionService) CancelSubscription(ctx context.Context, id int) error {
+	return s.repo.Deactivate(ctx, id)
+}
@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+
+	"gorm.io/gorm/schema"
+)
+
+var mockSubscription = &entity.Subscription{
+	ID:                    1,
+	SubscriptionPackageID: 1,
+	UserID:                1,
+	Active:                true,
+}
+
+type fakeSubscriptionRepository struct {
+	subscription *entity.Subscription
+	err          error
+}
+
+func (f *fakeSubscriptionRepository) Insert(context.Context, *entity.Subscription) error {
+	return f.err
+}
+
+func (f *fakeSubscriptionRepository) FindActiveByUserID(context.Context, int, time.Time) (*entity.Subscription, error) {
+	return f.subscription, f.err
+}
+
+func (f *fakeSubscriptionRepository) DeactivateExpired(context.Context, int, time.Time) error {
+	return f.err
+}
+
+func (f *fakeSubscriptionRepository) Deactivate(context.Context, int) error {
+	return f.err
+}
+
+func TestSubscriptionService_CreateSubscription(t *testing.T) {
+	type fields struct {
+		repo repository.SubscriptionRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeSubscriptionRepository{
+					err: apperror.ErrConflict,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeSubscriptionRepository{},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSubscriptionService(tt.fields.repo)
+			if err := s.CreateSubscription(context.Background(), &entity.Subscription{}); (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionService.CreateSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_GetActiveSubscription(t *testing.T) {
+	type fields struct {
+		repo repository.SubscriptionRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *entity.Subscription
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeSubscriptionRepository{
+					err: apperror.ErrNotFound,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeSubscriptionRepository{
+					subscription: mockSubscription,
+				},
+			},
+			want:    mockSubscription,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSubscriptionService(tt.fields.repo)
+			got, err := s.GetActiveSubscription(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionService.GetActiveSubscription() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SubscriptionService.GetActiveSubscription() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_HasActiveSubscription(t *testing.T) {
+	type fields struct {
+		repo repository.SubscriptionRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeSubscriptionRepository{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name: "Success: No active subscription",
+			fields: fields{
+				repo: &fakeSubscriptionRepository{
+					err: apperror.ErrNotFound,
+				},
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Success: Has active subscription",
+			fields: fields{
+				repo: &fakeSubscriptionRepository{
+					subscription: mockSubscription,
+				},
+			},
+			want:    true,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSubscriptionService(tt.fields.repo)
+			got, err := s.HasActiveSubscription(context.Background(), 1)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionService.HasActiveSubscription() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if got != tt.want {
+				t.Errorf("SubscriptionService.HasActiveSubscription() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_DeactivateExpiredSubscriptions(t *testing.T) {
+	type fields struct {
+		repo repository.SubscriptionRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeSubscriptionRepository{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeSubscriptionRepository{},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSubscriptionService(tt.fields.repo)
+			if err := s.DeactivateExpiredSubscriptions(context.Background(), 1); (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionService.DeactivateExpiredSubscriptions() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestSubscriptionService_CancelSubscription(t *testing.T) {
+	type fields struct {
+		repo repository.SubscriptionRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeSubscriptionRepository{
+					err: apperror.ErrNotFound,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeSubscriptionRepository{},
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewSubscriptionService(tt.fields.repo)
+			if err := s.CancelSubscription(context.Background(), 1); (err != nil) != tt.wantErr {
+				t.Errorf("SubscriptionService.CancelSubscription() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+)
+
+// TokenRevocationService is the interface used for the token revocation service.
+type TokenRevocationService interface {
+	RevokeToken(ctx context.Context, userID int, claims *entity.TokenClaims) error
+	RevokeAllTokens(ctx context.Context, userID int) error
+	IsRevoked(ctx context.Context, userID int, claims *entity.TokenClaims) (bool, error)
+}
+
+type tokenRevocationService struct {
+	repo repository.TokenRevocationRepository
+}
+
+// NewTokenRevocationService is a function used to initialize the token revocation service implementation.
+func NewTokenRevocationService(repo repository.TokenRevocationRepository) TokenRevocationService {
+	return &tokenRevocationService{
+		repo: repo,
+	}
+}
+
+// RevokeToken is a method for revoking a single access token of a user.
+func (t *tokenRevocationService) RevokeToken(ctx context.Context, userID int, claims *entity.TokenClaims) error {
+	return t.repo.InsertRevokedToken(ctx, entity.NewRevokedToken(userID, claims, time.Now().UTC()))
+}
+
+// RevokeAllTokens is a method for revoking every access token issued to a user before now.
+// Token issue times only have a precision of seconds, so tokens issued earlier in the current second stay valid.
+func (t *tokenRevocationService) RevokeAllTokens(ctx context.Context, userID int) error {
+	return t.repo.UpsertUserTokenRevocation(ctx, entity.NewUserTokenRevocation(userID, time.Now().UTC().Truncate(time.Second)))
+}
+
+// IsRevoked is a method for checking whether an access token of a user was revoked on its own or together with every other token of the user.
+func (t *tokenRevocationService) IsRevoked(ctx context.Context, userID int, claims *entity.TokenClaims) (bool, error) {
+	revokedBefore, err := t.repo.FindRevokedBefore(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	if claims.IssuedAt.Before(revokedBefore) {
+		return true, nil
+	}
+
+	return t.repo.ExistsRevokedToken(ctx, claims.ID)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+
+	"gorm.io/gorm/schema"
+)
+
+type fakeTokenRevocationRepository struct {
+	revokedTokens map[string]bool
+	revokedBefore time.Time
+	err           error
+}
+
+func (f *fakeTokenRevocationRepository) InsertRevokedToken(_ context.Context, revokedToken *entity.RevokedToken) error {
+	if f.revokedTokens == nil {
+		f.revokedTokens = map[string]bool{}
+	}
+	f.revokedTokens[revokedToken.ID] = true
+
+	return f.err
+}
+
+func (f *fakeTokenRevocationRepository) ExistsRevokedToken(_ context.Context, id string) (bool, error) {
+	return f.revokedTokens[id], f.err
+}
+
+func (f *fakeTokenRevocationRepository) UpsertUserTokenRevocation(_ context.Context, revocation *entity.UserTokenRevocation) error {
+	f.revokedBefore = revocation.RevokedBefore
+
+	return f.err
+}
+
+func (f *fakeTokenRevocationRepository) FindRevokedBefore(context.Context, int) (time.Time, error) {
+	return f.revokedBefore, f.err
+}
+
+func TestTokenRevocationService_IsRevoked(t *testing.T) {
+	issuedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	claims := &entity.TokenClaims{ID: "jti", IssuedAt: issuedAt, ExpiresAt: issuedAt.Add(time.Hour)}
+	tests := []struct {
+		name    string
+		repo    *fakeTokenRevocationRepository
+		want    bool
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			repo: &fakeTokenRevocationRepository{
+				err: schema.ErrUnsupportedDataType,
+			},
+			want:    false,
+			wantErr: true,
+		},
+		{
+			name:    "Not revoked",
+			repo:    &fakeTokenRevocationRepository{},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Issued in the second of the revocation of every token",
+			repo: &fakeTokenRevocationRepository{
+				revokedBefore: issuedAt,
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "Revoked on its own",
+			repo: &fakeTokenRevocationRepository{
+				revokedTokens: map[string]bool{"jti": true},
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "Revoked together with every token",
+			repo: &fakeTokenRevocationRepository{
+				revokedBefore: issuedAt.Add(time.Second),
+			},
+			want:    true,
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewTokenRevocationService(tt.repo)
+			got, err := s.IsRevoked(context.Background(), 1, claims)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TokenRevocationService.IsRevoked() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if got != tt.want {
+				t.Errorf("TokenRevocationService.IsRevoked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenRevocationService_RevokeToken(t *testing.T) {
+	repo := &fakeTokenRevocationRepository{}
+	s := NewTokenRevocationService(repo)
+	claims := &entity.TokenClaims{ID: "jti", IssuedAt: time.Now(), ExpiresAt: time.Now().Add(time.Hour)}
+
+	if err := s.RevokeToken(context.Background(), 1, claims); err != nil {
+		t.Fatalf("TokenRevocationService.RevokeToken() error = %v", err)
+	}
+	if revoked, _ := s.IsRevoked(context.Background(), 1, claims); !revoked {
+		t.Error("TokenRevocationService.RevokeToken() did not revoke the token")
+	}
+}
+
+func TestTokenRevocationService_RevokeAllTokens(t *testing.T) {
+	repo := &fakeTokenRevocationRepository{}
+	s := NewTokenRevocationService(repo)
+	claims := &entity.TokenClaims{ID: "jti", IssuedAt: time.Now().Add(-time.Minute), ExpiresAt: time.Now().Add(time.Hour)}
+
+	if err := s.RevokeAllTokens(context.Background(), 1); err != nil {
+		t.Fatalf("TokenRevocationService.RevokeAllTokens() error = %v", err)
+	}
+	if revoked, _ := s.IsRevoked(context.Background(), 1, claims); !revoked {
+		t.Error("TokenRevocationService.RevokeAllTokens() did not revoke a token issued before now")
+	}
+	if repo.revokedBefore.Nanosecond() != 0 {
+		t.Errorf("TokenRevocationService.RevokeAllTokens() revoked before %v, want a whole second", repo.revokedBefore)
+	}
+}
@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+const (
+	recoveryCodeCount = 10
+	recoveryCodeSize  = 10
+	// totpSkew is the number of time steps before and after the current one whose codes are accepted, to allow for clock drift.
+	totpSkew = 1
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactorService is the interface used for the two-factor service.
+type TwoFactorService interface {
+	IsEnabled(ctx context.Context, userID int) (bool, error)
+	Enroll(ctx context.Context, userID int) (string, error)
+	Confirm(ctx context.Context, userID int, code string) ([]string, error)
+	VerifyCode(ctx context.Context, userID int, code string) error
+	UseRecoveryCode(ctx context.Context, userID int, recoveryCode string) error
+}
+
+type twoFactorService struct {
+	twoFactorRepo    repository.TwoFactorRepository
+	recoveryCodeRepo repository.RecoveryCodeRepository
+}
+
+// NewTwoFactorService is a function used to initialize the two-factor service implementation.
+func NewTwoFactorService(twoFactorRepo repository.TwoFactorRepository, recoveryCodeRepo repository.RecoveryCodeRepository) TwoFactorService {
+	return &twoFactorService{
+		twoFactorRepo:    twoFactorRepo,
+		recoveryCodeRepo: recoveryCodeRepo,
+	}
+}
+
+// IsEnabled is a method for checking whether logging in as the user requires a second factor.
+func (t *twoFactorService) IsEnabled(ctx context.Context, userID int) (bool, error) {
+	twoFactor, err := t.twoFactorRepo.Find(ctx, userID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return twoFactor.IsEnabled(), nil
+}
+
+// Enroll is a method for generating a new TOTP secret for the user and returning it.
+// Enrolling again before confirming replaces the secret, while an enabled two-factor authentication is left as it is.
+func (t *twoFactorService) Enroll(ctx context.Context, userID int) (string, error) {
+	secret, err := util.RandomTOTPSecret()
+	if err != nil {
+		return "", err
+	}
+
+	currentTime := time.Now().UTC()
+	err = t.twoFactorRepo.Upsert(ctx, entity.NewTwoFactor(userID, secret, currentTime, currentTime))
+	if errors.Is(err, apperror.ErrConflict) {
+		return "", apperror.Wrap(apperror.KindConflict, constant.TwoFactorEnabled, err)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	return secret, nil
+}
+
+// Confirm is a method for enabling the two-factor authentication of the user with a first code from the authenticator,
+// and returning new recovery codes, of which only the hashes are stored.
+func (t *twoFactorService) Confirm(ctx context.Context, userID int, code string) ([]string, error) {
+	twoFactor, err := t.twoFactorRepo.Find(ctx, userID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil, apperror.Wrap(apperror.KindValidation, constant.TwoFactorNotEnrolled, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if twoFactor.IsEnabled() {
+		return nil, apperror.New(apperror.KindConflict, constant.TwoFactorEnabled)
+	}
+
+	currentTime := time.Now().UTC()
+	step, ok := matchTOTPStep(twoFactor, code, currentTime)
+	if !ok {
+		return nil, apperror.Validation(constant.InvalidTwoFactorCode, apperror.Field("code", "invalid code"))
+	}
+
+	err = t.twoFactorRepo.Confirm(ctx, userID, step, currentTime)
+	if errors.Is(err, apperror.ErrConflict) {
+		return nil, apperror.Wrap(apperror.KindConflict, constant.TwoFactorEnabled, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return t.replaceRecoveryCodes(ctx, userID, currentTime)
+}
+
+// VerifyCode is a method for checking a TOTP code of the user, so that it cannot be used again.
+func (t *twoFactorService) VerifyCode(ctx context.Context, userID int, code string) error {
+	twoFactor, err := t.twoFactorRepo.Find(ctx, userID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.Wrap(apperror.KindUnauthorized, constant.InvalidTwoFactorCode, err)
+	}
+	if err != nil {
+		return err
+	}
+
+	step, ok := matchTOTPStep(twoFactor, code, time.Now().UTC())
+	if !twoFactor.IsEnabled() || !ok {
+		return apperror.New(apperror.KindUnauthorized, constant.InvalidTwoFactorCode)
+	}
+
+	err = t.twoFactorRepo.UseStep(ctx, userID, step)
+	if errors.Is(err, apperror.ErrConflict) {
+		return apperror.Wrap(apperror.KindUnauthorized, constant.InvalidTwoFactorCode, err)
+	}
+
+	return err
+}
+
+// UseRecoveryCode is a method for consuming a recovery code of the user, so that it cannot be used again.
+func (t *twoFactorService) UseRecoveryCode(ctx context.Context, userID int, recoveryCode string) error {
+	err := t.recoveryCodeRepo.MarkUsed(ctx, userID, util.HashToken(normalizeRecoveryCode(recoveryCode)), time.Now().UTC())
+	if errors.Is(err, apperror.ErrNotFound) {
+		return apperror.Wrap(apperror.KindUnauthorized, constant.InvalidTwoFactorCode, err)
+	}
+
+	return err
+}
+
+// replaceRecoveryCodes is a method for generating new recovery codes for the user in place of the previous ones.
+func (t *twoFactorService) replaceRecoveryCodes(ctx context.Context, userID int, createdAt time.Time) ([]string, error) {
+	err := t.recoveryCodeRepo.DeleteByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, 0, recoveryCodeCount)
+	recoveryCodes := make([]*entity.RecoveryCode, 0, recoveryCodeCount)
+	for range recoveryCodeCount {
+		code, err := randomRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+
+		codes = append(codes, code)
+		recoveryCodes = append(recoveryCodes, entity.NewRecoveryCode(userID, util.HashToken(normalizeRecoveryCode(code)), createdAt))
+	}
+
+	err = t.recoveryCodeRepo.InsertBatch(ctx, recoveryCodes)
+	if err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
+
+// matchTOTPStep is a function to find the time step around the given time that the code is the TOTP code of.
+// Time steps up to the last used one are skipped, so that a code is only accepted once.
+func matchTOTPStep(twoFactor *entity.TwoFactor, code string, now time.Time) (int64, bool) {
+	code = strings.ReplaceAll(code, " ", "")
+	current := util.TOTPStep(now)
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= twoFactor.LastUsedStep {
+			continue
+		}
+
+		expected, err := util.TOTPCode(twoFactor.Secret, step)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// randomRecoveryCode is a function to generate a recovery code, in groups of four characters that are easy to write down.
+func randomRecoveryCode() (string, error) {
+	bytes := make([]byte, recoveryCodeSize)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := strings.ToLower(recoveryCodeEncoding.EncodeToString(bytes))
+	groups := make([]string, 0, len(encoded)/4)
+	for i := 0; i < len(encoded); i += 4 {
+		groups = append(groups, encoded[i:i+4])
+	}
+
+	return strings.Join(groups, "-"), nil
+}
+
+// normalizeRecoveryCode is a function to remove the formatting of a recovery code, so that it matches however it was typed.
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
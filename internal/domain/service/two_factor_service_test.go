@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+const testTOTPSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+type fakeTwoFactorRepository struct {
+	twoFactor *entity.TwoFactor
+	err       error
+}
+
+func (f *fakeTwoFactorRepository) Find(context.Context, int) (*entity.TwoFactor, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.twoFactor == nil {
+		return &entity.TwoFactor{}, apperror.ErrNotFound
+	}
+
+	return f.twoFactor, nil
+}
+
+func (f *fakeTwoFactorRepository) Upsert(_ context.Context, twoFactor *entity.TwoFactor) error {
+	if f.twoFactor != nil && f.twoFactor.IsEnabled() {
+		return apperror.ErrConflict
+	}
+
+	f.twoFactor = twoFactor
+
+	return f.err
+}
+
+func (f *fakeTwoFactorRepository) Confirm(_ context.Context, _ int, step int64, confirmedAt time.Time) error {
+	f.twoFactor.LastUsedStep = step
+	f.twoFactor.ConfirmedAt = &confirmedAt
+
+	return f.err
+}
+
+func (f *fakeTwoFactorRepository) UseStep(_ context.Context, _ int, step int64) error {
+	if step <= f.twoFactor.LastUsedStep {
+		return apperror.ErrConflict
+	}
+
+	f.twoFactor.LastUsedStep = step
+
+	return f.err
+}
+
+type fakeRecoveryCodeRepository struct {
+	recoveryCodes []*entity.RecoveryCode
+	err           error
+}
+
+func (f *fakeRecoveryCodeRepository) InsertBatch(_ context.Context, recoveryCodes []*entity.RecoveryCode) error {
+	f.recoveryCodes = append(f.recoveryCodes, recoveryCodes...)
+
+	return f.err
+}
+
+func (f *fakeRecoveryCodeRepository) DeleteByUserID(context.Context, int) error {
+	f.recoveryCodes = nil
+
+	return f.err
+}
+
+func (f *fakeRecoveryCodeRepository) MarkUsed(_ context.Context, _ int, codeHash string, usedAt time.Time) error {
+	for _, recoveryCode := range f.recoveryCodes {
+		if recoveryCode.CodeHash == codeHash && recoveryCode.UsedAt == nil {
+			recoveryCode.UsedAt = &usedAt
+
+			return f.err
+		}
+	}
+
+	return apperror.ErrNotFound
+}
+
+func currentTOTPCode(t *testing.T, offset int64) string {
+	t.Helper()
+
+	code, err := util.TOTPCode(testTOTPSecret, util.TOTPStep(time.Now())+offset)
+	if err != nil {
+		t.Fatalf("TOTPCode() error = %v", err)
+	}
+
+	return code
+}
+
+func enabledTwoFactor(lastUsedStep int64) *entity.TwoFactor {
+	confirmedAt := time.Now().UTC()
+
+	return &entity.TwoFactor{UserID: 1, Secret: testTOTPSecret, LastUsedStep: lastUsedStep, ConfirmedAt: &confirmedAt}
+}
+
+func Test_twoFactorService_Enroll(t *testing.T) {
+	repo := &fakeTwoFactorRepository{}
+	s := NewTwoFactorService(repo, &fakeRecoveryCodeRepository{})
+
+	secret, err := s.Enroll(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("twoFactorService.Enroll() error = %v", err)
+	}
+	if repo.twoFactor.Secret != secret || repo.twoFactor.IsEnabled() {
+		t.Errorf("twoFactorService.Enroll() stored %+v, want an unconfirmed enrollment with the secret", repo.twoFactor)
+	}
+
+	repo.twoFactor = enabledTwoFactor(0)
+	_, err = s.Enroll(context.Background(), 1)
+	if !errors.Is(err, apperror.ErrConflict) {
+		t.Errorf("twoFactorService.Enroll() error = %v, want a conflict when already enabled", err)
+	}
+}
+
+func Test_twoFactorService_Confirm(t *testing.T) {
+	tests := []struct {
+		name      string
+		twoFactor *entity.TwoFactor
+		code      string
+		wantErr   error
+	}{
+		{
+			name:    "Failed: Not enrolled",
+			code:    "123456",
+			wantErr: apperror.ErrValidation,
+		},
+		{
+			name:      "Failed: Already enabled",
+			twoFactor: enabledTwoFactor(0),
+			code:      "123456",
+			wantErr:   apperror.ErrConflict,
+		},
+		{
+			name:      "Failed: Wrong code",
+			twoFactor: &entity.TwoFactor{UserID: 1, Secret: testTOTPSecret},
+			code:      "000000",
+			wantErr:   apperror.ErrValidation,
+		},
+		{
+			name:      "Success",
+			twoFactor: &entity.TwoFactor{UserID: 1, Secret: testTOTPSecret},
+			code:      currentTOTPCode(t, 0),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			recoveryCodeRepo := &fakeRecoveryCodeRepository{}
+			s := NewTwoFactorService(&fakeTwoFactorRepository{twoFactor: test.twoFactor}, recoveryCodeRepo)
+			codes, err := s.Confirm(context.Background(), 1, test.code)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("twoFactorService.Confirm() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr != nil {
+				return
+			}
+
+			if !test.twoFactor.IsEnabled() {
+				t.Errorf("twoFactorService.Confirm() did not enable two-factor authentication")
+			}
+			if len(codes) != recoveryCodeCount || len(recoveryCodeRepo.recoveryCodes) != recoveryCodeCount {
+				t.Fatalf("twoFactorService.Confirm() = %d codes, stored %d, want %d", len(codes), len(recoveryCodeRepo.recoveryCodes), recoveryCodeCount)
+			}
+			if recoveryCodeRepo.recoveryCodes[0].CodeHash == codes[0] || strings.Contains(recoveryCodeRepo.recoveryCodes[0].CodeHash, "-") {
+				t.Errorf("twoFactorService.Confirm() stored %v, want the hash of the normalized code", recoveryCodeRepo.recoveryCodes[0].CodeHash)
+			}
+		})
+	}
+}
+
+func Test_twoFactorService_VerifyCode(t *testing.T) {
+	currentStep := util.TOTPStep(time.Now())
+	tests := []struct {
+		name      string
+		twoFactor *entity.TwoFactor
+		code      string
+		wantErr   error
+	}{
+		{
+			name:    "Failed: Not enrolled",
+			code:    currentTOTPCode(t, 0),
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name:      "Failed: Not confirmed",
+			twoFactor: &entity.TwoFactor{UserID: 1, Secret: testTOTPSecret},
+			code:      currentTOTPCode(t, 0),
+			wantErr:   apperror.ErrUnauthorized,
+		},
+		{
+			name:      "Failed: Wrong code",
+			twoFactor: enabledTwoFactor(0),
+			code:      "000000",
+			wantErr:   apperror.ErrUnauthorized,
+		},
+		{
+			name:      "Failed: Code too old",
+			twoFactor: enabledTwoFactor(0),
+			code:      currentTOTPCode(t, -3),
+			wantErr:   apperror.ErrUnauthorized,
+		},
+		{
+			name:      "Failed: Code already used",
+			twoFactor: enabledTwoFactor(currentStep + 1),
+			code:      currentTOTPCode(t, 0),
+			wantErr:   apperror.ErrUnauthorized,
+		},
+		{
+			name:      "Success",
+			twoFactor: enabledTwoFactor(0),
+			code:      currentTOTPCode(t, 0),
+		},
+		{
+			name:      "Success: Previous time step",
+			twoFactor: enabledTwoFactor(0),
+			code:      currentTOTPCode(t, -1),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewTwoFactorService(&fakeTwoFactorRepository{twoFactor: test.twoFactor}, &fakeRecoveryCodeRepository{})
+			err := s.VerifyCode(context.Background(), 1, test.code)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("twoFactorService.VerifyCode() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr == nil && !errors.Is(s.VerifyCode(context.Background(), 1, test.code), apperror.ErrUnauthorized) {
+				t.Errorf("twoFactorService.VerifyCode() accepted the same code twice")
+			}
+		})
+	}
+}
+
+func Test_twoFactorService_UseRecoveryCode(t *testing.T) {
+	recoveryCodeRepo := &fakeRecoveryCodeRepository{}
+	s := NewTwoFactorService(&fakeTwoFactorRepository{twoFactor: &entity.TwoFactor{UserID: 1, Secret: testTOTPSecret}}, recoveryCodeRepo)
+	codes, err := s.Confirm(context.Background(), 1, currentTOTPCode(t, 0))
+	if err != nil {
+		t.Fatalf("twoFactorService.Confirm() error = %v", err)
+	}
+
+	err = s.UseRecoveryCode(context.Background(), 1, "wrong-code")
+	if !errors.Is(err, apperror.ErrUnauthorized) {
+		t.Errorf("twoFactorService.UseRecoveryCode() error = %v, want unauthorized for an unknown code", err)
+	}
+
+	err = s.UseRecoveryCode(context.Background(), 1, strings.ToUpper(strings.ReplaceAll(codes[0], "-", " ")))
+	if err != nil {
+		t.Errorf("twoFactorService.UseRecoveryCode() error = %v for a code typed differently", err)
+	}
+
+	err = s.UseRecoveryCode(context.Background(), 1, codes[0])
+	if !errors.Is(err, apperror.ErrUnauthorized) {
+		t.Errorf("twoFactorService.UseRecoveryCode() error = %v, want unauthorized for a used code", err)
+	}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+)
+
+// UserIdentityService is the interface used for the user identity service.
+type UserIdentityService interface {
+	GetUserIdentity(ctx context.Context, provider, subject string) (*entity.UserIdentity, error)
+	LinkUserIdentity(ctx context.Context, userID int, identity *entity.ExternalIdentity) error
+}
+
+type userIdentityService struct {
+	repo repository.UserIdentityRepository
+}
+
+// NewUserIdentityService is a function used to initialize the user identity service implementation.
+func NewUserIdentityService(repo repository.UserIdentityRepository) UserIdentityService {
+	return &userIdentityService{
+		repo: repo,
+	}
+}
+
+// GetUserIdentity is a method for getting the user identity of an account at an identity provider.
+func (u *userIdentityService) GetUserIdentity(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	return u.repo.FindByProviderSubject(ctx, provider, subject)
+}
+
+// LinkUserIdentity is a method for linking the account of a verified ID token to a user, so that the user logs in with it from then on.
+func (u *userIdentityService) LinkUserIdentity(ctx context.Context, userID int, identity *entity.ExternalIdentity) error {
+	userIdentity := entity.NewUserIdentity(userID, identity.Provider, identity.Subject, identity.Email, time.Now().UTC())
+
+	return u.repo.Insert(ctx, userIdentity)
+}
@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+type fakeUserIdentityRepository struct {
+	userIdentities []*entity.UserIdentity
+}
+
+func (f *fakeUserIdentityRepository) Insert(_ context.Context, userIdentity *entity.UserIdentity) error {
+	for _, existing := range f.userIdentities {
+		if existing.Provider == userIdentity.Provider && existing.Subject == userIdentity.Subject {
+			return apperror.ErrConflict
+		}
+	}
+
+	userIdentity.ID = len(f.userIdentities) + 1
+	f.userIdentities = append(f.userIdentities, userIdentity)
+
+	return nil
+}
+
+func (f *fakeUserIdentityRepository) FindByProviderSubject(_ context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	for _, userIdentity := range f.userIdentities {
+		if userIdentity.Provider == provider && userIdentity.Subject == subject {
+			return userIdentity, nil
+		}
+	}
+
+	return &entity.UserIdentity{}, apperror.ErrNotFound
+}
+
+func TestUserIdentityService_LinkUserIdentity(t *testing.T) {
+	repo := &fakeUserIdentityRepository{}
+	s := NewUserIdentityService(repo)
+	identity := &entity.ExternalIdentity{Provider: "google", Subject: "subject", Email: "user@email.com", EmailVerified: true}
+
+	_, err := s.GetUserIdentity(context.Background(), "google", "subject")
+	if !errors.Is(err, apperror.ErrNotFound) {
+		t.Fatalf("UserIdentityService.GetUserIdentity() error = %v, wantErr %v", err, apperror.ErrNotFound)
+	}
+
+	err = s.LinkUserIdentity(context.Background(), 1, identity)
+	if err != nil {
+		t.Fatalf("UserIdentityService.LinkUserIdentity() error = %v", err)
+	}
+
+	userIdentity, err := s.GetUserIdentity(context.Background(), "google", "subject")
+	if err != nil || userIdentity.UserID != 1 || userIdentity.Email != "user@email.com" {
+		t.Errorf("UserIdentityService.GetUserIdentity() = %+v, %v, want the linked user", userIdentity, err)
+	}
+
+	err = s.LinkUserIdentity(context.Background(), 2, identity)
+	if !errors.Is(err, apperror.ErrConflict) {
+		t.Errorf("UserIdentityService.LinkUserIdentity() error = %v, wantErr %v", err, apperror.ErrConflict)
+	}
+}
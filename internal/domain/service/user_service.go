@@ -2,6 +2,8 @@ package service
 
 import (
 	"context"
+	"time"
+
 	"dealls-technical-test-dating-service/internal/domain/entity"
 	"dealls-technical-test-dating-service/internal/domain/repository"
 )
@@ -10,6 +12,14 @@ import (
 type UserService interface {
 	CreateUser(ctx context.Context, user *entity.User) (int, error)
 	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	GetUserByPhone(ctx context.Context, phone string) (*entity.User, error)
+	GetUserByID(ctx context.Context, id int) (*entity.User, error)
+	VerifyEmail(ctx context.Context, id int) error
+	UpdatePassword(ctx context.Context, id int, hashedPassword string) error
+	ChangeEmail(ctx context.Context, id int, email string) error
+	ChangeRole(ctx context.Context, id int, role string) error
+	UpdateProfile(ctx context.Context, user *entity.User) error
+	ChangeProfilePicture(ctx context.Context, id int, key string) (string, error)
 }
 
 type userService struct {
@@ -32,3 +42,46 @@ func (u *userService) CreateUser(ctx context.Context, user *entity.User) (int, e
 func (u *userService) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
 	return u.repo.FindByEmail(ctx, email)
 }
+
+// GetUserByPhone is a method for getting user based on phone number.
+func (u *userService) GetUserByPhone(ctx context.Context, phone string) (*entity.User, error) {
+	return u.repo.FindByPhone(ctx, phone)
+}
+
+// GetUserByID is a method for getting user based on ID.
+func (u *userService) GetUserByID(ctx context.Context, id int) (*entity.User, error) {
+	return u.repo.FindByID(ctx, id)
+}
+
+// VerifyEmail is a method for marking the email address of a user as verified.
+func (u *userService) VerifyEmail(ctx context.Context, id int) error {
+	return u.repo.UpdateEmailVerifiedAt(ctx, id, time.Now().UTC())
+}
+
+// UpdatePassword is a method for replacing the password of a user with the hashed password.
+func (u *userService) UpdatePassword(ctx context.Context, id int, hashedPassword string) error {
+	return u.repo.UpdatePassword(ctx, id, hashedPassword, time.Now().UTC())
+}
+
+// ChangeEmail is a method for replacing the email address of a user with a confirmed one, which makes it verified.
+func (u *userService) ChangeEmail(ctx context.Context, id int, email string) error {
+	return u.repo.UpdateEmail(ctx, id, email, time.Now().UTC())
+}
+
+// ChangeRole is a method for replacing the role of a user, which changes the permissions of the user at once.
+func (u *userService) ChangeRole(ctx context.Context, id int, role string) error {
+	return u.repo.UpdateRole(ctx, id, role, time.Now().UTC())
+}
+
+// UpdateProfile is a method for saving the name and location of a user.
+func (u *userService) UpdateProfile(ctx context.Context, user *entity.User) error {
+	user.UpdatedAt = time.Now().UTC()
+
+	return u.repo.UpdateProfile(ctx, user.ID, user.Name, user.Location, user.UpdatedAt)
+}
+
+// ChangeProfilePicture is a method for replacing the profile picture of a user with the photo stored under the key, or removing it with an empty key.
+// The key of the photo it replaced is returned, empty when the user had none.
+func (u *userService) ChangeProfilePicture(ctx context.Context, id int, key string) (string, error) {
+	return u.repo.UpdateProfilePictureKey(ctx, id, key, time.Now().UTC())
+}
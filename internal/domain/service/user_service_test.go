@@ -6,17 +6,17 @@ import (
 	"testing"
 	"time"
 
+	"dealls-technical-test-dating-service/internal/domain/apperror"
 	"dealls-technical-test-dating-service/internal/domain/entity"
 	"dealls-technical-test-dating-service/internal/domain/repository"
 
-	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
 
 var (
 	mockFailedUserRepository = &fakeUserRepository{
 		id:  0,
-		err: gorm.ErrDuplicatedKey,
+		err: apperror.ErrConflict,
 	}
 	mockSuccessUser = &entity.User{
 		ID:        1,
@@ -45,6 +45,38 @@ func (f *fakeUserRepository) FindByEmail(context.Context, string) (*entity.User,
 	return f.user, f.err
 }
 
+func (f *fakeUserRepository) FindByPhone(context.Context, string) (*entity.User, error) {
+	return f.user, f.err
+}
+
+func (f *fakeUserRepository) FindByID(context.Context, int) (*entity.User, error) {
+	return f.user, f.err
+}
+
+func (f *fakeUserRepository) UpdateEmailVerifiedAt(context.Context, int, time.Time) error {
+	return f.err
+}
+
+func (f *fakeUserRepository) UpdatePassword(context.Context, int, string, time.Time) error {
+	return f.err
+}
+
+func (f *fakeUserRepository) UpdateEmail(context.Context, int, string, time.Time) error {
+	return f.err
+}
+
+func (f *fakeUserRepository) UpdateRole(context.Context, int, string, time.Time) error {
+	return f.err
+}
+
+func (f *fakeUserRepository) UpdateProfile(context.Context, int, string, string, time.Time) error {
+	return f.err
+}
+
+func (f *fakeUserRepository) UpdateProfilePictureKey(context.Context, int, string, time.Time) (string, error) {
+	return "photos/1/old", f.err
+}
+
 func TestUserService_CreateUser(t *testing.T) {
 	type fields struct {
 		repo repository.UserRepository
@@ -141,3 +173,222 @@ func TestUserService_GetUserByEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestUserService_GetUserByID(t *testing.T) {
+	type fields struct {
+		repo repository.UserRepository
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *entity.User
+		wantErr bool
+	}{
+		{
+			name: "Failed",
+			fields: fields{
+				repo: &fakeUserRepository{
+					user: &entity.User{},
+					err:  schema.ErrUnsupportedDataType,
+				},
+			},
+			want:    &entity.User{},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				repo: &fakeUserRepository{
+					user: mockSuccessUser,
+					err:  nil,
+				},
+			},
+			want:    mockSuccessUser,
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserService(test.fields.repo)
+			got, err := u.GetUserByID(context.Background(), 1)
+			if (err != nil) != test.wantErr {
+				t.Errorf("UserService.GetUserByID() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("UserService.GetUserByID() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestUserService_VerifyEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    repository.UserRepository
+		wantErr bool
+	}{
+		{
+			name:    "Failed",
+			repo:    &fakeUserRepository{err: schema.ErrUnsupportedDataType},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			repo:    &fakeUserRepository{},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserService(test.repo)
+			if err := u.VerifyEmail(context.Background(), 1); (err != nil) != test.wantErr {
+				t.Errorf("UserService.VerifyEmail() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserService_UpdatePassword(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    repository.UserRepository
+		wantErr bool
+	}{
+		{
+			name:    "Failed",
+			repo:    &fakeUserRepository{err: schema.ErrUnsupportedDataType},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			repo:    &fakeUserRepository{},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserService(test.repo)
+			if err := u.UpdatePassword(context.Background(), 1, "hashed-password"); (err != nil) != test.wantErr {
+				t.Errorf("UserService.UpdatePassword() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserService_ChangeEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    repository.UserRepository
+		wantErr bool
+	}{
+		{
+			name:    "Failed",
+			repo:    &fakeUserRepository{err: apperror.New(apperror.KindConflict, "User already exists")},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			repo:    &fakeUserRepository{},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserService(test.repo)
+			if err := u.ChangeEmail(context.Background(), 1, "new@email.com"); (err != nil) != test.wantErr {
+				t.Errorf("UserService.ChangeEmail() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserService_ChangeRole(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    repository.UserRepository
+		wantErr bool
+	}{
+		{
+			name:    "Failed",
+			repo:    &fakeUserRepository{err: apperror.New(apperror.KindNotFound, "User not found")},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			repo:    &fakeUserRepository{},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserService(test.repo)
+			if err := u.ChangeRole(context.Background(), 1, entity.RoleAdmin); (err != nil) != test.wantErr {
+				t.Errorf("UserService.ChangeRole() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestUserService_UpdateProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    repository.UserRepository
+		wantErr bool
+	}{
+		{
+			name:    "Failed",
+			repo:    &fakeUserRepository{err: apperror.New(apperror.KindNotFound, "User not found")},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			repo:    &fakeUserRepository{},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserService(test.repo)
+			user := &entity.User{ID: 1, Name: "User", Location: "Indonesia"}
+			if err := u.UpdateProfile(context.Background(), user); (err != nil) != test.wantErr {
+				t.Errorf("UserService.UpdateProfile() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if user.UpdatedAt.IsZero() {
+				t.Errorf("UserService.UpdateProfile() did not set the update time")
+			}
+		})
+	}
+}
+
+func TestUserService_ChangeProfilePicture(t *testing.T) {
+	tests := []struct {
+		name    string
+		repo    repository.UserRepository
+		wantErr bool
+	}{
+		{
+			name:    "Failed",
+			repo:    &fakeUserRepository{err: apperror.New(apperror.KindNotFound, "User not found")},
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			repo:    &fakeUserRepository{},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserService(test.repo)
+			previousKey, err := u.ChangeProfilePicture(context.Background(), 1, "photos/1/key")
+			if (err != nil) != test.wantErr {
+				t.Errorf("UserService.ChangeProfilePicture() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if err == nil && previousKey != "photos/1/old" {
+				t.Errorf("UserService.ChangeProfilePicture() = %q, want the replaced key", previousKey)
+			}
+		})
+	}
+}
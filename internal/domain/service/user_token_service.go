@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+const userTokenSize = 32
+
+// UserTokenService is the interface used for the user token service.
+type UserTokenService interface {
+	IssueUserToken(ctx context.Context, userID int, purpose, email string, expiration time.Duration) (string, error)
+	CreateUserToken(ctx context.Context, userID int, purpose, token, email string, expiresAt time.Time) error
+	UseUserToken(ctx context.Context, purpose, token string) (*entity.UserToken, error)
+	RevokeUserTokens(ctx context.Context, userID int, purpose string) error
+	HasRecentUserToken(ctx context.Context, userID int, purpose string, within time.Duration) (bool, error)
+	IssueIPAddressToken(ctx context.Context, purpose, ipAddress string, expiration time.Duration, maxUsable int) (string, error)
+	DeleteUnusableUserTokens(ctx context.Context, purpose string) error
+}
+
+type userTokenService struct {
+	repo repository.UserTokenRepository
+}
+
+// NewUserTokenService is a function used to initialize the user token service implementation.
+func NewUserTokenService(repo repository.UserTokenRepository) UserTokenService {
+	return &userTokenService{
+		repo: repo,
+	}
+}
+
+// IssueUserToken is a method for generating a random user token sent to the email address, storing it and returning it.
+func (u *userTokenService) IssueUserToken(ctx context.Context, userID int, purpose, email string, expiration time.Duration) (string, error) {
+	token, err := util.RandomToken(userTokenSize)
+	if err != nil {
+		return "", err
+	}
+
+	err = u.CreateUserToken(ctx, userID, purpose, token, email, time.Now().UTC().Add(expiration))
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// CreateUserToken is a method for creating a user token sent to the email address, of which only the hash is stored.
+func (u *userTokenService) CreateUserToken(ctx context.Context, userID int, purpose, token, email string, expiresAt time.Time) error {
+	userToken := entity.NewUserToken(userID, purpose, util.HashToken(token), email, expiresAt, time.Now().UTC())
+
+	return u.repo.Insert(ctx, userToken)
+}
+
+// UseUserToken is a method for consuming a user token of the purpose, so that it cannot be used again, and returning it.
+func (u *userTokenService) UseUserToken(ctx context.Context, purpose, token string) (*entity.UserToken, error) {
+	userToken, err := u.repo.FindByTokenHash(ctx, purpose, util.HashToken(token))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil, apperror.Wrap(apperror.KindValidation, constant.InvalidUserToken, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	currentTime := time.Now().UTC()
+	if !userToken.IsUsable(currentTime) {
+		return nil, apperror.New(apperror.KindValidation, constant.InvalidUserToken)
+	}
+
+	err = u.repo.MarkUsed(ctx, userToken.ID, currentTime)
+	if errors.Is(err, apperror.ErrConflict) {
+		return nil, apperror.Wrap(apperror.KindValidation, constant.InvalidUserToken, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return userToken, nil
+}
+
+// RevokeUserTokens is a method for making every user token of a purpose issued to a user unusable.
+func (u *userTokenService) RevokeUserTokens(ctx context.Context, userID int, purpose string) error {
+	return u.repo.MarkUsedByUserID(ctx, userID, purpose, time.Now().UTC())
+}
+
+// HasRecentUserToken is a method for checking whether a user token of the purpose was issued to a user within the duration.
+func (u *userTokenService) HasRecentUserToken(ctx context.Context, userID int, purpose string, within time.Duration) (bool, error) {
+	userToken, err := u.repo.FindLatest(ctx, userID, purpose)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return time.Since(userToken.CreatedAt) < within, nil
+}
+
+// IssueIPAddressToken is a method for generating a random token of the purpose that belongs to no user, such as an OpenID Connect nonce,
+// storing it along with the IP address that requested it and returning it. A token is refused when the IP address already holds the maximum
+// number of usable tokens of the purpose, 0 meaning no limit, so that the endpoint cannot be used to fill the table. It must be called in a transaction,
+// which holds the lock taken on the IP address so that concurrent requests cannot all pass the check.
+func (u *userTokenService) IssueIPAddressToken(ctx context.Context, purpose, ipAddress string, expiration time.Duration, maxUsable int) (string, error) {
+	currentTime := time.Now().UTC()
+	if maxUsable > 0 && ipAddress != "" {
+		err := u.repo.LockIPAddress(ctx, purpose, ipAddress)
+		if err != nil {
+			return "", err
+		}
+
+		count, err := u.repo.CountUsableByIPAddress(ctx, purpose, ipAddress, currentTime)
+		if err != nil {
+			return "", err
+		}
+		if count >= maxUsable {
+			return "", apperror.New(apperror.KindQuotaExceeded, constant.TooManyNonces)
+		}
+	}
+
+	token, err := util.RandomToken(userTokenSize)
+	if err != nil {
+		return "", err
+	}
+
+	userToken := entity.NewUserToken(0, purpose, util.HashToken(token), "", currentTime.Add(expiration), currentTime)
+	userToken.IPAddress = ipAddress
+	err = u.repo.Insert(ctx, userToken)
+	if err != nil {
+		return "", err
+	}
+
+	return token, nil
+}
+
+// DeleteUnusableUserTokens is a method for deleting the used and expired user tokens of a purpose, for the purposes whose tokens are not kept.
+func (u *userTokenService) DeleteUnusableUserTokens(ctx context.Context, purpose string) error {
+	return u.repo.DeleteUnusable(ctx, purpose, time.Now().UTC())
+}
@@ -0,0 +1,329 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/pkg/util"
+
+	"gorm.io/gorm/schema"
+)
+
+type fakeUserTokenRepository struct {
+	userTokens  map[string]*entity.UserToken
+	lockedIPs   []string
+	insertErr   error
+	markUsedErr error
+}
+
+func (f *fakeUserTokenRepository) Insert(_ context.Context, userToken *entity.UserToken) error {
+	if f.insertErr != nil {
+		return f.insertErr
+	}
+
+	if f.userTokens == nil {
+		f.userTokens = map[string]*entity.UserToken{}
+	}
+	userToken.ID = len(f.userTokens) + 1
+	f.userTokens[userToken.TokenHash] = userToken
+
+	return nil
+}
+
+func (f *fakeUserTokenRepository) FindByTokenHash(_ context.Context, purpose, tokenHash string) (*entity.UserToken, error) {
+	userToken, ok := f.userTokens[tokenHash]
+	if !ok || userToken.Purpose != purpose {
+		return &entity.UserToken{}, apperror.ErrNotFound
+	}
+
+	return userToken, nil
+}
+
+func (f *fakeUserTokenRepository) FindLatest(_ context.Context, userID int, purpose string) (*entity.UserToken, error) {
+	var latest *entity.UserToken
+	for _, userToken := range f.userTokens {
+		if userToken.UserID == userID && userToken.Purpose == purpose && (latest == nil || userToken.CreatedAt.After(latest.CreatedAt)) {
+			latest = userToken
+		}
+	}
+	if latest == nil {
+		return &entity.UserToken{}, apperror.ErrNotFound
+	}
+
+	return latest, nil
+}
+
+func (f *fakeUserTokenRepository) MarkUsed(_ context.Context, id int, usedAt time.Time) error {
+	if f.markUsedErr != nil {
+		return f.markUsedErr
+	}
+
+	for _, userToken := range f.userTokens {
+		if userToken.ID == id {
+			userToken.UsedAt = &usedAt
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeUserTokenRepository) MarkUsedByUserID(_ context.Context, userID int, purpose string, usedAt time.Time) error {
+	for _, userToken := range f.userTokens {
+		if userToken.UserID == userID && userToken.Purpose == purpose && userToken.UsedAt == nil {
+			userToken.UsedAt = &usedAt
+		}
+	}
+
+	return nil
+}
+
+func (f *fakeUserTokenRepository) LockIPAddress(_ context.Context, _, ipAddress string) error {
+	f.lockedIPs = append(f.lockedIPs, ipAddress)
+
+	return nil
+}
+
+func (f *fakeUserTokenRepository) CountUsableByIPAddress(_ context.Context, purpose, ipAddress string, now time.Time) (int, error) {
+	count := 0
+	for _, userToken := range f.userTokens {
+		if userToken.Purpose == purpose && userToken.IPAddress == ipAddress && userToken.IsUsable(now) {
+			count++
+		}
+	}
+
+	return count, nil
+}
+
+func (f *fakeUserTokenRepository) DeleteUnusable(_ context.Context, purpose string, now time.Time) error {
+	for tokenHash, userToken := range f.userTokens {
+		if userToken.Purpose == purpose && !userToken.IsUsable(now) {
+			delete(f.userTokens, tokenHash)
+		}
+	}
+
+	return nil
+}
+
+func TestUserTokenService_IssueUserToken(t *testing.T) {
+	repo := &fakeUserTokenRepository{}
+	u := NewUserTokenService(repo)
+	token, err := u.IssueUserToken(context.Background(), 1, entity.UserTokenPurposePasswordReset, "user@email.com", time.Hour)
+	if err != nil {
+		t.Fatalf("UserTokenService.IssueUserToken() error = %v", err)
+	}
+
+	got, err := u.UseUserToken(context.Background(), entity.UserTokenPurposePasswordReset, token)
+	if err != nil {
+		t.Fatalf("UserTokenService.UseUserToken() error = %v for an issued token", err)
+	}
+	if got.UserID != 1 || got.Email != "user@email.com" {
+		t.Errorf("UserTokenService.IssueUserToken() stored %+v", got)
+	}
+}
+
+func TestUserTokenService_RevokeUserTokens(t *testing.T) {
+	u := NewUserTokenService(&fakeUserTokenRepository{})
+	token, _ := u.IssueUserToken(context.Background(), 1, entity.UserTokenPurposePasswordReset, "user@email.com", time.Hour)
+	err := u.RevokeUserTokens(context.Background(), 1, entity.UserTokenPurposePasswordReset)
+	if err != nil {
+		t.Fatalf("UserTokenService.RevokeUserTokens() error = %v", err)
+	}
+
+	_, err = u.UseUserToken(context.Background(), entity.UserTokenPurposePasswordReset, token)
+	if !errors.Is(err, apperror.ErrValidation) {
+		t.Errorf("UserTokenService.UseUserToken() error = %v for a revoked token, want %v", err, apperror.ErrValidation)
+	}
+}
+
+func TestUserTokenService_CreateUserToken(t *testing.T) {
+	repo := &fakeUserTokenRepository{}
+	u := NewUserTokenService(repo)
+	expiresAt := time.Now().Add(time.Hour)
+	err := u.CreateUserToken(context.Background(), 1, entity.UserTokenPurposeEmailVerification, "token", "user@email.com", expiresAt)
+	if err != nil {
+		t.Fatalf("UserTokenService.CreateUserToken() error = %v", err)
+	}
+
+	userToken, ok := repo.userTokens[util.HashToken("token")]
+	if !ok {
+		t.Fatal("UserTokenService.CreateUserToken() did not store the hash of the token")
+	}
+	if userToken.UserID != 1 || userToken.Email != "user@email.com" || !userToken.ExpiresAt.Equal(expiresAt) {
+		t.Errorf("UserTokenService.CreateUserToken() stored %+v", userToken)
+	}
+}
+
+func TestUserTokenService_UseUserToken(t *testing.T) {
+	used := time.Now().Add(-time.Minute)
+	newRepo := func() *fakeUserTokenRepository {
+		return &fakeUserTokenRepository{
+			userTokens: map[string]*entity.UserToken{
+				util.HashToken("token"):   {ID: 1, UserID: 1, Purpose: entity.UserTokenPurposeEmailVerification, ExpiresAt: time.Now().Add(time.Hour)},
+				util.HashToken("expired"): {ID: 2, UserID: 1, Purpose: entity.UserTokenPurposeEmailVerification, ExpiresAt: time.Now().Add(-time.Hour)},
+				util.HashToken("used"):    {ID: 3, UserID: 1, Purpose: entity.UserTokenPurposeEmailVerification, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &used},
+			},
+		}
+	}
+	tests := []struct {
+		name        string
+		token       string
+		markUsedErr error
+		wantErr     error
+	}{
+		{
+			name:    "Failed: Unknown token",
+			token:   "unknown",
+			wantErr: apperror.ErrValidation,
+		},
+		{
+			name:    "Failed: Expired token",
+			token:   "expired",
+			wantErr: apperror.ErrValidation,
+		},
+		{
+			name:    "Failed: Used token",
+			token:   "used",
+			wantErr: apperror.ErrValidation,
+		},
+		{
+			name:        "Failed: Used concurrently",
+			token:       "token",
+			markUsedErr: apperror.ErrConflict,
+			wantErr:     apperror.ErrValidation,
+		},
+		{
+			name:        "Failed: Mark used failed",
+			token:       "token",
+			markUsedErr: schema.ErrUnsupportedDataType,
+			wantErr:     schema.ErrUnsupportedDataType,
+		},
+		{
+			name:  "Success",
+			token: "token",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newRepo()
+			repo.markUsedErr = tt.markUsedErr
+			u := NewUserTokenService(repo)
+			got, err := u.UseUserToken(context.Background(), entity.UserTokenPurposeEmailVerification, tt.token)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UserTokenService.UseUserToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr == nil && (got.ID != 1 || got.UsedAt == nil) {
+				t.Errorf("UserTokenService.UseUserToken() = %+v, want the token marked as used", got)
+			}
+		})
+	}
+}
+
+func TestUserTokenService_HasRecentUserToken(t *testing.T) {
+	tests := []struct {
+		name       string
+		userTokens map[string]*entity.UserToken
+		want       bool
+	}{
+		{
+			name: "No token",
+			want: false,
+		},
+		{
+			name: "Old token",
+			userTokens: map[string]*entity.UserToken{
+				"hash": {UserID: 1, Purpose: entity.UserTokenPurposeEmailVerification, CreatedAt: time.Now().Add(-time.Hour)},
+			},
+			want: false,
+		},
+		{
+			name: "Recent token",
+			userTokens: map[string]*entity.UserToken{
+				"hash": {UserID: 1, Purpose: entity.UserTokenPurposeEmailVerification, CreatedAt: time.Now().Add(-time.Second)},
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := NewUserTokenService(&fakeUserTokenRepository{userTokens: tt.userTokens})
+			got, err := u.HasRecentUserToken(context.Background(), 1, entity.UserTokenPurposeEmailVerification, time.Minute)
+			if err != nil {
+				t.Fatalf("UserTokenService.HasRecentUserToken() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("UserTokenService.HasRecentUserToken() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUserTokenService_IssueIPAddressToken(t *testing.T) {
+	repo := &fakeUserTokenRepository{}
+	u := NewUserTokenService(repo)
+	for range 2 {
+		_, err := u.IssueIPAddressToken(context.Background(), entity.UserTokenPurposeOIDCNonce, "203.0.113.1", time.Hour, 2)
+		if err != nil {
+			t.Fatalf("UserTokenService.IssueIPAddressToken() error = %v", err)
+		}
+	}
+
+	_, err := u.IssueIPAddressToken(context.Background(), entity.UserTokenPurposeOIDCNonce, "203.0.113.1", time.Hour, 2)
+	if !errors.Is(err, apperror.ErrQuotaExceeded) {
+		t.Errorf("UserTokenService.IssueIPAddressToken() error = %v past the maximum, want %v", err, apperror.ErrQuotaExceeded)
+	}
+	if !reflect.DeepEqual(repo.lockedIPs, []string{"203.0.113.1", "203.0.113.1", "203.0.113.1"}) {
+		t.Errorf("UserTokenService.IssueIPAddressToken() locked %v, want the IP address on every call", repo.lockedIPs)
+	}
+
+	token, err := u.IssueIPAddressToken(context.Background(), entity.UserTokenPurposeOIDCNonce, "203.0.113.2", time.Hour, 2)
+	if err != nil {
+		t.Fatalf("UserTokenService.IssueIPAddressToken() error = %v for another IP address", err)
+	}
+
+	got, err := u.UseUserToken(context.Background(), entity.UserTokenPurposeOIDCNonce, token)
+	if err != nil {
+		t.Fatalf("UserTokenService.UseUserToken() error = %v for an issued token", err)
+	}
+	if got.UserID != 0 || got.IPAddress != "203.0.113.2" {
+		t.Errorf("UserTokenService.IssueIPAddressToken() stored %+v", got)
+	}
+
+	// A used token frees its place.
+	for _, userToken := range repo.userTokens {
+		if userToken.IPAddress == "203.0.113.1" {
+			userToken.UsedAt = &got.CreatedAt
+
+			break
+		}
+	}
+	_, err = u.IssueIPAddressToken(context.Background(), entity.UserTokenPurposeOIDCNonce, "203.0.113.1", time.Hour, 2)
+	if err != nil {
+		t.Errorf("UserTokenService.IssueIPAddressToken() error = %v after a token was used", err)
+	}
+}
+
+func TestUserTokenService_DeleteUnusableUserTokens(t *testing.T) {
+	used := time.Now().Add(-time.Minute)
+	repo := &fakeUserTokenRepository{
+		userTokens: map[string]*entity.UserToken{
+			util.HashToken("token"):   {ID: 1, Purpose: entity.UserTokenPurposeOIDCNonce, ExpiresAt: time.Now().Add(time.Hour)},
+			util.HashToken("expired"): {ID: 2, Purpose: entity.UserTokenPurposeOIDCNonce, ExpiresAt: time.Now().Add(-time.Hour)},
+			util.HashToken("used"):    {ID: 3, Purpose: entity.UserTokenPurposeOIDCNonce, ExpiresAt: time.Now().Add(time.Hour), UsedAt: &used},
+			util.HashToken("other"):   {ID: 4, UserID: 1, Purpose: entity.UserTokenPurposePasswordReset, ExpiresAt: time.Now().Add(-time.Hour)},
+		},
+	}
+	u := NewUserTokenService(repo)
+	err := u.DeleteUnusableUserTokens(context.Background(), entity.UserTokenPurposeOIDCNonce)
+	if err != nil {
+		t.Fatalf("UserTokenService.DeleteUnusableUserTokens() error = %v", err)
+	}
+
+	if len(repo.userTokens) != 2 || repo.userTokens[util.HashToken("token")] == nil || repo.userTokens[util.HashToken("other")] == nil {
+		t.Errorf("UserTokenService.DeleteUnusableUserTokens() kept %v, want the usable token and the tokens of other purposes", repo.userTokens)
+	}
+}
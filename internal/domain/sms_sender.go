@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// SMSSender is an interface that represents the text message functionality needed by the domain.
+type SMSSender interface {
+	Send(ctx context.Context, sms *entity.SMS) error
+}
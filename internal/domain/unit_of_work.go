@@ -0,0 +1,10 @@
+package domain
+
+import "context"
+
+// UnitOfWork is an interface that represents the transaction functionality needed by the domain.
+// Every repository call made with the context passed to fn takes part in the same transaction,
+// which is committed when fn returns nil and rolled back otherwise.
+type UnitOfWork interface {
+	Do(ctx context.Context, fn func(ctx context.Context) error) error
+}
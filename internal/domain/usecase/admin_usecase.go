@@ -0,0 +1,63 @@
+package usecase
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// AdminUsecase is the interface used for the admin use case.
+type AdminUsecase interface {
+	GetUser(ctx context.Context, id int) (*entity.UserResponse, error)
+	ChangeUserRole(ctx context.Context, admin *entity.User, id int, req *entity.ChangeRoleRequest) (*entity.UserResponse, error)
+}
+
+type adminUsecase struct {
+	userService  service.UserService
+	photoService service.PhotoService
+}
+
+// NewAdminUsecase is a function used to initialize the admin use case implementation.
+func NewAdminUsecase(us service.UserService, phs service.PhotoService) AdminUsecase {
+	return &adminUsecase{
+		userService:  us,
+		photoService: phs,
+	}
+}
+
+func (a *adminUsecase) GetUser(ctx context.Context, id int) (*entity.UserResponse, error) {
+	user, err := a.userService.GetUserByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	userResp := entity.NewUserResponse(user)
+	err = setProfilePicture(ctx, a.photoService, user.ProfilePictureKey, userResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return userResp, nil
+}
+
+func (a *adminUsecase) ChangeUserRole(ctx context.Context, admin *entity.User, id int, req *entity.ChangeRoleRequest) (*entity.UserResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// Admins cannot demote themselves, so that the last admin cannot leave the service without one by mistake.
+	if id == admin.ID {
+		return nil, apperror.New(apperror.KindForbidden, constant.CannotChangeOwnRole)
+	}
+
+	err = a.userService.ChangeRole(ctx, id, req.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.GetUser(ctx, id)
+}
@@ -0,0 +1,132 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+)
+
+var mockAdmin = &entity.User{ID: 1, Email: "admin@email.com", Role: entity.RoleAdmin}
+
+func Test_adminUsecase_GetUser(t *testing.T) {
+	user := &entity.User{ID: 2, Email: "email@email.com", Role: entity.RoleUser}
+	type fields struct {
+		userService service.UserService
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *entity.UserResponse
+		wantErr bool
+	}{
+		{
+			name: "Failed: User not found",
+			fields: fields{
+				userService: &fakeUserService{
+					err: apperror.ErrNotFound,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				userService: &fakeUserService{
+					user: user,
+				},
+			},
+			want:    entity.NewUserResponse(user),
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewAdminUsecase(test.fields.userService, &fakePhotoService{})
+			got, err := a.GetUser(context.Background(), 2)
+			if (err != nil) != test.wantErr {
+				t.Errorf("adminUsecase.GetUser() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("adminUsecase.GetUser() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_adminUsecase_ChangeUserRole(t *testing.T) {
+	type args struct {
+		id  int
+		req *entity.ChangeRoleRequest
+	}
+	tests := []struct {
+		name        string
+		userService *fakeUserService
+		args        args
+		wantRole    string
+		wantErrKind apperror.Kind
+		wantErr     bool
+	}{
+		{
+			name:        "Failed: Invalid role",
+			userService: &fakeUserService{},
+			args:        args{id: 2, req: &entity.ChangeRoleRequest{Role: "owner"}},
+			wantErrKind: apperror.KindValidation,
+			wantErr:     true,
+		},
+		{
+			name:        "Failed: Own role",
+			userService: &fakeUserService{},
+			args:        args{id: mockAdmin.ID, req: &entity.ChangeRoleRequest{Role: entity.RoleUser}},
+			wantErrKind: apperror.KindForbidden,
+			wantErr:     true,
+		},
+		{
+			name:        "Failed: User not found",
+			userService: &fakeUserService{err: apperror.ErrNotFound},
+			args:        args{id: 2, req: &entity.ChangeRoleRequest{Role: entity.RoleModerator}},
+			wantRole:    entity.RoleModerator,
+			wantErrKind: apperror.KindNotFound,
+			wantErr:     true,
+		},
+		{
+			name:        "Success",
+			userService: &fakeUserService{user: &entity.User{ID: 2, Role: entity.RoleModerator}},
+			args:        args{id: 2, req: &entity.ChangeRoleRequest{Role: entity.RoleModerator}},
+			wantRole:    entity.RoleModerator,
+			wantErr:     false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			a := NewAdminUsecase(test.userService, &fakePhotoService{})
+			got, err := a.ChangeUserRole(context.Background(), mockAdmin, test.args.id, test.args.req)
+			if (err != nil) != test.wantErr {
+				t.Errorf("adminUsecase.ChangeUserRole() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if test.userService.role != test.wantRole {
+				t.Errorf("adminUsecase.ChangeUserRole() changed role = %v, want %v", test.userService.role, test.wantRole)
+			}
+			if err != nil {
+				var appErr *apperror.Error
+				if !errors.As(err, &appErr) || appErr.Kind != test.wantErrKind {
+					t.Errorf("adminUsecase.ChangeUserRole() error = %v, want kind %v", err, test.wantErrKind)
+				}
+
+				return
+			}
+			if got.Role != test.wantRole {
+				t.Errorf("adminUsecase.ChangeUserRole() role = %v, want %v", got.Role, test.wantRole)
+			}
+		})
+	}
+}
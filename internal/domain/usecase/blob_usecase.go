@@ -0,0 +1,50 @@
+package usecase
+
+import (
+	"context"
+	"mime"
+	"path"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// BlobUsecase is the interface used for the blob use case.
+type BlobUsecase interface {
+	GetBlob(ctx context.Context, key string, expires int64, signature string) (*entity.Blob, error)
+}
+
+type blobUsecase struct {
+	blobStore         domain.BlobStore
+	signedURLVerifier domain.SignedURLVerifier
+}
+
+// NewBlobUsecase is a function used to initialize the blob use case implementation.
+func NewBlobUsecase(bs domain.BlobStore, v domain.SignedURLVerifier) BlobUsecase {
+	return &blobUsecase{
+		blobStore:         bs,
+		signedURLVerifier: v,
+	}
+}
+
+func (b *blobUsecase) GetBlob(ctx context.Context, key string, expires int64, signature string) (*entity.Blob, error) {
+	expiresAt := time.Unix(expires, 0)
+	if !time.Now().Before(expiresAt) || !b.signedURLVerifier.VerifySignedURL(key, expiresAt, signature) {
+		return nil, apperror.New(apperror.KindForbidden, constant.InvalidSignedURL)
+	}
+
+	data, err := b.blobStore.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &entity.Blob{ContentType: contentType, Data: data, ExpiresAt: expiresAt}, nil
+}
@@ -0,0 +1,100 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+)
+
+type fakeBlobStore struct {
+	data []byte
+	err  error
+}
+
+func (f *fakeBlobStore) Put(context.Context, string, string, []byte) error {
+	return f.err
+}
+
+func (f *fakeBlobStore) Get(context.Context, string) ([]byte, error) {
+	return f.data, f.err
+}
+
+func (f *fakeBlobStore) Delete(context.Context, string) error {
+	return f.err
+}
+
+func (f *fakeBlobStore) SignedURL(context.Context, string, time.Time) (string, error) {
+	return "", f.err
+}
+
+type fakeSignedURLVerifier struct {
+	valid bool
+}
+
+func (f *fakeSignedURLVerifier) VerifySignedURL(string, time.Time, string) bool {
+	return f.valid
+}
+
+func Test_blobUsecase_GetBlob(t *testing.T) {
+	future := time.Now().Add(time.Hour).Unix()
+	tests := []struct {
+		name            string
+		blobStore       *fakeBlobStore
+		verifier        *fakeSignedURLVerifier
+		expires         int64
+		wantContentType string
+		wantErrKind     apperror.Kind
+	}{
+		{
+			name:        "Failed: Expired URL",
+			blobStore:   &fakeBlobStore{data: []byte("data")},
+			verifier:    &fakeSignedURLVerifier{valid: true},
+			expires:     time.Now().Add(-time.Minute).Unix(),
+			wantErrKind: apperror.KindForbidden,
+		},
+		{
+			name:        "Failed: Invalid signature",
+			blobStore:   &fakeBlobStore{data: []byte("data")},
+			verifier:    &fakeSignedURLVerifier{valid: false},
+			expires:     future,
+			wantErrKind: apperror.KindForbidden,
+		},
+		{
+			name:        "Failed: File not found",
+			blobStore:   &fakeBlobStore{err: apperror.New(apperror.KindNotFound, "File not found")},
+			verifier:    &fakeSignedURLVerifier{valid: true},
+			expires:     future,
+			wantErrKind: apperror.KindNotFound,
+		},
+		{
+			name:            "Success",
+			blobStore:       &fakeBlobStore{data: []byte("data")},
+			verifier:        &fakeSignedURLVerifier{valid: true},
+			expires:         future,
+			wantContentType: "image/jpeg",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b := NewBlobUsecase(test.blobStore, test.verifier)
+			got, err := b.GetBlob(context.Background(), "photos/1/abc/large.jpg", test.expires, "signature")
+			if test.wantErrKind != "" {
+				var appErr *apperror.Error
+				if !errors.As(err, &appErr) || appErr.Kind != test.wantErrKind {
+					t.Errorf("blobUsecase.GetBlob() error = %v, want kind %v", err, test.wantErrKind)
+				}
+
+				return
+			}
+			if err != nil {
+				t.Fatalf("blobUsecase.GetBlob() error = %v", err)
+			}
+			if got.ContentType != test.wantContentType || string(got.Data) != "data" || got.ExpiresAt.Unix() != test.expires {
+				t.Errorf("blobUsecase.GetBlob() = %+v", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+// DiscoveryUsecase is the interface used for the discovery use case.
+type DiscoveryUsecase interface {
+	Discover(ctx context.Context, user *entity.User, req *entity.PageRequest) (*entity.DiscoverResponse, error)
+}
+
+type discoveryUsecase struct {
+	profileService     service.ProfileService
+	entitlementService service.EntitlementService
+	photoService       service.PhotoService
+	config             domain.Config
+}
+
+// NewDiscoveryUsecase is a function used to initialize the discovery use case implementation.
+func NewDiscoveryUsecase(ps service.ProfileService, es service.EntitlementService, phs service.PhotoService, cfg domain.Config) DiscoveryUsecase {
+	return &discoveryUsecase{
+		profileService:     ps,
+		entitlementService: es,
+		photoService:       phs,
+		config:             cfg,
+	}
+}
+
+func (d *discoveryUsecase) Discover(ctx context.Context, user *entity.User, req *entity.PageRequest) (*entity.DiscoverResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// One extra profile is requested to find out whether there is a next page.
+	pageSize := req.PageSize()
+	swipeDate := util.DateIn(time.Now(), d.config.GetSwipeResetLocation())
+	candidates, err := d.profileService.GetDiscoverableProfiles(ctx, user.ID, swipeDate, req.Cursor, pageSize+1, d.config.IsVerifiedEmailRequiredForDiscovery())
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor *int
+	if len(candidates) > pageSize {
+		candidates = candidates[:pageSize]
+		nextCursor = &candidates[pageSize-1].ProfileID
+	}
+
+	userIDs := make([]int, len(candidates))
+	for i, candidate := range candidates {
+		userIDs[i] = candidate.UserID
+	}
+
+	verifiedUsers, err := d.entitlementService.GetUsersWithFeature(ctx, userIDs, entity.FeatureVerifiedLabel)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, candidate := range candidates {
+		candidate.Verified = candidate.Verified || verifiedUsers[candidate.UserID]
+
+		err = setProfilePicture(ctx, d.photoService, candidate.ProfilePictureKey, candidate)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entity.NewDiscoverResponse(candidates, nextCursor), nil
+}
@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+
+	"gorm.io/gorm/schema"
+)
+
+var mockCandidates = []*entity.ProfileCandidate{
+	{ProfileID: 2, UserID: 2},
+	{ProfileID: 3, UserID: 3},
+	{ProfileID: 4, UserID: 4},
+}
+
+type fakeEntitlementService struct {
+	hasFeature bool
+	users      map[int]bool
+	err        error
+}
+
+func (f *fakeEntitlementService) HasFeature(context.Context, int, string) (bool, error) {
+	return f.hasFeature, f.err
+}
+
+func (f *fakeEntitlementService) GetUsersWithFeature(context.Context, []int, string) (map[int]bool, error) {
+	return f.users, f.err
+}
+
+func Test_discoveryUsecase_Discover(t *testing.T) {
+	nextCursor := 3
+	type fields struct {
+		profileService     service.ProfileService
+		entitlementService service.EntitlementService
+	}
+	type args struct {
+		req *entity.PageRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.DiscoverResponse
+		wantErr bool
+	}{
+		{
+			name: "Failed: Invalid request",
+			args: args{
+				req: &entity.PageRequest{
+					Cursor: -1,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Get discoverable profiles failed",
+			fields: fields{
+				profileService: &fakeProfileService{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			args: args{
+				req: &entity.PageRequest{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Get verified users failed",
+			fields: fields{
+				profileService: &fakeProfileService{
+					candidates: mockCandidates,
+				},
+				entitlementService: &fakeEntitlementService{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			args: args{
+				req: &entity.PageRequest{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success: Has next page",
+			fields: fields{
+				profileService: &fakeProfileService{
+					candidates: mockCandidates,
+				},
+				entitlementService: &fakeEntitlementService{},
+			},
+			args: args{
+				req: &entity.PageRequest{
+					Limit: 2,
+				},
+			},
+			want:    entity.NewDiscoverResponse(mockCandidates[:2], &nextCursor),
+			wantErr: false,
+		},
+		{
+			name: "Success: Last page",
+			fields: fields{
+				profileService: &fakeProfileService{
+					candidates: mockCandidates,
+				},
+				entitlementService: &fakeEntitlementService{},
+			},
+			args: args{
+				req: &entity.PageRequest{
+					Cursor: 1,
+				},
+			},
+			want:    entity.NewDiscoverResponse(mockCandidates, nil),
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := NewDiscoveryUsecase(test.fields.profileService, test.fields.entitlementService, &fakePhotoService{}, &fakeConfig{})
+			got, err := d.Discover(context.Background(), mockSwipeUser, test.args.req)
+			if (err != nil) != test.wantErr {
+				t.Errorf("discoveryUsecase.Discover() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("discoveryUsecase.Discover() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_discoveryUsecase_Discover_Verified_Label(t *testing.T) {
+	candidates := []*entity.ProfileCandidate{
+		{ProfileID: 2, UserID: 2},
+		{ProfileID: 3, UserID: 3},
+	}
+	d := NewDiscoveryUsecase(&fakeProfileService{candidates: candidates}, &fakeEntitlementService{users: map[int]bool{3: true}}, &fakePhotoService{}, &fakeConfig{})
+	got, err := d.Discover(context.Background(), mockSwipeUser, &entity.PageRequest{})
+	if err != nil {
+		t.Fatalf("discoveryUsecase.Discover() error = %v", err)
+	}
+	if got.Profiles[0].Verified || !got.Profiles[1].Verified {
+		t.Errorf("discoveryUsecase.Discover() verified = [%v %v], want [false true]", got.Profiles[0].Verified, got.Profiles[1].Verified)
+	}
+}
+
+func Test_discoveryUsecase_Discover_Verified_Email_Only(t *testing.T) {
+	profileService := &fakeProfileService{}
+	d := NewDiscoveryUsecase(profileService, &fakeEntitlementService{}, &fakePhotoService{}, &fakeConfig{verifiedEmailRequiredForDiscovery: true})
+	_, err := d.Discover(context.Background(), mockSwipeUser, &entity.PageRequest{})
+	if err != nil {
+		t.Fatalf("discoveryUsecase.Discover() error = %v", err)
+	}
+	if !profileService.verifiedEmailOnly {
+		t.Error("discoveryUsecase.Discover() did not leave out the users without a verified email address")
+	}
+}
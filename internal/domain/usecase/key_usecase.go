@@ -0,0 +1,28 @@
+package usecase
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// KeyUsecase is the interface used for the key use case.
+type KeyUsecase interface {
+	GetJWKS(ctx context.Context) *entity.JSONWebKeySet
+}
+
+type keyUsecase struct {
+	auth domain.Auth
+}
+
+// NewKeyUsecase is a function used to initialize the key use case implementation.
+func NewKeyUsecase(a domain.Auth) KeyUsecase {
+	return &keyUsecase{
+		auth: a,
+	}
+}
+
+func (k *keyUsecase) GetJWKS(context.Context) *entity.JSONWebKeySet {
+	return k.auth.JWKS()
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+func Test_keyUsecase_GetJWKS(t *testing.T) {
+	jwks := &entity.JSONWebKeySet{Keys: []*entity.JSONWebKey{{Kty: "OKP", Kid: "kid"}}}
+	k := NewKeyUsecase(&fakeAuth{jwks: jwks})
+	if got := k.GetJWKS(context.Background()); !reflect.DeepEqual(got, jwks) {
+		t.Errorf("keyUsecase.GetJWKS() = %v, want %v", got, jwks)
+	}
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+)
+
+// MatchUsecase is the interface used for the match use case.
+type MatchUsecase interface {
+	GetMatches(ctx context.Context, user *entity.User, req *entity.PageRequest) (*entity.MatchesResponse, error)
+	Unmatch(ctx context.Context, user *entity.User, id int) error
+}
+
+type matchUsecase struct {
+	matchService service.MatchService
+	photoService service.PhotoService
+}
+
+// NewMatchUsecase is a function used to initialize the match use case implementation.
+func NewMatchUsecase(ms service.MatchService, phs service.PhotoService) MatchUsecase {
+	return &matchUsecase{
+		matchService: ms,
+		photoService: phs,
+	}
+}
+
+func (m *matchUsecase) GetMatches(ctx context.Context, user *entity.User, req *entity.PageRequest) (*entity.MatchesResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	// One extra match is requested to find out whether there is a next page.
+	pageSize := req.PageSize()
+	matches, err := m.matchService.GetMatches(ctx, user.ID, req.Cursor, pageSize+1)
+	if err != nil {
+		return nil, err
+	}
+
+	var nextCursor *int
+	if len(matches) > pageSize {
+		matches = matches[:pageSize]
+		nextCursor = &matches[pageSize-1].ID
+	}
+
+	for _, match := range matches {
+		err = setProfilePicture(ctx, m.photoService, match.ProfilePictureKey, match)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return entity.NewMatchesResponse(matches, nextCursor), nil
+}
+
+func (m *matchUsecase) Unmatch(ctx context.Context, user *entity.User, id int) error {
+	return m.matchService.Unmatch(ctx, id, user.ID)
+}
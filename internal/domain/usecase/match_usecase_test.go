@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+
+	"gorm.io/gorm/schema"
+)
+
+var mockMatches = []*entity.MatchDetail{
+	{ID: 5, UserID: 2, ProfileID: 2},
+	{ID: 4, UserID: 3, ProfileID: 3},
+	{ID: 3, UserID: 4, ProfileID: 4},
+}
+
+type fakeMatchService struct {
+	matches []*entity.MatchDetail
+	err     error
+}
+
+func (f *fakeMatchService) GetMatches(context.Context, int, int, int) ([]*entity.MatchDetail, error) {
+	return f.matches, f.err
+}
+
+func (f *fakeMatchService) Unmatch(context.Context, int, int) error {
+	return f.err
+}
+
+func Test_matchUsecase_GetMatches(t *testing.T) {
+	nextCursor := 4
+	type fields struct {
+		matchService service.MatchService
+	}
+	type args struct {
+		req *entity.PageRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.MatchesResponse
+		wantErr bool
+	}{
+		{
+			name: "Failed: Invalid request",
+			args: args{
+				req: &entity.PageRequest{
+					Limit: -1,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Get matches failed",
+			fields: fields{
+				matchService: &fakeMatchService{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			args: args{
+				req: &entity.PageRequest{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success: Has next page",
+			fields: fields{
+				matchService: &fakeMatchService{
+					matches: mockMatches,
+				},
+			},
+			args: args{
+				req: &entity.PageRequest{
+					Limit: 2,
+				},
+			},
+			want:    entity.NewMatchesResponse(mockMatches[:2], &nextCursor),
+			wantErr: false,
+		},
+		{
+			name: "Success: Last page",
+			fields: fields{
+				matchService: &fakeMatchService{
+					matches: mockMatches,
+				},
+			},
+			args: args{
+				req: &entity.PageRequest{
+					Cursor: 6,
+				},
+			},
+			want:    entity.NewMatchesResponse(mockMatches, nil),
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewMatchUsecase(test.fields.matchService, &fakePhotoService{})
+			got, err := m.GetMatches(context.Background(), mockSwipeUser, test.args.req)
+			if (err != nil) != test.wantErr {
+				t.Errorf("matchUsecase.GetMatches() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("matchUsecase.GetMatches() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_matchUsecase_Unmatch(t *testing.T) {
+	type fields struct {
+		matchService service.MatchService
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "Failed: Match not found",
+			fields: fields{
+				matchService: &fakeMatchService{
+					err: apperror.ErrNotFound,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				matchService: &fakeMatchService{},
+			},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewMatchUsecase(test.fields.matchService, &fakePhotoService{})
+			if err := m.Unmatch(context.Background(), mockSwipeUser, 1); (err != nil) != test.wantErr {
+				t.Errorf("matchUsecase.Unmatch() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,197 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+)
+
+// ProfileUsecase is the interface used for the profile use case.
+type ProfileUsecase interface {
+	GetMyProfile(ctx context.Context, user *entity.User) (*entity.ProfileResponse, error)
+	UpdateMyProfile(ctx context.Context, user *entity.User, req *entity.UpdateProfileRequest) (*entity.ProfileResponse, error)
+	GetProfile(ctx context.Context, id int) (*entity.ProfileCandidate, error)
+	UploadPhoto(ctx context.Context, user *entity.User, upload *entity.PhotoUpload) (*entity.ProfileResponse, error)
+	DeletePhoto(ctx context.Context, user *entity.User) error
+}
+
+type profileUsecase struct {
+	userService        service.UserService
+	profileService     service.ProfileService
+	entitlementService service.EntitlementService
+	photoService       service.PhotoService
+	unitOfWork         domain.UnitOfWork
+}
+
+// NewProfileUsecase is a function used to initialize the profile use case implementation.
+func NewProfileUsecase(us service.UserService, ps service.ProfileService, es service.EntitlementService, phs service.PhotoService, uow domain.UnitOfWork) ProfileUsecase {
+	return &profileUsecase{
+		userService:        us,
+		profileService:     ps,
+		entitlementService: es,
+		photoService:       phs,
+		unitOfWork:         uow,
+	}
+}
+
+func (p *profileUsecase) GetMyProfile(ctx context.Context, user *entity.User) (*entity.ProfileResponse, error) {
+	profile, err := p.profileService.GetProfileByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return p.profileResponse(ctx, user, profile)
+}
+
+func (p *profileUsecase) UpdateMyProfile(ctx context.Context, user *entity.User, req *entity.UpdateProfileRequest) (*entity.ProfileResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := p.profileService.GetProfileByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The user in the context is shared with the rest of the request, so the changes are made to a copy.
+	updatedUser := *user
+	req.Apply(&updatedUser, profile)
+
+	err = p.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		err := p.userService.UpdateProfile(ctx, &updatedUser)
+		if err != nil {
+			return err
+		}
+
+		return p.profileService.UpdateProfile(ctx, profile)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.profileResponse(ctx, &updatedUser, profile)
+}
+
+func (p *profileUsecase) GetProfile(ctx context.Context, id int) (*entity.ProfileCandidate, error) {
+	candidate, err := p.profileService.GetProfileCandidate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	verified, err := p.isVerified(ctx, candidate.UserID, candidate.Verified)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate.Verified = verified
+
+	err = setProfilePicture(ctx, p.photoService, candidate.ProfilePictureKey, candidate)
+	if err != nil {
+		return nil, err
+	}
+
+	return candidate, nil
+}
+
+func (p *profileUsecase) UploadPhoto(ctx context.Context, user *entity.User, upload *entity.PhotoUpload) (*entity.ProfileResponse, error) {
+	profile, err := p.profileService.GetProfileByUserID(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := p.photoService.StorePhoto(ctx, user.ID, upload)
+	if err != nil {
+		return nil, err
+	}
+
+	// The key replaced is the one stored when the change is made, which a concurrent upload may have changed since the user was loaded.
+	var previousKey string
+	err = p.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		previousKey, err = p.userService.ChangeProfilePicture(ctx, user.ID, key)
+
+		return err
+	})
+	if err != nil {
+		_ = p.photoService.DeletePhoto(ctx, key)
+
+		return nil, err
+	}
+
+	// The previous photo is no longer referenced, and a failure to delete it only leaves unused files behind.
+	if previousKey != "" {
+		_ = p.photoService.DeletePhoto(ctx, previousKey)
+	}
+
+	updatedUser := *user
+	updatedUser.ProfilePictureKey = key
+
+	return p.profileResponse(ctx, &updatedUser, profile)
+}
+
+func (p *profileUsecase) DeletePhoto(ctx context.Context, user *entity.User) error {
+	var previousKey string
+	err := p.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		previousKey, err = p.userService.ChangeProfilePicture(ctx, user.ID, "")
+
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if previousKey != "" {
+		_ = p.photoService.DeletePhoto(ctx, previousKey)
+	}
+
+	return nil
+}
+
+// profileResponse is a method for getting the profile response of the current user.
+func (p *profileUsecase) profileResponse(ctx context.Context, user *entity.User, profile *entity.Profile) (*entity.ProfileResponse, error) {
+	verified, err := p.isVerified(ctx, user.ID, profile.Verified)
+	if err != nil {
+		return nil, err
+	}
+
+	profileResp := entity.NewProfileResponse(user, profile, time.Now())
+	profileResp.Verified = verified
+
+	err = setProfilePicture(ctx, p.photoService, user.ProfilePictureKey, profileResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return profileResp, nil
+}
+
+// isVerified is a method for checking whether a profile is shown as verified, either by itself or through the verified label of a subscription, as in the discovery feed.
+func (p *profileUsecase) isVerified(ctx context.Context, userID int, profileVerified bool) (bool, error) {
+	if profileVerified {
+		return true, nil
+	}
+
+	return p.entitlementService.HasFeature(ctx, userID, entity.FeatureVerifiedLabel)
+}
+
+// profilePictureHolder is the interface of the responses that show the profile picture of a user.
+type profilePictureHolder interface {
+	SetProfilePicture(urls *entity.PhotoURLs)
+}
+
+// setProfilePicture is a function to show the profile picture stored under the key in the response, with URLs signed for it.
+func setProfilePicture(ctx context.Context, ps service.PhotoService, key string, holder profilePictureHolder) error {
+	urls, err := ps.GetPhotoURLs(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	holder.SetProfilePicture(urls)
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+
+	"gorm.io/gorm/schema"
+)
+
+var mockProfileUser = &entity.User{ID: 1, Email: "user@email.com", Name: "User", Location: "Indonesia", BirthDate: time.Now().AddDate(-25, 0, -1)}
+
+func Test_profileUsecase_GetMyProfile(t *testing.T) {
+	tests := []struct {
+		name               string
+		profileService     *fakeProfileService
+		entitlementService *fakeEntitlementService
+		wantVerified       bool
+		wantErr            bool
+	}{
+		{
+			name:               "Failed: Profile not found",
+			profileService:     &fakeProfileService{err: apperror.ErrNotFound},
+			entitlementService: &fakeEntitlementService{},
+			wantErr:            true,
+		},
+		{
+			name:               "Failed: Check verified label failed",
+			profileService:     &fakeProfileService{profile: &entity.Profile{ID: 2, UserID: 1}},
+			entitlementService: &fakeEntitlementService{err: schema.ErrUnsupportedDataType},
+			wantErr:            true,
+		},
+		{
+			name:               "Success: Verified through subscription",
+			profileService:     &fakeProfileService{profile: &entity.Profile{ID: 2, UserID: 1}},
+			entitlementService: &fakeEntitlementService{hasFeature: true},
+			wantVerified:       true,
+			wantErr:            false,
+		},
+		{
+			name:               "Success",
+			profileService:     &fakeProfileService{profile: &entity.Profile{ID: 2, UserID: 1}},
+			entitlementService: &fakeEntitlementService{},
+			wantVerified:       false,
+			wantErr:            false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := NewProfileUsecase(&fakeUserService{}, test.profileService, test.entitlementService, &fakePhotoService{}, &fakeUnitOfWork{})
+			got, err := p.GetMyProfile(context.Background(), mockProfileUser)
+			if (err != nil) != test.wantErr {
+				t.Errorf("profileUsecase.GetMyProfile() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if err != nil {
+				return
+			}
+			if got.ProfileID != 2 || got.Email != mockProfileUser.Email || got.Age != 25 || got.Verified != test.wantVerified {
+				t.Errorf("profileUsecase.GetMyProfile() = %+v, want verified %v", got, test.wantVerified)
+			}
+		})
+	}
+}
+
+func Test_profileUsecase_UpdateMyProfile(t *testing.T) {
+	blank := ""
+	name := " New Name "
+	bio := "New bio"
+	tests := []struct {
+		name           string
+		userService    *fakeUserService
+		profileService *fakeProfileService
+		req            *entity.UpdateProfileRequest
+		wantRolledBack bool
+		wantErr        bool
+	}{
+		{
+			name:           "Failed: Invalid request",
+			userService:    &fakeUserService{},
+			profileService: &fakeProfileService{profile: &entity.Profile{ID: 2, UserID: 1}},
+			req:            &entity.UpdateProfileRequest{Name: &blank},
+			wantErr:        true,
+		},
+		{
+			name:           "Failed: Profile not found",
+			userService:    &fakeUserService{},
+			profileService: &fakeProfileService{err: apperror.ErrNotFound},
+			req:            &entity.UpdateProfileRequest{Bio: &bio},
+			wantErr:        true,
+		},
+		{
+			name:           "Failed: Update profile failed",
+			userService:    &fakeUserService{},
+			profileService: &fakeProfileService{profile: &entity.Profile{ID: 2, UserID: 1}, updateErr: schema.ErrUnsupportedDataType},
+			req:            &entity.UpdateProfileRequest{Name: &name, Bio: &bio},
+			wantRolledBack: true,
+			wantErr:        true,
+		},
+		{
+			name:           "Success",
+			userService:    &fakeUserService{},
+			profileService: &fakeProfileService{profile: &entity.Profile{ID: 2, UserID: 1, Interests: "Hiking"}},
+			req:            &entity.UpdateProfileRequest{Name: &name, Bio: &bio},
+			wantErr:        false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			uow := &fakeUnitOfWork{}
+			p := NewProfileUsecase(test.userService, test.profileService, &fakeEntitlementService{}, &fakePhotoService{}, uow)
+			got, err := p.UpdateMyProfile(context.Background(), mockProfileUser, test.req)
+			if (err != nil) != test.wantErr {
+				t.Errorf("profileUsecase.UpdateMyProfile() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if uow.rolledBack != test.wantRolledBack {
+				t.Errorf("profileUsecase.UpdateMyProfile() rolled back = %v, want %v", uow.rolledBack, test.wantRolledBack)
+			}
+			if err != nil {
+				return
+			}
+			if got.Name != "New Name" || got.Location != "Indonesia" || got.Bio != bio || got.Interests != "Hiking" {
+				t.Errorf("profileUsecase.UpdateMyProfile() = %+v", got)
+			}
+			if test.userService.updated.Name != "New Name" || test.profileService.updated.Bio != bio {
+				t.Errorf("profileUsecase.UpdateMyProfile() saved %+v, %+v", test.userService.updated, test.profileService.updated)
+			}
+			if mockProfileUser.Name != "User" {
+				t.Errorf("profileUsecase.UpdateMyProfile() changed the user in the context")
+			}
+		})
+	}
+}
+
+func Test_profileUsecase_GetProfile(t *testing.T) {
+	tests := []struct {
+		name               string
+		profileService     *fakeProfileService
+		entitlementService *fakeEntitlementService
+		wantVerified       bool
+		wantErr            bool
+	}{
+		{
+			name:               "Failed: Profile not found",
+			profileService:     &fakeProfileService{err: apperror.ErrNotFound},
+			entitlementService: &fakeEntitlementService{},
+			wantErr:            true,
+		},
+		{
+			name:               "Success: Verified profile",
+			profileService:     &fakeProfileService{candidate: &entity.ProfileCandidate{ProfileID: 2, UserID: 2, Verified: true}},
+			entitlementService: &fakeEntitlementService{err: schema.ErrUnsupportedDataType},
+			wantVerified:       true,
+			wantErr:            false,
+		},
+		{
+			name:               "Success: Verified through subscription",
+			profileService:     &fakeProfileService{candidate: &entity.ProfileCandidate{ProfileID: 2, UserID: 2}},
+			entitlementService: &fakeEntitlementService{hasFeature: true},
+			wantVerified:       true,
+			wantErr:            false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := NewProfileUsecase(&fakeUserService{}, test.profileService, test.entitlementService, &fakePhotoService{}, &fakeUnitOfWork{})
+			got, err := p.GetProfile(context.Background(), 2)
+			if (err != nil) != test.wantErr {
+				t.Errorf("profileUsecase.GetProfile() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if err == nil && got.Verified != test.wantVerified {
+				t.Errorf("profileUsecase.GetProfile() verified = %v, want %v", got.Verified, test.wantVerified)
+			}
+		})
+	}
+}
+
+func Test_profileUsecase_UploadPhoto(t *testing.T) {
+	urls := &entity.PhotoURLs{Large: "large", Medium: "medium", Small: "small"}
+	tests := []struct {
+		name           string
+		user           *entity.User
+		userService    *fakeUserService
+		photo          *fakePhotoService
+		wantDeleted    []string
+		wantErr        bool
+		wantRolledBack bool
+	}{
+		{
+			name:        "Failed: Store photo failed",
+			user:        mockProfileUser,
+			userService: &fakeUserService{},
+			photo:       &fakePhotoService{err: apperror.New(apperror.KindValidation, "photo must be a JPEG or PNG image")},
+			wantErr:     true,
+		},
+		{
+			name:           "Failed: Change profile picture failed",
+			user:           mockProfileUser,
+			userService:    &fakeUserService{err: schema.ErrUnsupportedDataType},
+			photo:          &fakePhotoService{key: "photos/1/new", urls: urls},
+			wantDeleted:    []string{"photos/1/new"},
+			wantErr:        true,
+			wantRolledBack: true,
+		},
+		{
+			name:        "Success: Replace previous photo",
+			user:        &entity.User{ID: 1, Name: "User", ProfilePictureKey: "photos/1/old"},
+			userService: &fakeUserService{profilePictureKey: "photos/1/old"},
+			photo:       &fakePhotoService{key: "photos/1/new", urls: urls},
+			wantDeleted: []string{"photos/1/old"},
+			wantErr:     false,
+		},
+		{
+			name:        "Success: Replace photo stored since the user was loaded",
+			user:        mockProfileUser,
+			userService: &fakeUserService{profilePictureKey: "photos/1/concurrent"},
+			photo:       &fakePhotoService{key: "photos/1/new", urls: urls},
+			wantDeleted: []string{"photos/1/concurrent"},
+			wantErr:     false,
+		},
+		{
+			name:        "Success",
+			user:        mockProfileUser,
+			userService: &fakeUserService{},
+			photo:       &fakePhotoService{key: "photos/1/new", urls: urls},
+			wantErr:     false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			profileService := &fakeProfileService{profile: &entity.Profile{ID: 2, UserID: 1}}
+			uow := &fakeUnitOfWork{}
+			p := NewProfileUsecase(test.userService, profileService, &fakeEntitlementService{}, test.photo, uow)
+			got, err := p.UploadPhoto(context.Background(), test.user, entity.NewPhotoUpload("image/png", nil))
+			if (err != nil) != test.wantErr {
+				t.Errorf("profileUsecase.UploadPhoto() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if uow.rolledBack != test.wantRolledBack {
+				t.Errorf("profileUsecase.UploadPhoto() rolled back = %v, want %v", uow.rolledBack, test.wantRolledBack)
+			}
+			if len(test.photo.deleted) != len(test.wantDeleted) || (len(test.wantDeleted) > 0 && test.photo.deleted[0] != test.wantDeleted[0]) {
+				t.Errorf("profileUsecase.UploadPhoto() deleted = %v, want %v", test.photo.deleted, test.wantDeleted)
+			}
+			if err != nil {
+				return
+			}
+			if test.userService.profilePictureKey != "photos/1/new" || got.ProfilePicture != urls || got.ProfilePictureURL != "large" {
+				t.Errorf("profileUsecase.UploadPhoto() = %+v, key %q", got, test.userService.profilePictureKey)
+			}
+		})
+	}
+}
+
+func Test_profileUsecase_DeletePhoto(t *testing.T) {
+	userService := &fakeUserService{profilePictureKey: "photos/1/old"}
+	photo := &fakePhotoService{}
+	p := NewProfileUsecase(userService, &fakeProfileService{}, &fakeEntitlementService{}, photo, &fakeUnitOfWork{})
+
+	// The photo stored when it is deleted is the one removed, even when the user was loaded without it.
+	err := p.DeletePhoto(context.Background(), &entity.User{ID: 1})
+	if err != nil {
+		t.Fatalf("profileUsecase.DeletePhoto() error = %v", err)
+	}
+	if userService.profilePictureKey != "" || len(photo.deleted) != 1 || photo.deleted[0] != "photos/1/old" {
+		t.Errorf("profileUsecase.DeletePhoto() key = %q, deleted = %v", userService.profilePictureKey, photo.deleted)
+	}
+}
@@ -0,0 +1,103 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+// SubscriptionUsecase is the interface used for the subscription use case.
+type SubscriptionUsecase interface {
+	GetSubscriptionPackages(ctx context.Context) ([]*entity.SubscriptionPackage, error)
+	Purchase(ctx context.Context, user *entity.User, req *entity.PurchaseSubscriptionRequest) (*entity.SubscriptionResponse, error)
+	GetCurrentSubscription(ctx context.Context, user *entity.User) (*entity.SubscriptionResponse, error)
+	CancelSubscription(ctx context.Context, user *entity.User) error
+}
+
+type subscriptionUsecase struct {
+	subscriptionPackageService service.SubscriptionPackageService
+	subscriptionService        service.SubscriptionService
+	paymentGateway             domain.PaymentGateway
+}
+
+// NewSubscriptionUsecase is a function used to initialize the subscription use case implementation.
+func NewSubscriptionUsecase(sps service.SubscriptionPackageService, ss service.SubscriptionService, pg domain.PaymentGateway) SubscriptionUsecase {
+	return &subscriptionUsecase{
+		subscriptionPackageService: sps,
+		subscriptionService:        ss,
+		paymentGateway:             pg,
+	}
+}
+
+func (s *subscriptionUsecase) GetSubscriptionPackages(ctx context.Context) ([]*entity.SubscriptionPackage, error) {
+	return s.subscriptionPackageService.GetSubscriptionPackages(ctx)
+}
+
+func (s *subscriptionUsecase) Purchase(ctx context.Context, user *entity.User, req *entity.PurchaseSubscriptionRequest) (*entity.SubscriptionResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionPackage, err := s.subscriptionPackageService.GetSubscriptionPackageByID(ctx, req.SubscriptionPackageID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.subscriptionService.DeactivateExpiredSubscriptions(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscribed, err := s.subscriptionService.HasActiveSubscription(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if subscribed {
+		return nil, apperror.New(apperror.KindConflict, constant.SubscriptionExists)
+	}
+
+	paymentReference, err := s.paymentGateway.Charge(ctx, user.ID, subscriptionPackage.Price, req.PaymentToken)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription := entity.NewSubscription(subscriptionPackage, user.ID, util.StartOfDay(time.Now()), paymentReference)
+	err = s.subscriptionService.CreateSubscription(ctx, subscription)
+	if err != nil {
+		return nil, errors.Join(err, s.paymentGateway.Refund(ctx, paymentReference))
+	}
+
+	return entity.NewSubscriptionResponse(subscription, subscriptionPackage), nil
+}
+
+func (s *subscriptionUsecase) GetCurrentSubscription(ctx context.Context, user *entity.User) (*entity.SubscriptionResponse, error) {
+	subscription, err := s.subscriptionService.GetActiveSubscription(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	subscriptionPackage, err := s.subscriptionPackageService.GetSubscriptionPackageByID(ctx, subscription.SubscriptionPackageID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewSubscriptionResponse(subscription, subscriptionPackage), nil
+}
+
+func (s *subscriptionUsecase) CancelSubscription(ctx context.Context, user *entity.User) error {
+	subscription, err := s.subscriptionService.GetActiveSubscription(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.subscriptionService.CancelSubscription(ctx, subscription.ID)
+}
@@ -0,0 +1,344 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+	"dealls-technical-test-dating-service/pkg/constant"
+
+	"gorm.io/gorm/schema"
+)
+
+var (
+	mockSubscriptionPackage = &entity.SubscriptionPackage{
+		ID:           1,
+		Name:         "PREMIUM_MONTHLY",
+		LifetimeDays: 30,
+		Price:        99000,
+	}
+	mockActiveSubscription = &entity.Subscription{
+		ID:                    1,
+		SubscriptionPackageID: 1,
+		UserID:                1,
+		Active:                true,
+	}
+	mockSuccessPurchaseRequest = &entity.PurchaseSubscriptionRequest{
+		SubscriptionPackageID: 1,
+		PaymentToken:          "tok_visa",
+	}
+	mockSuccessSubscriptionPackageService = &fakeSubscriptionPackageService{
+		subscriptionPackage: mockSubscriptionPackage,
+	}
+)
+
+type fakeSubscriptionPackageService struct {
+	subscriptionPackages []*entity.SubscriptionPackage
+	subscriptionPackage  *entity.SubscriptionPackage
+	err                  error
+}
+
+func (f *fakeSubscriptionPackageService) GetSubscriptionPackages(context.Context) ([]*entity.SubscriptionPackage, error) {
+	return f.subscriptionPackages, f.err
+}
+
+func (f *fakeSubscriptionPackageService) GetSubscriptionPackageByID(context.Context, int) (*entity.SubscriptionPackage, error) {
+	return f.subscriptionPackage, f.err
+}
+
+type fakeSubscriptionService struct {
+	subscription  *entity.Subscription
+	subscribed    bool
+	subscribedErr error
+	createErr     error
+	err           error
+}
+
+func (f *fakeSubscriptionService) CreateSubscription(context.Context, *entity.Subscription) error {
+	return f.createErr
+}
+
+func (f *fakeSubscriptionService) GetActiveSubscription(context.Context, int) (*entity.Subscription, error) {
+	return f.subscription, f.err
+}
+
+func (f *fakeSubscriptionService) HasActiveSubscription(context.Context, int) (bool, error) {
+	return f.subscribed, f.subscribedErr
+}
+
+func (f *fakeSubscriptionService) DeactivateExpiredSubscriptions(context.Context, int) error {
+	return f.err
+}
+
+func (f *fakeSubscriptionService) CancelSubscription(context.Context, int) error {
+	return f.err
+}
+
+type fakePaymentGateway struct {
+	reference string
+	err       error
+	refunded  bool
+}
+
+func (f *fakePaymentGateway) Charge(context.Context, int, int64, string) (string, error) {
+	return f.reference, f.err
+}
+
+func (f *fakePaymentGateway) Refund(context.Context, string) error {
+	f.refunded = true
+
+	return nil
+}
+
+func Test_subscriptionUsecase_Purchase(t *testing.T) {
+	type fields struct {
+		subscriptionPackageService service.SubscriptionPackageService
+		subscriptionService        service.SubscriptionService
+		paymentGateway             domain.PaymentGateway
+	}
+	type args struct {
+		req *entity.PurchaseSubscriptionRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Failed: Invalid request",
+			args: args{
+				req: &entity.PurchaseSubscriptionRequest{},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Package not found",
+			fields: fields{
+				subscriptionPackageService: &fakeSubscriptionPackageService{
+					err: apperror.ErrNotFound,
+				},
+			},
+			args: args{
+				req: mockSuccessPurchaseRequest,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Deactivate expired subscriptions failed",
+			fields: fields{
+				subscriptionPackageService: mockSuccessSubscriptionPackageService,
+				subscriptionService: &fakeSubscriptionService{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			args: args{
+				req: mockSuccessPurchaseRequest,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Check active subscription failed",
+			fields: fields{
+				subscriptionPackageService: mockSuccessSubscriptionPackageService,
+				subscriptionService: &fakeSubscriptionService{
+					subscribedErr: schema.ErrUnsupportedDataType,
+				},
+			},
+			args: args{
+				req: mockSuccessPurchaseRequest,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Active subscription exists",
+			fields: fields{
+				subscriptionPackageService: mockSuccessSubscriptionPackageService,
+				subscriptionService: &fakeSubscriptionService{
+					subscribed: true,
+				},
+			},
+			args: args{
+				req: mockSuccessPurchaseRequest,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Payment declined",
+			fields: fields{
+				subscriptionPackageService: mockSuccessSubscriptionPackageService,
+				subscriptionService:        &fakeSubscriptionService{},
+				paymentGateway: &fakePaymentGateway{
+					err: apperror.New(apperror.KindPaymentRequired, constant.PaymentDeclined),
+				},
+			},
+			args: args{
+				req: mockSuccessPurchaseRequest,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Create subscription failed",
+			fields: fields{
+				subscriptionPackageService: mockSuccessSubscriptionPackageService,
+				subscriptionService: &fakeSubscriptionService{
+					createErr: apperror.ErrConflict,
+				},
+				paymentGateway: &fakePaymentGateway{
+					reference: "reference",
+				},
+			},
+			args: args{
+				req: mockSuccessPurchaseRequest,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				subscriptionPackageService: mockSuccessSubscriptionPackageService,
+				subscriptionService:        &fakeSubscriptionService{},
+				paymentGateway: &fakePaymentGateway{
+					reference: "reference",
+				},
+			},
+			args: args{
+				req: mockSuccessPurchaseRequest,
+			},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewSubscriptionUsecase(test.fields.subscriptionPackageService, test.fields.subscriptionService, test.fields.paymentGateway)
+			got, err := s.Purchase(context.Background(), mockSwipeUser, test.args.req)
+			if (err != nil) != test.wantErr {
+				t.Errorf("subscriptionUsecase.Purchase() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if !test.wantErr && (got.SubscriptionPackage != mockSubscriptionPackage || !got.Active) {
+				t.Errorf("subscriptionUsecase.Purchase() = %+v", got)
+			}
+		})
+	}
+}
+
+func Test_subscriptionUsecase_Purchase_Refund_On_Failure(t *testing.T) {
+	paymentGateway := &fakePaymentGateway{
+		reference: "reference",
+	}
+	s := NewSubscriptionUsecase(mockSuccessSubscriptionPackageService, &fakeSubscriptionService{createErr: apperror.ErrConflict}, paymentGateway)
+	_, err := s.Purchase(context.Background(), mockSwipeUser, mockSuccessPurchaseRequest)
+	if !errors.Is(err, apperror.ErrConflict) {
+		t.Fatalf("subscriptionUsecase.Purchase() error = %v, want %v", err, apperror.ErrConflict)
+	}
+	if !paymentGateway.refunded {
+		t.Error("subscriptionUsecase.Purchase() did not refund the payment")
+	}
+}
+
+func Test_subscriptionUsecase_GetCurrentSubscription(t *testing.T) {
+	type fields struct {
+		subscriptionPackageService service.SubscriptionPackageService
+		subscriptionService        service.SubscriptionService
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		want    *entity.SubscriptionResponse
+		wantErr bool
+	}{
+		{
+			name: "Failed: Subscription not found",
+			fields: fields{
+				subscriptionService: &fakeSubscriptionService{
+					err: apperror.ErrNotFound,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Package not found",
+			fields: fields{
+				subscriptionPackageService: &fakeSubscriptionPackageService{
+					err: apperror.ErrNotFound,
+				},
+				subscriptionService: &fakeSubscriptionService{
+					subscription: mockActiveSubscription,
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				subscriptionPackageService: mockSuccessSubscriptionPackageService,
+				subscriptionService: &fakeSubscriptionService{
+					subscription: mockActiveSubscription,
+				},
+			},
+			want:    entity.NewSubscriptionResponse(mockActiveSubscription, mockSubscriptionPackage),
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewSubscriptionUsecase(test.fields.subscriptionPackageService, test.fields.subscriptionService, nil)
+			got, err := s.GetCurrentSubscription(context.Background(), mockSwipeUser)
+			if (err != nil) != test.wantErr {
+				t.Errorf("subscriptionUsecase.GetCurrentSubscription() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("subscriptionUsecase.GetCurrentSubscription() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_subscriptionUsecase_CancelSubscription(t *testing.T) {
+	type fields struct {
+		subscriptionService service.SubscriptionService
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		wantErr bool
+	}{
+		{
+			name: "Failed: Subscription not found",
+			fields: fields{
+				subscriptionService: &fakeSubscriptionService{
+					err: apperror.ErrNotFound,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				subscriptionService: &fakeSubscriptionService{
+					subscription: mockActiveSubscription,
+				},
+			},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewSubscriptionUsecase(nil, test.fields.subscriptionService, nil)
+			if err := s.CancelSubscription(context.Background(), mockSwipeUser); (err != nil) != test.wantErr {
+				t.Errorf("subscriptionUsecase.CancelSubscription() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+// SwipeUsecase is the interface used for the swipe use case.
+type SwipeUsecase interface {
+	Swipe(ctx context.Context, user *entity.User, req *entity.SwipeRequest) (*entity.SwipeResponse, error)
+}
+
+type swipeUsecase struct {
+	profileService         service.ProfileService
+	activityService        service.ActivityService
+	activityCounterService service.ActivityCounterService
+	entitlementService     service.EntitlementService
+	unitOfWork             domain.UnitOfWork
+	config                 domain.Config
+}
+
+// NewSwipeUsecase is a function used to initialize the swipe use case implementation.
+func NewSwipeUsecase(ps service.ProfileService, as service.ActivityService, acs service.ActivityCounterService, es service.EntitlementService, uow domain.UnitOfWork, cfg domain.Config) SwipeUsecase {
+	return &swipeUsecase{
+		profileService:         ps,
+		activityService:        as,
+		activityCounterService: acs,
+		entitlementService:     es,
+		unitOfWork:             uow,
+		config:                 cfg,
+	}
+}
+
+func (s *swipeUsecase) Swipe(ctx context.Context, user *entity.User, req *entity.SwipeRequest) (*entity.SwipeResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	profile, err := s.profileService.GetProfileByID(ctx, req.ProfileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if profile.UserID == user.ID {
+		return nil, apperror.New(apperror.KindValidation, constant.CannotSwipeOwnProfile)
+	}
+
+	unlimited, err := s.entitlementService.HasFeature(ctx, user.ID, entity.FeatureNoSwipeQuota)
+	if err != nil {
+		return nil, err
+	}
+
+	// The swipe only counts toward the quota when it is recorded, since both are written in one transaction.
+	currentTime := time.Now().UTC()
+	activity := entity.NewActivity(user.ID, profile.ID, strings.ToUpper(req.Action), util.DateIn(currentTime, s.config.GetSwipeResetLocation()), currentTime)
+	var remainingSwipes *int
+	var match *entity.Match
+	err = s.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		if !unlimited {
+			remaining, err := s.activityCounterService.ConsumeSwipe(ctx, user.ID, activity.ActivityDate, s.config.GetDailySwipeLimit())
+			if err != nil {
+				return err
+			}
+
+			remainingSwipes = &remaining
+		}
+
+		var err error
+		if activity.Action == entity.ActionLike {
+			match, err = s.activityService.CreateLike(ctx, activity, profile.UserID)
+		} else {
+			err = s.activityService.CreateActivity(ctx, activity)
+		}
+		// The activity date is unique per user and profile, so a second swipe on the same day conflicts even when both are concurrent.
+		if errors.Is(err, apperror.ErrConflict) {
+			return apperror.Wrap(apperror.KindConflict, constant.ProfileAlreadySwiped, err)
+		}
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewSwipeResponse(activity, remainingSwipes, match), nil
+}
@@ -0,0 +1,249 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+	"dealls-technical-test-dating-service/pkg/constant"
+
+	"gorm.io/gorm/schema"
+)
+
+var (
+	mockSwipeUser           = &entity.User{ID: 1}
+	mockSuccessSwipeRequest = &entity.SwipeRequest{
+		ProfileID: 2,
+		Action:    "like",
+	}
+	mockSuccessProfileService = &fakeProfileService{
+		profile: &entity.Profile{
+			ID:     2,
+			UserID: 2,
+		},
+	}
+)
+
+type fakeActivityService struct {
+	match *entity.Match
+	err   error
+}
+
+func (f *fakeActivityService) CreateActivity(context.Context, *entity.Activity) error {
+	return f.err
+}
+
+func (f *fakeActivityService) CreateLike(context.Context, *entity.Activity, int) (*entity.Match, error) {
+	return f.match, f.err
+}
+
+type fakeActivityCounterService struct {
+	remainingSwipes int
+	err             error
+}
+
+func (f *fakeActivityCounterService) ConsumeSwipe(context.Context, int, time.Time, int) (int, error) {
+	return f.remainingSwipes, f.err
+}
+
+func Test_swipeUsecase_Swipe(t *testing.T) {
+	type fields struct {
+		profileService         service.ProfileService
+		activityService        service.ActivityService
+		activityCounterService service.ActivityCounterService
+		entitlementService     service.EntitlementService
+	}
+	type args struct {
+		req *entity.SwipeRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.SwipeResponse
+		wantErr bool
+	}{
+		{
+			name: "Failed: Invalid request",
+			args: args{
+				req: &entity.SwipeRequest{},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Profile not found",
+			fields: fields{
+				profileService: &fakeProfileService{
+					profile: &entity.Profile{},
+					err:     apperror.ErrNotFound,
+				},
+			},
+			args: args{
+				req: mockSuccessSwipeRequest,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Own profile",
+			fields: fields{
+				profileService: &fakeProfileService{
+					profile: &entity.Profile{
+						ID:     2,
+						UserID: 1,
+					},
+				},
+			},
+			args: args{
+				req: mockSuccessSwipeRequest,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Check entitlement failed",
+			fields: fields{
+				profileService:  mockSuccessProfileService,
+				activityService: &fakeActivityService{},
+				entitlementService: &fakeEntitlementService{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			args: args{
+				req: mockSuccessSwipeRequest,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Quota exceeded",
+			fields: fields{
+				profileService:     mockSuccessProfileService,
+				activityService:    &fakeActivityService{},
+				entitlementService: &fakeEntitlementService{},
+				activityCounterService: &fakeActivityCounterService{
+					err: apperror.New(apperror.KindQuotaExceeded, constant.SwipeQuotaExceeded),
+				},
+			},
+			args: args{
+				req: mockSuccessSwipeRequest,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Create activity failed",
+			fields: fields{
+				profileService: mockSuccessProfileService,
+				activityService: &fakeActivityService{
+					err: schema.ErrUnsupportedDataType,
+				},
+				activityCounterService: &fakeActivityCounterService{},
+				entitlementService:     &fakeEntitlementService{},
+			},
+			args: args{
+				req: mockSuccessSwipeRequest,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := NewSwipeUsecase(test.fields.profileService, test.fields.activityService, test.fields.activityCounterService, test.fields.entitlementService, &fakeUnitOfWork{}, &fakeConfig{dailySwipeLimit: 10})
+			got, err := s.Swipe(context.Background(), mockSwipeUser, test.args.req)
+			if (err != nil) != test.wantErr {
+				t.Errorf("swipeUsecase.Swipe() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("swipeUsecase.Swipe() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_swipeUsecase_Swipe_Success(t *testing.T) {
+	s := NewSwipeUsecase(mockSuccessProfileService, &fakeActivityService{}, &fakeActivityCounterService{remainingSwipes: 9}, &fakeEntitlementService{}, &fakeUnitOfWork{}, &fakeConfig{dailySwipeLimit: 10})
+	got, err := s.Swipe(context.Background(), mockSwipeUser, mockSuccessSwipeRequest)
+	if err != nil {
+		t.Fatalf("swipeUsecase.Swipe() error = %v", err)
+	}
+	if got.Activity.UserID != 1 || got.Activity.ProfileID != 2 || got.Activity.Action != entity.ActionLike {
+		t.Errorf("swipeUsecase.Swipe() = %+v", got.Activity)
+	}
+	if got.RemainingSwipes == nil || *got.RemainingSwipes != 9 {
+		t.Errorf("swipeUsecase.Swipe() remaining swipes = %v, want 9", got.RemainingSwipes)
+	}
+	if got.Matched || got.Match != nil {
+		t.Errorf("swipeUsecase.Swipe() matched = %v, want false", got.Matched)
+	}
+}
+
+func Test_swipeUsecase_Swipe_Success_Matched(t *testing.T) {
+	match := &entity.Match{ID: 1, UserOneID: 1, UserTwoID: 2}
+	s := NewSwipeUsecase(mockSuccessProfileService, &fakeActivityService{match: match}, &fakeActivityCounterService{remainingSwipes: 9}, &fakeEntitlementService{}, &fakeUnitOfWork{}, &fakeConfig{dailySwipeLimit: 10})
+	got, err := s.Swipe(context.Background(), mockSwipeUser, mockSuccessSwipeRequest)
+	if err != nil {
+		t.Fatalf("swipeUsecase.Swipe() error = %v", err)
+	}
+	if !got.Matched || got.Match != match {
+		t.Errorf("swipeUsecase.Swipe() match = %v, want %v", got.Match, match)
+	}
+}
+
+func Test_swipeUsecase_Swipe_Success_Pass_Never_Matches(t *testing.T) {
+	match := &entity.Match{ID: 1, UserOneID: 1, UserTwoID: 2}
+	s := NewSwipeUsecase(mockSuccessProfileService, &fakeActivityService{match: match}, &fakeActivityCounterService{remainingSwipes: 9}, &fakeEntitlementService{}, &fakeUnitOfWork{}, &fakeConfig{dailySwipeLimit: 10})
+	got, err := s.Swipe(context.Background(), mockSwipeUser, &entity.SwipeRequest{ProfileID: 2, Action: entity.ActionPass})
+	if err != nil {
+		t.Fatalf("swipeUsecase.Swipe() error = %v", err)
+	}
+	if got.Matched || got.Match != nil {
+		t.Errorf("swipeUsecase.Swipe() matched = %v, want false", got.Matched)
+	}
+}
+
+func Test_swipeUsecase_Swipe_Rollback_Quota_On_Failure(t *testing.T) {
+	unitOfWork := &fakeUnitOfWork{}
+	s := NewSwipeUsecase(mockSuccessProfileService, &fakeActivityService{err: schema.ErrUnsupportedDataType}, &fakeActivityCounterService{}, &fakeEntitlementService{}, unitOfWork, &fakeConfig{dailySwipeLimit: 10})
+	_, err := s.Swipe(context.Background(), mockSwipeUser, mockSuccessSwipeRequest)
+	if err == nil {
+		t.Fatal("swipeUsecase.Swipe() error = nil, want error")
+	}
+	if !unitOfWork.rolledBack {
+		t.Error("swipeUsecase.Swipe() did not roll back the consumed swipe")
+	}
+}
+
+func Test_swipeUsecase_Swipe_Failed_Already_Swiped_Today(t *testing.T) {
+	activityService := &fakeActivityService{err: apperror.Wrap(apperror.KindConflict, "Activity already exists", schema.ErrUnsupportedDataType)}
+	s := NewSwipeUsecase(mockSuccessProfileService, activityService, &fakeActivityCounterService{}, &fakeEntitlementService{}, &fakeUnitOfWork{}, &fakeConfig{dailySwipeLimit: 10})
+	_, err := s.Swipe(context.Background(), mockSwipeUser, mockSuccessSwipeRequest)
+
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Kind != apperror.KindConflict || appErr.Message != constant.ProfileAlreadySwiped {
+		t.Errorf("swipeUsecase.Swipe() error = %v, want %q", err, constant.ProfileAlreadySwiped)
+	}
+}
+
+func Test_swipeUsecase_Swipe_Success_No_Swipe_Quota(t *testing.T) {
+	activityCounterService := &fakeActivityCounterService{
+		err: apperror.New(apperror.KindQuotaExceeded, constant.SwipeQuotaExceeded),
+	}
+	s := NewSwipeUsecase(mockSuccessProfileService, &fakeActivityService{}, activityCounterService, &fakeEntitlementService{hasFeature: true}, &fakeUnitOfWork{}, &fakeConfig{dailySwipeLimit: 10})
+	got, err := s.Swipe(context.Background(), mockSwipeUser, mockSuccessSwipeRequest)
+	if err != nil {
+		t.Fatalf("swipeUsecase.Swipe() error = %v", err)
+	}
+	if got.RemainingSwipes != nil {
+		t.Errorf("swipeUsecase.Swipe() remaining swipes = %v, want nil", *got.RemainingSwipes)
+	}
+}
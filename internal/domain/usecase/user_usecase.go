@@ -4,96 +4,1201 @@ package usecase
 import (
 	"context"
 	"errors"
-	"fmt"
+	"net/url"
 	"strings"
+	"sync"
 	"time"
 
 	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
 	"dealls-technical-test-dating-service/internal/domain/entity"
 	"dealls-technical-test-dating-service/internal/domain/service"
 	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
 )
 
-type (
-	hashPassword        func(password string) (string, error)
-	isValidPasswordHash func(password, hashedPassword string) bool
-)
+// dummyPassword is the password hashed once to have a hash to compare passwords of unknown emails with.
+const dummyPassword = "dummy-password"
 
 // UserUsecase is the interface used for the user use case.
 type UserUsecase interface {
 	Signup(ctx context.Context, req *entity.UserSignupRequest) error
 	Login(ctx context.Context, req *entity.UserLoginRequest) (*entity.UserLoginResponse, error)
+	RequestPhoneOTP(ctx context.Context, req *entity.PhoneOTPRequest) error
+	SignupWithPhone(ctx context.Context, req *entity.PhoneSignupRequest) (*entity.UserLoginResponse, error)
+	LoginWithPhone(ctx context.Context, req *entity.PhoneLoginRequest) (*entity.UserLoginResponse, error)
+	IssueIdentityProviderNonce(ctx context.Context, provider string) (*entity.IdentityProviderNonceResponse, error)
+	LoginWithIdentityProvider(ctx context.Context, req *entity.IdentityProviderLoginRequest) (*entity.UserLoginResponse, error)
+	RefreshToken(ctx context.Context, req *entity.RefreshTokenRequest) (*entity.UserLoginResponse, error)
+	Logout(ctx context.Context, user *entity.User, claims *entity.TokenClaims, req *entity.LogoutRequest) error
+	LogoutAll(ctx context.Context, user *entity.User, claims *entity.TokenClaims) error
+	VerifyEmail(ctx context.Context, req *entity.VerifyEmailRequest) error
+	ResendVerificationEmail(ctx context.Context, req *entity.ResendVerificationEmailRequest) error
+	ForgotPassword(ctx context.Context, req *entity.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, req *entity.ResetPasswordRequest) error
+	ChangePassword(ctx context.Context, user *entity.User, req *entity.ChangePasswordRequest) (*entity.UserLoginResponse, error)
+	ChangeEmail(ctx context.Context, user *entity.User, req *entity.ChangeEmailRequest) error
+	ConfirmEmailChange(ctx context.Context, req *entity.ConfirmEmailChangeRequest) error
+	LoginTwoFactor(ctx context.Context, req *entity.TwoFactorLoginRequest) (*entity.UserLoginResponse, error)
+	EnrollTwoFactor(ctx context.Context, user *entity.User) (*entity.TwoFactorEnrollmentResponse, error)
+	ConfirmTwoFactor(ctx context.Context, user *entity.User, req *entity.ConfirmTwoFactorRequest) (*entity.RecoveryCodesResponse, error)
+	GetSessions(ctx context.Context, user *entity.User, claims *entity.TokenClaims) (*entity.SessionsResponse, error)
+	DeleteSession(ctx context.Context, user *entity.User, id int) error
+	GetMe(ctx context.Context, user *entity.User) (*entity.UserResponse, error)
 }
 
 type userUsecase struct {
-	userService         service.UserService
-	profileService      service.ProfileService
-	config              domain.Config
-	auth                domain.Auth
-	hashPassword        hashPassword
-	isValidPasswordHash isValidPasswordHash
+	userService            service.UserService
+	profileService         service.ProfileService
+	refreshTokenService    service.RefreshTokenService
+	tokenRevocationService service.TokenRevocationService
+	userTokenService       service.UserTokenService
+	loginThrottleService   service.LoginThrottleService
+	twoFactorService       service.TwoFactorService
+	sessionService         service.SessionService
+	phoneOTPService        service.PhoneOTPService
+	userIdentityService    service.UserIdentityService
+	photoService           service.PhotoService
+	unitOfWork             domain.UnitOfWork
+	config                 domain.Config
+	auth                   domain.Auth
+	mailer                 domain.Mailer
+	smsSender              domain.SMSSender
+	passwordHasher         domain.PasswordHasher
+	identityProviders      map[string]domain.IdentityProvider
+	dummyPasswordHash      func() (string, error)
 }
 
 // NewUserUsecase is a function used to initialize the user use case implementation.
-func NewUserUsecase(us service.UserService, ps service.ProfileService, cfg domain.Config, a domain.Auth, hashPassword hashPassword, ivph isValidPasswordHash) UserUsecase {
+func NewUserUsecase(
+	us service.UserService, ps service.ProfileService, rts service.RefreshTokenService, trs service.TokenRevocationService, uts service.UserTokenService,
+	lts service.LoginThrottleService, tfs service.TwoFactorService, ss service.SessionService, pos service.PhoneOTPService, uis service.UserIdentityService,
+	phs service.PhotoService, uow domain.UnitOfWork, cfg domain.Config, a domain.Auth, m domain.Mailer, sms domain.SMSSender, ph domain.PasswordHasher, idps []domain.IdentityProvider,
+) UserUsecase {
+	identityProviders := make(map[string]domain.IdentityProvider, len(idps))
+	for _, idp := range idps {
+		identityProviders[idp.Name()] = idp
+	}
+
 	return &userUsecase{
-		userService:         us,
-		profileService:      ps,
-		config:              cfg,
-		auth:                a,
-		hashPassword:        hashPassword,
-		isValidPasswordHash: ivph,
+		userService:            us,
+		profileService:         ps,
+		refreshTokenService:    rts,
+		tokenRevocationService: trs,
+		userTokenService:       uts,
+		loginThrottleService:   lts,
+		twoFactorService:       tfs,
+		sessionService:         ss,
+		phoneOTPService:        pos,
+		userIdentityService:    uis,
+		photoService:           phs,
+		unitOfWork:             uow,
+		config:                 cfg,
+		auth:                   a,
+		mailer:                 m,
+		smsSender:              sms,
+		passwordHasher:         ph,
+		identityProviders:      identityProviders,
+		dummyPasswordHash: sync.OnceValues(func() (string, error) {
+			return ph.Hash(dummyPassword)
+		}),
 	}
 }
 
 func (u *userUsecase) Signup(ctx context.Context, req *entity.UserSignupRequest) error {
 	err := req.Validate()
 	if err != nil {
-		return fmt.Errorf("%s: %s", constant.InvalidRequestBody, err.Error())
+		return err
 	}
 
-	password, err := u.hashPassword(req.Password)
+	password, err := u.passwordHasher.Hash(req.Password)
 	if err != nil {
 		return err
 	}
 
 	currentTime := time.Now().UTC()
 	user := entity.NewUser(strings.ToLower(req.Email), password, req.Name, req.BirthDate, req.Gender, req.Location, "", currentTime, currentTime)
-	id, err := u.userService.CreateUser(ctx, user)
+
+	var mail *entity.Mail
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		id, err := u.userService.CreateUser(ctx, user)
+		if err != nil {
+			return err
+		}
+
+		profile := entity.NewProfile(id, "", "", false, currentTime, currentTime)
+		err = u.profileService.CreateProfile(ctx, profile)
+		if err != nil {
+			return err
+		}
+
+		mail, err = u.verificationMail(ctx, id, user.Email)
+
+		return err
+	})
 	if err != nil {
 		return err
 	}
 
-	profile := entity.NewProfile(id, "", "", false, currentTime, currentTime)
-	err = u.profileService.CreateProfile(ctx, profile)
+	return u.mailer.Send(ctx, mail)
+}
+
+func (u *userUsecase) Login(ctx context.Context, req *entity.UserLoginRequest) (*entity.UserLoginResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	email := strings.ToLower(req.Email)
+	clientIP := domain.ClientIPFromContext(ctx)
+	err = u.loginThrottleService.CheckLogin(ctx, email, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := u.userService.GetUserByEmail(ctx, email)
+	found := err == nil
+	if !found && !errors.Is(err, apperror.ErrNotFound) {
+		return nil, err
+	}
+
+	// An unknown email is answered like a wrong password, after comparing the password with a dummy hash that takes as long.
+	passwordHash := ""
+	if found {
+		passwordHash = user.Password
+	} else {
+		passwordHash, err = u.dummyPasswordHash()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !u.passwordHasher.Verify(req.Password, passwordHash) || !found {
+		var userID *int
+		if found {
+			userID = &user.ID
+		}
+
+		err = u.loginThrottleService.RecordFailedLogin(ctx, email, clientIP, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, apperror.New(apperror.KindUnauthorized, constant.InvalidEmailPassword)
+	}
+
+	twoFactorEnabled, err := u.twoFactorService.IsEnabled(ctx, user.ID)
 	if err != nil {
+		return nil, err
+	}
+
+	// With two-factor authentication, the failed attempts are only forgotten once a code is given too,
+	// so that knowing the password is not enough to lift a lockout earned by guessing codes.
+	if !twoFactorEnabled {
+		err = u.loginThrottleService.ResetFailedLogins(ctx, email)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	u.rehashPassword(ctx, user, req.Password)
+
+	if u.config.IsVerifiedEmailRequiredForLogin() && !user.IsEmailVerified() {
+		return nil, apperror.New(apperror.KindForbidden, constant.EmailNotVerified)
+	}
+
+	if twoFactorEnabled {
+		return u.createTwoFactorChallenge(ctx, user)
+	}
+
+	var resp *entity.UserLoginResponse
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		resp, err = u.issueLoginTokens(ctx, user)
+
 		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	return resp, nil
 }
 
-func (u *userUsecase) Login(ctx context.Context, req *entity.UserLoginRequest) (*entity.UserLoginResponse, error) {
+func (u *userUsecase) RequestPhoneOTP(ctx context.Context, req *entity.PhoneOTPRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	phone, _ := util.NormalizePhone(req.Phone)
+
+	var sms *entity.SMS
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		phoneOTP, code, err := u.phoneOTPService.IssueOTP(ctx, phone, domain.ClientIPFromContext(ctx))
+		if err != nil {
+			return err
+		}
+
+		sms = entity.NewPhoneOTPSMS(phone, code, phoneOTP.ExpiresAt.Sub(phoneOTP.CreatedAt))
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.smsSender.Send(ctx, sms)
+}
+
+func (u *userUsecase) SignupWithPhone(ctx context.Context, req *entity.PhoneSignupRequest) (*entity.UserLoginResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	phone, _ := util.NormalizePhone(req.Phone)
+	currentTime := time.Now().UTC()
+	user := entity.NewPhoneUser(phone, req.Name, req.BirthDate, req.Gender, req.Location, currentTime)
+
+	var resp *entity.UserLoginResponse
+	var invalidCodeErr error
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		// A wrong code is committed as an attempt, while any later failure rolls the code back so that it can still be used.
+		err := u.phoneOTPService.VerifyOTP(ctx, phone, req.Code)
+		if errors.Is(err, apperror.ErrUnauthorized) {
+			invalidCodeErr = err
+
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Whether the phone number has signed up is only told to whoever proved owning it, and the code can then log in.
+		_, err = u.userService.GetUserByPhone(ctx, phone)
+		if err == nil {
+			return apperror.New(apperror.KindConflict, constant.PhoneAlreadyUsed)
+		}
+		if !errors.Is(err, apperror.ErrNotFound) {
+			return err
+		}
+
+		id, err := u.userService.CreateUser(ctx, user)
+		if errors.Is(err, apperror.ErrConflict) {
+			return apperror.Wrap(apperror.KindConflict, constant.PhoneAlreadyUsed, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		profile := entity.NewProfile(id, "", "", false, currentTime, currentTime)
+		err = u.profileService.CreateProfile(ctx, profile)
+		if err != nil {
+			return err
+		}
+
+		// The user has no password to log in with, so the sign up logs in right away.
+		resp, err = u.issueLoginTokens(ctx, user)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if invalidCodeErr != nil {
+		return nil, invalidCodeErr
+	}
+
+	return resp, nil
+}
+
+func (u *userUsecase) LoginWithPhone(ctx context.Context, req *entity.PhoneLoginRequest) (*entity.UserLoginResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	phone, _ := util.NormalizePhone(req.Phone)
+	clientIP := domain.ClientIPFromContext(ctx)
+	err = u.loginThrottleService.CheckLogin(ctx, phone, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	var user *entity.User
+	var invalidCodeErr error
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		user, err = u.userService.GetUserByPhone(ctx, phone)
+		if errors.Is(err, apperror.ErrNotFound) {
+			user = nil
+		} else if err != nil {
+			return err
+		}
+
+		// Unknown numbers get the same answer as a wrong code, so that the endpoint does not tell which numbers have signed up.
+		err = u.phoneOTPService.VerifyOTP(ctx, phone, req.Code)
+		if errors.Is(err, apperror.ErrUnauthorized) {
+			invalidCodeErr = err
+
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// Once the code proves owning the number, an unknown one is told apart so that clients can offer to sign up,
+		// and the code is rolled back so that it can be used to sign up.
+		if user == nil {
+			return apperror.New(apperror.KindNotFound, constant.PhoneNotRegistered)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if invalidCodeErr != nil {
+		var userID *int
+		if user != nil {
+			userID = &user.ID
+		}
+
+		err = u.loginThrottleService.RecordFailedLogin(ctx, phone, clientIP, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, invalidCodeErr
+	}
+
+	twoFactorEnabled, err := u.twoFactorService.IsEnabled(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if twoFactorEnabled {
+		return u.createTwoFactorChallenge(ctx, user)
+	}
+
+	err = u.loginThrottleService.ResetFailedLogins(ctx, phone)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *entity.UserLoginResponse
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		resp, err = u.issueLoginTokens(ctx, user)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (u *userUsecase) IssueIdentityProviderNonce(ctx context.Context, provider string) (*entity.IdentityProviderNonceResponse, error) {
+	_, ok := u.identityProviders[provider]
+	if !ok {
+		return nil, apperror.New(apperror.KindNotFound, "Identity provider not found")
+	}
+
+	// Nonces are only needed until they are used or expire, so the ones that cannot be used anymore are deleted as new ones are issued.
+	err := u.userTokenService.DeleteUnusableUserTokens(ctx, entity.UserTokenPurposeOIDCNonce)
+	if err != nil {
+		return nil, err
+	}
+
+	expiration := u.config.GetOIDCNonceExpiration()
+	expiresAt := time.Now().UTC().Add(expiration)
+	var nonce string
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		var err error
+		nonce, err = u.userTokenService.IssueIPAddressToken(ctx, entity.UserTokenPurposeOIDCNonce, domain.ClientIPFromContext(ctx), expiration, u.config.GetOIDCNonceMaxPerIP())
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewIdentityProviderNonceResponse(nonce, expiresAt), nil
+}
+
+func (u *userUsecase) LoginWithIdentityProvider(ctx context.Context, req *entity.IdentityProviderLoginRequest) (*entity.UserLoginResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	identityProvider, ok := u.identityProviders[req.Provider]
+	if !ok {
+		return nil, apperror.Validation(constant.InvalidRequestBody, apperror.Field("provider", "provider is not supported"))
+	}
+
+	identity, err := identityProvider.VerifyIDToken(ctx, req.IDToken, req.Nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	user, linked, err := u.findIdentityUser(ctx, identity)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		err = req.ValidateSignup(identity.Name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var resp *entity.UserLoginResponse
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		_, err := u.userTokenService.UseUserToken(ctx, entity.UserTokenPurposeOIDCNonce, req.Nonce)
+		if errors.Is(err, apperror.ErrValidation) {
+			return apperror.Wrap(apperror.KindUnauthorized, constant.InvalidNonce, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		if user == nil {
+			currentTime := time.Now().UTC()
+			user = entity.NewIdentityProviderUser(identity, req, currentTime)
+			id, err := u.userService.CreateUser(ctx, user)
+			if err != nil {
+				return err
+			}
+
+			profile := entity.NewProfile(id, "", "", false, currentTime, currentTime)
+			err = u.profileService.CreateProfile(ctx, profile)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !linked {
+			err := u.userIdentityService.LinkUserIdentity(ctx, user.ID, identity)
+			if err != nil {
+				return err
+			}
+		}
+
+		twoFactorEnabled, err := u.twoFactorService.IsEnabled(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+		if twoFactorEnabled {
+			resp, err = u.createTwoFactorChallenge(ctx, user)
+
+			return err
+		}
+
+		resp, err = u.issueLoginTokens(ctx, user)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+// findIdentityUser is a method for finding the user an identity provider account logs in as, and whether the account is already linked to the user.
+// An account that is not linked yet belongs to the user with the email address the provider verified, and to no user when there is none.
+// The email address of that user must be verified as well, since whoever signed up with an address they do not own would otherwise get the account.
+func (u *userUsecase) findIdentityUser(ctx context.Context, identity *entity.ExternalIdentity) (*entity.User, bool, error) {
+	userIdentity, err := u.userIdentityService.GetUserIdentity(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		user, err := u.userService.GetUserByID(ctx, userIdentity.UserID)
+
+		return user, true, err
+	}
+	if !errors.Is(err, apperror.ErrNotFound) {
+		return nil, false, err
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, false, apperror.New(apperror.KindForbidden, constant.ProviderEmailNotVerified)
+	}
+
+	identity.Email = strings.ToLower(identity.Email)
+	user, err := u.userService.GetUserByEmail(ctx, identity.Email)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if !user.IsEmailVerified() {
+		return nil, false, apperror.New(apperror.KindConflict, constant.EmailLinkNotVerified)
+	}
+
+	return user, false, nil
+}
+
+func (u *userUsecase) RefreshToken(ctx context.Context, req *entity.RefreshTokenRequest) (*entity.UserLoginResponse, error) {
 	err := req.Validate()
 	if err != nil {
-		return nil, fmt.Errorf("%s: %s", constant.InvalidRequestBody, err.Error())
+		return nil, err
+	}
+
+	var resp *entity.UserLoginResponse
+	var invalidTokenErr error
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		rotated, refreshToken, err := u.refreshTokenService.RotateRefreshToken(ctx, req.RefreshToken, u.config.GetRefreshTokenExpiration())
+		// An invalid token is only reported after the commit, so that the family of a reused token stays revoked.
+		if errors.Is(err, apperror.ErrUnauthorized) {
+			invalidTokenErr = err
+
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		user, err := u.userService.GetUserByID(ctx, rotated.UserID)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return apperror.Wrap(apperror.KindUnauthorized, constant.InvalidRefreshToken, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		claims, token, err := u.auth.GenerateToken(user.ID, user.Email, user.Role)
+		if err != nil {
+			return err
+		}
+
+		err = u.sessionService.RefreshSession(ctx, rotated.FamilyID, claims.ID)
+		if err != nil {
+			return err
+		}
+
+		resp = entity.NewUserLoginResponse(token, refreshToken)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if invalidTokenErr != nil {
+		return nil, invalidTokenErr
+	}
+
+	return resp, nil
+}
+
+func (u *userUsecase) Logout(ctx context.Context, user *entity.User, claims *entity.TokenClaims, req *entity.LogoutRequest) error {
+	if req.RefreshToken != "" {
+		err := u.refreshTokenService.RevokeRefreshToken(ctx, user.ID, req.RefreshToken)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := u.sessionService.DeleteSessionByTokenID(ctx, claims.ID)
+	if err != nil {
+		return err
 	}
 
+	return u.tokenRevocationService.RevokeToken(ctx, user.ID, claims)
+}
+
+func (u *userUsecase) LogoutAll(ctx context.Context, user *entity.User, claims *entity.TokenClaims) error {
+	err := u.refreshTokenService.RevokeUserRefreshTokens(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = u.tokenRevocationService.RevokeAllTokens(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = u.sessionService.DeleteUserSessions(ctx, user.ID)
+	if err != nil {
+		return err
+	}
+
+	// Tokens issued in the same second as the revocation survive it, so the token used to log out is revoked on its own.
+	return u.tokenRevocationService.RevokeToken(ctx, user.ID, claims)
+}
+
+func (u *userUsecase) VerifyEmail(ctx context.Context, req *entity.VerifyEmailRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	claims, err := u.auth.ValidateActionToken(entity.UserTokenPurposeEmailVerification, req.Token)
+	if err != nil {
+		return apperror.Wrap(apperror.KindValidation, constant.InvalidUserToken, err)
+	}
+
+	return u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		userToken, err := u.userTokenService.UseUserToken(ctx, entity.UserTokenPurposeEmailVerification, claims.ID)
+		if err != nil {
+			return err
+		}
+
+		user, err := u.userService.GetUserByID(ctx, userToken.UserID)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return apperror.Wrap(apperror.KindValidation, constant.InvalidUserToken, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		// A token sent to an address the user no longer has says nothing about the current one.
+		if user.Email != userToken.Email {
+			return apperror.New(apperror.KindValidation, constant.InvalidUserToken)
+		}
+
+		return u.userService.VerifyEmail(ctx, user.ID)
+	})
+}
+
+func (u *userUsecase) ResendVerificationEmail(ctx context.Context, req *entity.ResendVerificationEmailRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Unknown and verified addresses, and requests within the resend interval, get the same answer as a sent email,
+	// so that the endpoint cannot be used to find out which addresses have signed up.
 	user, err := u.userService.GetUserByEmail(ctx, strings.ToLower(req.Email))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.IsEmailVerified() {
+		return nil
+	}
+
+	var mail *entity.Mail
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		recent, err := u.userTokenService.HasRecentUserToken(ctx, user.ID, entity.UserTokenPurposeEmailVerification, u.config.GetEmailVerificationResendInterval())
+		if err != nil || recent {
+			return err
+		}
+
+		mail, err = u.verificationMail(ctx, user.ID, user.Email)
+
+		return err
+	})
+	if err != nil || mail == nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, mail)
+}
+
+// verificationMail is a method for storing a signed single-use token that verifies the email address of a user
+// and building the email with its link, which is only sent once the token is committed.
+func (u *userUsecase) verificationMail(ctx context.Context, userID int, email string) (*entity.Mail, error) {
+	claims, token, err := u.auth.GenerateActionToken(entity.UserTokenPurposeEmailVerification, userID, email, u.config.GetEmailVerificationTokenExpiration())
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.userTokenService.CreateUserToken(ctx, userID, entity.UserTokenPurposeEmailVerification, claims.ID, email, claims.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	link, err := tokenLink(u.config.GetEmailVerificationURL(), token)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewEmailVerificationMail(email, link), nil
+}
+
+func (u *userUsecase) ForgotPassword(ctx context.Context, req *entity.ForgotPasswordRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	// As with resending a verification email, unknown addresses and throttled requests look like a sent email.
+	user, err := u.userService.GetUserByEmail(ctx, strings.ToLower(req.Email))
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	recent, err := u.userTokenService.HasRecentUserToken(ctx, user.ID, entity.UserTokenPurposePasswordReset, u.config.GetPasswordResetRequestInterval())
+	if err != nil || recent {
+		return err
+	}
+
+	var mail *entity.Mail
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		token, err := u.userTokenService.IssueUserToken(ctx, user.ID, entity.UserTokenPurposePasswordReset, user.Email, u.config.GetPasswordResetTokenExpiration())
+		if err != nil {
+			return err
+		}
+
+		link, err := tokenLink(u.config.GetPasswordResetURL(), token)
+		if err != nil {
+			return err
+		}
+
+		mail = entity.NewPasswordResetMail(user.Email, link)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, mail)
+}
+
+func (u *userUsecase) ResetPassword(ctx context.Context, req *entity.ResetPasswordRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	password, err := u.passwordHasher.Hash(req.Password)
+	if err != nil {
+		return err
+	}
+
+	return u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		userToken, err := u.userTokenService.UseUserToken(ctx, entity.UserTokenPurposePasswordReset, req.Token)
+		if err != nil {
+			return err
+		}
+
+		user, err := u.userService.GetUserByID(ctx, userToken.UserID)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return apperror.Wrap(apperror.KindValidation, constant.InvalidUserToken, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		if user.Email != userToken.Email {
+			return apperror.New(apperror.KindValidation, constant.InvalidUserToken)
+		}
+
+		err = u.userService.UpdatePassword(ctx, user.ID, password)
+		if err != nil {
+			return err
+		}
+
+		// Other reset links and every session are ended, since whoever knew the old password may still hold them.
+		err = u.userTokenService.RevokeUserTokens(ctx, user.ID, entity.UserTokenPurposePasswordReset)
+		if err != nil {
+			return err
+		}
+
+		err = u.refreshTokenService.RevokeUserRefreshTokens(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		err = u.sessionService.DeleteUserSessions(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		return u.tokenRevocationService.RevokeAllTokens(ctx, user.ID)
+	})
+}
+
+func (u *userUsecase) ChangePassword(ctx context.Context, user *entity.User, req *entity.ChangePasswordRequest) (*entity.UserLoginResponse, error) {
+	err := req.Validate()
 	if err != nil {
 		return nil, err
 	}
 
-	if !u.isValidPasswordHash(req.Password, user.Password) {
-		return nil, errors.New(constant.InvalidEmailPassword)
+	// Users who signed up with a phone number or an identity provider have no password to change, but can set one with a password reset.
+	if !user.HasPassword() {
+		return nil, apperror.New(apperror.KindConflict, constant.PasswordNotSet)
+	}
+
+	// The current password is throttled like a login, so that a stolen access token cannot be used to guess it.
+	identifier := user.Identifier()
+	clientIP := domain.ClientIPFromContext(ctx)
+	err = u.loginThrottleService.CheckLogin(ctx, identifier, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	if !u.passwordHasher.Verify(req.CurrentPassword, user.Password) {
+		err = u.loginThrottleService.RecordFailedLogin(ctx, identifier, clientIP, &user.ID)
+		if err != nil {
+			return nil, err
+		}
+
+		return nil, apperror.Validation(constant.InvalidRequestBody, apperror.Field("current_password", "current_password is incorrect"))
+	}
+
+	password, err := u.passwordHasher.Hash(req.NewPassword)
+	if err != nil {
+		return nil, err
 	}
 
-	token, err := u.auth.GenerateToken(user.Email, u.config.GetJWTKey())
+	var resp *entity.UserLoginResponse
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		err := u.userService.UpdatePassword(ctx, user.ID, password)
+		if err != nil {
+			return err
+		}
+
+		err = u.userTokenService.RevokeUserTokens(ctx, user.ID, entity.UserTokenPurposePasswordReset)
+		if err != nil {
+			return err
+		}
+
+		err = u.refreshTokenService.RevokeUserRefreshTokens(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		err = u.tokenRevocationService.RevokeAllTokens(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		err = u.sessionService.DeleteUserSessions(ctx, user.ID)
+		if err != nil {
+			return err
+		}
+
+		// Every other session is ended, while the one that changed the password continues with the new tokens.
+		resp, err = u.issueLoginTokens(ctx, user)
+
+		return err
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	resp := entity.NewUserLoginResponse(token)
+	// The current password was given, so the failed attempts are forgotten as on a login.
+	err = u.loginThrottleService.ResetFailedLogins(ctx, identifier)
+	if err != nil {
+		return nil, err
+	}
 
 	return resp, nil
 }
+
+func (u *userUsecase) ChangeEmail(ctx context.Context, user *entity.User, req *entity.ChangeEmailRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	// Users who signed up with a phone number link an email address without a password, having none.
+	if user.HasPassword() {
+		if req.Password == "" {
+			return apperror.Validation(constant.InvalidRequestBody, apperror.Field("password", "password is required"))
+		}
+		if !u.passwordHasher.Verify(req.Password, user.Password) {
+			return apperror.Validation(constant.InvalidRequestBody, apperror.Field("password", "password is incorrect"))
+		}
+	}
+
+	email := strings.ToLower(req.Email)
+	if email == user.Email {
+		return apperror.Validation(constant.InvalidRequestBody, apperror.Field("email", "email must be different from the current one"))
+	}
+
+	_, err = u.userService.GetUserByEmail(ctx, email)
+	if err == nil {
+		return apperror.New(apperror.KindConflict, constant.EmailAlreadyUsed)
+	}
+	if !errors.Is(err, apperror.ErrNotFound) {
+		return err
+	}
+
+	// The current address stays in use until the new one is confirmed, and only the latest link can confirm a change.
+	var mail *entity.Mail
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		err := u.userTokenService.RevokeUserTokens(ctx, user.ID, entity.UserTokenPurposeEmailChange)
+		if err != nil {
+			return err
+		}
+
+		token, err := u.userTokenService.IssueUserToken(ctx, user.ID, entity.UserTokenPurposeEmailChange, email, u.config.GetEmailChangeTokenExpiration())
+		if err != nil {
+			return err
+		}
+
+		link, err := tokenLink(u.config.GetEmailChangeURL(), token)
+		if err != nil {
+			return err
+		}
+
+		mail = entity.NewEmailChangeMail(email, link)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, mail)
+}
+
+func (u *userUsecase) ConfirmEmailChange(ctx context.Context, req *entity.ConfirmEmailChangeRequest) error {
+	err := req.Validate()
+	if err != nil {
+		return err
+	}
+
+	var mail *entity.Mail
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		userToken, err := u.userTokenService.UseUserToken(ctx, entity.UserTokenPurposeEmailChange, req.Token)
+		if err != nil {
+			return err
+		}
+
+		user, err := u.userService.GetUserByID(ctx, userToken.UserID)
+		if errors.Is(err, apperror.ErrNotFound) {
+			return apperror.Wrap(apperror.KindValidation, constant.InvalidUserToken, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		err = u.userService.ChangeEmail(ctx, user.ID, userToken.Email)
+		if errors.Is(err, apperror.ErrConflict) {
+			return apperror.Wrap(apperror.KindConflict, constant.EmailAlreadyUsed, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		// A user who had no email address has no previous one to tell.
+		if user.Email != "" {
+			mail = entity.NewEmailChangedMail(user.Email, userToken.Email)
+		}
+
+		return nil
+	})
+	if err != nil || mail == nil {
+		return err
+	}
+
+	return u.mailer.Send(ctx, mail)
+}
+
+// tokenLink is a function used to build the link of a page given the token as query parameter.
+func tokenLink(pageURL, token string) (string, error) {
+	link, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+
+	return link.String(), nil
+}
+
+// rehashPassword is a method for hashing the password again when its hash was made with another algorithm or parameters.
+// A failure does not fail the login, the password is rehashed on a later login instead.
+func (u *userUsecase) rehashPassword(ctx context.Context, user *entity.User, password string) {
+	if !u.passwordHasher.NeedsRehash(user.Password) {
+		return
+	}
+
+	hashedPassword, err := u.passwordHasher.Hash(password)
+	if err != nil {
+		return
+	}
+
+	_ = u.userService.UpdatePassword(ctx, user.ID, hashedPassword)
+}
+
+func (u *userUsecase) LoginTwoFactor(ctx context.Context, req *entity.TwoFactorLoginRequest) (*entity.UserLoginResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := u.auth.ValidateActionToken(entity.UserTokenPurposeTwoFactorLogin, req.ChallengeToken)
+	if err != nil {
+		return nil, apperror.Wrap(apperror.KindUnauthorized, constant.InvalidChallenge, err)
+	}
+
+	user, err := u.userService.GetUserByID(ctx, claims.UserID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		return nil, apperror.Wrap(apperror.KindUnauthorized, constant.InvalidChallenge, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Email != claims.Email {
+		return nil, apperror.New(apperror.KindUnauthorized, constant.InvalidChallenge)
+	}
+
+	// Failed attempts are counted against what the user logged in with, the phone number of users without an email address.
+	account := user.Identifier()
+	clientIP := domain.ClientIPFromContext(ctx)
+	err = u.loginThrottleService.CheckLogin(ctx, account, clientIP)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp *entity.UserLoginResponse
+	wrongCode := false
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		// The code is checked before the challenge is used, so that a mistyped code can be given again with the same challenge.
+		err := u.verifySecondFactor(ctx, user.ID, req)
+		if err != nil {
+			wrongCode = errors.Is(err, apperror.ErrUnauthorized)
+
+			return err
+		}
+
+		_, err = u.userTokenService.UseUserToken(ctx, entity.UserTokenPurposeTwoFactorLogin, claims.ID)
+		if errors.Is(err, apperror.ErrValidation) {
+			return apperror.Wrap(apperror.KindUnauthorized, constant.InvalidChallenge, err)
+		}
+		if err != nil {
+			return err
+		}
+
+		resp, err = u.issueLoginTokens(ctx, user)
+
+		return err
+	})
+	if wrongCode {
+		recordErr := u.loginThrottleService.RecordFailedLogin(ctx, account, clientIP, &user.ID)
+		if recordErr != nil {
+			return nil, recordErr
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.loginThrottleService.ResetFailedLogins(ctx, account)
+	if err != nil {
+		return nil, err
+	}
+
+	return resp, nil
+}
+
+func (u *userUsecase) EnrollTwoFactor(ctx context.Context, user *entity.User) (*entity.TwoFactorEnrollmentResponse, error) {
+	secret, err := u.twoFactorService.Enroll(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewTwoFactorEnrollmentResponse(secret, util.TOTPURI(u.config.GetTwoFactorIssuer(), user.Identifier(), secret)), nil
+}
+
+func (u *userUsecase) ConfirmTwoFactor(ctx context.Context, user *entity.User, req *entity.ConfirmTwoFactorRequest) (*entity.RecoveryCodesResponse, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	var recoveryCodes []string
+	err = u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		recoveryCodes, err = u.twoFactorService.Confirm(ctx, user.ID, req.Code)
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewRecoveryCodesResponse(recoveryCodes), nil
+}
+
+func (u *userUsecase) GetSessions(ctx context.Context, user *entity.User, claims *entity.TokenClaims) (*entity.SessionsResponse, error) {
+	// A session not seen for as long as a refresh token lasts cannot be refreshed anymore, so it is left out.
+	seenSince := time.Now().UTC().Add(-u.config.GetRefreshTokenExpiration())
+	sessions, err := u.sessionService.GetUserSessions(ctx, user.ID, seenSince)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewSessionsResponse(sessions, claims.ID), nil
+}
+
+func (u *userUsecase) DeleteSession(ctx context.Context, user *entity.User, id int) error {
+	return u.unitOfWork.Do(ctx, func(ctx context.Context) error {
+		session, err := u.sessionService.DeleteSession(ctx, id, user.ID)
+		if err != nil {
+			return err
+		}
+
+		// The access token of the session is rejected once the session is gone, and its refresh tokens are revoked here.
+		return u.refreshTokenService.RevokeRefreshTokenFamily(ctx, session.RefreshFamilyID)
+	})
+}
+
+func (u *userUsecase) GetMe(ctx context.Context, user *entity.User) (*entity.UserResponse, error) {
+	userResp := entity.NewUserResponse(user)
+
+	err := setProfilePicture(ctx, u.photoService, user.ProfilePictureKey, userResp)
+	if err != nil {
+		return nil, err
+	}
+
+	return userResp, nil
+}
+
+// issueLoginTokens is a method for issuing an access token and a refresh token to a user who logged in,
+// recording the login as a session of the device the request came from.
+func (u *userUsecase) issueLoginTokens(ctx context.Context, user *entity.User) (*entity.UserLoginResponse, error) {
+	claims, token, err := u.auth.GenerateToken(user.ID, user.Email, user.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, rawRefreshToken, err := u.refreshTokenService.CreateRefreshToken(ctx, user.ID, u.config.GetRefreshTokenExpiration())
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.sessionService.CreateSession(ctx, user.ID, claims.ID, refreshToken.FamilyID, domain.UserAgentFromContext(ctx), domain.ClientIPFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewUserLoginResponse(token, rawRefreshToken), nil
+}
+
+// createTwoFactorChallenge is a method for issuing a signed single-use challenge token that a user with two-factor authentication
+// exchanges along with a code for the tokens.
+func (u *userUsecase) createTwoFactorChallenge(ctx context.Context, user *entity.User) (*entity.UserLoginResponse, error) {
+	claims, token, err := u.auth.GenerateActionToken(entity.UserTokenPurposeTwoFactorLogin, user.ID, user.Email, u.config.GetTwoFactorChallengeExpiration())
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.userTokenService.CreateUserToken(ctx, user.ID, entity.UserTokenPurposeTwoFactorLogin, claims.ID, user.Email, claims.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.NewTwoFactorChallengeResponse(token), nil
+}
+
+// verifySecondFactor is a method for checking the TOTP code or the recovery code given to complete the login of a user.
+func (u *userUsecase) verifySecondFactor(ctx context.Context, userID int, req *entity.TwoFactorLoginRequest) error {
+	if req.RecoveryCode != "" {
+		return u.twoFactorService.UseRecoveryCode(ctx, userID, req.RecoveryCode)
+	}
+
+	return u.twoFactorService.VerifyCode(ctx, userID, req.Code)
+}
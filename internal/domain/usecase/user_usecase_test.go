@@ -4,15 +4,16 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
 	"dealls-technical-test-dating-service/internal/domain/entity"
 	"dealls-technical-test-dating-service/internal/domain/service"
 
 	"golang.org/x/crypto/bcrypt"
-	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
 
@@ -44,263 +45,2517 @@ var (
 		},
 		err: nil,
 	}
+	mockHashPassword = func(string) (string, error) {
+		return mockPassword, nil
+	}
 	mockIsValidPasswordHash = func(string, string) bool {
 		return true
 	}
+	mockActionTokenClaims = &entity.ActionTokenClaims{
+		ID:        "jti",
+		Purpose:   entity.UserTokenPurposeEmailVerification,
+		UserID:    1,
+		Email:     "user@email.com",
+		ExpiresAt: time.Now().Add(time.Hour).UTC(),
+	}
 )
 
 type fakeUserService struct {
-	id   int
-	user *entity.User
-	err  error
+	id         int
+	user       *entity.User
+	verifiedID int
+	password   string
+	email      string
+	role       string
+	updated    *entity.User
+	err        error
+
+	profilePictureKey string
 }
 
-func (f *fakeUserService) CreateUser(context.Context, *entity.User) (int, error) {
-	return f.id, f.err
+func (f *fakeUserService) CreateUser(_ context.Context, user *entity.User) (int, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+
+	user.ID = f.id
+
+	return f.id, nil
 }
 
 func (f *fakeUserService) GetUserByEmail(context.Context, string) (*entity.User, error) {
+	if f.err == nil && f.user == nil {
+		return nil, apperror.ErrNotFound
+	}
+
+	return f.user, f.err
+}
+
+func (f *fakeUserService) GetUserByPhone(_ context.Context, phone string) (*entity.User, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if f.user == nil || f.user.Phone != phone {
+		return nil, apperror.ErrNotFound
+	}
+
+	return f.user, nil
+}
+
+func (f *fakeUserService) GetUserByID(context.Context, int) (*entity.User, error) {
 	return f.user, f.err
 }
 
+func (f *fakeUserService) VerifyEmail(_ context.Context, id int) error {
+	f.verifiedID = id
+
+	return f.err
+}
+
+func (f *fakeUserService) UpdatePassword(_ context.Context, _ int, hashedPassword string) error {
+	f.password = hashedPassword
+
+	return f.err
+}
+
+func (f *fakeUserService) ChangeEmail(_ context.Context, _ int, email string) error {
+	f.email = email
+
+	return f.err
+}
+
+func (f *fakeUserService) ChangeRole(_ context.Context, _ int, role string) error {
+	f.role = role
+
+	return f.err
+}
+
+func (f *fakeUserService) UpdateProfile(_ context.Context, user *entity.User) error {
+	f.updated = user
+
+	return f.err
+}
+
+func (f *fakeUserService) ChangeProfilePicture(_ context.Context, _ int, key string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+
+	previousKey := f.profilePictureKey
+	f.profilePictureKey = key
+
+	return previousKey, nil
+}
+
+type fakeRefreshTokenService struct {
+	refreshToken  *entity.RefreshToken
+	token         string
+	err           error
+	revokedToken  string
+	revokedFamily string
+	revokedUserID int
+}
+
+func (f *fakeRefreshTokenService) CreateRefreshToken(_ context.Context, userID int, _ time.Duration) (*entity.RefreshToken, string, error) {
+	return &entity.RefreshToken{UserID: userID, FamilyID: "family"}, f.token, f.err
+}
+
+func (f *fakeRefreshTokenService) RotateRefreshToken(context.Context, string, time.Duration) (*entity.RefreshToken, string, error) {
+	return f.refreshToken, f.token, f.err
+}
+
+func (f *fakeRefreshTokenService) RevokeRefreshToken(_ context.Context, _ int, token string) error {
+	f.revokedToken = token
+
+	return f.err
+}
+
+func (f *fakeRefreshTokenService) RevokeRefreshTokenFamily(_ context.Context, familyID string) error {
+	f.revokedFamily = familyID
+
+	return f.err
+}
+
+func (f *fakeRefreshTokenService) RevokeUserRefreshTokens(_ context.Context, userID int) error {
+	f.revokedUserID = userID
+
+	return f.err
+}
+
+type fakeTokenRevocationService struct {
+	revokedTokenIDs []string
+	revokedAll      bool
+	err             error
+}
+
+func (f *fakeTokenRevocationService) RevokeToken(_ context.Context, _ int, claims *entity.TokenClaims) error {
+	f.revokedTokenIDs = append(f.revokedTokenIDs, claims.ID)
+
+	return f.err
+}
+
+func (f *fakeTokenRevocationService) RevokeAllTokens(context.Context, int) error {
+	f.revokedAll = true
+
+	return f.err
+}
+
+func (f *fakeTokenRevocationService) IsRevoked(context.Context, int, *entity.TokenClaims) (bool, error) {
+	return false, f.err
+}
+
 type fakeProfileService struct {
-	err error
+	profile           *entity.Profile
+	candidate         *entity.ProfileCandidate
+	candidates        []*entity.ProfileCandidate
+	verifiedEmailOnly bool
+	updated           *entity.Profile
+	err               error
+	updateErr         error
 }
 
 func (f *fakeProfileService) CreateProfile(context.Context, *entity.Profile) error {
 	return f.err
 }
 
+func (f *fakeProfileService) GetProfileByID(context.Context, int) (*entity.Profile, error) {
+	return f.profile, f.err
+}
+
+func (f *fakeProfileService) GetProfileByUserID(context.Context, int) (*entity.Profile, error) {
+	return f.profile, f.err
+}
+
+func (f *fakeProfileService) GetProfileCandidate(context.Context, int) (*entity.ProfileCandidate, error) {
+	return f.candidate, f.err
+}
+
+func (f *fakeProfileService) UpdateProfile(_ context.Context, profile *entity.Profile) error {
+	f.updated = profile
+
+	return f.updateErr
+}
+
+func (f *fakeProfileService) GetDiscoverableProfiles(_ context.Context, _ int, _ time.Time, _, _ int, verifiedEmailOnly bool) ([]*entity.ProfileCandidate, error) {
+	f.verifiedEmailOnly = verifiedEmailOnly
+
+	return f.candidates, f.err
+}
+
 type fakeAuth struct {
-	token string
+	token        string
+	claims       *entity.TokenClaims
+	actionClaims *entity.ActionTokenClaims
+	jwks         *entity.JSONWebKeySet
+	err          error
+}
+
+func (f *fakeAuth) GenerateToken(userID int, email, role string) (*entity.TokenClaims, string, error) {
+	return &entity.TokenClaims{ID: "access-jti", UserID: userID, Email: email, Role: role}, f.token, f.err
+}
+
+func (f *fakeAuth) ValidateToken(string) (*entity.TokenClaims, error) {
+	return f.claims, f.err
+}
+
+func (f *fakeAuth) GenerateActionToken(string, int, string, time.Duration) (*entity.ActionTokenClaims, string, error) {
+	return f.actionClaims, f.token, f.err
+}
+
+func (f *fakeAuth) ValidateActionToken(string, string) (*entity.ActionTokenClaims, error) {
+	return f.actionClaims, f.err
+}
+
+func (f *fakeAuth) JWKS() *entity.JSONWebKeySet {
+	return f.jwks
+}
+
+type fakeUserTokenService struct {
+	userToken       *entity.UserToken
+	recent          bool
+	createdToken    string
+	createdIP       string
+	usedPurpose     string
+	revokedPurpose  string
+	deletedPurposes []string
+	err             error
+}
+
+func (f *fakeUserTokenService) IssueUserToken(context.Context, int, string, string, time.Duration) (string, error) {
+	f.createdToken = "opaque-token"
+
+	return f.createdToken, f.err
+}
+
+func (f *fakeUserTokenService) CreateUserToken(_ context.Context, _ int, _, token, _ string, _ time.Time) error {
+	f.createdToken = token
+
+	return f.err
+}
+
+func (f *fakeUserTokenService) UseUserToken(_ context.Context, purpose, _ string) (*entity.UserToken, error) {
+	f.usedPurpose = purpose
+
+	return f.userToken, f.err
+}
+
+func (f *fakeUserTokenService) RevokeUserTokens(_ context.Context, _ int, purpose string) error {
+	f.revokedPurpose = purpose
+
+	return f.err
+}
+
+func (f *fakeUserTokenService) HasRecentUserToken(context.Context, int, string, time.Duration) (bool, error) {
+	return f.recent, f.err
+}
+
+func (f *fakeUserTokenService) IssueIPAddressToken(_ context.Context, _, ipAddress string, _ time.Duration, _ int) (string, error) {
+	f.createdToken = "opaque-token"
+	f.createdIP = ipAddress
+
+	return f.createdToken, f.err
+}
+
+func (f *fakeUserTokenService) DeleteUnusableUserTokens(_ context.Context, purpose string) error {
+	f.deletedPurposes = append(f.deletedPurposes, purpose)
+
+	return nil
+}
+
+type fakeLoginThrottleService struct {
+	checkErr        error
+	failedAccounts  []string
+	failedUserIDs   []*int
+	resetEmails     []string
+	recordFailedErr error
+}
+
+func (f *fakeLoginThrottleService) CheckLogin(context.Context, string, string) error {
+	return f.checkErr
+}
+
+func (f *fakeLoginThrottleService) RecordFailedLogin(_ context.Context, account, _ string, userID *int) error {
+	f.failedAccounts = append(f.failedAccounts, account)
+	f.failedUserIDs = append(f.failedUserIDs, userID)
+
+	return f.recordFailedErr
+}
+
+func (f *fakeLoginThrottleService) ResetFailedLogins(_ context.Context, email string) error {
+	f.resetEmails = append(f.resetEmails, email)
+
+	return nil
+}
+
+type fakePasswordHasher struct {
+	hash        func(password string) (string, error)
+	verify      func(password, hashedPassword string) bool
+	needsRehash bool
+}
+
+func (f *fakePasswordHasher) Hash(password string) (string, error) {
+	return f.hash(password)
+}
+
+func (f *fakePasswordHasher) Verify(password, hashedPassword string) bool {
+	return f.verify(password, hashedPassword)
+}
+
+func (f *fakePasswordHasher) NeedsRehash(string) bool {
+	return f.needsRehash
+}
+
+type fakeTwoFactorService struct {
+	enabled       bool
+	secret        string
+	recoveryCodes []string
+	verifyErr     error
+	verifiedCodes []string
+	err           error
+}
+
+func (f *fakeTwoFactorService) IsEnabled(context.Context, int) (bool, error) {
+	return f.enabled, f.err
+}
+
+func (f *fakeTwoFactorService) Enroll(context.Context, int) (string, error) {
+	return f.secret, f.err
+}
+
+func (f *fakeTwoFactorService) Confirm(context.Context, int, string) ([]string, error) {
+	return f.recoveryCodes, f.err
+}
+
+func (f *fakeTwoFactorService) VerifyCode(_ context.Context, _ int, code string) error {
+	f.verifiedCodes = append(f.verifiedCodes, code)
+
+	return f.verifyErr
+}
+
+func (f *fakeTwoFactorService) UseRecoveryCode(_ context.Context, _ int, recoveryCode string) error {
+	f.verifiedCodes = append(f.verifiedCodes, recoveryCode)
+
+	return f.verifyErr
+}
+
+type fakeSessionService struct {
+	sessions         []*entity.Session
+	createdTokenIDs  []string
+	refreshedTokenID string
+	deletedTokenID   string
+	deletedUserID    int
+	err              error
+}
+
+func (f *fakeSessionService) CreateSession(_ context.Context, _ int, tokenID, _, _, _ string) error {
+	f.createdTokenIDs = append(f.createdTokenIDs, tokenID)
+
+	return f.err
+}
+
+func (f *fakeSessionService) RefreshSession(_ context.Context, _, tokenID string) error {
+	f.refreshedTokenID = tokenID
+
+	return f.err
+}
+
+func (f *fakeSessionService) TouchSession(context.Context, int, string) error {
+	return f.err
+}
+
+func (f *fakeSessionService) GetUserSessions(context.Context, int, time.Time) ([]*entity.Session, error) {
+	return f.sessions, f.err
+}
+
+func (f *fakeSessionService) DeleteSession(_ context.Context, id, userID int) (*entity.Session, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	for _, session := range f.sessions {
+		if session.ID == id && session.UserID == userID {
+			return session, nil
+		}
+	}
+
+	return nil, apperror.ErrNotFound
+}
+
+func (f *fakeSessionService) DeleteSessionByTokenID(_ context.Context, tokenID string) error {
+	f.deletedTokenID = tokenID
+
+	return f.err
+}
+
+func (f *fakeSessionService) DeleteUserSessions(_ context.Context, userID int) error {
+	f.deletedUserID = userID
+
+	return f.err
+}
+
+type fakePhoneOTPService struct {
+	code          string
+	issuedPhones  []string
+	issuedIPs     []string
+	verifiedCodes []string
+	issueErr      error
+	verifyErr     error
+}
+
+func (f *fakePhoneOTPService) IssueOTP(_ context.Context, phone, ipAddress string) (*entity.PhoneOTP, string, error) {
+	if f.issueErr != nil {
+		return nil, "", f.issueErr
+	}
+
+	f.issuedPhones = append(f.issuedPhones, phone)
+	f.issuedIPs = append(f.issuedIPs, ipAddress)
+	createdAt := time.Now().UTC()
+
+	return entity.NewPhoneOTP(phone, ipAddress, "hash", createdAt.Add(5*time.Minute), createdAt), f.code, nil
+}
+
+func (f *fakePhoneOTPService) VerifyOTP(_ context.Context, _, code string) error {
+	f.verifiedCodes = append(f.verifiedCodes, code)
+
+	return f.verifyErr
+}
+
+type fakeUserIdentityService struct {
+	userIdentity *entity.UserIdentity
+	linked       []*entity.UserIdentity
+	err          error
+}
+
+func (f *fakeUserIdentityService) GetUserIdentity(context.Context, string, string) (*entity.UserIdentity, error) {
+	if f.err == nil && f.userIdentity == nil {
+		return nil, apperror.ErrNotFound
+	}
+
+	return f.userIdentity, f.err
+}
+
+func (f *fakeUserIdentityService) LinkUserIdentity(_ context.Context, userID int, identity *entity.ExternalIdentity) error {
+	f.linked = append(f.linked, entity.NewUserIdentity(userID, identity.Provider, identity.Subject, identity.Email, time.Now().UTC()))
+
+	return f.err
+}
+
+type fakePhotoService struct {
+	key     string
+	urls    *entity.PhotoURLs
+	deleted []string
+	err     error
+}
+
+func (f *fakePhotoService) StorePhoto(context.Context, int, *entity.PhotoUpload) (string, error) {
+	return f.key, f.err
+}
+
+func (f *fakePhotoService) DeletePhoto(_ context.Context, key string) error {
+	f.deleted = append(f.deleted, key)
+
+	return nil
+}
+
+func (f *fakePhotoService) GetPhotoURLs(_ context.Context, key string) (*entity.PhotoURLs, error) {
+	if key == "" {
+		return nil, nil
+	}
+
+	return f.urls, nil
+}
+
+type fakeIdentityProvider struct {
+	identity *entity.ExternalIdentity
+	err      error
+}
+
+func (f *fakeIdentityProvider) Name() string {
+	return "test"
+}
+
+func (f *fakeIdentityProvider) VerifyIDToken(context.Context, string, string) (*entity.ExternalIdentity, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	identity := *f.identity
+
+	return &identity, nil
+}
+
+type fakeSMSSender struct {
+	messages []*entity.SMS
+	err      error
+}
+
+func (f *fakeSMSSender) Send(_ context.Context, sms *entity.SMS) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.messages = append(f.messages, sms)
+
+	return nil
+}
+
+type fakeMailer struct {
+	mails []*entity.Mail
 	err   error
 }
 
-func (f *fakeAuth) GenerateToken(string, string) (string, error) {
-	return f.token, f.err
+func (f *fakeMailer) Send(_ context.Context, mail *entity.Mail) error {
+	if f.err != nil {
+		return f.err
+	}
+
+	f.mails = append(f.mails, mail)
+
+	return nil
+}
+
+type fakeUnitOfWork struct {
+	committed  bool
+	rolledBack bool
+}
+
+func (f *fakeUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	err := fn(ctx)
+	if err != nil {
+		f.rolledBack = true
+
+		return err
+	}
+
+	f.committed = true
+
+	return nil
+}
+
+type fakeConfig struct {
+	refreshTokenExpiration            time.Duration
+	dailySwipeLimit                   int
+	emailVerificationTokenExpiration  time.Duration
+	emailVerificationResendInterval   time.Duration
+	verifiedEmailRequiredForLogin     bool
+	verifiedEmailRequiredForDiscovery bool
+}
+
+func (f *fakeConfig) GetRefreshTokenExpiration() time.Duration {
+	return f.refreshTokenExpiration
+}
+
+func (f *fakeConfig) GetDailySwipeLimit() int {
+	return f.dailySwipeLimit
+}
+
+func (f *fakeConfig) GetSwipeResetLocation() *time.Location {
+	return time.UTC
+}
+
+func (f *fakeConfig) GetEmailVerificationTokenExpiration() time.Duration {
+	return f.emailVerificationTokenExpiration
+}
+
+func (f *fakeConfig) GetEmailVerificationResendInterval() time.Duration {
+	return f.emailVerificationResendInterval
+}
+
+func (f *fakeConfig) GetEmailVerificationURL() string {
+	return "http://localhost/verify-email"
+}
+
+func (f *fakeConfig) GetPasswordResetTokenExpiration() time.Duration {
+	return time.Hour
+}
+
+func (f *fakeConfig) GetPasswordResetRequestInterval() time.Duration {
+	return time.Minute
+}
+
+func (f *fakeConfig) GetPasswordResetURL() string {
+	return "http://localhost/reset-password"
+}
+
+func (f *fakeConfig) GetEmailChangeTokenExpiration() time.Duration {
+	return 24 * time.Hour
+}
+
+func (f *fakeConfig) GetEmailChangeURL() string {
+	return "http://localhost/confirm-email-change"
+}
+
+func (f *fakeConfig) GetTwoFactorIssuer() string {
+	return "Dating Service"
+}
+
+func (f *fakeConfig) GetTwoFactorChallengeExpiration() time.Duration {
+	return 5 * time.Minute
+}
+
+func (f *fakeConfig) GetOIDCNonceExpiration() time.Duration {
+	return 10 * time.Minute
+}
+
+func (f *fakeConfig) GetOIDCNonceMaxPerIP() int {
+	return 20
+}
+
+func (f *fakeConfig) IsVerifiedEmailRequiredForLogin() bool {
+	return f.verifiedEmailRequiredForLogin
+}
+
+func (f *fakeConfig) IsVerifiedEmailRequiredForDiscovery() bool {
+	return f.verifiedEmailRequiredForDiscovery
+}
+
+func Test_userUsecase_Signup(t *testing.T) {
+	type fields struct {
+		userService         service.UserService
+		profileService      service.ProfileService
+		config              domain.Config
+		auth                domain.Auth
+		mailer              domain.Mailer
+		hashPassword        func(password string) (string, error)
+		isValidPasswordHash func(password, hashedPassword string) bool
+	}
+	type args struct {
+		req *entity.UserSignupRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Failed: Invalid request",
+			args: args{
+				req: &entity.UserSignupRequest{
+					Email: "",
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Password hash failed",
+			fields: fields{
+				hashPassword: func(string) (string, error) {
+					return "", bcrypt.ErrPasswordTooLong
+				},
+			},
+			args: args{
+				req: mockSuccessSignupRequest,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Create user failed",
+			fields: fields{
+				userService: &fakeUserService{
+					id:  0,
+					err: apperror.ErrConflict,
+				},
+				hashPassword: func(string) (string, error) {
+					return mockPassword, nil
+				},
+			},
+			args: args{
+				req: mockSuccessSignupRequest,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Create profile failed",
+			fields: fields{
+				userService: &fakeUserService{
+					id:  1,
+					err: nil,
+				},
+				profileService: &fakeProfileService{
+					err: apperror.ErrConflict,
+				},
+				hashPassword: func(string) (string, error) {
+					return mockPassword, nil
+				},
+			},
+			args: args{
+				req: mockSuccessSignupRequest,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Failed: Send verification email failed",
+			fields: fields{
+				userService: &fakeUserService{
+					id:  1,
+					err: nil,
+				},
+				profileService: &fakeProfileService{
+					err: nil,
+				},
+				config: &fakeConfig{},
+				auth: &fakeAuth{
+					token:        "token",
+					actionClaims: mockActionTokenClaims,
+				},
+				mailer: &fakeMailer{
+					err: errors.New("Failed to send an email"),
+				},
+				hashPassword: func(string) (string, error) {
+					return mockPassword, nil
+				},
+			},
+			args: args{
+				req: mockSuccessSignupRequest,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				userService: &fakeUserService{
+					id:  1,
+					err: nil,
+				},
+				profileService: &fakeProfileService{
+					err: nil,
+				},
+				config: &fakeConfig{},
+				auth: &fakeAuth{
+					token:        "token",
+					actionClaims: mockActionTokenClaims,
+				},
+				mailer: &fakeMailer{},
+				hashPassword: func(string) (string, error) {
+					return mockPassword, nil
+				},
+			},
+			args: args{
+				req: mockSuccessSignupRequest,
+			},
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			usecase := &userUsecase{
+				userService:      test.fields.userService,
+				profileService:   test.fields.profileService,
+				userTokenService: &fakeUserTokenService{},
+				unitOfWork:       &fakeUnitOfWork{},
+				config:           test.fields.config,
+				auth:             test.fields.auth,
+				mailer:           test.fields.mailer,
+				passwordHasher:   &fakePasswordHasher{hash: test.fields.hashPassword, verify: test.fields.isValidPasswordHash},
+			}
+			if err := usecase.Signup(context.Background(), test.args.req); (err != nil) != test.wantErr {
+				t.Errorf("userUsecase.Signup() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_Signup_Rollback_On_Create_Profile_Failure(t *testing.T) {
+	unitOfWork := &fakeUnitOfWork{}
+	u := NewUserUsecase(
+		&fakeUserService{id: 1}, &fakeProfileService{err: apperror.ErrConflict}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+		&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{}, unitOfWork,
+		&fakeConfig{}, &fakeAuth{}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{hash: mockHashPassword, verify: mockIsValidPasswordHash}, nil,
+	)
+	err := u.Signup(context.Background(), mockSuccessSignupRequest)
+	if !errors.Is(err, apperror.ErrConflict) {
+		t.Fatalf("userUsecase.Signup() error = %v, want %v", err, apperror.ErrConflict)
+	}
+	if !unitOfWork.rolledBack || unitOfWork.committed {
+		t.Error("userUsecase.Signup() did not roll back the created user")
+	}
+}
+
+func Test_userUsecase_Signup_Commit_On_Success(t *testing.T) {
+	unitOfWork := &fakeUnitOfWork{}
+	userTokenService := &fakeUserTokenService{}
+	mailer := &fakeMailer{}
+	u := NewUserUsecase(
+		&fakeUserService{id: 1}, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, userTokenService,
+		&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+		unitOfWork, &fakeConfig{}, &fakeAuth{token: "token", actionClaims: mockActionTokenClaims}, mailer, &fakeSMSSender{},
+		&fakePasswordHasher{hash: mockHashPassword, verify: mockIsValidPasswordHash}, nil,
+	)
+	err := u.Signup(context.Background(), mockSuccessSignupRequest)
+	if err != nil {
+		t.Fatalf("userUsecase.Signup() error = %v", err)
+	}
+	if !unitOfWork.committed || unitOfWork.rolledBack {
+		t.Error("userUsecase.Signup() did not commit the created user and profile")
+	}
+	if userTokenService.createdToken != mockActionTokenClaims.ID {
+		t.Errorf("userUsecase.Signup() recorded token %v, want %v", userTokenService.createdToken, mockActionTokenClaims.ID)
+	}
+	if len(mailer.mails) != 1 || mailer.mails[0].To != "user@email.com" || !strings.Contains(mailer.mails[0].Body, "http://localhost/verify-email?token=token") {
+		t.Errorf("userUsecase.Signup() sent %+v, want a verification link to user@email.com", mailer.mails)
+	}
+}
+
+func Test_userUsecase_Signup_Send_Email_After_Commit(t *testing.T) {
+	unitOfWork := &fakeUnitOfWork{}
+	u := NewUserUsecase(
+		&fakeUserService{id: 1}, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+		&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{}, unitOfWork,
+		&fakeConfig{}, &fakeAuth{token: "token", actionClaims: mockActionTokenClaims}, &fakeMailer{err: errors.New("Failed to send an email")}, &fakeSMSSender{},
+		&fakePasswordHasher{hash: mockHashPassword, verify: mockIsValidPasswordHash}, nil,
+	)
+	err := u.Signup(context.Background(), mockSuccessSignupRequest)
+	if err == nil {
+		t.Fatal("userUsecase.Signup() error = nil, want the mailer error")
+	}
+	if !unitOfWork.committed {
+		t.Error("userUsecase.Signup() sent the email before committing the created user")
+	}
+}
+
+func Test_userUsecase_Login(t *testing.T) {
+	type fields struct {
+		userService         service.UserService
+		profileService      service.ProfileService
+		refreshTokenService service.RefreshTokenService
+		config              domain.Config
+		auth                domain.Auth
+		hashPassword        func(password string) (string, error)
+		isValidPasswordHash func(password, hashedPassword string) bool
+	}
+	type args struct {
+		req *entity.UserLoginRequest
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    *entity.UserLoginResponse
+		wantErr bool
+	}{
+		{
+			name: "Failed: Invalid request",
+			args: args{
+				req: &entity.UserLoginRequest{
+					Email: "",
+				},
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Get user by email failed",
+			fields: fields{
+				userService: &fakeUserService{
+					user: &entity.User{},
+					err:  schema.ErrUnsupportedDataType,
+				},
+			},
+			args: args{
+				req: mockSuccessLoginRequest,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Invalid password hash",
+			fields: fields{
+				userService: mockSuccessUserService,
+				isValidPasswordHash: func(string, string) bool {
+					return false
+				},
+			},
+			args: args{
+				req: mockSuccessLoginRequest,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Email not verified",
+			fields: fields{
+				userService: mockSuccessUserService,
+				config: &fakeConfig{
+					verifiedEmailRequiredForLogin: true,
+				},
+				isValidPasswordHash: mockIsValidPasswordHash,
+			},
+			args: args{
+				req: mockSuccessLoginRequest,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Generate token failed",
+			fields: fields{
+				userService: mockSuccessUserService,
+				auth: &fakeAuth{
+					err: errors.New("Failed to generate a token"),
+				},
+				config:              &fakeConfig{},
+				isValidPasswordHash: mockIsValidPasswordHash,
+			},
+			args: args{
+				req: mockSuccessLoginRequest,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Failed: Create refresh token failed",
+			fields: fields{
+				userService: mockSuccessUserService,
+				refreshTokenService: &fakeRefreshTokenService{
+					err: schema.ErrUnsupportedDataType,
+				},
+				auth: &fakeAuth{
+					token: "token",
+				},
+				config:              &fakeConfig{},
+				isValidPasswordHash: mockIsValidPasswordHash,
+			},
+			args: args{
+				req: mockSuccessLoginRequest,
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				userService: mockSuccessUserService,
+				refreshTokenService: &fakeRefreshTokenService{
+					token: "refresh-token",
+				},
+				auth: &fakeAuth{
+					token: "token",
+					err:   nil,
+				},
+				config:              &fakeConfig{},
+				isValidPasswordHash: mockIsValidPasswordHash,
+			},
+			args: args{
+				req: mockSuccessLoginRequest,
+			},
+			want:    entity.NewUserLoginResponse("token", "refresh-token"),
+			wantErr: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserUsecase(
+				test.fields.userService, test.fields.profileService, test.fields.refreshTokenService, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				&fakeUnitOfWork{}, test.fields.config, test.fields.auth, &fakeMailer{}, &fakeSMSSender{},
+				&fakePasswordHasher{hash: test.fields.hashPassword, verify: test.fields.isValidPasswordHash}, nil,
+			)
+			got, err := u.Login(context.Background(), test.args.req)
+			if (err != nil) != test.wantErr {
+				t.Errorf("userUsecase.Login() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("userUsecase.Login() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_Login_Failed_Attempts(t *testing.T) {
+	tests := []struct {
+		name                 string
+		userService          *fakeUserService
+		loginThrottleService *fakeLoginThrottleService
+		isValidPasswordHash  func(password, hashedPassword string) bool
+		wantErr              error
+		wantFailedUserIDs    []*int
+		wantResetEmails      []string
+	}{
+		{
+			name:                 "Failed: Locked out",
+			userService:          mockSuccessUserService,
+			loginThrottleService: &fakeLoginThrottleService{checkErr: apperror.New(apperror.KindQuotaExceeded, "Too many failed login attempts")},
+			isValidPasswordHash:  mockIsValidPasswordHash,
+			wantErr:              apperror.ErrQuotaExceeded,
+		},
+		{
+			name:                 "Failed: Unknown email",
+			userService:          &fakeUserService{err: apperror.New(apperror.KindNotFound, "User not found")},
+			loginThrottleService: &fakeLoginThrottleService{},
+			isValidPasswordHash: func(password, hashedPassword string) bool {
+				return hashedPassword == mockPassword
+			},
+			wantErr:           apperror.ErrUnauthorized,
+			wantFailedUserIDs: []*int{nil},
+		},
+		{
+			name:                 "Failed: Wrong password",
+			userService:          mockSuccessUserService,
+			loginThrottleService: &fakeLoginThrottleService{},
+			isValidPasswordHash:  func(string, string) bool { return false },
+			wantErr:              apperror.ErrUnauthorized,
+			wantFailedUserIDs:    []*int{&mockSuccessUserService.user.ID},
+		},
+		{
+			name:                 "Success",
+			userService:          mockSuccessUserService,
+			loginThrottleService: &fakeLoginThrottleService{},
+			isValidPasswordHash:  mockIsValidPasswordHash,
+			wantResetEmails:      []string{mockSuccessLoginRequest.Email},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserUsecase(
+				test.userService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+				test.loginThrottleService, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{}, &fakeUnitOfWork{},
+				&fakeConfig{}, &fakeAuth{}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{hash: mockHashPassword, verify: test.isValidPasswordHash}, nil,
+			)
+			_, err := u.Login(context.Background(), mockSuccessLoginRequest)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.Login() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(test.loginThrottleService.failedUserIDs, test.wantFailedUserIDs) {
+				t.Errorf("userUsecase.Login() recorded failed attempts of %v, want %v", test.loginThrottleService.failedUserIDs, test.wantFailedUserIDs)
+			}
+			if !reflect.DeepEqual(test.loginThrottleService.resetEmails, test.wantResetEmails) {
+				t.Errorf("userUsecase.Login() reset failed attempts of %v, want %v", test.loginThrottleService.resetEmails, test.wantResetEmails)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_Login_Rehash_Password(t *testing.T) {
+	tests := []struct {
+		name         string
+		needsRehash  bool
+		wantPassword string
+	}{
+		{
+			name:         "Success: Hash is current",
+			needsRehash:  false,
+			wantPassword: "",
+		},
+		{
+			name:         "Success: Hash is rehashed",
+			needsRehash:  true,
+			wantPassword: "rehashed",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userService := &fakeUserService{user: mockSuccessUserService.user}
+			passwordHasher := &fakePasswordHasher{
+				hash:        func(string) (string, error) { return "rehashed", nil },
+				verify:      mockIsValidPasswordHash,
+				needsRehash: test.needsRehash,
+			}
+			u := NewUserUsecase(
+				userService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{token: "token"}, &fakeMailer{}, &fakeSMSSender{}, passwordHasher, nil,
+			)
+			_, err := u.Login(context.Background(), mockSuccessLoginRequest)
+			if err != nil {
+				t.Fatalf("userUsecase.Login() error = %v", err)
+			}
+			if userService.password != test.wantPassword {
+				t.Errorf("userUsecase.Login() saved password = %q, want %q", userService.password, test.wantPassword)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_Login_Creates_Session(t *testing.T) {
+	sessionService := &fakeSessionService{}
+	u := NewUserUsecase(
+		mockSuccessUserService, &fakeProfileService{}, &fakeRefreshTokenService{token: "refresh-token"}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+		&fakeLoginThrottleService{}, &fakeTwoFactorService{}, sessionService, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+		&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{token: "token"}, &fakeMailer{}, &fakeSMSSender{},
+		&fakePasswordHasher{verify: mockIsValidPasswordHash}, nil,
+	)
+	_, err := u.Login(domain.WithUserAgent(context.Background(), "curl/8.4.0"), mockSuccessLoginRequest)
+	if err != nil {
+		t.Fatalf("userUsecase.Login() error = %v", err)
+	}
+	if !reflect.DeepEqual(sessionService.createdTokenIDs, []string{"access-jti"}) {
+		t.Errorf("userUsecase.Login() created sessions %v, want one for the access token", sessionService.createdTokenIDs)
+	}
+
+	sessionService.err = schema.ErrUnsupportedDataType
+	if _, err := u.Login(context.Background(), mockSuccessLoginRequest); !errors.Is(err, schema.ErrUnsupportedDataType) {
+		t.Errorf("userUsecase.Login() error = %v when the session cannot be created, want %v", err, schema.ErrUnsupportedDataType)
+	}
+}
+
+func Test_userUsecase_RefreshToken(t *testing.T) {
+	mockRotatedRefreshToken := &entity.RefreshToken{ID: 1, UserID: 1, FamilyID: "family"}
+	type fields struct {
+		userService         service.UserService
+		refreshTokenService service.RefreshTokenService
+		auth                domain.Auth
+	}
+	tests := []struct {
+		name           string
+		fields         fields
+		sessionErr     error
+		req            *entity.RefreshTokenRequest
+		want           *entity.UserLoginResponse
+		wantErr        error
+		wantRolledBack bool
+	}{
+		{
+			name:    "Failed: Invalid request",
+			req:     &entity.RefreshTokenRequest{},
+			wantErr: apperror.ErrValidation,
+		},
+		{
+			name: "Failed: Rotate refresh token failed",
+			fields: fields{
+				refreshTokenService: &fakeRefreshTokenService{
+					err: apperror.New(apperror.KindUnauthorized, "Invalid or expired refresh token"),
+				},
+			},
+			req:     &entity.RefreshTokenRequest{RefreshToken: "refresh-token"},
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name: "Failed: User no longer exists",
+			fields: fields{
+				userService: &fakeUserService{
+					err: apperror.ErrNotFound,
+				},
+				refreshTokenService: &fakeRefreshTokenService{
+					refreshToken: mockRotatedRefreshToken,
+					token:        "new-refresh-token",
+				},
+			},
+			req:            &entity.RefreshTokenRequest{RefreshToken: "refresh-token"},
+			wantErr:        apperror.ErrUnauthorized,
+			wantRolledBack: true,
+		},
+		{
+			name: "Failed: Generate token failed",
+			fields: fields{
+				userService: mockSuccessUserService,
+				refreshTokenService: &fakeRefreshTokenService{
+					refreshToken: mockRotatedRefreshToken,
+					token:        "new-refresh-token",
+				},
+				auth: &fakeAuth{
+					err: schema.ErrUnsupportedDataType,
+				},
+			},
+			req:            &entity.RefreshTokenRequest{RefreshToken: "refresh-token"},
+			wantErr:        schema.ErrUnsupportedDataType,
+			wantRolledBack: true,
+		},
+		{
+			name: "Failed: Session deleted",
+			fields: fields{
+				userService: mockSuccessUserService,
+				refreshTokenService: &fakeRefreshTokenService{
+					refreshToken: mockRotatedRefreshToken,
+					token:        "new-refresh-token",
+				},
+				auth: &fakeAuth{
+					token: "token",
+				},
+			},
+			sessionErr:     apperror.New(apperror.KindUnauthorized, "Invalid or expired refresh token"),
+			req:            &entity.RefreshTokenRequest{RefreshToken: "refresh-token"},
+			wantErr:        apperror.ErrUnauthorized,
+			wantRolledBack: true,
+		},
+		{
+			name: "Success",
+			fields: fields{
+				userService: mockSuccessUserService,
+				refreshTokenService: &fakeRefreshTokenService{
+					refreshToken: mockRotatedRefreshToken,
+					token:        "new-refresh-token",
+				},
+				auth: &fakeAuth{
+					token: "token",
+				},
+			},
+			req:  &entity.RefreshTokenRequest{RefreshToken: "refresh-token"},
+			want: entity.NewUserLoginResponse("token", "new-refresh-token"),
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sessionService := &fakeSessionService{err: test.sessionErr}
+			unitOfWork := &fakeUnitOfWork{}
+			u := NewUserUsecase(
+				test.fields.userService, &fakeProfileService{}, test.fields.refreshTokenService, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, sessionService, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				unitOfWork, &fakeConfig{}, test.fields.auth, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+			)
+			got, err := u.RefreshToken(context.Background(), test.req)
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("userUsecase.RefreshToken() error = %v, wantErr %v", err, test.wantErr)
+
+				return
+			}
+			if unitOfWork.rolledBack != test.wantRolledBack {
+				t.Errorf("userUsecase.RefreshToken() rolled back = %v, want %v", unitOfWork.rolledBack, test.wantRolledBack)
+			}
+			if !reflect.DeepEqual(got, test.want) {
+				t.Errorf("userUsecase.RefreshToken() = %v, want %v", got, test.want)
+			}
+			if test.wantErr == nil && sessionService.refreshedTokenID != "access-jti" {
+				t.Errorf("userUsecase.RefreshToken() moved the session to %v, want the new access token", sessionService.refreshedTokenID)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_Logout(t *testing.T) {
+	claims := &entity.TokenClaims{ID: "jti"}
+	tests := []struct {
+		name             string
+		req              *entity.LogoutRequest
+		refreshErr       error
+		wantRevokedToken string
+		wantErr          bool
+	}{
+		{
+			name:       "Failed: Revoke refresh token failed",
+			req:        &entity.LogoutRequest{RefreshToken: "refresh-token"},
+			refreshErr: schema.ErrUnsupportedDataType,
+			wantErr:    true,
+		},
+		{
+			name:             "Success: Without refresh token",
+			req:              &entity.LogoutRequest{},
+			wantRevokedToken: "",
+		},
+		{
+			name:             "Success: With refresh token",
+			req:              &entity.LogoutRequest{RefreshToken: "refresh-token"},
+			wantRevokedToken: "refresh-token",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			refreshTokenService := &fakeRefreshTokenService{err: test.refreshErr}
+			tokenRevocationService := &fakeTokenRevocationService{}
+			sessionService := &fakeSessionService{}
+			u := NewUserUsecase(
+				mockSuccessUserService, &fakeProfileService{}, refreshTokenService, tokenRevocationService, &fakeUserTokenService{},
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, sessionService, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+			)
+			err := u.Logout(context.Background(), mockSuccessUserService.user, claims, test.req)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("userUsecase.Logout() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr {
+				return
+			}
+			if refreshTokenService.revokedToken != test.wantRevokedToken {
+				t.Errorf("userUsecase.Logout() revoked refresh token = %v, want %v", refreshTokenService.revokedToken, test.wantRevokedToken)
+			}
+			if !reflect.DeepEqual(tokenRevocationService.revokedTokenIDs, []string{"jti"}) {
+				t.Errorf("userUsecase.Logout() revoked tokens = %v, want [jti]", tokenRevocationService.revokedTokenIDs)
+			}
+			if sessionService.deletedTokenID != "jti" {
+				t.Errorf("userUsecase.Logout() deleted the session of %v, want jti", sessionService.deletedTokenID)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_LogoutAll(t *testing.T) {
+	refreshTokenService := &fakeRefreshTokenService{}
+	tokenRevocationService := &fakeTokenRevocationService{}
+	sessionService := &fakeSessionService{}
+	u := NewUserUsecase(
+		mockSuccessUserService, &fakeProfileService{}, refreshTokenService, tokenRevocationService, &fakeUserTokenService{},
+		&fakeLoginThrottleService{}, &fakeTwoFactorService{}, sessionService, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+		&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+	)
+	err := u.LogoutAll(context.Background(), mockSuccessUserService.user, &entity.TokenClaims{ID: "jti"})
+	if err != nil {
+		t.Fatalf("userUsecase.LogoutAll() error = %v", err)
+	}
+	if refreshTokenService.revokedUserID != mockSuccessUserService.user.ID {
+		t.Errorf("userUsecase.LogoutAll() did not revoke the refresh tokens of the user")
+	}
+	if !tokenRevocationService.revokedAll || !reflect.DeepEqual(tokenRevocationService.revokedTokenIDs, []string{"jti"}) {
+		t.Errorf("userUsecase.LogoutAll() did not revoke every token and the current one")
+	}
+	if sessionService.deletedUserID != mockSuccessUserService.user.ID {
+		t.Errorf("userUsecase.LogoutAll() did not delete the sessions of the user")
+	}
+}
+
+func Test_userUsecase_VerifyEmail(t *testing.T) {
+	mockUserToken := &entity.UserToken{ID: 1, UserID: 1, Purpose: entity.UserTokenPurposeEmailVerification, Email: "user@email.com"}
+	tests := []struct {
+		name             string
+		req              *entity.VerifyEmailRequest
+		userService      *fakeUserService
+		auth             *fakeAuth
+		userTokenService *fakeUserTokenService
+		wantErr          error
+	}{
+		{
+			name:             "Failed: Invalid request",
+			req:              &entity.VerifyEmailRequest{},
+			userService:      &fakeUserService{},
+			auth:             &fakeAuth{},
+			userTokenService: &fakeUserTokenService{},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Failed: Invalid signature",
+			req:              &entity.VerifyEmailRequest{Token: "token"},
+			userService:      &fakeUserService{},
+			auth:             &fakeAuth{err: errors.New("signature is invalid")},
+			userTokenService: &fakeUserTokenService{},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Failed: Token already used",
+			req:              &entity.VerifyEmailRequest{Token: "token"},
+			userService:      &fakeUserService{},
+			auth:             &fakeAuth{actionClaims: mockActionTokenClaims},
+			userTokenService: &fakeUserTokenService{err: apperror.New(apperror.KindValidation, "Invalid, expired or already used token")},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Failed: Email changed since the token was sent",
+			req:              &entity.VerifyEmailRequest{Token: "token"},
+			userService:      &fakeUserService{user: &entity.User{ID: 1, Email: "other@email.com"}},
+			auth:             &fakeAuth{actionClaims: mockActionTokenClaims},
+			userTokenService: &fakeUserTokenService{userToken: mockUserToken},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Success",
+			req:              &entity.VerifyEmailRequest{Token: "token"},
+			userService:      &fakeUserService{user: &entity.User{ID: 1, Email: "user@email.com"}},
+			auth:             &fakeAuth{actionClaims: mockActionTokenClaims},
+			userTokenService: &fakeUserTokenService{userToken: mockUserToken},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserUsecase(
+				test.userService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, test.userTokenService,
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				&fakeUnitOfWork{}, &fakeConfig{}, test.auth, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+			)
+			err := u.VerifyEmail(context.Background(), test.req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.VerifyEmail() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr == nil && test.userService.verifiedID != 1 {
+				t.Errorf("userUsecase.VerifyEmail() verified user %v, want 1", test.userService.verifiedID)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_ResendVerificationEmail(t *testing.T) {
+	verifiedAt := time.Now().UTC()
+	tests := []struct {
+		name             string
+		req              *entity.ResendVerificationEmailRequest
+		userService      *fakeUserService
+		userTokenService *fakeUserTokenService
+		wantErr          error
+		wantSent         bool
+	}{
+		{
+			name:             "Failed: Invalid request",
+			req:              &entity.ResendVerificationEmailRequest{Email: "email"},
+			userService:      &fakeUserService{},
+			userTokenService: &fakeUserTokenService{},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Success: Unknown email",
+			req:              &entity.ResendVerificationEmailRequest{Email: "user@email.com"},
+			userService:      &fakeUserService{err: apperror.ErrNotFound},
+			userTokenService: &fakeUserTokenService{},
+		},
+		{
+			name:             "Success: Already verified",
+			req:              &entity.ResendVerificationEmailRequest{Email: "user@email.com"},
+			userService:      &fakeUserService{user: &entity.User{ID: 1, Email: "user@email.com", EmailVerifiedAt: &verifiedAt}},
+			userTokenService: &fakeUserTokenService{},
+		},
+		{
+			name:             "Success: Throttled",
+			req:              &entity.ResendVerificationEmailRequest{Email: "user@email.com"},
+			userService:      &fakeUserService{user: &entity.User{ID: 1, Email: "user@email.com"}},
+			userTokenService: &fakeUserTokenService{recent: true},
+		},
+		{
+			name:             "Success: Sent",
+			req:              &entity.ResendVerificationEmailRequest{Email: "User@Email.com"},
+			userService:      &fakeUserService{user: &entity.User{ID: 1, Email: "user@email.com"}},
+			userTokenService: &fakeUserTokenService{},
+			wantSent:         true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mailer := &fakeMailer{}
+			u := NewUserUsecase(
+				test.userService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, test.userTokenService,
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{}, &fakeUnitOfWork{},
+				&fakeConfig{}, &fakeAuth{token: "token", actionClaims: mockActionTokenClaims}, mailer, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+			)
+			err := u.ResendVerificationEmail(context.Background(), test.req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.ResendVerificationEmail() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if sent := len(mailer.mails) == 1; sent != test.wantSent {
+				t.Errorf("userUsecase.ResendVerificationEmail() sent = %v, want %v", sent, test.wantSent)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_ForgotPassword(t *testing.T) {
+	errSend := errors.New("connection refused")
+	tests := []struct {
+		name             string
+		req              *entity.ForgotPasswordRequest
+		userService      *fakeUserService
+		userTokenService *fakeUserTokenService
+		mailer           *fakeMailer
+		wantErr          error
+		wantSent         bool
+	}{
+		{
+			name:             "Failed: Invalid request",
+			req:              &entity.ForgotPasswordRequest{},
+			userService:      &fakeUserService{},
+			userTokenService: &fakeUserTokenService{},
+			mailer:           &fakeMailer{},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Failed: Send email",
+			req:              &entity.ForgotPasswordRequest{Email: "user@email.com"},
+			userService:      &fakeUserService{user: &entity.User{ID: 1, Email: "user@email.com"}},
+			userTokenService: &fakeUserTokenService{},
+			mailer:           &fakeMailer{err: errSend},
+			wantErr:          errSend,
+		},
+		{
+			name:             "Success: Unknown email",
+			req:              &entity.ForgotPasswordRequest{Email: "user@email.com"},
+			userService:      &fakeUserService{err: apperror.ErrNotFound},
+			userTokenService: &fakeUserTokenService{},
+			mailer:           &fakeMailer{},
+		},
+		{
+			name:             "Success: Throttled",
+			req:              &entity.ForgotPasswordRequest{Email: "user@email.com"},
+			userService:      &fakeUserService{user: &entity.User{ID: 1, Email: "user@email.com"}},
+			userTokenService: &fakeUserTokenService{recent: true},
+			mailer:           &fakeMailer{},
+		},
+		{
+			name:             "Success: Sent",
+			req:              &entity.ForgotPasswordRequest{Email: "User@Email.com"},
+			userService:      &fakeUserService{user: &entity.User{ID: 1, Email: "user@email.com"}},
+			userTokenService: &fakeUserTokenService{},
+			mailer:           &fakeMailer{},
+			wantSent:         true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserUsecase(
+				test.userService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, test.userTokenService,
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{}, test.mailer, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+			)
+			err := u.ForgotPassword(context.Background(), test.req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.ForgotPassword() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if sent := len(test.mailer.mails) == 1; sent != test.wantSent {
+				t.Fatalf("userUsecase.ForgotPassword() sent = %v, want %v", sent, test.wantSent)
+			}
+			if test.wantSent && !strings.Contains(test.mailer.mails[0].Body, "token=opaque-token") {
+				t.Errorf("userUsecase.ForgotPassword() mail body = %q, want the reset link", test.mailer.mails[0].Body)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_ResetPassword(t *testing.T) {
+	mockUserToken := &entity.UserToken{ID: 1, UserID: 1, Purpose: entity.UserTokenPurposePasswordReset, Email: "user@email.com"}
+	tests := []struct {
+		name             string
+		req              *entity.ResetPasswordRequest
+		userService      *fakeUserService
+		userTokenService *fakeUserTokenService
+		wantErr          error
+	}{
+		{
+			name:             "Failed: Invalid request",
+			req:              &entity.ResetPasswordRequest{Token: "token", Password: "short"},
+			userService:      &fakeUserService{},
+			userTokenService: &fakeUserTokenService{},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Failed: Token already used",
+			req:              &entity.ResetPasswordRequest{Token: "token", Password: mockPassword},
+			userService:      &fakeUserService{},
+			userTokenService: &fakeUserTokenService{err: apperror.New(apperror.KindValidation, "Invalid, expired or already used token")},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Failed: User deleted",
+			req:              &entity.ResetPasswordRequest{Token: "token", Password: mockPassword},
+			userService:      &fakeUserService{err: apperror.ErrNotFound},
+			userTokenService: &fakeUserTokenService{userToken: mockUserToken},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Failed: Email changed since the token was sent",
+			req:              &entity.ResetPasswordRequest{Token: "token", Password: mockPassword},
+			userService:      &fakeUserService{user: &entity.User{ID: 1, Email: "other@email.com"}},
+			userTokenService: &fakeUserTokenService{userToken: mockUserToken},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Success",
+			req:              &entity.ResetPasswordRequest{Token: "token", Password: mockPassword},
+			userService:      &fakeUserService{user: &entity.User{ID: 1, Email: "user@email.com"}},
+			userTokenService: &fakeUserTokenService{userToken: mockUserToken},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			refreshTokenService := &fakeRefreshTokenService{}
+			tokenRevocationService := &fakeTokenRevocationService{}
+			u := NewUserUsecase(
+				test.userService, &fakeProfileService{}, refreshTokenService, tokenRevocationService, test.userTokenService,
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{hash: mockHashPassword}, nil,
+			)
+			err := u.ResetPassword(context.Background(), test.req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.ResetPassword() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr != nil {
+				return
+			}
+			if test.userService.password == "" {
+				t.Errorf("userUsecase.ResetPassword() did not update the password")
+			}
+			if test.userTokenService.revokedPurpose != entity.UserTokenPurposePasswordReset {
+				t.Errorf("userUsecase.ResetPassword() did not revoke the other reset tokens")
+			}
+			if refreshTokenService.revokedUserID != 1 || !tokenRevocationService.revokedAll {
+				t.Errorf("userUsecase.ResetPassword() did not revoke the sessions of the user")
+			}
+		})
+	}
+}
+
+func Test_userUsecase_ChangePassword(t *testing.T) {
+	user := &entity.User{ID: 1, Email: "user@email.com", Password: "hashed-password"}
+	tests := []struct {
+		name                string
+		user                *entity.User
+		req                 *entity.ChangePasswordRequest
+		checkErr            error
+		isValidPasswordHash func(password, hashedPassword string) bool
+		wantErr             error
+		wantFailedAccounts  []string
+		wantResetAccounts   []string
+	}{
+		{
+			name:                "Failed: Invalid request",
+			user:                user,
+			req:                 &entity.ChangePasswordRequest{CurrentPassword: mockPassword, NewPassword: "short"},
+			isValidPasswordHash: mockIsValidPasswordHash,
+			wantErr:             apperror.ErrValidation,
+		},
+		{
+			name:                "Failed: No password",
+			user:                &entity.User{ID: 1, Phone: "+6281234567890"},
+			req:                 &entity.ChangePasswordRequest{CurrentPassword: mockPassword, NewPassword: "new-password"},
+			isValidPasswordHash: mockIsValidPasswordHash,
+			wantErr:             apperror.ErrConflict,
+		},
+		{
+			name:                "Failed: Locked out",
+			user:                user,
+			req:                 &entity.ChangePasswordRequest{CurrentPassword: mockPassword, NewPassword: "new-password"},
+			checkErr:            apperror.New(apperror.KindQuotaExceeded, "Too many failed login attempts"),
+			isValidPasswordHash: mockIsValidPasswordHash,
+			wantErr:             apperror.ErrQuotaExceeded,
+		},
+		{
+			name:                "Failed: Incorrect current password",
+			user:                user,
+			req:                 &entity.ChangePasswordRequest{CurrentPassword: "wrong-password", NewPassword: "new-password"},
+			isValidPasswordHash: func(string, string) bool { return false },
+			wantErr:             apperror.ErrValidation,
+			wantFailedAccounts:  []string{"user@email.com"},
+		},
+		{
+			name:                "Success",
+			user:                user,
+			req:                 &entity.ChangePasswordRequest{CurrentPassword: mockPassword, NewPassword: "new-password"},
+			isValidPasswordHash: mockIsValidPasswordHash,
+			wantResetAccounts:   []string{"user@email.com"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userService := &fakeUserService{}
+			refreshTokenService := &fakeRefreshTokenService{token: "refresh-token"}
+			tokenRevocationService := &fakeTokenRevocationService{}
+			sessionService := &fakeSessionService{}
+			loginThrottleService := &fakeLoginThrottleService{checkErr: test.checkErr}
+			u := NewUserUsecase(
+				userService, &fakeProfileService{}, refreshTokenService, tokenRevocationService, &fakeUserTokenService{},
+				loginThrottleService, &fakeTwoFactorService{}, sessionService, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{}, &fakeUnitOfWork{},
+				&fakeConfig{}, &fakeAuth{token: "token"}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{hash: mockHashPassword, verify: test.isValidPasswordHash}, nil,
+			)
+			resp, err := u.ChangePassword(context.Background(), test.user, test.req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.ChangePassword() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(loginThrottleService.failedAccounts, test.wantFailedAccounts) {
+				t.Errorf("userUsecase.ChangePassword() recorded failed logins for %v, want %v", loginThrottleService.failedAccounts, test.wantFailedAccounts)
+			}
+			if !reflect.DeepEqual(loginThrottleService.resetEmails, test.wantResetAccounts) {
+				t.Errorf("userUsecase.ChangePassword() reset failed logins for %v, want %v", loginThrottleService.resetEmails, test.wantResetAccounts)
+			}
+			if test.wantErr != nil {
+				if userService.password != "" {
+					t.Errorf("userUsecase.ChangePassword() updated the password")
+				}
+
+				return
+			}
+			if userService.password == "" {
+				t.Errorf("userUsecase.ChangePassword() did not update the password")
+			}
+			if refreshTokenService.revokedUserID != user.ID || !tokenRevocationService.revokedAll || sessionService.deletedUserID != user.ID {
+				t.Errorf("userUsecase.ChangePassword() did not revoke the other sessions of the user")
+			}
+			if !reflect.DeepEqual(sessionService.createdTokenIDs, []string{"access-jti"}) {
+				t.Errorf("userUsecase.ChangePassword() created sessions %v, want one for the new access token", sessionService.createdTokenIDs)
+			}
+			if !reflect.DeepEqual(resp, entity.NewUserLoginResponse("token", "refresh-token")) {
+				t.Errorf("userUsecase.ChangePassword() = %v, want new tokens", resp)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_ChangeEmail(t *testing.T) {
+	user := &entity.User{ID: 1, Email: "user@email.com", Password: "hashed-password"}
+	tests := []struct {
+		name                string
+		req                 *entity.ChangeEmailRequest
+		userService         *fakeUserService
+		isValidPasswordHash func(password, hashedPassword string) bool
+		wantErr             error
+	}{
+		{
+			name:                "Failed: Invalid request",
+			req:                 &entity.ChangeEmailRequest{Email: "email", Password: mockPassword},
+			userService:         &fakeUserService{},
+			isValidPasswordHash: mockIsValidPasswordHash,
+			wantErr:             apperror.ErrValidation,
+		},
+		{
+			name:                "Failed: Password missing",
+			req:                 &entity.ChangeEmailRequest{Email: "new@email.com"},
+			userService:         &fakeUserService{err: apperror.ErrNotFound},
+			isValidPasswordHash: mockIsValidPasswordHash,
+			wantErr:             apperror.ErrValidation,
+		},
+		{
+			name:                "Failed: Incorrect password",
+			req:                 &entity.ChangeEmailRequest{Email: "new@email.com", Password: "wrong-password"},
+			userService:         &fakeUserService{err: apperror.ErrNotFound},
+			isValidPasswordHash: func(string, string) bool { return false },
+			wantErr:             apperror.ErrValidation,
+		},
+		{
+			name:                "Failed: Same email",
+			req:                 &entity.ChangeEmailRequest{Email: "User@Email.com", Password: mockPassword},
+			userService:         &fakeUserService{err: apperror.ErrNotFound},
+			isValidPasswordHash: mockIsValidPasswordHash,
+			wantErr:             apperror.ErrValidation,
+		},
+		{
+			name:                "Failed: Email already used",
+			req:                 &entity.ChangeEmailRequest{Email: "other@email.com", Password: mockPassword},
+			userService:         &fakeUserService{user: &entity.User{ID: 2, Email: "other@email.com"}},
+			isValidPasswordHash: mockIsValidPasswordHash,
+			wantErr:             apperror.ErrConflict,
+		},
+		{
+			name:                "Success",
+			req:                 &entity.ChangeEmailRequest{Email: "New@Email.com", Password: mockPassword},
+			userService:         &fakeUserService{err: apperror.ErrNotFound},
+			isValidPasswordHash: mockIsValidPasswordHash,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mailer := &fakeMailer{}
+			userTokenService := &fakeUserTokenService{}
+			u := NewUserUsecase(
+				test.userService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, userTokenService,
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{}, mailer, &fakeSMSSender{}, &fakePasswordHasher{verify: test.isValidPasswordHash}, nil,
+			)
+			err := u.ChangeEmail(context.Background(), user, test.req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.ChangeEmail() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr != nil {
+				return
+			}
+			if test.userService.email != "" {
+				t.Errorf("userUsecase.ChangeEmail() changed the email before it was confirmed")
+			}
+			if len(mailer.mails) != 1 || mailer.mails[0].To != "new@email.com" || !strings.Contains(mailer.mails[0].Body, "token=opaque-token") {
+				t.Errorf("userUsecase.ChangeEmail() did not send the confirmation link to the new email")
+			}
+			if userTokenService.revokedPurpose != entity.UserTokenPurposeEmailChange {
+				t.Errorf("userUsecase.ChangeEmail() did not revoke the previous confirmation links")
+			}
+		})
+	}
+}
+
+func Test_userUsecase_ChangeEmail_Without_Password(t *testing.T) {
+	user := &entity.User{ID: 1, Phone: "+6281234567890"}
+	mailer := &fakeMailer{}
+	passwordHasher := &fakePasswordHasher{verify: func(string, string) bool {
+		t.Error("userUsecase.ChangeEmail() checked a password of a user without one")
+
+		return false
+	}}
+	u := NewUserUsecase(
+		&fakeUserService{err: apperror.ErrNotFound}, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+		&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{}, &fakeUnitOfWork{},
+		&fakeConfig{}, &fakeAuth{}, mailer, &fakeSMSSender{}, passwordHasher, nil,
+	)
+
+	err := u.ChangeEmail(context.Background(), user, &entity.ChangeEmailRequest{Email: "new@email.com"})
+	if err != nil {
+		t.Fatalf("userUsecase.ChangeEmail() error = %v", err)
+	}
+	if len(mailer.mails) != 1 || mailer.mails[0].To != "new@email.com" {
+		t.Errorf("userUsecase.ChangeEmail() did not send the confirmation link to the new email")
+	}
+}
+
+func Test_userUsecase_ConfirmEmailChange_Without_Previous_Email(t *testing.T) {
+	userService := &fakeUserService{user: &entity.User{ID: 1, Phone: "+6281234567890"}}
+	userTokenService := &fakeUserTokenService{userToken: &entity.UserToken{ID: 1, UserID: 1, Purpose: entity.UserTokenPurposeEmailChange, Email: "new@email.com"}}
+	mailer := &fakeMailer{}
+	u := NewUserUsecase(
+		userService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, userTokenService,
+		&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{}, &fakeUnitOfWork{},
+		&fakeConfig{}, &fakeAuth{}, mailer, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+	)
+
+	err := u.ConfirmEmailChange(context.Background(), &entity.ConfirmEmailChangeRequest{Token: "token"})
+	if err != nil {
+		t.Fatalf("userUsecase.ConfirmEmailChange() error = %v", err)
+	}
+	if userService.email != "new@email.com" {
+		t.Errorf("userUsecase.ConfirmEmailChange() changed the email to %q, want %q", userService.email, "new@email.com")
+	}
+	if len(mailer.mails) != 0 {
+		t.Errorf("userUsecase.ConfirmEmailChange() sent %d mails, want none to a previous email that does not exist", len(mailer.mails))
+	}
+}
+
+func Test_userUsecase_ConfirmEmailChange(t *testing.T) {
+	mockUserToken := &entity.UserToken{ID: 1, UserID: 1, Purpose: entity.UserTokenPurposeEmailChange, Email: "new@email.com"}
+	tests := []struct {
+		name             string
+		req              *entity.ConfirmEmailChangeRequest
+		userService      *fakeUserService
+		userTokenService *fakeUserTokenService
+		wantErr          error
+	}{
+		{
+			name:             "Failed: Invalid request",
+			req:              &entity.ConfirmEmailChangeRequest{},
+			userService:      &fakeUserService{},
+			userTokenService: &fakeUserTokenService{},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Failed: Token already used",
+			req:              &entity.ConfirmEmailChangeRequest{Token: "token"},
+			userService:      &fakeUserService{},
+			userTokenService: &fakeUserTokenService{err: apperror.New(apperror.KindValidation, "Invalid, expired or already used token")},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Failed: User deleted",
+			req:              &entity.ConfirmEmailChangeRequest{Token: "token"},
+			userService:      &fakeUserService{err: apperror.ErrNotFound},
+			userTokenService: &fakeUserTokenService{userToken: mockUserToken},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Success",
+			req:              &entity.ConfirmEmailChangeRequest{Token: "token"},
+			userService:      &fakeUserService{user: &entity.User{ID: 1, Email: "user@email.com"}},
+			userTokenService: &fakeUserTokenService{userToken: mockUserToken},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mailer := &fakeMailer{}
+			u := NewUserUsecase(
+				test.userService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, test.userTokenService,
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{}, mailer, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+			)
+			err := u.ConfirmEmailChange(context.Background(), test.req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.ConfirmEmailChange() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr != nil {
+				return
+			}
+			if test.userService.email != "new@email.com" {
+				t.Errorf("userUsecase.ConfirmEmailChange() changed the email to %q, want %q", test.userService.email, "new@email.com")
+			}
+			if len(mailer.mails) != 1 || mailer.mails[0].To != "user@email.com" {
+				t.Errorf("userUsecase.ConfirmEmailChange() did not notify the previous email")
+			}
+		})
+	}
+}
+
+func Test_userUsecase_Login_Two_Factor_Challenge(t *testing.T) {
+	loginThrottleService := &fakeLoginThrottleService{}
+	userTokenService := &fakeUserTokenService{}
+	claims := &entity.ActionTokenClaims{ID: "jti", Purpose: entity.UserTokenPurposeTwoFactorLogin, UserID: 1, Email: "user@email.com"}
+	u := NewUserUsecase(
+		mockSuccessUserService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, userTokenService,
+		loginThrottleService, &fakeTwoFactorService{enabled: true}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+		&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{token: "challenge", actionClaims: claims}, &fakeMailer{}, &fakeSMSSender{},
+		&fakePasswordHasher{hash: mockHashPassword, verify: mockIsValidPasswordHash}, nil,
+	)
+
+	resp, err := u.Login(context.Background(), mockSuccessLoginRequest)
+	if err != nil {
+		t.Fatalf("userUsecase.Login() error = %v", err)
+	}
+	if !reflect.DeepEqual(resp, entity.NewTwoFactorChallengeResponse("challenge")) {
+		t.Errorf("userUsecase.Login() = %+v, want only a challenge token", resp)
+	}
+	if userTokenService.createdToken != "jti" {
+		t.Errorf("userUsecase.Login() stored challenge %q, want %q", userTokenService.createdToken, "jti")
+	}
+	if len(loginThrottleService.resetEmails) != 0 {
+		t.Errorf("userUsecase.Login() reset failed attempts of %v before the second factor", loginThrottleService.resetEmails)
+	}
+}
+
+func Test_userUsecase_LoginTwoFactor(t *testing.T) {
+	claims := &entity.ActionTokenClaims{ID: "jti", Purpose: entity.UserTokenPurposeTwoFactorLogin, UserID: 1, Email: "user@email.com"}
+	otherClaims := &entity.ActionTokenClaims{ID: "jti", Purpose: entity.UserTokenPurposeTwoFactorLogin, UserID: 1, Email: "old@email.com"}
+	tests := []struct {
+		name              string
+		req               *entity.TwoFactorLoginRequest
+		auth              *fakeAuth
+		twoFactorService  *fakeTwoFactorService
+		userTokenService  *fakeUserTokenService
+		wantErr           error
+		wantFailedUserIDs []*int
+		wantResetEmails   []string
+	}{
+		{
+			name:             "Failed: Invalid request body",
+			req:              &entity.TwoFactorLoginRequest{ChallengeToken: "challenge"},
+			auth:             &fakeAuth{actionClaims: claims},
+			twoFactorService: &fakeTwoFactorService{},
+			userTokenService: &fakeUserTokenService{},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Failed: Invalid challenge token",
+			req:              &entity.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"},
+			auth:             &fakeAuth{err: errors.New("invalid token")},
+			twoFactorService: &fakeTwoFactorService{},
+			userTokenService: &fakeUserTokenService{},
+			wantErr:          apperror.ErrUnauthorized,
+		},
+		{
+			name:              "Failed: Wrong code",
+			req:               &entity.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"},
+			auth:              &fakeAuth{actionClaims: claims},
+			twoFactorService:  &fakeTwoFactorService{verifyErr: apperror.New(apperror.KindUnauthorized, "Invalid two-factor authentication code")},
+			userTokenService:  &fakeUserTokenService{userToken: &entity.UserToken{UserID: 1, Email: "user@email.com"}},
+			wantErr:           apperror.ErrUnauthorized,
+			wantFailedUserIDs: []*int{&claims.UserID},
+		},
+		{
+			name:             "Failed: Challenge already used",
+			req:              &entity.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"},
+			auth:             &fakeAuth{actionClaims: claims},
+			twoFactorService: &fakeTwoFactorService{},
+			userTokenService: &fakeUserTokenService{err: apperror.New(apperror.KindValidation, "Invalid, expired or already used token")},
+			wantErr:          apperror.ErrUnauthorized,
+		},
+		{
+			name:             "Failed: Email changed since the challenge",
+			req:              &entity.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"},
+			auth:             &fakeAuth{actionClaims: otherClaims},
+			twoFactorService: &fakeTwoFactorService{},
+			userTokenService: &fakeUserTokenService{userToken: &entity.UserToken{UserID: 1, Email: "old@email.com"}},
+			wantErr:          apperror.ErrUnauthorized,
+		},
+		{
+			name:             "Success: Code",
+			req:              &entity.TwoFactorLoginRequest{ChallengeToken: "challenge", Code: "123456"},
+			auth:             &fakeAuth{token: "token", actionClaims: claims},
+			twoFactorService: &fakeTwoFactorService{},
+			userTokenService: &fakeUserTokenService{userToken: &entity.UserToken{UserID: 1, Email: "user@email.com"}},
+			wantResetEmails:  []string{"user@email.com"},
+		},
+		{
+			name:             "Success: Recovery code",
+			req:              &entity.TwoFactorLoginRequest{ChallengeToken: "challenge", RecoveryCode: "abcd-efgh-ijkl-mnop"},
+			auth:             &fakeAuth{token: "token", actionClaims: claims},
+			twoFactorService: &fakeTwoFactorService{},
+			userTokenService: &fakeUserTokenService{userToken: &entity.UserToken{UserID: 1, Email: "user@email.com"}},
+			wantResetEmails:  []string{"user@email.com"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			loginThrottleService := &fakeLoginThrottleService{}
+			u := NewUserUsecase(
+				mockSuccessUserService, &fakeProfileService{}, &fakeRefreshTokenService{token: "refresh-token"}, &fakeTokenRevocationService{}, test.userTokenService,
+				loginThrottleService, test.twoFactorService, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				&fakeUnitOfWork{}, &fakeConfig{}, test.auth, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+			)
+			resp, err := u.LoginTwoFactor(context.Background(), test.req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.LoginTwoFactor() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr == nil && !reflect.DeepEqual(resp, entity.NewUserLoginResponse("token", "refresh-token")) {
+				t.Errorf("userUsecase.LoginTwoFactor() = %+v, want the tokens", resp)
+			}
+			if !reflect.DeepEqual(loginThrottleService.failedUserIDs, test.wantFailedUserIDs) {
+				t.Errorf("userUsecase.LoginTwoFactor() recorded failed attempts of %v, want %v", loginThrottleService.failedUserIDs, test.wantFailedUserIDs)
+			}
+			if !reflect.DeepEqual(loginThrottleService.resetEmails, test.wantResetEmails) {
+				t.Errorf("userUsecase.LoginTwoFactor() reset failed attempts of %v, want %v", loginThrottleService.resetEmails, test.wantResetEmails)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_EnrollTwoFactor(t *testing.T) {
+	u := NewUserUsecase(
+		mockSuccessUserService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+		&fakeLoginThrottleService{}, &fakeTwoFactorService{secret: "SECRET"}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+		&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+	)
+
+	resp, err := u.EnrollTwoFactor(context.Background(), mockSuccessUserService.user)
+	if err != nil {
+		t.Fatalf("userUsecase.EnrollTwoFactor() error = %v", err)
+	}
+	if resp.Secret != "SECRET" || !strings.HasPrefix(resp.OTPAuthURI, "otpauth://totp/Dating%20Service:user@email.com?") {
+		t.Errorf("userUsecase.EnrollTwoFactor() = %+v, want the secret and an otpauth URI for the user", resp)
+	}
+}
+
+func Test_userUsecase_ConfirmTwoFactor(t *testing.T) {
+	tests := []struct {
+		name             string
+		req              *entity.ConfirmTwoFactorRequest
+		twoFactorService *fakeTwoFactorService
+		wantErr          error
+		wantCodes        []string
+	}{
+		{
+			name:             "Failed: Invalid request body",
+			req:              &entity.ConfirmTwoFactorRequest{},
+			twoFactorService: &fakeTwoFactorService{},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Failed: Wrong code",
+			req:              &entity.ConfirmTwoFactorRequest{Code: "123456"},
+			twoFactorService: &fakeTwoFactorService{err: apperror.New(apperror.KindValidation, "Invalid two-factor authentication code")},
+			wantErr:          apperror.ErrValidation,
+		},
+		{
+			name:             "Success",
+			req:              &entity.ConfirmTwoFactorRequest{Code: "123456"},
+			twoFactorService: &fakeTwoFactorService{recoveryCodes: []string{"abcd-efgh-ijkl-mnop"}},
+			wantCodes:        []string{"abcd-efgh-ijkl-mnop"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			u := NewUserUsecase(
+				mockSuccessUserService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+				&fakeLoginThrottleService{}, test.twoFactorService, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+			)
+			resp, err := u.ConfirmTwoFactor(context.Background(), mockSuccessUserService.user, test.req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.ConfirmTwoFactor() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr == nil && !reflect.DeepEqual(resp.RecoveryCodes, test.wantCodes) {
+				t.Errorf("userUsecase.ConfirmTwoFactor() = %v, want %v", resp.RecoveryCodes, test.wantCodes)
+			}
+		})
+	}
+}
+
+func Test_userUsecase_GetSessions(t *testing.T) {
+	sessionService := &fakeSessionService{
+		sessions: []*entity.Session{{ID: 1, UserID: 1, TokenID: "jti"}, {ID: 2, UserID: 1, TokenID: "other"}},
+	}
+	u := NewUserUsecase(
+		mockSuccessUserService, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+		&fakeLoginThrottleService{}, &fakeTwoFactorService{}, sessionService, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+		&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+	)
+
+	resp, err := u.GetSessions(context.Background(), mockSuccessUserService.user, &entity.TokenClaims{ID: "jti"})
+	if err != nil {
+		t.Fatalf("userUsecase.GetSessions() error = %v", err)
+	}
+	if len(resp.Sessions) != 2 || !resp.Sessions[0].Current || resp.Sessions[1].Current {
+		t.Errorf("userUsecase.GetSessions() = %+v, want both sessions with the first one as current", resp.Sessions)
+	}
 }
 
-type fakeConfig struct {
-	key string
+func Test_userUsecase_DeleteSession(t *testing.T) {
+	tests := []struct {
+		name        string
+		id          int
+		wantErr     error
+		wantRevoked string
+	}{
+		{
+			name:    "Failed: Session not found",
+			id:      2,
+			wantErr: apperror.ErrNotFound,
+		},
+		{
+			name:        "Success",
+			id:          1,
+			wantRevoked: "family",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			refreshTokenService := &fakeRefreshTokenService{}
+			sessionService := &fakeSessionService{
+				sessions: []*entity.Session{{ID: 1, UserID: 1, RefreshFamilyID: "family"}},
+			}
+			u := NewUserUsecase(
+				mockSuccessUserService, &fakeProfileService{}, refreshTokenService, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, sessionService, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				&fakeUnitOfWork{}, &fakeConfig{}, &fakeAuth{}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+			)
+			err := u.DeleteSession(context.Background(), mockSuccessUserService.user, test.id)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.DeleteSession() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if refreshTokenService.revokedFamily != test.wantRevoked {
+				t.Errorf("userUsecase.DeleteSession() revoked family = %q, want %q", refreshTokenService.revokedFamily, test.wantRevoked)
+			}
+		})
+	}
 }
 
-func (f *fakeConfig) GetJWTKey() string {
-	return f.key
+func Test_userUsecase_RequestPhoneOTP(t *testing.T) {
+	tests := []struct {
+		name            string
+		req             *entity.PhoneOTPRequest
+		phoneOTPService *fakePhoneOTPService
+		smsSender       *fakeSMSSender
+		wantErr         error
+	}{
+		{
+			name:            "Failed: Invalid phone",
+			req:             &entity.PhoneOTPRequest{Phone: "081234567890"},
+			phoneOTPService: &fakePhoneOTPService{},
+			smsSender:       &fakeSMSSender{},
+			wantErr:         apperror.ErrValidation,
+		},
+		{
+			name:            "Failed: Code recently sent",
+			req:             &entity.PhoneOTPRequest{Phone: "+6281234567890"},
+			phoneOTPService: &fakePhoneOTPService{issueErr: apperror.New(apperror.KindQuotaExceeded, "A code was recently sent")},
+			smsSender:       &fakeSMSSender{},
+			wantErr:         apperror.ErrQuotaExceeded,
+		},
+		{
+			name:            "Failed: SMS not sent",
+			req:             &entity.PhoneOTPRequest{Phone: "+6281234567890"},
+			phoneOTPService: &fakePhoneOTPService{code: "123456"},
+			smsSender:       &fakeSMSSender{err: errors.New("gateway unavailable")},
+			wantErr:         errors.New("gateway unavailable"),
+		},
+		{
+			name:            "Success",
+			req:             &entity.PhoneOTPRequest{Phone: "+62 812-3456-7890"},
+			phoneOTPService: &fakePhoneOTPService{code: "123456"},
+			smsSender:       &fakeSMSSender{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			unitOfWork := &fakeUnitOfWork{}
+			u := NewUserUsecase(
+				&fakeUserService{}, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, test.phoneOTPService, &fakeUserIdentityService{}, &fakePhotoService{}, unitOfWork,
+				&fakeConfig{}, &fakeAuth{}, &fakeMailer{}, test.smsSender, &fakePasswordHasher{}, nil,
+			)
+			err := u.RequestPhoneOTP(domain.WithClientIP(context.Background(), "203.0.113.1"), test.req)
+			if test.wantErr == nil && err != nil || test.wantErr != nil && (err == nil || !errors.Is(err, test.wantErr) && err.Error() != test.wantErr.Error()) {
+				t.Fatalf("userUsecase.RequestPhoneOTP() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.smsSender.err != nil && !unitOfWork.committed {
+				t.Errorf("userUsecase.RequestPhoneOTP() sent the code before committing it")
+			}
+			if test.wantErr != nil {
+				return
+			}
+			if len(test.smsSender.messages) != 1 || test.smsSender.messages[0].To != "+6281234567890" || !strings.Contains(test.smsSender.messages[0].Body, "123456") {
+				t.Errorf("userUsecase.RequestPhoneOTP() did not send the code to the normalized phone number")
+			}
+			if !reflect.DeepEqual(test.phoneOTPService.issuedIPs, []string{"203.0.113.1"}) {
+				t.Errorf("userUsecase.RequestPhoneOTP() issued the code for IP addresses %v, want the client IP address", test.phoneOTPService.issuedIPs)
+			}
+		})
+	}
 }
 
-func Test_userUsecase_Signup(t *testing.T) {
-	type fields struct {
-		userService         service.UserService
-		profileService      service.ProfileService
-		config              domain.Config
-		auth                domain.Auth
-		hashPassword        hashPassword
-		isValidPasswordHash isValidPasswordHash
+func Test_userUsecase_SignupWithPhone(t *testing.T) {
+	req := &entity.PhoneSignupRequest{Phone: "+6281234567890", Code: "123456", Name: "User", BirthDate: "2000-01-01", Gender: "FEMALE", Location: "Indonesia"}
+	tests := []struct {
+		name            string
+		userService     *fakeUserService
+		phoneOTPService *fakePhoneOTPService
+		wantErr         error
+		wantRolledBack  bool
+	}{
+		{
+			name:            "Failed: Phone already used",
+			userService:     &fakeUserService{user: &entity.User{ID: 1, Phone: "+6281234567890"}},
+			phoneOTPService: &fakePhoneOTPService{},
+			wantErr:         apperror.ErrConflict,
+			wantRolledBack:  true,
+		},
+		{
+			name:            "Failed: Invalid code",
+			userService:     &fakeUserService{id: 2},
+			phoneOTPService: &fakePhoneOTPService{verifyErr: apperror.New(apperror.KindUnauthorized, "Invalid or expired code")},
+			wantErr:         apperror.ErrUnauthorized,
+		},
+		{
+			name:            "Success",
+			userService:     &fakeUserService{id: 2},
+			phoneOTPService: &fakePhoneOTPService{},
+		},
 	}
-	type args struct {
-		req *entity.UserSignupRequest
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sessionService := &fakeSessionService{}
+			unitOfWork := &fakeUnitOfWork{}
+			u := NewUserUsecase(
+				test.userService, &fakeProfileService{}, &fakeRefreshTokenService{token: "refresh-token"}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, sessionService, test.phoneOTPService, &fakeUserIdentityService{}, &fakePhotoService{}, unitOfWork,
+				&fakeConfig{}, &fakeAuth{token: "token"}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+			)
+			resp, err := u.SignupWithPhone(context.Background(), req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.SignupWithPhone() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(test.phoneOTPService.verifiedCodes, []string{"123456"}) {
+				t.Errorf("userUsecase.SignupWithPhone() verified codes %v, want the given code", test.phoneOTPService.verifiedCodes)
+			}
+			if unitOfWork.rolledBack != test.wantRolledBack {
+				t.Errorf("userUsecase.SignupWithPhone() rolled back = %v, want %v", unitOfWork.rolledBack, test.wantRolledBack)
+			}
+			if test.wantErr != nil {
+				if len(sessionService.createdTokenIDs) != 0 {
+					t.Errorf("userUsecase.SignupWithPhone() logged in a user that did not sign up")
+				}
+
+				return
+			}
+			if !reflect.DeepEqual(resp, entity.NewUserLoginResponse("token", "refresh-token")) {
+				t.Errorf("userUsecase.SignupWithPhone() = %v, want tokens", resp)
+			}
+		})
 	}
+}
+
+func Test_userUsecase_LoginWithPhone(t *testing.T) {
+	phoneUser := &entity.User{ID: 2, Phone: "+6281234567890"}
+	req := &entity.PhoneLoginRequest{Phone: "0062 812 3456 7890", Code: "123456"}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		wantErr bool
+		name             string
+		userService      *fakeUserService
+		phoneOTPService  *fakePhoneOTPService
+		twoFactorService *fakeTwoFactorService
+		want             *entity.UserLoginResponse
+		wantErr          error
+		wantFailedLogin  bool
+		wantRolledBack   bool
 	}{
 		{
-			name: "Failed: Invalid request",
-			args: args{
-				req: &entity.UserSignupRequest{
-					Email: "",
-				},
-			},
-			wantErr: true,
+			name:             "Failed: Phone not registered",
+			userService:      &fakeUserService{},
+			phoneOTPService:  &fakePhoneOTPService{},
+			twoFactorService: &fakeTwoFactorService{},
+			wantErr:          apperror.ErrNotFound,
+			wantRolledBack:   true,
 		},
 		{
-			name: "Failed: Password hash failed",
-			fields: fields{
-				hashPassword: func(string) (string, error) {
-					return "", bcrypt.ErrPasswordTooLong
-				},
-			},
-			args: args{
-				req: mockSuccessSignupRequest,
-			},
-			wantErr: true,
+			name:             "Failed: Phone not registered with invalid code",
+			userService:      &fakeUserService{},
+			phoneOTPService:  &fakePhoneOTPService{verifyErr: apperror.New(apperror.KindUnauthorized, "Invalid or expired code")},
+			twoFactorService: &fakeTwoFactorService{},
+			wantErr:          apperror.ErrUnauthorized,
+			wantFailedLogin:  true,
 		},
 		{
-			name: "Failed: Create user failed",
-			fields: fields{
-				userService: &fakeUserService{
-					id:  0,
-					err: gorm.ErrDuplicatedKey,
-				},
-				hashPassword: func(string) (string, error) {
-					return mockPassword, nil
-				},
-			},
-			args: args{
-				req: mockSuccessSignupRequest,
-			},
-			wantErr: true,
+			name:             "Failed: Invalid code",
+			userService:      &fakeUserService{user: phoneUser},
+			phoneOTPService:  &fakePhoneOTPService{verifyErr: apperror.New(apperror.KindUnauthorized, "Invalid or expired code")},
+			twoFactorService: &fakeTwoFactorService{},
+			wantErr:          apperror.ErrUnauthorized,
+			wantFailedLogin:  true,
 		},
 		{
-			name: "Failed: Create profile failed",
-			fields: fields{
-				userService: &fakeUserService{
-					id:  1,
-					err: nil,
-				},
-				profileService: &fakeProfileService{
-					err: gorm.ErrDuplicatedKey,
-				},
-				hashPassword: func(string) (string, error) {
-					return mockPassword, nil
-				},
-			},
-			args: args{
-				req: mockSuccessSignupRequest,
-			},
-			wantErr: true,
+			name:             "Success with two-factor challenge",
+			userService:      &fakeUserService{user: phoneUser},
+			phoneOTPService:  &fakePhoneOTPService{},
+			twoFactorService: &fakeTwoFactorService{enabled: true},
+			want:             entity.NewTwoFactorChallengeResponse("token"),
 		},
 		{
-			name: "Success",
-			fields: fields{
-				userService: &fakeUserService{
-					id:  1,
-					err: nil,
-				},
-				profileService: &fakeProfileService{
-					err: nil,
-				},
-				hashPassword: func(string) (string, error) {
-					return mockPassword, nil
-				},
-			},
-			args: args{
-				req: mockSuccessSignupRequest,
-			},
-			wantErr: false,
+			name:             "Success",
+			userService:      &fakeUserService{user: phoneUser},
+			phoneOTPService:  &fakePhoneOTPService{},
+			twoFactorService: &fakeTwoFactorService{},
+			want:             entity.NewUserLoginResponse("token", "refresh-token"),
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			usecase := &userUsecase{
-				userService:         test.fields.userService,
-				profileService:      test.fields.profileService,
-				config:              test.fields.config,
-				auth:                test.fields.auth,
-				hashPassword:        test.fields.hashPassword,
-				isValidPasswordHash: test.fields.isValidPasswordHash,
+			loginThrottleService := &fakeLoginThrottleService{}
+			unitOfWork := &fakeUnitOfWork{}
+			u := NewUserUsecase(
+				test.userService, &fakeProfileService{}, &fakeRefreshTokenService{token: "refresh-token"}, &fakeTokenRevocationService{}, &fakeUserTokenService{},
+				loginThrottleService, test.twoFactorService, &fakeSessionService{}, test.phoneOTPService, &fakeUserIdentityService{}, &fakePhotoService{}, unitOfWork,
+				&fakeConfig{}, &fakeAuth{token: "token", actionClaims: mockActionTokenClaims}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, nil,
+			)
+			resp, err := u.LoginWithPhone(context.Background(), req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.LoginWithPhone() error = %v, wantErr %v", err, test.wantErr)
 			}
-			if err := usecase.Signup(context.Background(), test.args.req); (err != nil) != test.wantErr {
-				t.Errorf("userUsecase.Signup() error = %v, wantErr %v", err, test.wantErr)
+			if unitOfWork.rolledBack != test.wantRolledBack {
+				t.Errorf("userUsecase.LoginWithPhone() rolled back = %v, want %v", unitOfWork.rolledBack, test.wantRolledBack)
+			}
+			if test.wantFailedLogin != reflect.DeepEqual(loginThrottleService.failedAccounts, []string{"+6281234567890"}) {
+				t.Errorf("userUsecase.LoginWithPhone() recorded failed logins of %v, want one of the phone number: %v", loginThrottleService.failedAccounts, test.wantFailedLogin)
+			}
+			if !reflect.DeepEqual(resp, test.want) {
+				t.Errorf("userUsecase.LoginWithPhone() = %v, want %v", resp, test.want)
+			}
+			wantReset := test.want != nil && test.want.ChallengeToken == ""
+			if wantReset != reflect.DeepEqual(loginThrottleService.resetEmails, []string{"+6281234567890"}) {
+				t.Errorf("userUsecase.LoginWithPhone() reset failed logins of %v, want a reset: %v", loginThrottleService.resetEmails, wantReset)
 			}
 		})
 	}
 }
 
-func Test_userUsecase_Login(t *testing.T) {
-	type fields struct {
-		userService         service.UserService
-		profileService      service.ProfileService
-		config              domain.Config
-		auth                domain.Auth
-		hashPassword        hashPassword
-		isValidPasswordHash isValidPasswordHash
-	}
-	type args struct {
-		req *entity.UserLoginRequest
-	}
+func Test_userUsecase_LoginWithIdentityProvider(t *testing.T) {
+	currentTime := time.Now().UTC()
+	verifiedUser := &entity.User{ID: 1, Email: "user@email.com", EmailVerifiedAt: &currentTime}
+	identity := &entity.ExternalIdentity{Provider: "test", Subject: "subject", Email: "User@Email.com", EmailVerified: true, Name: "User"}
+	req := &entity.IdentityProviderLoginRequest{Provider: "test", IDToken: "id-token", Nonce: "nonce"}
+	signupReq := &entity.IdentityProviderLoginRequest{Provider: "test", IDToken: "id-token", Nonce: "nonce", BirthDate: "2000-01-01", Gender: "FEMALE", Location: "Indonesia"}
 	tests := []struct {
-		name    string
-		fields  fields
-		args    args
-		want    *entity.UserLoginResponse
-		wantErr bool
+		name                string
+		req                 *entity.IdentityProviderLoginRequest
+		userService         *fakeUserService
+		userIdentityService *fakeUserIdentityService
+		identityProvider    *fakeIdentityProvider
+		twoFactorService    *fakeTwoFactorService
+		nonceErr            error
+		want                *entity.UserLoginResponse
+		wantErr             error
+		wantLinkedUserID    int
+		wantRolledBack      bool
 	}{
 		{
-			name: "Failed: Invalid request",
-			args: args{
-				req: &entity.UserLoginRequest{
-					Email: "",
-				},
-			},
-			want:    nil,
-			wantErr: true,
+			name:                "Failed: Provider not supported",
+			req:                 &entity.IdentityProviderLoginRequest{Provider: "other", IDToken: "id-token", Nonce: "nonce"},
+			userService:         &fakeUserService{},
+			userIdentityService: &fakeUserIdentityService{},
+			identityProvider:    &fakeIdentityProvider{identity: identity},
+			twoFactorService:    &fakeTwoFactorService{},
+			wantErr:             apperror.ErrValidation,
 		},
 		{
-			name: "Failed: Get user by email failed",
-			fields: fields{
-				userService: &fakeUserService{
-					user: &entity.User{},
-					err:  schema.ErrUnsupportedDataType,
-				},
-			},
-			args: args{
-				req: mockSuccessLoginRequest,
-			},
-			want:    nil,
-			wantErr: true,
+			name:                "Failed: Invalid ID token",
+			req:                 req,
+			userService:         &fakeUserService{},
+			userIdentityService: &fakeUserIdentityService{},
+			identityProvider:    &fakeIdentityProvider{err: apperror.New(apperror.KindUnauthorized, "Invalid or expired ID token")},
+			twoFactorService:    &fakeTwoFactorService{},
+			wantErr:             apperror.ErrUnauthorized,
 		},
 		{
-			name: "Failed: Invalid password hash",
-			fields: fields{
-				userService: mockSuccessUserService,
-				isValidPasswordHash: func(string, string) bool {
-					return false
-				},
-			},
-			args: args{
-				req: mockSuccessLoginRequest,
-			},
-			want:    nil,
-			wantErr: true,
+			name:                "Failed: Email not verified by the provider",
+			req:                 req,
+			userService:         &fakeUserService{user: verifiedUser},
+			userIdentityService: &fakeUserIdentityService{},
+			identityProvider:    &fakeIdentityProvider{identity: &entity.ExternalIdentity{Provider: "test", Subject: "subject", Email: "user@email.com"}},
+			twoFactorService:    &fakeTwoFactorService{},
+			wantErr:             apperror.ErrForbidden,
 		},
 		{
-			name: "Failed: Generate token failed",
-			fields: fields{
-				userService: mockSuccessUserService,
-				auth: &fakeAuth{
-					err: errors.New("Failed to generate a token"),
-				},
-				config:              &fakeConfig{},
-				isValidPasswordHash: mockIsValidPasswordHash,
-			},
-			args: args{
-				req: mockSuccessLoginRequest,
-			},
-			want:    nil,
-			wantErr: true,
+			name:                "Failed: Email of a user who has not verified it",
+			req:                 req,
+			userService:         &fakeUserService{user: &entity.User{ID: 1, Email: "user@email.com", Password: "hashed-password"}},
+			userIdentityService: &fakeUserIdentityService{},
+			identityProvider:    &fakeIdentityProvider{identity: identity},
+			twoFactorService:    &fakeTwoFactorService{},
+			wantErr:             apperror.ErrConflict,
 		},
 		{
-			name: "Success",
-			fields: fields{
-				userService: mockSuccessUserService,
-				auth: &fakeAuth{
-					token: "token",
-					err:   nil,
-				},
-				config:              &fakeConfig{},
-				isValidPasswordHash: mockIsValidPasswordHash,
-			},
-			args: args{
-				req: mockSuccessLoginRequest,
-			},
-			want:    entity.NewUserLoginResponse("token"),
-			wantErr: false,
+			name:                "Failed: Profile missing to sign up",
+			req:                 req,
+			userService:         &fakeUserService{id: 2},
+			userIdentityService: &fakeUserIdentityService{},
+			identityProvider:    &fakeIdentityProvider{identity: identity},
+			twoFactorService:    &fakeTwoFactorService{},
+			wantErr:             apperror.ErrValidation,
+		},
+		{
+			name:                "Failed: Nonce unknown or already used",
+			req:                 req,
+			userService:         &fakeUserService{user: verifiedUser},
+			userIdentityService: &fakeUserIdentityService{userIdentity: &entity.UserIdentity{ID: 1, UserID: 1, Provider: "test", Subject: "subject"}},
+			identityProvider:    &fakeIdentityProvider{identity: &entity.ExternalIdentity{Provider: "test", Subject: "subject"}},
+			twoFactorService:    &fakeTwoFactorService{},
+			nonceErr:            apperror.New(apperror.KindValidation, "Invalid, expired or already used token"),
+			wantErr:             apperror.ErrUnauthorized,
+			wantRolledBack:      true,
+		},
+		{
+			name:                "Success: Linked account",
+			req:                 req,
+			userService:         &fakeUserService{user: verifiedUser},
+			userIdentityService: &fakeUserIdentityService{userIdentity: &entity.UserIdentity{ID: 1, UserID: 1, Provider: "test", Subject: "subject"}},
+			identityProvider:    &fakeIdentityProvider{identity: &entity.ExternalIdentity{Provider: "test", Subject: "subject"}},
+			twoFactorService:    &fakeTwoFactorService{},
+			want:                entity.NewUserLoginResponse("token", "refresh-token"),
+		},
+		{
+			name:                "Success: Linked by verified email",
+			req:                 req,
+			userService:         &fakeUserService{user: verifiedUser},
+			userIdentityService: &fakeUserIdentityService{},
+			identityProvider:    &fakeIdentityProvider{identity: identity},
+			twoFactorService:    &fakeTwoFactorService{},
+			want:                entity.NewUserLoginResponse("token", "refresh-token"),
+			wantLinkedUserID:    1,
+		},
+		{
+			name:                "Success: Linked by verified email with two-factor challenge",
+			req:                 req,
+			userService:         &fakeUserService{user: verifiedUser},
+			userIdentityService: &fakeUserIdentityService{},
+			identityProvider:    &fakeIdentityProvider{identity: identity},
+			twoFactorService:    &fakeTwoFactorService{enabled: true},
+			want:                entity.NewTwoFactorChallengeResponse("token"),
+			wantLinkedUserID:    1,
+		},
+		{
+			name:                "Success: Sign up",
+			req:                 signupReq,
+			userService:         &fakeUserService{id: 2},
+			userIdentityService: &fakeUserIdentityService{},
+			identityProvider:    &fakeIdentityProvider{identity: identity},
+			twoFactorService:    &fakeTwoFactorService{},
+			want:                entity.NewUserLoginResponse("token", "refresh-token"),
+			wantLinkedUserID:    2,
 		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			u := NewUserUsecase(test.fields.userService, test.fields.profileService, test.fields.config, test.fields.auth, test.fields.hashPassword, test.fields.isValidPasswordHash)
-			got, err := u.Login(context.Background(), test.args.req)
-			if (err != nil) != test.wantErr {
-				t.Errorf("userUsecase.Login() error = %v, wantErr %v", err, test.wantErr)
+			userTokenService := &fakeUserTokenService{err: test.nonceErr}
+			uow := &fakeUnitOfWork{}
+			u := NewUserUsecase(
+				test.userService, &fakeProfileService{}, &fakeRefreshTokenService{token: "refresh-token"}, &fakeTokenRevocationService{}, userTokenService,
+				&fakeLoginThrottleService{}, test.twoFactorService, &fakeSessionService{}, &fakePhoneOTPService{}, test.userIdentityService, &fakePhotoService{},
+				uow, &fakeConfig{}, &fakeAuth{token: "token", actionClaims: mockActionTokenClaims}, &fakeMailer{}, &fakeSMSSender{},
+				&fakePasswordHasher{}, []domain.IdentityProvider{test.identityProvider},
+			)
+			resp, err := u.LoginWithIdentityProvider(context.Background(), test.req)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.LoginWithIdentityProvider() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if !reflect.DeepEqual(resp, test.want) {
+				t.Errorf("userUsecase.LoginWithIdentityProvider() = %v, want %v", resp, test.want)
+			}
+			if test.want != nil && userTokenService.usedPurpose != entity.UserTokenPurposeOIDCNonce {
+				t.Errorf("userUsecase.LoginWithIdentityProvider() used a %q token, want the nonce consumed", userTokenService.usedPurpose)
+			}
+			if uow.rolledBack != test.wantRolledBack {
+				t.Errorf("userUsecase.LoginWithIdentityProvider() rolled back = %v, want %v", uow.rolledBack, test.wantRolledBack)
+			}
+
+			linked := test.userIdentityService.linked
+			if test.wantLinkedUserID == 0 && len(linked) != 0 {
+				t.Errorf("userUsecase.LoginWithIdentityProvider() linked %+v, want no link", linked[0])
+			}
+			if test.wantLinkedUserID != 0 && (len(linked) != 1 || linked[0].UserID != test.wantLinkedUserID || linked[0].Email != "user@email.com") {
+				t.Errorf("userUsecase.LoginWithIdentityProvider() linked %v, want the account linked to user %d by its lowercase email", linked, test.wantLinkedUserID)
+			}
+		})
+	}
+}
 
+func Test_userUsecase_IssueIdentityProviderNonce(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		issueErr error
+		wantErr  error
+	}{
+		{
+			name:     "Failed: Provider not supported",
+			provider: "other",
+			wantErr:  apperror.ErrNotFound,
+		},
+		{
+			name:     "Failed: Too many nonces",
+			provider: "test",
+			issueErr: apperror.New(apperror.KindQuotaExceeded, "Too many unused nonces"),
+			wantErr:  apperror.ErrQuotaExceeded,
+		},
+		{
+			name:     "Success",
+			provider: "test",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			userTokenService := &fakeUserTokenService{err: test.issueErr}
+			unitOfWork := &fakeUnitOfWork{}
+			u := NewUserUsecase(
+				&fakeUserService{}, &fakeProfileService{}, &fakeRefreshTokenService{}, &fakeTokenRevocationService{}, userTokenService,
+				&fakeLoginThrottleService{}, &fakeTwoFactorService{}, &fakeSessionService{}, &fakePhoneOTPService{}, &fakeUserIdentityService{}, &fakePhotoService{},
+				unitOfWork, &fakeConfig{}, &fakeAuth{}, &fakeMailer{}, &fakeSMSSender{}, &fakePasswordHasher{}, []domain.IdentityProvider{&fakeIdentityProvider{}},
+			)
+			resp, err := u.IssueIdentityProviderNonce(domain.WithClientIP(context.Background(), "203.0.113.1"), test.provider)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("userUsecase.IssueIdentityProviderNonce() error = %v, wantErr %v", err, test.wantErr)
+			}
+			if test.wantErr != nil {
 				return
 			}
-			if !reflect.DeepEqual(got, test.want) {
-				t.Errorf("userUsecase.Login() = %v, want %v", got, test.want)
+			if !reflect.DeepEqual(userTokenService.deletedPurposes, []string{entity.UserTokenPurposeOIDCNonce}) {
+				t.Errorf("userUsecase.IssueIdentityProviderNonce() deleted the unusable tokens of %v, want the nonces", userTokenService.deletedPurposes)
+			}
+			if !unitOfWork.committed || userTokenService.createdIP != "203.0.113.1" {
+				t.Errorf("userUsecase.IssueIdentityProviderNonce() did not issue the nonce to the client IP address in a transaction")
+			}
+			if resp.Nonce != userTokenService.createdToken || resp.ExpiresAt.Before(time.Now().UTC().Add(9*time.Minute)) {
+				t.Errorf("userUsecase.IssueIdentityProviderNonce() = %+v, want the issued nonce expiring in 10 minutes", resp)
 			}
 		})
 	}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"crypto/ed25519"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// SigningMethodEdDSA is the EdDSA signing method using Ed25519 keys, which the JWT library does not provide.
+var SigningMethodEdDSA = &signingMethodEdDSA{}
+
+type signingMethodEdDSA struct{}
+
+func init() {
+	jwt.RegisterSigningMethod(SigningMethodEdDSA.Alg(), func() jwt.SigningMethod {
+		return SigningMethodEdDSA
+	})
+}
+
+// Alg is a method for getting the name of the signing method used in the JWT header.
+func (s *signingMethodEdDSA) Alg() string {
+	return "EdDSA"
+}
+
+// Sign is a method for signing a string with an Ed25519 private key.
+func (s *signingMethodEdDSA) Sign(signingString string, key interface{}) (string, error) {
+	privateKey, ok := key.(ed25519.PrivateKey)
+	if !ok {
+		return "", jwt.ErrInvalidKeyType
+	}
+
+	return jwt.EncodeSegment(ed25519.Sign(privateKey, []byte(signingString))), nil
+}
+
+// Verify is a method for verifying the signature of a string with an Ed25519 public key.
+func (s *signingMethodEdDSA) Verify(signingString, signature string, key interface{}) error {
+	publicKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return jwt.ErrInvalidKeyType
+	}
+
+	decoded, err := jwt.DecodeSegment(signature)
+	if err != nil {
+		return err
+	}
+	if !ed25519.Verify(publicKey, []byte(signingString), decoded) {
+		return jwt.ErrSignatureInvalid
+	}
+
+	return nil
+}
@@ -2,30 +2,191 @@
 package auth
 
 import (
+	"errors"
+	"fmt"
+	"strconv"
 	"time"
 
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
+
 	"github.com/dgrijalva/jwt-go"
 )
 
+const tokenIDSize = 16
+
 // JWTClaims is a struct that represents the attributes used to generate the JWT.
+// The user is the subject of the token, and users who signed up with a phone number have no email in it.
+// The role is the one the user had when the token was issued, for clients to tell which features to show.
 type JWTClaims struct {
-	Email string `json:"email"`
+	Email string `json:"email,omitempty"`
+	Role  string `json:"role,omitempty"`
+	jwt.StandardClaims
+	expiration time.Duration
+	keys       *KeySet
+}
+
+// actionClaims is a struct that represents the attributes of a JWT that lets a user perform a single action.
+// The action is the audience of the token, so that an action token is never accepted as an access token or for another action.
+type actionClaims struct {
+	Email string `json:"email,omitempty"`
 	jwt.StandardClaims
 }
 
 // NewJWTClaims is a function used to initialize the JWT claims.
-func NewJWTClaims(expiration time.Duration) *JWTClaims {
+func NewJWTClaims(expiration time.Duration, keys *KeySet) *JWTClaims {
 	return &JWTClaims{
+		expiration: expiration,
+		keys:       keys,
+	}
+}
+
+// GenerateToken is a method for generating JWT with a unique ID, so that it can be revoked on its own, returning its claims along with it.
+// The token is signed with the signing key of the key set and names it in its kid header.
+func (j *JWTClaims) GenerateToken(userID int, email, role string) (*entity.TokenClaims, string, error) {
+	id, err := util.RandomToken(tokenIDSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	currentTime := time.Unix(time.Now().Unix(), 0).UTC()
+	claims := &entity.TokenClaims{
+		ID:        id,
+		UserID:    userID,
+		Email:     email,
+		Role:      role,
+		IssuedAt:  currentTime,
+		ExpiresAt: currentTime.Add(j.expiration),
+	}
+	token, err := j.sign(&JWTClaims{
+		Email: email,
+		Role:  role,
+		StandardClaims: jwt.StandardClaims{
+			Id:        id,
+			Subject:   strconv.Itoa(userID),
+			IssuedAt:  claims.IssuedAt.Unix(),
+			ExpiresAt: claims.ExpiresAt.Unix(),
+		},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return claims, token, nil
+}
+
+// ValidateToken is a method for validating JWT with the key named by its kid header and returning its claims.
+func (j *JWTClaims) ValidateToken(tokenString string) (*entity.TokenClaims, error) {
+	claims := &JWTClaims{}
+	err := j.parse(tokenString, claims)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil || claims.Id == "" || claims.Audience != "" {
+		return nil, errors.New(constant.InvalidToken)
+	}
+
+	return &entity.TokenClaims{
+		ID:        claims.Id,
+		UserID:    userID,
+		Email:     claims.Email,
+		Role:      claims.Role,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0).UTC(),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0).UTC(),
+	}, nil
+}
+
+// GenerateActionToken is a method for generating JWT that lets a user perform the action of the purpose, returning its claims along with it.
+func (j *JWTClaims) GenerateActionToken(purpose string, userID int, email string, expiration time.Duration) (*entity.ActionTokenClaims, string, error) {
+	id, err := util.RandomToken(tokenIDSize)
+	if err != nil {
+		return nil, "", err
+	}
+
+	currentTime := time.Unix(time.Now().Unix(), 0).UTC()
+	claims := &entity.ActionTokenClaims{
+		ID:        id,
+		Purpose:   purpose,
+		UserID:    userID,
+		Email:     email,
+		IssuedAt:  currentTime,
+		ExpiresAt: currentTime.Add(expiration),
+	}
+	token, err := j.sign(&actionClaims{
+		Email: email,
 		StandardClaims: jwt.StandardClaims{
-			ExpiresAt: time.Now().Add(expiration).Unix(),
+			Id:        id,
+			Subject:   strconv.Itoa(userID),
+			Audience:  purpose,
+			IssuedAt:  claims.IssuedAt.Unix(),
+			ExpiresAt: claims.ExpiresAt.Unix(),
 		},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	return claims, token, nil
+}
+
+// ValidateActionToken is a method for validating JWT that lets a user perform the action of the purpose and returning its claims.
+func (j *JWTClaims) ValidateActionToken(purpose, tokenString string) (*entity.ActionTokenClaims, error) {
+	claims := &actionClaims{}
+	err := j.parse(tokenString, claims)
+	if err != nil {
+		return nil, err
+	}
+
+	userID, err := strconv.Atoi(claims.Subject)
+	if err != nil || claims.Id == "" || !claims.VerifyAudience(purpose, true) {
+		return nil, errors.New(constant.InvalidToken)
 	}
+
+	return &entity.ActionTokenClaims{
+		ID:        claims.Id,
+		Purpose:   purpose,
+		UserID:    userID,
+		Email:     claims.Email,
+		IssuedAt:  time.Unix(claims.IssuedAt, 0).UTC(),
+		ExpiresAt: time.Unix(claims.ExpiresAt, 0).UTC(),
+	}, nil
+}
+
+// JWKS is a method for getting the public keys that tokens are verified with.
+func (j *JWTClaims) JWKS() *entity.JSONWebKeySet {
+	return j.keys.JWKS()
+}
+
+// sign is a method for signing the claims with the signing key of the key set, naming it in the kid header.
+func (j *JWTClaims) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(j.keys.signingKey.method, claims)
+	token.Header["kid"] = j.keys.signingKey.id
+
+	return token.SignedString(j.keys.signer)
 }
 
-// GenerateToken is a method for generating JWT.
-func (j *JWTClaims) GenerateToken(email, key string) (string, error) {
-	j.Email = email
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, j)
+// parse is a method for verifying the signature and the time claims of a JWT with the key named by its kid header, decoding the claims.
+func (j *JWTClaims) parse(tokenString string, claims jwt.Claims) error {
+	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, ok := j.keys.find(kid)
+		if !ok {
+			return nil, fmt.Errorf("Unknown signing key: %v", token.Header["kid"])
+		}
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return key.publicKey, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !token.Valid {
+		return errors.New(constant.InvalidToken)
+	}
 
-	return token.SignedString([]byte(key))
+	return nil
 }
@@ -1,40 +1,269 @@
 package auth_test
 
 import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
 	"testing"
 	"time"
 
 	"dealls-technical-test-dating-service/internal/infrastructure/auth"
+
+	"github.com/dgrijalva/jwt-go"
 )
 
+func newEd25519Key(t *testing.T) ed25519.PrivateKey {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("ed25519.GenerateKey() error = %v", err)
+	}
+
+	return privateKey
+}
+
+func newRSAKey(t *testing.T, bits int) *rsa.PrivateKey {
+	t.Helper()
+
+	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+
+	return privateKey
+}
+
+func newKeySet(t *testing.T, signer crypto.Signer, verificationKeys ...crypto.PublicKey) *auth.KeySet {
+	t.Helper()
+
+	keySet, err := auth.NewKeySet(signer, verificationKeys...)
+	if err != nil {
+		t.Fatalf("auth.NewKeySet() error = %v", err)
+	}
+
+	return keySet
+}
+
 func TestJWTClaims_GenerateToken(t *testing.T) {
-	type args struct {
-		email string
-		key   string
+	tests := []struct {
+		name    string
+		signer  crypto.Signer
+		wantAlg string
+	}{
+		{
+			name:    "EdDSA",
+			signer:  newEd25519Key(t),
+			wantAlg: "EdDSA",
+		},
+		{
+			name:    "RS256",
+			signer:  newRSAKey(t, 2048),
+			wantAlg: "RS256",
+		},
 	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keySet := newKeySet(t, tt.signer)
+			j := auth.NewJWTClaims(24*time.Hour, keySet)
+			claims, token, err := j.GenerateToken(1, "user@email.com", "user")
+			if err != nil {
+				t.Fatalf("JWTClaims.GenerateToken() error = %v", err)
+			}
+
+			parsed, _, err := new(jwt.Parser).ParseUnverified(token, &jwt.StandardClaims{})
+			if err != nil {
+				t.Fatalf("jwt.Parser.ParseUnverified() error = %v", err)
+			}
+			if parsed.Header["alg"] != tt.wantAlg || parsed.Header["kid"] != keySet.JWKS().Keys[0].Kid {
+				t.Errorf("JWTClaims.GenerateToken() header = %v, want alg %v and the kid of the signing key", parsed.Header, tt.wantAlg)
+			}
+
+			validated, err := j.ValidateToken(token)
+			if err != nil || *validated != *claims {
+				t.Errorf("JWTClaims.ValidateToken() = %+v, %v, want the generated claims %+v", validated, err, claims)
+			}
+		})
+	}
+}
+
+func TestJWTClaims_ValidateToken(t *testing.T) {
+	signer := newEd25519Key(t)
+	keySet := newKeySet(t, signer)
+	_, validToken, _ := auth.NewJWTClaims(24*time.Hour, keySet).GenerateToken(1, "user@email.com", "admin")
+	_, expiredToken, _ := auth.NewJWTClaims(-time.Hour, keySet).GenerateToken(1, "user@email.com", "user")
+	_, otherKeyToken, _ := auth.NewJWTClaims(24*time.Hour, newKeySet(t, newEd25519Key(t))).GenerateToken(1, "user@email.com", "user")
+	_, phoneToken, _ := auth.NewJWTClaims(24*time.Hour, keySet).GenerateToken(2, "", "user")
+
+	// A token signed with HMAC using the public key as the secret must not be accepted for an asymmetric key.
+	hmacToken := jwt.NewWithClaims(jwt.SigningMethodHS256, &auth.JWTClaims{
+		Email:          "user@email.com",
+		StandardClaims: jwt.StandardClaims{Id: "id", ExpiresAt: time.Now().Add(time.Hour).Unix()},
+	})
+	hmacToken.Header["kid"] = keySet.JWKS().Keys[0].Kid
+	publicKey, _ := x509.MarshalPKIXPublicKey(signer.Public())
+	confusedToken, _ := hmacToken.SignedString(publicKey)
+
+	tests := []struct {
+		name       string
+		token      string
+		want       string
+		wantUserID int
+		wantRole   string
+		wantErr    bool
+	}{
+		{
+			name:    "Failed: Malformed token",
+			token:   "token",
+			wantErr: true,
+		},
+		{
+			name:    "Failed: Unknown signing key",
+			token:   otherKeyToken,
+			wantErr: true,
+		},
+		{
+			name:    "Failed: Expired token",
+			token:   expiredToken,
+			wantErr: true,
+		},
+		{
+			name:    "Failed: Unexpected signing method",
+			token:   confusedToken,
+			wantErr: true,
+		},
+		{
+			name:       "Success",
+			token:      validToken,
+			want:       "user@email.com",
+			wantUserID: 1,
+			wantRole:   "admin",
+			wantErr:    false,
+		},
+		{
+			name:       "Success without email",
+			token:      phoneToken,
+			want:       "",
+			wantUserID: 2,
+			wantRole:   "user",
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := auth.NewJWTClaims(24*time.Hour, keySet)
+			got, err := j.ValidateToken(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("JWTClaims.ValidateToken() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Email != tt.want || got.UserID != tt.wantUserID || got.Role != tt.wantRole || got.ID == "" || !got.ExpiresAt.After(got.IssuedAt) {
+				t.Errorf("JWTClaims.ValidateToken() = %+v, want claims issued to user %v with email %q and role %q", got, tt.wantUserID, tt.want, tt.wantRole)
+			}
+		})
+	}
+}
+
+func TestJWTClaims_ValidateToken_After_Key_Rotation(t *testing.T) {
+	oldSigner := newRSAKey(t, 2048)
+	newSigner := newEd25519Key(t)
+	_, oldToken, _ := auth.NewJWTClaims(24*time.Hour, newKeySet(t, oldSigner)).GenerateToken(1, "user@email.com", "user")
+
+	rotated := auth.NewJWTClaims(24*time.Hour, newKeySet(t, newSigner, oldSigner.Public()))
+	if _, err := rotated.ValidateToken(oldToken); err != nil {
+		t.Errorf("JWTClaims.ValidateToken() error = %v for a token signed with the retiring key", err)
+	}
+
+	_, newToken, _ := rotated.GenerateToken(1, "user@email.com", "user")
+	if _, err := rotated.ValidateToken(newToken); err != nil {
+		t.Errorf("JWTClaims.ValidateToken() error = %v for a token signed with the new key", err)
+	}
+
+	retired := auth.NewJWTClaims(24*time.Hour, newKeySet(t, newSigner))
+	if _, err := retired.ValidateToken(oldToken); err == nil {
+		t.Error("JWTClaims.ValidateToken() accepted a token signed with a retired key")
+	}
+}
+
+func TestJWTClaims_GenerateToken_Unique_ID(t *testing.T) {
+	j := auth.NewJWTClaims(24*time.Hour, newKeySet(t, newEd25519Key(t)))
+	_, token, _ := j.GenerateToken(1, "user@email.com", "user")
+	_, otherToken, _ := j.GenerateToken(1, "user@email.com", "user")
+
+	claims, err := j.ValidateToken(token)
+	if err != nil {
+		t.Fatalf("JWTClaims.ValidateToken() error = %v", err)
+	}
+	otherClaims, err := j.ValidateToken(otherToken)
+	if err != nil {
+		t.Fatalf("JWTClaims.ValidateToken() error = %v", err)
+	}
+	if claims.ID == otherClaims.ID {
+		t.Errorf("JWTClaims.GenerateToken() issued two tokens with the same ID %v", claims.ID)
+	}
+}
+
+func TestJWTClaims_ValidateActionToken(t *testing.T) {
+	j := auth.NewJWTClaims(24*time.Hour, newKeySet(t, newEd25519Key(t)))
+	issued, validToken, err := j.GenerateActionToken("EMAIL_VERIFICATION", 1, "user@email.com", time.Hour)
+	if err != nil {
+		t.Fatalf("JWTClaims.GenerateActionToken() error = %v", err)
+	}
+	_, expiredToken, _ := j.GenerateActionToken("EMAIL_VERIFICATION", 1, "user@email.com", -time.Hour)
+	_, otherPurposeToken, _ := j.GenerateActionToken("PASSWORD_RESET", 1, "user@email.com", time.Hour)
+	_, accessToken, _ := j.GenerateToken(1, "user@email.com", "user")
+
 	tests := []struct {
 		name    string
-		args    args
+		token   string
 		wantErr bool
 	}{
 		{
-			name: "Success",
-			args: args{
-				email: "user@email.com",
-				key:   "key",
-			},
+			name:    "Failed: Expired token",
+			token:   expiredToken,
+			wantErr: true,
+		},
+		{
+			name:    "Failed: Token of another purpose",
+			token:   otherPurposeToken,
+			wantErr: true,
+		},
+		{
+			name:    "Failed: Access token",
+			token:   accessToken,
+			wantErr: true,
+		},
+		{
+			name:    "Success",
+			token:   validToken,
 			wantErr: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			j := auth.NewJWTClaims(24 * time.Hour)
-			_, err := j.GenerateToken(tt.args.email, tt.args.key)
+			got, err := j.ValidateActionToken("EMAIL_VERIFICATION", tt.token)
 			if (err != nil) != tt.wantErr {
-				t.Errorf("JWTClaims.GenerateToken() error = %v, wantErr %v", err, tt.wantErr)
+				t.Errorf("JWTClaims.ValidateActionToken() error = %v, wantErr %v", err, tt.wantErr)
 
 				return
 			}
+			if !tt.wantErr && *got != *issued {
+				t.Errorf("JWTClaims.ValidateActionToken() = %+v, want %+v", got, issued)
+			}
 		})
 	}
 }
+
+func TestJWTClaims_ValidateToken_Rejects_Action_Token(t *testing.T) {
+	j := auth.NewJWTClaims(24*time.Hour, newKeySet(t, newEd25519Key(t)))
+	_, actionToken, _ := j.GenerateActionToken("EMAIL_VERIFICATION", 1, "user@email.com", time.Hour)
+	if _, err := j.ValidateToken(actionToken); err == nil {
+		t.Error("JWTClaims.ValidateToken() accepted an action token as an access token")
+	}
+}
@@ -0,0 +1,236 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/sirupsen/logrus"
+)
+
+const minRSAKeyBits = 2048
+
+// verificationKey is a struct that represents a public key that tokens can be verified with.
+type verificationKey struct {
+	id        string
+	method    jwt.SigningMethod
+	publicKey crypto.PublicKey
+	jwk       *entity.JSONWebKey
+}
+
+// KeySet is a struct that represents the key used to sign new tokens and every key that tokens are still verified with, identified by their kid.
+// Keys are identified by their RFC 7638 thumbprint, so the same key file always gets the same kid on every instance.
+type KeySet struct {
+	signer     crypto.Signer
+	signingKey *verificationKey
+	keys       map[string]*verificationKey
+	jwks       *entity.JSONWebKeySet
+}
+
+// NewKeySet is a function used to initialize the key set with the key that signs new tokens and the public keys of retiring or upcoming keys.
+func NewKeySet(signer crypto.Signer, verificationKeys ...crypto.PublicKey) (*KeySet, error) {
+	signingKey, err := newVerificationKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	keySet := &KeySet{
+		signer:     signer,
+		signingKey: signingKey,
+		keys:       map[string]*verificationKey{},
+		jwks:       &entity.JSONWebKeySet{Keys: []*entity.JSONWebKey{}},
+	}
+	keySet.add(signingKey)
+	for _, publicKey := range verificationKeys {
+		key, err := newVerificationKey(publicKey)
+		if err != nil {
+			return nil, err
+		}
+
+		keySet.add(key)
+	}
+
+	return keySet, nil
+}
+
+// LoadKeySet is a function used to initialize the key set from the PEM file of the signing key and the PEM files of the other verification keys.
+// Without a signing key file, an ephemeral key is generated when it is allowed so that the service can run locally,
+// but its tokens do not survive a restart.
+func LoadKeySet(signingKeyFile string, verificationKeyFiles []string, allowEphemeralKey bool) (*KeySet, error) {
+	var signer crypto.Signer
+	if signingKeyFile == "" {
+		if !allowEphemeralKey {
+			return nil, errors.New("no JWT signing key file is configured, set JWT_SIGNING_KEY_FILE or JWT_ALLOW_EPHEMERAL_KEY=true")
+		}
+
+		logrus.Warn("No JWT signing key file is configured, signing tokens with an ephemeral key")
+
+		_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, err
+		}
+
+		signer = privateKey
+	} else {
+		data, err := os.ReadFile(signingKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		signer, err = parsePrivateKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", signingKeyFile, err)
+		}
+	}
+
+	verificationKeys := make([]crypto.PublicKey, 0, len(verificationKeyFiles))
+	for _, file := range verificationKeyFiles {
+		file = strings.TrimSpace(file)
+		if file == "" {
+			continue
+		}
+
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		publicKey, err := parsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		verificationKeys = append(verificationKeys, publicKey)
+	}
+
+	return NewKeySet(signer, verificationKeys...)
+}
+
+// JWKS is a method for getting the public keys of the key set as a JSON web key set.
+func (k *KeySet) JWKS() *entity.JSONWebKeySet {
+	return k.jwks
+}
+
+// add is a method for adding a verification key to the key set, ignoring a key that is already in it.
+func (k *KeySet) add(key *verificationKey) {
+	if _, ok := k.keys[key.id]; ok {
+		return
+	}
+
+	k.keys[key.id] = key
+	k.jwks.Keys = append(k.jwks.Keys, key.jwk)
+}
+
+// find is a method for finding a verification key by its kid.
+func (k *KeySet) find(id string) (*verificationKey, bool) {
+	key, ok := k.keys[id]
+
+	return key, ok
+}
+
+// newVerificationKey is a function used to initialize the verification key of a public key, choosing its signing method from its type.
+func newVerificationKey(publicKey crypto.PublicKey) (*verificationKey, error) {
+	var (
+		method     jwt.SigningMethod
+		jwk        *entity.JSONWebKey
+		thumbprint string
+	)
+
+	switch key := publicKey.(type) {
+	case *rsa.PublicKey:
+		if key.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA keys must be at least %d bits long", minRSAKeyBits)
+		}
+
+		method = jwt.SigningMethodRS256
+		jwk = &entity.JSONWebKey{
+			Kty: "RSA",
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}
+		thumbprint = fmt.Sprintf(`{"e":%q,"kty":%q,"n":%q}`, jwk.E, jwk.Kty, jwk.N)
+	case ed25519.PublicKey:
+		method = SigningMethodEdDSA
+		jwk = &entity.JSONWebKey{
+			Kty: "OKP",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(key),
+		}
+		thumbprint = fmt.Sprintf(`{"crv":%q,"kty":%q,"x":%q}`, jwk.Crv, jwk.Kty, jwk.X)
+	default:
+		return nil, fmt.Errorf("unsupported key type %T, only RSA and Ed25519 keys are supported", publicKey)
+	}
+
+	hash := sha256.Sum256([]byte(thumbprint))
+	jwk.Kid = base64.RawURLEncoding.EncodeToString(hash[:])
+	jwk.Use = "sig"
+	jwk.Alg = method.Alg()
+
+	return &verificationKey{
+		id:        jwk.Kid,
+		method:    method,
+		publicKey: publicKey,
+		jwk:       jwk,
+	}, nil
+}
+
+// parsePrivateKey is a function to parse an RSA or Ed25519 private key from PEM data in PKCS #1 or PKCS #8 form.
+func parsePrivateKey(data []byte) (crypto.Signer, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		switch signer := key.(type) {
+		case *rsa.PrivateKey:
+			return signer, nil
+		case ed25519.PrivateKey:
+			return signer, nil
+		}
+
+		return nil, fmt.Errorf("unsupported private key type %T, only RSA and Ed25519 keys are supported", key)
+	}
+
+	return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+}
+
+// parsePublicKey is a function to parse a public key from PEM data, which may also hold the private key it belongs to.
+func parsePublicKey(data []byte) (crypto.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM data found")
+	}
+
+	if block.Type == "PUBLIC KEY" {
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	}
+
+	signer, err := parsePrivateKey(data)
+	if err != nil {
+		return nil, err
+	}
+
+	return signer.Public(), nil
+}
@@ -0,0 +1,151 @@
+package auth_test
+
+import (
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/infrastructure/auth"
+)
+
+func writePEM(t *testing.T, blockType string, bytes []byte) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "key.pem")
+	err := os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: bytes}), 0o600)
+	if err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	return file
+}
+
+func TestNewKeySet_JWKS(t *testing.T) {
+	// The key and thumbprint of the Ed25519 example in RFC 8037.
+	seed, _ := base64.RawURLEncoding.DecodeString("nWGxne_9WmC6hEr0kuwsxERJxWl7MmkZcDusAxyuf2A")
+	keySet, err := auth.NewKeySet(ed25519.NewKeyFromSeed(seed))
+	if err != nil {
+		t.Fatalf("auth.NewKeySet() error = %v", err)
+	}
+
+	jwks := keySet.JWKS()
+	if len(jwks.Keys) != 1 {
+		t.Fatalf("KeySet.JWKS() has %d keys, want 1", len(jwks.Keys))
+	}
+
+	key := jwks.Keys[0]
+	if key.Kty != "OKP" || key.Crv != "Ed25519" || key.Alg != "EdDSA" || key.Use != "sig" {
+		t.Errorf("KeySet.JWKS() key = %+v, want an Ed25519 signature key", key)
+	}
+	if key.X != "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo" {
+		t.Errorf("KeySet.JWKS() x = %v", key.X)
+	}
+	if key.Kid != "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k" {
+		t.Errorf("KeySet.JWKS() kid = %v, want the RFC 7638 thumbprint", key.Kid)
+	}
+}
+
+func TestNewKeySet_Failed_Short_RSA_Key(t *testing.T) {
+	_, err := auth.NewKeySet(newRSAKey(t, 1024))
+	if err == nil {
+		t.Error("auth.NewKeySet() error = nil, want an error for a 1024 bits RSA key")
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	signer := newEd25519Key(t)
+	signerBytes, _ := x509.MarshalPKCS8PrivateKey(signer)
+	retiring := newRSAKey(t, 2048)
+	retiringPublicBytes, _ := x509.MarshalPKIXPublicKey(retiring.Public())
+	upcoming := newRSAKey(t, 2048)
+
+	keySet, err := auth.LoadKeySet(writePEM(t, "PRIVATE KEY", signerBytes), []string{
+		writePEM(t, "PUBLIC KEY", retiringPublicBytes),
+		writePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(upcoming)),
+	}, false)
+	if err != nil {
+		t.Fatalf("auth.LoadKeySet() error = %v", err)
+	}
+
+	jwks := keySet.JWKS()
+	if len(jwks.Keys) != 3 || jwks.Keys[0].Alg != "EdDSA" || jwks.Keys[1].Alg != "RS256" || jwks.Keys[2].Alg != "RS256" {
+		t.Fatalf("KeySet.JWKS() = %+v, want the signing key followed by both verification keys", jwks.Keys)
+	}
+
+	_, retiringToken, _ := auth.NewJWTClaims(time.Hour, newKeySet(t, retiring)).GenerateToken(1, "user@email.com", "user")
+	if _, err := auth.NewJWTClaims(time.Hour, keySet).ValidateToken(retiringToken); err != nil {
+		t.Errorf("JWTClaims.ValidateToken() error = %v for a token signed with a verification key", err)
+	}
+}
+
+func TestLoadKeySet_Ephemeral(t *testing.T) {
+	keySet, err := auth.LoadKeySet("", nil, true)
+	if err != nil {
+		t.Fatalf("auth.LoadKeySet() error = %v", err)
+	}
+	if len(keySet.JWKS().Keys) != 1 {
+		t.Errorf("KeySet.JWKS() has %d keys, want the ephemeral signing key", len(keySet.JWKS().Keys))
+	}
+}
+
+func TestLoadKeySet_Failed(t *testing.T) {
+	tests := []struct {
+		name                 string
+		signingKeyFile       string
+		verificationKeyFiles []string
+	}{
+		{
+			name: "No signing key file",
+		},
+		{
+			name:           "Missing signing key file",
+			signingKeyFile: filepath.Join(t.TempDir(), "missing.pem"),
+		},
+		{
+			name:           "Not PEM",
+			signingKeyFile: writeFile(t, "not a key"),
+		},
+		{
+			name:           "Public key as signing key",
+			signingKeyFile: writePEM(t, "PUBLIC KEY", mustMarshalPKIX(t, newEd25519Key(t).Public())),
+		},
+		{
+			name:                 "Invalid verification key",
+			verificationKeyFiles: []string{writePEM(t, "CERTIFICATE", []byte("certificate"))},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := auth.LoadKeySet(tt.signingKeyFile, tt.verificationKeyFiles, false); err == nil {
+				t.Error("auth.LoadKeySet() error = nil, want an error")
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, content string) string {
+	t.Helper()
+
+	file := filepath.Join(t.TempDir(), "file")
+	if err := os.WriteFile(file, []byte(content), 0o600); err != nil {
+		t.Fatalf("os.WriteFile() error = %v", err)
+	}
+
+	return file
+}
+
+func mustMarshalPKIX(t *testing.T, publicKey any) []byte {
+	t.Helper()
+
+	bytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		t.Fatalf("x509.MarshalPKIXPublicKey() error = %v", err)
+	}
+
+	return bytes
+}
@@ -0,0 +1,310 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/config"
+	"dealls-technical-test-dating-service/pkg/constant"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	oidcDiscoveryPath = "/.well-known/openid-configuration"
+	// oidcKeysRefreshInterval is how long after fetching the keys of a provider a token naming an unknown key is rejected
+	// without fetching them again, so that tokens with made up kids cannot make the service flood the provider.
+	oidcKeysRefreshInterval = time.Minute
+	// oidcClockSkew is how far the clock of the provider may be off when checking the time claims of its tokens.
+	oidcClockSkew       = time.Minute
+	oidcRequestTimeout  = 10 * time.Second
+	oidcMaxResponseSize = 1 << 20
+)
+
+// OIDCProvider is a struct used to implement the identity provider interface defined in the domain for an OpenID Connect provider.
+// The signing keys of the provider are found through its discovery document and fetched again when a token names a key that is not known yet,
+// so that the provider can rotate them.
+type OIDCProvider struct {
+	name     string
+	issuer   string
+	clientID string
+	client   *http.Client
+
+	mu            sync.Mutex
+	keys          map[string]*verificationKey
+	keysFetchedAt time.Time
+}
+
+// oidcDiscovery is a struct that represents the attributes of the discovery document of an OpenID Connect provider that are used.
+type oidcDiscovery struct {
+	Issuer  string `json:"issuer"`
+	JWKSURI string `json:"jwks_uri"`
+}
+
+// idTokenClaims is a struct that represents the claims of an ID token that are checked or used.
+type idTokenClaims struct {
+	Issuer          string    `json:"iss"`
+	Subject         string    `json:"sub"`
+	Audience        audience  `json:"aud"`
+	AuthorizedParty string    `json:"azp"`
+	ExpiresAt       int64     `json:"exp"`
+	IssuedAt        int64     `json:"iat"`
+	Nonce           string    `json:"nonce"`
+	Email           string    `json:"email"`
+	EmailVerified   claimBool `json:"email_verified"`
+	Name            string    `json:"name"`
+}
+
+// audience is a type that represents the aud claim, which is either a single string or an array of strings.
+type audience []string
+
+// claimBool is a type that represents a boolean claim, which some providers send as a string.
+type claimBool bool
+
+// NewOIDCProvider is a function used to initialize the OpenID Connect provider that issues ID tokens for the client ID.
+func NewOIDCProvider(name, issuer, clientID string, client *http.Client) *OIDCProvider {
+	return &OIDCProvider{
+		name:     name,
+		issuer:   issuer,
+		clientID: clientID,
+		client:   client,
+		keys:     map[string]*verificationKey{},
+	}
+}
+
+// Name is a method for getting the name that clients choose the provider with.
+func (o *OIDCProvider) Name() string {
+	return o.name
+}
+
+// VerifyIDToken is a method for verifying the signature, issuer, audience, time claims and nonce of an ID token of the provider, returning the account it identifies.
+// Errors reaching the provider are returned as they are, so that they are not mistaken for an invalid token.
+func (o *OIDCProvider) VerifyIDToken(ctx context.Context, idToken, nonce string) (*entity.ExternalIdentity, error) {
+	var keyErr error
+	claims := &idTokenClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := o.key(ctx, kid)
+		if err != nil {
+			keyErr = err
+
+			return nil, err
+		}
+		if token.Method.Alg() != key.method.Alg() {
+			return nil, fmt.Errorf("Unexpected signing method: %v", token.Header["alg"])
+		}
+
+		return key.publicKey, nil
+	})
+	if keyErr != nil {
+		return nil, keyErr
+	}
+	if err != nil {
+		return nil, apperror.Wrap(apperror.KindUnauthorized, constant.InvalidIDToken, err)
+	}
+
+	// A token issued for several clients must name this one as the party it was issued to.
+	if claims.Issuer != o.issuer || claims.Subject == "" || !slices.Contains(claims.Audience, o.clientID) ||
+		len(claims.Audience) > 1 && claims.AuthorizedParty != o.clientID ||
+		subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return nil, apperror.New(apperror.KindUnauthorized, constant.InvalidIDToken)
+	}
+
+	return &entity.ExternalIdentity{
+		Provider:      o.name,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: bool(claims.EmailVerified),
+		Name:          claims.Name,
+	}, nil
+}
+
+// key is a method for finding the signing key of the provider named by a kid, fetching the keys of the provider when it is not known.
+func (o *OIDCProvider) key(ctx context.Context, kid string) (*verificationKey, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	key, ok := o.keys[kid]
+	if ok {
+		return key, nil
+	}
+	if time.Since(o.keysFetchedAt) < oidcKeysRefreshInterval {
+		return nil, apperror.New(apperror.KindUnauthorized, constant.InvalidIDToken)
+	}
+
+	keys, err := o.fetchKeys(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("fetching the keys of the %s identity provider: %w", o.name, err)
+	}
+
+	o.keys = keys
+	o.keysFetchedAt = time.Now()
+
+	key, ok = o.keys[kid]
+	if !ok {
+		return nil, apperror.New(apperror.KindUnauthorized, constant.InvalidIDToken)
+	}
+
+	return key, nil
+}
+
+// fetchKeys is a method for fetching the signing keys of the provider from the JWKS named by its discovery document.
+// Keys of other types than RSA and Ed25519 and keys that are not for signatures are skipped.
+func (o *OIDCProvider) fetchKeys(ctx context.Context) (map[string]*verificationKey, error) {
+	discovery := &oidcDiscovery{}
+	err := o.getJSON(ctx, strings.TrimSuffix(o.issuer, "/")+oidcDiscoveryPath, discovery)
+	if err != nil {
+		return nil, err
+	}
+	if discovery.Issuer != o.issuer {
+		return nil, fmt.Errorf("discovery document of issuer %q", discovery.Issuer)
+	}
+
+	jwks := &entity.JSONWebKeySet{}
+	err = o.getJSON(ctx, discovery.JWKSURI, jwks)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := map[string]*verificationKey{}
+	for _, jwk := range jwks.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := parseJSONWebKey(jwk)
+		if err != nil {
+			continue
+		}
+
+		key, err := newVerificationKey(publicKey)
+		if err != nil || jwk.Alg != "" && jwk.Alg != key.method.Alg() {
+			continue
+		}
+
+		key.id = jwk.Kid
+		keys[jwk.Kid] = key
+	}
+
+	return keys, nil
+}
+
+// getJSON is a method for getting a JSON document from the provider.
+func (o *OIDCProvider) getJSON(ctx context.Context, url string, v interface{}) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+
+	return json.NewDecoder(io.LimitReader(resp.Body, oidcMaxResponseSize)).Decode(v)
+}
+
+// parseJSONWebKey is a function to parse the public key of an RSA or Ed25519 JSON web key.
+func parseJSONWebKey(jwk *entity.JSONWebKey) (crypto.PublicKey, error) {
+	switch {
+	case jwk.Kty == "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(jwk.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(jwk.E)
+		if err != nil {
+			return nil, err
+		}
+
+		exponent := new(big.Int).SetBytes(e)
+		if !exponent.IsInt64() || exponent.Int64() > 1<<31-1 {
+			return nil, errors.New("RSA exponent is too large")
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case jwk.Kty == "OKP" && jwk.Crv == "Ed25519":
+		x, err := base64.RawURLEncoding.DecodeString(jwk.X)
+		if err != nil {
+			return nil, err
+		}
+		if len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 public key size")
+		}
+
+		return ed25519.PublicKey(x), nil
+	}
+
+	return nil, fmt.Errorf("unsupported JSON web key type %q", jwk.Kty)
+}
+
+// Valid is a method for checking the time claims of the ID token, allowing for the clock of the provider being off.
+func (c *idTokenClaims) Valid() error {
+	now := time.Now()
+	if c.ExpiresAt == 0 || now.Add(-oidcClockSkew).After(time.Unix(c.ExpiresAt, 0)) {
+		return errors.New("ID token is expired")
+	}
+	if now.Add(oidcClockSkew).Before(time.Unix(c.IssuedAt, 0)) {
+		return errors.New("ID token is used before it was issued")
+	}
+
+	return nil
+}
+
+// UnmarshalJSON is a method for decoding an aud claim of a single string or an array of strings.
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if json.Unmarshal(data, &single) == nil {
+		*a = audience{single}
+
+		return nil
+	}
+
+	var multiple []string
+	err := json.Unmarshal(data, &multiple)
+	*a = multiple
+
+	return err
+}
+
+// UnmarshalJSON is a method for decoding a boolean claim sent as a boolean or as a string.
+func (b *claimBool) UnmarshalJSON(data []byte) error {
+	value, err := strconv.ParseBool(strings.Trim(string(data), `"`))
+	*b = claimBool(value)
+
+	return err
+}
+
+// NewOIDCProviders is a function used to initialize the OpenID Connect providers given in the configuration.
+func NewOIDCProviders(cfg *config.Config) []domain.IdentityProvider {
+	client := &http.Client{Timeout: oidcRequestTimeout}
+	providers := []domain.IdentityProvider{}
+	for _, provider := range cfg.GetOIDCProviders() {
+		providers = append(providers, NewOIDCProvider(provider.Name, provider.Issuer, provider.ClientID, client))
+	}
+
+	return providers
+}
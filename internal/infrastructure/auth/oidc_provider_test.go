@@ -0,0 +1,110 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/auth"
+	"dealls-technical-test-dating-service/internal/infrastructure/auth/oidctest"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+func TestOIDCProvider_VerifyIDToken(t *testing.T) {
+	issuer := oidctest.NewIssuer()
+	defer issuer.Close()
+	otherIssuer := oidctest.NewIssuer()
+	defer otherIssuer.Close()
+
+	provider := auth.NewOIDCProvider("test", issuer.URL, "client", http.DefaultClient)
+	tests := []struct {
+		name    string
+		idToken string
+		want    *entity.ExternalIdentity
+		wantErr error
+	}{
+		{
+			name:    "Failed: Malformed token",
+			idToken: "token",
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name:    "Failed: Signed by another key",
+			idToken: otherIssuer.IDToken(jwt.MapClaims{"iss": issuer.URL, "aud": "client", "sub": "subject", "nonce": "nonce"}),
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name:    "Failed: Another issuer",
+			idToken: issuer.IDToken(jwt.MapClaims{"iss": otherIssuer.URL, "aud": "client", "sub": "subject", "nonce": "nonce"}),
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name:    "Failed: Another audience",
+			idToken: issuer.IDToken(jwt.MapClaims{"aud": "other-client", "sub": "subject", "nonce": "nonce"}),
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name:    "Failed: Several audiences without authorized party",
+			idToken: issuer.IDToken(jwt.MapClaims{"aud": []string{"other-client", "client"}, "sub": "subject", "nonce": "nonce"}),
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name:    "Failed: Another nonce",
+			idToken: issuer.IDToken(jwt.MapClaims{"aud": "client", "sub": "subject", "nonce": "other-nonce"}),
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name:    "Failed: Missing subject",
+			idToken: issuer.IDToken(jwt.MapClaims{"aud": "client", "nonce": "nonce"}),
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name:    "Failed: Expired",
+			idToken: issuer.IDToken(jwt.MapClaims{"aud": "client", "sub": "subject", "nonce": "nonce", "exp": time.Now().Add(-time.Hour).Unix()}),
+			wantErr: apperror.ErrUnauthorized,
+		},
+		{
+			name:    "Success",
+			idToken: issuer.IDToken(jwt.MapClaims{"aud": "client", "sub": "subject", "nonce": "nonce", "email": "user@email.com", "email_verified": true, "name": "User"}),
+			want:    &entity.ExternalIdentity{Provider: "test", Subject: "subject", Email: "user@email.com", EmailVerified: true, Name: "User"},
+		},
+		{
+			name:    "Success: Several audiences with authorized party and email verified as a string",
+			idToken: issuer.IDToken(jwt.MapClaims{"aud": []string{"other-client", "client"}, "azp": "client", "sub": "subject", "nonce": "nonce", "email": "user@email.com", "email_verified": "true"}),
+			want:    &entity.ExternalIdentity{Provider: "test", Subject: "subject", Email: "user@email.com", EmailVerified: true},
+		},
+		{
+			name:    "Success: Email not verified",
+			idToken: issuer.IDToken(jwt.MapClaims{"aud": "client", "sub": "subject", "nonce": "nonce", "email": "user@email.com"}),
+			want:    &entity.ExternalIdentity{Provider: "test", Subject: "subject", Email: "user@email.com"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := provider.VerifyIDToken(context.Background(), tt.idToken, "nonce")
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("OIDCProvider.VerifyIDToken() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.want != nil && *got != *tt.want {
+				t.Errorf("OIDCProvider.VerifyIDToken() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOIDCProvider_VerifyIDToken_Failed_Provider_Unreachable(t *testing.T) {
+	issuer := oidctest.NewIssuer()
+	idToken := issuer.IDToken(jwt.MapClaims{"aud": "client", "sub": "subject", "nonce": "nonce"})
+	issuer.Close()
+
+	provider := auth.NewOIDCProvider("test", issuer.URL, "client", http.DefaultClient)
+	_, err := provider.VerifyIDToken(context.Background(), idToken, "nonce")
+	if err == nil || errors.Is(err, apperror.ErrUnauthorized) {
+		t.Errorf("OIDCProvider.VerifyIDToken() error = %v, want an error reaching the provider", err)
+	}
+}
@@ -0,0 +1,87 @@
+// Package oidctest provides a fake OpenID Connect provider for tests, in the way httptest provides HTTP servers.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+const (
+	discoveryPath = "/.well-known/openid-configuration"
+	jwksPath      = "/jwks"
+	keyID         = "oidctest"
+)
+
+// Issuer is a struct that represents a local OpenID Connect provider, which serves its discovery document and JWKS
+// and issues ID tokens signed with an RSA key generated when it starts.
+type Issuer struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+}
+
+// NewIssuer is a function used to start a fake OpenID Connect provider, whose issuer is its URL.
+// The caller should call Close when finished, to shut it down.
+func NewIssuer() *Issuer {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		panic("oidctest: generating a key: " + err.Error())
+	}
+
+	issuer := &Issuer{key: key}
+	mux := http.NewServeMux()
+	mux.HandleFunc(discoveryPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, map[string]string{"issuer": issuer.URL, "jwks_uri": issuer.URL + jwksPath})
+	})
+	mux.HandleFunc(jwksPath, func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, &entity.JSONWebKeySet{Keys: []*entity.JSONWebKey{{
+			Kty: "RSA",
+			Use: "sig",
+			Alg: jwt.SigningMethodRS256.Alg(),
+			Kid: keyID,
+			N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	issuer.Server = httptest.NewServer(mux)
+
+	return issuer
+}
+
+// IDToken is a method for issuing an ID token with the claims, such as aud, sub, nonce and email.
+// The iss, iat and exp claims are set to the issuer, the current time and an hour later unless the claims have them.
+func (i *Issuer) IDToken(claims jwt.MapClaims) string {
+	now := time.Now()
+	token := jwt.MapClaims{
+		"iss": i.URL,
+		"iat": now.Unix(),
+		"exp": now.Add(time.Hour).Unix(),
+	}
+	for name, value := range claims {
+		token[name] = value
+	}
+
+	signed := jwt.NewWithClaims(jwt.SigningMethodRS256, token)
+	signed.Header["kid"] = keyID
+	idToken, err := signed.SignedString(i.key)
+	if err != nil {
+		panic("oidctest: signing an ID token: " + err.Error())
+	}
+
+	return idToken
+}
+
+// writeJSON is a function to write a JSON response.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,103 @@
+// Package cache contains in-memory caches shared by the infrastructure implementations.
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepInterval is the number of writes between two removals of the expired entries.
+const sweepInterval = 1024
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+	version   uint64
+}
+
+// TTLCache is a struct that represents a concurrency-safe in-memory cache whose entries expire after a fixed duration.
+// Every call to Set moves the version of the cache forward, so that a value read from the source before a Set can be kept from replacing it.
+type TTLCache[K comparable, V any] struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[K]entry[V]
+	writes  int
+	version uint64
+	now     func() time.Time
+}
+
+// NewTTLCache is a function used to initialize the TTL cache.
+func NewTTLCache[K comparable, V any](ttl time.Duration) *TTLCache[K, V] {
+	return &TTLCache[K, V]{
+		ttl:     ttl,
+		entries: map[K]entry[V]{},
+		now:     time.Now,
+	}
+}
+
+// Get is a method for getting the value of a key that has not expired yet.
+func (c *TTLCache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if !ok || !c.now().Before(cached.expiresAt) {
+		var zero V
+
+		return zero, false
+	}
+
+	return cached.value, true
+}
+
+// Version is a method for getting the current version of the cache, to be taken before reading a value from the source.
+func (c *TTLCache[K, V]) Version() uint64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.version
+}
+
+// Set is a method for setting the value of a key until the TTL elapses.
+func (c *TTLCache[K, V]) Set(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	c.set(key, value, c.version)
+}
+
+// SetIfUnchanged is a method for setting the value of a key read from the source at the version, unless the key was set since.
+// It reports whether the value was set.
+func (c *TTLCache[K, V]) SetIfUnchanged(key K, value V, version uint64) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	cached, ok := c.entries[key]
+	if ok && cached.version > version {
+		return false
+	}
+
+	c.set(key, value, version)
+
+	return true
+}
+
+// set is a method for storing the entry of a key and removing the expired entries every sweep interval, which must be called with the lock held.
+func (c *TTLCache[K, V]) set(key K, value V, version uint64) {
+	now := c.now()
+	c.entries[key] = entry[V]{
+		value:     value,
+		expiresAt: now.Add(c.ttl),
+		version:   version,
+	}
+
+	c.writes++
+	if c.writes%sweepInterval == 0 {
+		for key, cached := range c.entries {
+			if !now.Before(cached.expiresAt) {
+				delete(c.entries, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTTLCache(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewTTLCache[string, int](time.Minute)
+	c.now = func() time.Time { return now }
+
+	if _, ok := c.Get("key"); ok {
+		t.Fatal("TTLCache.Get() found a key that was never set")
+	}
+
+	c.Set("key", 1)
+	if got, ok := c.Get("key"); !ok || got != 1 {
+		t.Errorf("TTLCache.Get() = %v, %v, want 1, true", got, ok)
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok := c.Get("key"); ok {
+		t.Error("TTLCache.Get() found an expired key")
+	}
+}
+
+func TestTTLCache_Sweeps_Expired_Entries(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := NewTTLCache[int, int](time.Minute)
+	c.now = func() time.Time { return now }
+
+	c.Set(-1, 0)
+	now = now.Add(time.Minute)
+	for i := 1; i < sweepInterval; i++ {
+		c.Set(i, i)
+	}
+
+	if _, ok := c.entries[-1]; ok {
+		t.Error("TTLCache.Set() kept an expired entry after a sweep")
+	}
+	if len(c.entries) != sweepInterval-1 {
+		t.Errorf("TTLCache has %d entries, want %d", len(c.entries), sweepInterval-1)
+	}
+}
+
+func TestTTLCache_SetIfUnchanged(t *testing.T) {
+	c := NewTTLCache[string, bool](time.Minute)
+
+	version := c.Version()
+	if !c.SetIfUnchanged("key", false, version) {
+		t.Error("TTLCache.SetIfUnchanged() = false for a key that was not set")
+	}
+
+	// A value read before the key was set must not replace the value set since.
+	version = c.Version()
+	c.Set("key", true)
+	if c.SetIfUnchanged("key", false, version) {
+		t.Error("TTLCache.SetIfUnchanged() = true for a key set after the version")
+	}
+	if got, ok := c.Get("key"); !ok || !got {
+		t.Errorf("TTLCache.Get() = %v, %v, want true, true", got, ok)
+	}
+
+	if !c.SetIfUnchanged("key", true, c.Version()) {
+		t.Error("TTLCache.SetIfUnchanged() = false for a value read after the key was set")
+	}
+}
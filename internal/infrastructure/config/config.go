@@ -2,12 +2,19 @@
 package config
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"slices"
+	"strings"
 	"time"
 
+	"dealls-technical-test-dating-service/internal/domain/entity"
+
 	"github.com/caarlos0/env"
 	"github.com/joho/godotenv"
+	"golang.org/x/crypto/bcrypt"
 )
 
 const (
@@ -17,6 +24,25 @@ const (
 	envDocs    = "envDocs"
 )
 
+// Values of REQUIRE_VERIFIED_EMAIL.
+const (
+	RequireVerifiedEmailNone      = "none"
+	RequireVerifiedEmailDiscovery = "discovery"
+	RequireVerifiedEmailLogin     = "login"
+)
+
+// Values of MAILER.
+const (
+	MailerLog  = "log"
+	MailerSMTP = "smtp"
+)
+
+// Values of PASSWORD_HASH_ALGORITHM.
+const (
+	PasswordHashAlgorithmArgon2id = "argon2id"
+	PasswordHashAlgorithmBcrypt   = "bcrypt"
+)
+
 // Config is a struct that represents a list of environment variables for configuration.
 type Config struct {
 	Port     string `env:"PORT"      envDefault:"8080"    envDocs:"The port that the service listens to"`
@@ -33,8 +59,80 @@ type Config struct {
 	PostgresMaxIdleConnections    int           `env:"POSTGRES_MAX_IDLE_CONNECTIONS"    envDefault:"10"                                 envDocs:"Maximum number of buffered connections to the PostgreSQL"`
 	PostgresConnectionMaxLifetime time.Duration `env:"POSTGRES_CONNECTION_MAX_LIFETIME" envDefault:"3600s"                              envDocs:"Maximum lifetime of an idle connection to PostgreSQL in seconds"`
 
-	JWTKey        string        `env:"JWT_KEY"        envDocs:"Key for the JWT signed string"`
-	JWTExpiration time.Duration `env:"JWT_EXPIRATION" envDefault:"24h"                        envDocs:"JWT expiration duration"`
+	JWTSigningKeyFile       string        `env:"JWT_SIGNING_KEY_FILE"                             envDocs:"Path of the PEM file of the RSA or Ed25519 private key that signs new JWTs"`
+	JWTAllowEphemeralKey    bool          `env:"JWT_ALLOW_EPHEMERAL_KEY" envDefault:"false"        envDocs:"Whether JWTs are signed with an ephemeral key when JWT_SIGNING_KEY_FILE is empty, for local development only"`
+	JWTVerificationKeyFiles []string      `env:"JWT_VERIFICATION_KEY_FILES" envSeparator:","       envDocs:"Comma-separated paths of the PEM files of other keys that JWTs are still verified with, such as a retiring key"`
+	JWTExpiration           time.Duration `env:"JWT_EXPIRATION"           envDefault:"15m"  envDocs:"JWT expiration duration"`
+	RefreshTokenExpiration  time.Duration `env:"REFRESH_TOKEN_EXPIRATION" envDefault:"720h" envDocs:"Refresh token expiration duration"`
+
+	TokenRevocationCacheTTL time.Duration `env:"TOKEN_REVOCATION_CACHE_TTL" envDefault:"30s" envDocs:"Duration for which token revocation lookups are cached in memory, and thus how long a revocation made by another instance may take to apply"`
+
+	DailySwipeLimit    int    `env:"DAILY_SWIPE_LIMIT"     envDefault:"10"  envDocs:"Maximum number of swipes a user can make per day"`
+	SwipeResetTimeZone string `env:"SWIPE_RESET_TIME_ZONE" envDefault:"UTC" envDocs:"IANA time zone, such as Asia/Jakarta, whose midnight starts a new day for the swipe quota and for swiping on a profile again"`
+
+	PasswordHashAlgorithm string `env:"PASSWORD_HASH_ALGORITHM" envDefault:"argon2id" envDocs:"Algorithm new password hashes are made with: argon2id or bcrypt, hashes of the other one are still verified and upgraded on login"`
+	Argon2idMemory        int    `env:"ARGON2ID_MEMORY"         envDefault:"19456"    envDocs:"Memory used by Argon2id in KiB"`
+	Argon2idIterations    int    `env:"ARGON2ID_ITERATIONS"     envDefault:"2"        envDocs:"Number of passes of Argon2id over the memory"`
+	Argon2idParallelism   int    `env:"ARGON2ID_PARALLELISM"    envDefault:"1"        envDocs:"Number of threads used by Argon2id"`
+	BcryptCost            int    `env:"BCRYPT_COST"             envDefault:"10"       envDocs:"Cost of bcrypt, between 4 and 31"`
+
+	TrustedClientIPHeader       string        `env:"TRUSTED_CLIENT_IP_HEADER"                           envDocs:"Header set by the reverse proxy with the client IP address, such as X-Forwarded-For, the connection address is used when empty"`
+	LoginMaxFailedAttempts      int           `env:"LOGIN_MAX_FAILED_ATTEMPTS"        envDefault:"5"   envDocs:"Number of failed login attempts for an email address after which it is locked out, 0 to disable"`
+	LoginMaxFailedAttemptsPerIP int           `env:"LOGIN_MAX_FAILED_ATTEMPTS_PER_IP" envDefault:"50"  envDocs:"Number of failed login attempts from an IP address after which it is locked out, 0 to disable"`
+	LoginLockoutDuration        time.Duration `env:"LOGIN_LOCKOUT_DURATION"           envDefault:"1m"  envDocs:"Duration of the first lockout, which doubles with every further failed attempt"`
+	LoginMaxLockoutDuration     time.Duration `env:"LOGIN_MAX_LOCKOUT_DURATION"       envDefault:"1h"  envDocs:"Maximum duration of a lockout"`
+	LoginFailedAttemptWindow    time.Duration `env:"LOGIN_FAILED_ATTEMPT_WINDOW"      envDefault:"1h"  envDocs:"Duration without failed attempts after which the count of failed login attempts starts over"`
+
+	RequireVerifiedEmail             string        `env:"REQUIRE_VERIFIED_EMAIL"              envDefault:"none" envDocs:"What users must verify their email address for: none, discovery, or login (which implies discovery)"`
+	EmailVerificationTokenExpiration time.Duration `env:"EMAIL_VERIFICATION_TOKEN_EXPIRATION" envDefault:"24h"  envDocs:"Email verification token expiration duration"`
+	EmailVerificationResendInterval  time.Duration `env:"EMAIL_VERIFICATION_RESEND_INTERVAL"  envDefault:"1m"   envDocs:"Minimum duration between two verification emails sent to the same user"`
+	EmailVerificationURL             string        `env:"EMAIL_VERIFICATION_URL"              envDefault:"http://localhost:8080/verify-email" envDocs:"URL of the page that verifies the email address, given the token query parameter"`
+
+	PasswordResetTokenExpiration time.Duration `env:"PASSWORD_RESET_TOKEN_EXPIRATION" envDefault:"1h" envDocs:"Password reset token expiration duration"`
+	PasswordResetRequestInterval time.Duration `env:"PASSWORD_RESET_REQUEST_INTERVAL" envDefault:"1m" envDocs:"Minimum duration between two password reset emails sent to the same user"`
+	PasswordResetURL             string        `env:"PASSWORD_RESET_URL"              envDefault:"http://localhost:8080/reset-password" envDocs:"URL of the page that resets the password, given the token query parameter"`
+
+	EmailChangeTokenExpiration time.Duration `env:"EMAIL_CHANGE_TOKEN_EXPIRATION" envDefault:"24h" envDocs:"Email change confirmation token expiration duration"`
+	EmailChangeURL             string        `env:"EMAIL_CHANGE_URL"              envDefault:"http://localhost:8080/confirm-email-change" envDocs:"URL of the page that confirms a new email address, given the token query parameter"`
+
+	TwoFactorIssuer              string        `env:"TWO_FACTOR_ISSUER"               envDefault:"Dating Service" envDocs:"Name that authenticator apps show next to the accounts"`
+	TwoFactorChallengeExpiration time.Duration `env:"TWO_FACTOR_CHALLENGE_EXPIRATION" envDefault:"5m"             envDocs:"Duration the challenge token returned by the login of users with two-factor authentication can be exchanged for tokens"`
+
+	PhoneOTPExpiration         time.Duration `env:"PHONE_OTP_EXPIRATION"             envDefault:"5m" envDocs:"Duration the one-time password sent by SMS to sign up or log in with a phone number can be used"`
+	PhoneOTPMaxAttempts        int           `env:"PHONE_OTP_MAX_ATTEMPTS"           envDefault:"5"  envDocs:"Number of wrong codes after which a one-time password sent by SMS cannot be used anymore"`
+	PhoneOTPResendInterval     time.Duration `env:"PHONE_OTP_RESEND_INTERVAL"        envDefault:"1m" envDocs:"Minimum duration between two one-time passwords sent to the same phone number"`
+	PhoneOTPMaxDailySends      int           `env:"PHONE_OTP_MAX_DAILY_SENDS"        envDefault:"5"  envDocs:"Number of one-time passwords sent to a phone number in 24 hours after which no more are sent, 0 to disable"`
+	PhoneOTPMaxDailySendsPerIP int           `env:"PHONE_OTP_MAX_DAILY_SENDS_PER_IP" envDefault:"20" envDocs:"Number of one-time passwords requested from an IP address in 24 hours after which no more are sent, 0 to disable"`
+	SMSLogFile                 string        `env:"SMS_LOG_FILE"                                     envDocs:"Path of the file that text messages are appended to in place of sending them, the log is used when empty"`
+
+	BlobStoreDir          string        `env:"BLOB_STORE_DIR"           envDefault:"data/blobs"                            envDocs:"Directory that uploaded files, such as profile photos, are stored in"`
+	BlobBaseURL           string        `env:"BLOB_BASE_URL"            envDefault:"http://localhost:8080/dating/v1/blobs" envDocs:"Public URL of the blob API that the signed URLs of stored files start with"`
+	BlobURLSigningKey     string        `env:"BLOB_URL_SIGNING_KEY"                                                        envDocs:"Secret key that the URLs of stored files are signed with"`
+	BlobAllowEphemeralKey bool          `env:"BLOB_ALLOW_EPHEMERAL_KEY" envDefault:"false"                                 envDocs:"Whether URLs are signed with an ephemeral key when BLOB_URL_SIGNING_KEY is empty, for local development only"`
+	PhotoMaxSize          int           `env:"PHOTO_MAX_SIZE"           envDefault:"5242880"                               envDocs:"Maximum size in bytes of an uploaded photo"`
+	PhotoURLExpiration    time.Duration `env:"PHOTO_URL_EXPIRATION"     envDefault:"1h"                                    envDocs:"Duration the signed URLs of photos can be used"`
+
+	OIDCProviders       []string      `env:"OIDC_PROVIDERS"        envSeparator:"," envDocs:"Comma-separated OpenID Connect providers users can log in with, each given as name|issuer|client_id"`
+	OIDCNonceExpiration time.Duration `env:"OIDC_NONCE_EXPIRATION" envDefault:"10m" envDocs:"Duration the nonce issued for a login with an OpenID Connect provider can be used"`
+	OIDCNonceMaxPerIP   int           `env:"OIDC_NONCE_MAX_PER_IP" envDefault:"20"  envDocs:"Number of unused nonces an IP address can hold, after which no more are issued to it until they are used or expire, 0 to disable"`
+
+	Mailer       string `env:"MAILER"        envDefault:"log"                   envDocs:"How emails are sent: log to write them to MAIL_LOG_FILE or the log, or smtp"`
+	MailFrom     string `env:"MAIL_FROM"     envDefault:"no-reply@dating.local" envDocs:"Sender address of emails"`
+	MailLogFile  string `env:"MAIL_LOG_FILE"                                    envDocs:"Path of the file that emails are appended to by the log mailer, the log is used when empty"`
+	SMTPHost     string `env:"SMTP_HOST"                                        envDocs:"Host of the SMTP server"`
+	SMTPPort     string `env:"SMTP_PORT"     envDefault:"587"                   envDocs:"Port of the SMTP server"`
+	SMTPUsername string `env:"SMTP_USERNAME"                                    envDocs:"Username to authenticate to the SMTP server, authentication is skipped when empty"`
+	SMTPPassword string `env:"SMTP_PASSWORD"                                    envDocs:"Password to authenticate to the SMTP server"`
+
+	// swipeResetLocation is the time zone named by SWIPE_RESET_TIME_ZONE, which is resolved once when the configuration is loaded.
+	swipeResetLocation *time.Location
+}
+
+// OIDCProvider is a struct that represents an OpenID Connect provider given in OIDC_PROVIDERS.
+type OIDCProvider struct {
+	Name     string
+	Issuer   string
+	ClientID string
 }
 
 // LoadConfig is the function used to load the configuration..
@@ -44,12 +142,62 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 
+	return ParseConfig()
+}
+
+// ParseConfig is the function used to parse and validate the configuration from the environment variables already set.
+func ParseConfig() (*Config, error) {
 	var cfg Config
-	err = env.Parse(&cfg)
+	err := env.Parse(&cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	if !slices.Contains([]string{RequireVerifiedEmailNone, RequireVerifiedEmailDiscovery, RequireVerifiedEmailLogin}, cfg.RequireVerifiedEmail) {
+		return nil, fmt.Errorf("invalid REQUIRE_VERIFIED_EMAIL: %q", cfg.RequireVerifiedEmail)
+	}
+	if !slices.Contains([]string{MailerLog, MailerSMTP}, cfg.Mailer) {
+		return nil, fmt.Errorf("invalid MAILER: %q", cfg.Mailer)
+	}
+	if !slices.Contains([]string{PasswordHashAlgorithmArgon2id, PasswordHashAlgorithmBcrypt}, cfg.PasswordHashAlgorithm) {
+		return nil, fmt.Errorf("invalid PASSWORD_HASH_ALGORITHM: %q", cfg.PasswordHashAlgorithm)
+	}
+	if cfg.Argon2idIterations < 1 || cfg.Argon2idParallelism < 1 || cfg.Argon2idParallelism > math.MaxUint8 ||
+		cfg.Argon2idMemory < 8*cfg.Argon2idParallelism || cfg.Argon2idMemory > math.MaxUint32 {
+		return nil, errors.New("invalid ARGON2ID_MEMORY, ARGON2ID_ITERATIONS or ARGON2ID_PARALLELISM")
+	}
+	if cfg.DailySwipeLimit < 0 {
+		return nil, fmt.Errorf("invalid DAILY_SWIPE_LIMIT: %d", cfg.DailySwipeLimit)
+	}
+	cfg.swipeResetLocation, err = time.LoadLocation(cfg.SwipeResetTimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SWIPE_RESET_TIME_ZONE: %q", cfg.SwipeResetTimeZone)
+	}
+	if cfg.PhoneOTPMaxAttempts < 1 {
+		return nil, fmt.Errorf("invalid PHONE_OTP_MAX_ATTEMPTS: %d", cfg.PhoneOTPMaxAttempts)
+	}
+	if cfg.PhoneOTPMaxDailySends < 0 || cfg.PhoneOTPMaxDailySendsPerIP < 0 {
+		return nil, errors.New("invalid PHONE_OTP_MAX_DAILY_SENDS or PHONE_OTP_MAX_DAILY_SENDS_PER_IP")
+	}
+	if cfg.OIDCNonceMaxPerIP < 0 {
+		return nil, fmt.Errorf("invalid OIDC_NONCE_MAX_PER_IP: %d", cfg.OIDCNonceMaxPerIP)
+	}
+	if cfg.PhotoMaxSize < 1 {
+		return nil, fmt.Errorf("invalid PHOTO_MAX_SIZE: %d", cfg.PhotoMaxSize)
+	}
+	names := map[string]bool{}
+	for _, entry := range cfg.OIDCProviders {
+		provider, ok := parseOIDCProvider(entry)
+		if !ok || names[provider.Name] {
+			return nil, fmt.Errorf("invalid OIDC_PROVIDERS entry: %q", entry)
+		}
+
+		names[provider.Name] = true
+	}
+	if cfg.BcryptCost < bcrypt.MinCost || cfg.BcryptCost > bcrypt.MaxCost {
+		return nil, fmt.Errorf("invalid BCRYPT_COST: %d", cfg.BcryptCost)
+	}
+
 	return &cfg, nil
 }
 
@@ -57,21 +205,149 @@ func LoadConfig() (*Config, error) {
 func (c Config) Help() []string {
 	config := reflect.TypeOf(c)
 
-	help := make([]string, 1+config.NumField())
+	help := make([]string, 1, 1+config.NumField())
 	help[0] = helpTitle
 
 	for i := 0; i < config.NumField(); i++ {
 		field := config.Field(i)
 		env := field.Tag.Get(envName)
+		if env == "" {
+			continue
+		}
+
 		envDefault := field.Tag.Get(envDefault)
 		envDocs := field.Tag.Get(envDocs)
-		help[i+1] = fmt.Sprintf("%s | Description: %s | Default: %s", env, envDocs, envDefault)
+		help = append(help, fmt.Sprintf("%s | Description: %s | Default: %s", env, envDocs, envDefault))
 	}
 
 	return help
 }
 
-// GetJWTKey is a method for getting the JWT key.
-func (c Config) GetJWTKey() string {
-	return c.JWTKey
+// GetRefreshTokenExpiration is a method for getting the refresh token expiration duration.
+func (c Config) GetRefreshTokenExpiration() time.Duration {
+	return c.RefreshTokenExpiration
+}
+
+// GetDailySwipeLimit is a method for getting the daily swipe limit.
+func (c Config) GetDailySwipeLimit() int {
+	return c.DailySwipeLimit
+}
+
+// GetSwipeResetLocation is a method for getting the time zone whose calendar days swipes are counted on, which is resolved when the configuration is loaded.
+func (c Config) GetSwipeResetLocation() *time.Location {
+	return c.swipeResetLocation
+}
+
+// GetLoginAccountThrottlePolicy is a method for getting the policy of the failed login attempts counted against an email address.
+func (c Config) GetLoginAccountThrottlePolicy() *entity.LoginThrottlePolicy {
+	return entity.NewLoginThrottlePolicy(c.LoginMaxFailedAttempts, c.LoginLockoutDuration, c.LoginMaxLockoutDuration, c.LoginFailedAttemptWindow)
+}
+
+// GetLoginIPThrottlePolicy is a method for getting the policy of the failed login attempts counted against an IP address.
+func (c Config) GetLoginIPThrottlePolicy() *entity.LoginThrottlePolicy {
+	return entity.NewLoginThrottlePolicy(c.LoginMaxFailedAttemptsPerIP, c.LoginLockoutDuration, c.LoginMaxLockoutDuration, c.LoginFailedAttemptWindow)
+}
+
+// GetPhoneOTPPolicy is a method for getting the policy of the one-time passwords sent by SMS.
+func (c Config) GetPhoneOTPPolicy() *entity.PhoneOTPPolicy {
+	return entity.NewPhoneOTPPolicy(c.PhoneOTPExpiration, c.PhoneOTPMaxAttempts, c.PhoneOTPResendInterval, c.PhoneOTPMaxDailySends, c.PhoneOTPMaxDailySendsPerIP)
+}
+
+// GetPhotoPolicy is a method for getting the policy of the photos uploaded by users.
+func (c Config) GetPhotoPolicy() *entity.PhotoPolicy {
+	return entity.NewPhotoPolicy(c.PhotoMaxSize, c.PhotoURLExpiration)
+}
+
+// GetOIDCProviders is a method for getting the OpenID Connect providers given in OIDC_PROVIDERS, which are validated when the configuration is loaded.
+func (c Config) GetOIDCProviders() []*OIDCProvider {
+	providers := make([]*OIDCProvider, 0, len(c.OIDCProviders))
+	for _, entry := range c.OIDCProviders {
+		provider, ok := parseOIDCProvider(entry)
+		if ok {
+			providers = append(providers, provider)
+		}
+	}
+
+	return providers
+}
+
+// parseOIDCProvider is a function to parse an OIDC_PROVIDERS entry, made of the name, the issuer and the client ID of the provider.
+func parseOIDCProvider(entry string) (*OIDCProvider, bool) {
+	parts := strings.Split(strings.TrimSpace(entry), "|")
+	if len(parts) != 3 || slices.Contains(parts, "") {
+		return nil, false
+	}
+
+	return &OIDCProvider{Name: parts[0], Issuer: parts[1], ClientID: parts[2]}, true
+}
+
+// GetOIDCNonceExpiration is a method for getting the duration the nonce issued for a login with an OpenID Connect provider can be used.
+func (c Config) GetOIDCNonceExpiration() time.Duration {
+	return c.OIDCNonceExpiration
+}
+
+// GetOIDCNonceMaxPerIP is a method for getting the number of unused nonces an IP address can hold.
+func (c Config) GetOIDCNonceMaxPerIP() int {
+	return c.OIDCNonceMaxPerIP
+}
+
+// GetEmailVerificationTokenExpiration is a method for getting the email verification token expiration duration.
+func (c Config) GetEmailVerificationTokenExpiration() time.Duration {
+	return c.EmailVerificationTokenExpiration
+}
+
+// GetEmailVerificationResendInterval is a method for getting the minimum duration between two verification emails.
+func (c Config) GetEmailVerificationResendInterval() time.Duration {
+	return c.EmailVerificationResendInterval
+}
+
+// GetEmailVerificationURL is a method for getting the URL of the page that verifies the email address.
+func (c Config) GetEmailVerificationURL() string {
+	return c.EmailVerificationURL
+}
+
+// GetPasswordResetTokenExpiration is a method for getting the password reset token expiration duration.
+func (c Config) GetPasswordResetTokenExpiration() time.Duration {
+	return c.PasswordResetTokenExpiration
+}
+
+// GetPasswordResetRequestInterval is a method for getting the minimum duration between two password reset emails.
+func (c Config) GetPasswordResetRequestInterval() time.Duration {
+	return c.PasswordResetRequestInterval
+}
+
+// GetPasswordResetURL is a method for getting the URL of the page that resets the password.
+func (c Config) GetPasswordResetURL() string {
+	return c.PasswordResetURL
+}
+
+// GetEmailChangeTokenExpiration is a method for getting the email change confirmation token expiration duration.
+func (c Config) GetEmailChangeTokenExpiration() time.Duration {
+	return c.EmailChangeTokenExpiration
+}
+
+// GetEmailChangeURL is a method for getting the URL of the page that confirms a new email address.
+func (c Config) GetEmailChangeURL() string {
+	return c.EmailChangeURL
+}
+
+// GetTwoFactorIssuer is a method for getting the name that authenticator apps show next to the accounts.
+func (c Config) GetTwoFactorIssuer() string {
+	return c.TwoFactorIssuer
+}
+
+// GetTwoFactorChallengeExpiration is a method for getting the two-factor authentication challenge token expiration duration.
+func (c Config) GetTwoFactorChallengeExpiration() time.Duration {
+	return c.TwoFactorChallengeExpiration
+}
+
+// IsVerifiedEmailRequiredForLogin is a method for checking whether users must verify their email address to log in.
+func (c Config) IsVerifiedEmailRequiredForLogin() bool {
+	return c.RequireVerifiedEmail == RequireVerifiedEmailLogin
+}
+
+// IsVerifiedEmailRequiredForDiscovery is a method for checking whether users must verify their email address to be shown in discovery.
+// Users who cannot log in before verifying are hidden as well.
+func (c Config) IsVerifiedEmailRequiredForDiscovery() bool {
+	return c.RequireVerifiedEmail == RequireVerifiedEmailDiscovery || c.RequireVerifiedEmail == RequireVerifiedEmailLogin
 }
@@ -0,0 +1,58 @@
+package config
+
+import "testing"
+
+// setRequiredEnv is a function to set the environment variables that have no default.
+func setRequiredEnv(t *testing.T) {
+	t.Setenv("POSTGRES_HOST", "localhost")
+	t.Setenv("POSTGRES_PORT", "5432")
+	t.Setenv("POSTGRES_USER", "postgres")
+	t.Setenv("POSTGRES_PASSWORD", "postgres")
+}
+
+func TestParseConfig(t *testing.T) {
+	setRequiredEnv(t)
+	t.Setenv("SWIPE_RESET_TIME_ZONE", "Asia/Jakarta")
+
+	cfg, err := ParseConfig()
+	if err != nil {
+		t.Fatalf("ParseConfig() error = %v", err)
+	}
+	if got := cfg.GetSwipeResetLocation().String(); got != "Asia/Jakarta" {
+		t.Errorf("GetSwipeResetLocation() = %v, want Asia/Jakarta", got)
+	}
+	for _, help := range cfg.Help() {
+		if help == " | Description:  | Default: " {
+			t.Error("Help() lists a field that is not an environment variable")
+		}
+	}
+}
+
+func TestParseConfig_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		key   string
+		value string
+	}{
+		{
+			name:  "Negative daily swipe limit",
+			key:   "DAILY_SWIPE_LIMIT",
+			value: "-1",
+		},
+		{
+			name:  "Unknown swipe reset time zone",
+			key:   "SWIPE_RESET_TIME_ZONE",
+			value: "Mars/Olympus_Mons",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setRequiredEnv(t)
+			t.Setenv(tt.key, tt.value)
+
+			if _, err := ParseConfig(); err == nil {
+				t.Errorf("ParseConfig() with %s=%s succeeded, want an error", tt.key, tt.value)
+			}
+		})
+	}
+}
@@ -34,10 +34,13 @@ type Postgres struct {
 // NewPostgres is a function used to initialize the PostgreSQL client.
 func NewPostgres(cfg *config.Config) (*Postgres, error) {
 	postgresDSN := fmt.Sprintf("host=%s port=%s dbname=%s user=%s password=%s sslmode=%s", cfg.PostgresHost, cfg.PostgresPort, cfg.PostgresDBName, cfg.PostgresUser, cfg.PostgresPassword, cfg.PostgresSSLMode)
+	// Errors are translated so that repositories can tell unique and foreign key violations apart from other errors, such as a user signing up
+	// with an email address another request has just taken, which is a conflict rather than an internal error.
 	postgresClient, err := gorm.Open(postgres.Open(postgresDSN), &gorm.Config{
 		DisableAutomaticPing:   true,
 		SkipDefaultTransaction: true,
 		PrepareStmt:            true,
+		TranslateError:         true,
 	})
 	if err != nil {
 		return nil, err
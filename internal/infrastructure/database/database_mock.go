@@ -20,7 +20,8 @@ func DBMock(t *testing.T) (*sql.DB, *gorm.DB, sqlmock.Sqlmock) {
 	}
 
 	gormDB, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
-		Logger: logger.Default.LogMode(logger.Info),
+		Logger:         logger.Default.LogMode(logger.Info),
+		TranslateError: true,
 	})
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,19 @@
+create table if not exists two_factors
+(
+  user_id integer primary key references users(id),
+  secret varchar(64) not null,
+  last_used_step bigint not null default 0,
+  confirmed_at timestamp with time zone,
+  created_at timestamp with time zone not null default current_timestamp,
+  updated_at timestamp with time zone not null default current_timestamp
+);
+
+create table if not exists recovery_codes
+(
+  id serial primary key,
+  user_id integer not null references users(id),
+  code_hash varchar(64) not null,
+  created_at timestamp with time zone not null default current_timestamp,
+  used_at timestamp with time zone,
+  unique (user_id, code_hash)
+);
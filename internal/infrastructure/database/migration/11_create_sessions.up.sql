@@ -0,0 +1,14 @@
+create table if not exists sessions
+(
+  id serial primary key,
+  user_id integer not null references users(id),
+  token_id varchar(64) not null unique,
+  refresh_family_id varchar(64) not null,
+  device_name varchar(255) not null,
+  ip_address varchar(64) not null,
+  created_at timestamp with time zone not null default current_timestamp,
+  last_seen_at timestamp with time zone not null default current_timestamp
+);
+
+create index if not exists sessions_user_id_idx on sessions (user_id);
+create index if not exists sessions_refresh_family_id_idx on sessions (refresh_family_id);
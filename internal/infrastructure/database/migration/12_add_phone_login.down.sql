@@ -0,0 +1,5 @@
+drop table if exists phone_otps;
+alter table users drop constraint if exists users_email_or_phone_check;
+alter table users drop column if exists phone_verified_at;
+alter table users drop column if exists phone;
+alter table users alter column email set not null;
@@ -0,0 +1,17 @@
+alter table users alter column email drop not null;
+alter table users add column if not exists phone varchar(16) unique;
+alter table users add column if not exists phone_verified_at timestamp with time zone;
+alter table users add constraint users_email_or_phone_check check (email is not null or phone is not null);
+
+create table if not exists phone_otps
+(
+  id serial primary key,
+  phone varchar(16) not null,
+  code_hash varchar(64) not null,
+  attempts integer not null default 0,
+  expires_at timestamp with time zone not null,
+  created_at timestamp with time zone not null default current_timestamp,
+  used_at timestamp with time zone
+);
+
+create index if not exists phone_otps_phone_created_at_idx on phone_otps (phone, created_at);
@@ -0,0 +1,12 @@
+create table if not exists user_identities
+(
+  id serial primary key,
+  user_id integer not null references users(id),
+  provider varchar(64) not null,
+  subject varchar(255) not null,
+  email varchar(255) not null,
+  created_at timestamp with time zone not null default current_timestamp,
+  unique (provider, subject)
+);
+
+create index if not exists user_identities_user_id_idx on user_identities (user_id);
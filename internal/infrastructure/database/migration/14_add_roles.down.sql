@@ -0,0 +1,5 @@
+alter table users drop column if exists role;
+
+drop table if exists role_permissions;
+
+drop table if exists roles;
@@ -0,0 +1,21 @@
+create table if not exists roles
+(
+  name varchar(32) primary key
+);
+
+create table if not exists role_permissions
+(
+  role varchar(32) not null references roles(name) on delete cascade,
+  permission varchar(64) not null,
+  primary key (role, permission)
+);
+
+insert into roles (name) values ('user'), ('moderator'), ('admin') on conflict do nothing;
+
+insert into role_permissions (role, permission) values
+  ('moderator', 'users:read'),
+  ('admin', 'users:read'),
+  ('admin', 'users:manage_roles')
+on conflict do nothing;
+
+alter table users add column if not exists role varchar(32) not null default 'user' references roles(name);
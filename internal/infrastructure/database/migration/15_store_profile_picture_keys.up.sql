@@ -0,0 +1 @@
+alter table users rename column profile_picture_url to profile_picture_key;
@@ -0,0 +1,2 @@
+drop index if exists activities_user_id_profile_id_activity_date_idx;
+alter table activities drop column if exists activity_date;
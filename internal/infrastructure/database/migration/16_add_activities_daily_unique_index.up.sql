@@ -0,0 +1,7 @@
+alter table activities add column if not exists activity_date date;
+update activities set activity_date = (created_at at time zone 'UTC')::date where activity_date is null;
+-- Only the first swipe of a user on a profile on a date is kept, as the unique index below requires.
+delete from activities duplicate using activities first
+where duplicate.user_id = first.user_id and duplicate.profile_id = first.profile_id and duplicate.activity_date = first.activity_date and duplicate.id > first.id;
+alter table activities alter column activity_date set not null;
+create unique index if not exists activities_user_id_profile_id_activity_date_idx on activities (user_id, profile_id, activity_date);
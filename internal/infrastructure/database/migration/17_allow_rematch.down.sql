@@ -0,0 +1,5 @@
+drop index if exists matches_user_one_id_user_two_id_current_idx;
+-- Only the first match of a pair of users is kept, as the unique constraint below requires.
+delete from matches later using matches first
+where later.user_one_id = first.user_one_id and later.user_two_id = first.user_two_id and later.id > first.id;
+alter table matches add constraint matches_user_one_id_user_two_id_key unique (user_one_id, user_two_id);
@@ -0,0 +1,3 @@
+alter table matches drop constraint if exists matches_user_one_id_user_two_id_key;
+-- A pair of users has at most one current match, while any number of unmatched ones are kept as history.
+create unique index if not exists matches_user_one_id_user_two_id_current_idx on matches (user_one_id, user_two_id) where unmatched_at is null;
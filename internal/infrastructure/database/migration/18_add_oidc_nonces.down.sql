@@ -0,0 +1,2 @@
+delete from user_tokens where user_id is null;
+alter table user_tokens alter column user_id set not null;
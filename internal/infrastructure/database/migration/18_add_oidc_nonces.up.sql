@@ -0,0 +1 @@
+alter table user_tokens alter column user_id drop not null;
@@ -0,0 +1,3 @@
+drop index if exists phone_otps_ip_address_created_at_idx;
+
+alter table phone_otps drop column if exists ip_address;
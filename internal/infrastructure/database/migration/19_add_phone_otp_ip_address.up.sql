@@ -0,0 +1,3 @@
+alter table phone_otps add column if not exists ip_address varchar(64);
+
+create index if not exists phone_otps_ip_address_created_at_idx on phone_otps (ip_address, created_at);
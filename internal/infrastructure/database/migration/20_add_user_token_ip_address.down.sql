@@ -0,0 +1,4 @@
+drop index if exists user_tokens_purpose_expires_at_idx;
+drop index if exists user_tokens_purpose_ip_address_idx;
+
+alter table user_tokens drop column if exists ip_address;
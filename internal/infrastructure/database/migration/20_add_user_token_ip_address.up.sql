@@ -0,0 +1,4 @@
+alter table user_tokens add column if not exists ip_address varchar(64);
+
+create index if not exists user_tokens_purpose_ip_address_idx on user_tokens (purpose, ip_address);
+create index if not exists user_tokens_purpose_expires_at_idx on user_tokens (purpose, expires_at);
@@ -0,0 +1 @@
+drop index if exists activity_counters_user_id_date_idx;
@@ -0,0 +1 @@
+create unique index if not exists activity_counters_user_id_date_idx on activity_counters (user_id, date);
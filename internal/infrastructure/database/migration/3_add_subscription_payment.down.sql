@@ -0,0 +1,5 @@
+drop index if exists subscriptions_user_id_active_idx;
+
+alter table subscriptions drop column if exists payment_reference;
+
+alter table subscription_packages drop column if exists price;
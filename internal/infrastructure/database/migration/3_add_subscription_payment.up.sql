@@ -0,0 +1,10 @@
+alter table subscription_packages add column if not exists price bigint not null default 0;
+
+alter table subscriptions add column if not exists payment_reference varchar(255);
+
+create unique index if not exists subscriptions_user_id_active_idx on subscriptions (user_id) where active;
+
+insert into subscription_packages (name, lifetime_days, price) values
+  ('PREMIUM_MONTHLY', 30, 99000),
+  ('PREMIUM_YEARLY', 365, 899000)
+on conflict (name) do nothing;
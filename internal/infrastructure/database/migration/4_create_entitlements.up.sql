@@ -0,0 +1,14 @@
+create table if not exists entitlements
+(
+  id serial primary key,
+  subscription_package_id integer not null references subscription_packages(id),
+  feature varchar(50) not null check (feature IN ('NO_SWIPE_QUOTA', 'VERIFIED_LABEL')),
+  unique (subscription_package_id, feature)
+);
+
+insert into entitlements (subscription_package_id, feature)
+select subscription_packages.id, features.feature
+from subscription_packages
+cross join (values ('NO_SWIPE_QUOTA'), ('VERIFIED_LABEL')) as features(feature)
+where subscription_packages.name in ('PREMIUM_MONTHLY', 'PREMIUM_YEARLY')
+on conflict (subscription_package_id, feature) do nothing;
@@ -0,0 +1,12 @@
+create table if not exists matches
+(
+  id serial primary key,
+  user_one_id integer not null references users(id),
+  user_two_id integer not null references users(id),
+  created_at timestamp with time zone not null default current_timestamp,
+  unmatched_at timestamp with time zone,
+  check (user_one_id < user_two_id),
+  unique (user_one_id, user_two_id)
+);
+
+create index if not exists matches_user_two_id_idx on matches (user_two_id);
@@ -0,0 +1,13 @@
+create table if not exists refresh_tokens
+(
+  id serial primary key,
+  user_id integer not null references users(id),
+  family_id varchar(64) not null,
+  token_hash varchar(64) not null unique,
+  expires_at timestamp with time zone not null,
+  created_at timestamp with time zone not null default current_timestamp,
+  rotated_at timestamp with time zone,
+  revoked_at timestamp with time zone
+);
+
+create index if not exists refresh_tokens_family_id_idx on refresh_tokens (family_id);
@@ -0,0 +1,2 @@
+drop table if exists user_token_revocations;
+drop table if exists revoked_tokens;
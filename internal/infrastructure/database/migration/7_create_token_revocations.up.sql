@@ -0,0 +1,15 @@
+create table if not exists revoked_tokens
+(
+  id varchar(64) primary key,
+  user_id integer not null references users(id),
+  expires_at timestamp with time zone not null,
+  revoked_at timestamp with time zone not null default current_timestamp
+);
+
+create index if not exists revoked_tokens_expires_at_idx on revoked_tokens (expires_at);
+
+create table if not exists user_token_revocations
+(
+  user_id integer primary key references users(id),
+  revoked_before timestamp with time zone not null
+);
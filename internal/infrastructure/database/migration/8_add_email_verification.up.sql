@@ -0,0 +1,15 @@
+alter table users add column if not exists email_verified_at timestamp with time zone;
+
+create table if not exists user_tokens
+(
+  id serial primary key,
+  user_id integer not null references users(id),
+  purpose varchar(32) not null,
+  token_hash varchar(64) not null unique,
+  email varchar(255) not null,
+  expires_at timestamp with time zone not null,
+  created_at timestamp with time zone not null default current_timestamp,
+  used_at timestamp with time zone
+);
+
+create index if not exists user_tokens_user_id_purpose_created_at_idx on user_tokens (user_id, purpose, created_at);
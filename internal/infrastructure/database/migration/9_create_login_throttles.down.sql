@@ -0,0 +1,2 @@
+drop table if exists login_lockouts;
+drop table if exists login_throttles;
@@ -0,0 +1,26 @@
+create table if not exists login_throttles
+(
+  id serial primary key,
+  scope varchar(16) not null,
+  subject varchar(255) not null,
+  failed_attempts integer not null,
+  last_failed_at timestamp with time zone not null,
+  locked_until timestamp with time zone,
+  created_at timestamp with time zone not null default current_timestamp,
+  updated_at timestamp with time zone not null default current_timestamp,
+  unique (scope, subject)
+);
+
+create table if not exists login_lockouts
+(
+  id serial primary key,
+  scope varchar(16) not null,
+  subject varchar(255) not null,
+  user_id integer references users(id),
+  ip_address varchar(64) not null,
+  failed_attempts integer not null,
+  locked_until timestamp with time zone not null,
+  created_at timestamp with time zone not null default current_timestamp
+);
+
+create index if not exists login_lockouts_subject_idx on login_lockouts (scope, subject);
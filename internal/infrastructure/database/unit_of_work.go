@@ -0,0 +1,76 @@
+package database
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+)
+
+type transactionContextKey struct{}
+
+// transaction is a struct that represents a transaction carried by the context along with the functions to run once it is committed.
+type transaction struct {
+	tx          *gorm.DB
+	afterCommit []func()
+}
+
+// UnitOfWorkImpl is a struct used to implement the unit of work interface defined in the domain.
+type UnitOfWorkImpl struct {
+	db *gorm.DB
+}
+
+// NewUnitOfWork is a function used to initialize the unit of work implementation.
+func NewUnitOfWork(db *gorm.DB) *UnitOfWorkImpl {
+	return &UnitOfWorkImpl{
+		db: db,
+	}
+}
+
+// Do is a method for running a function inside a transaction carried by the context.
+// Nested calls run inside a savepoint of the outer transaction, so their after-commit functions wait for the outer commit.
+func (u *UnitOfWorkImpl) Do(ctx context.Context, fn func(ctx context.Context) error) error {
+	current := &transaction{}
+	err := Conn(ctx, u.db).Transaction(func(tx *gorm.DB) error {
+		current.tx = tx
+
+		return fn(context.WithValue(ctx, transactionContextKey{}, current))
+	})
+	if err != nil {
+		return err
+	}
+
+	if outer, ok := ctx.Value(transactionContextKey{}).(*transaction); ok {
+		outer.afterCommit = append(outer.afterCommit, current.afterCommit...)
+
+		return nil
+	}
+
+	for _, afterCommit := range current.afterCommit {
+		afterCommit()
+	}
+
+	return nil
+}
+
+// Conn is a function used by repositories to get the transaction carried by the context, or the database when there is none.
+func Conn(ctx context.Context, db *gorm.DB) *gorm.DB {
+	t, ok := ctx.Value(transactionContextKey{}).(*transaction)
+	if ok {
+		return t.tx.WithContext(ctx)
+	}
+
+	return db.WithContext(ctx)
+}
+
+// AfterCommit is a function used by repositories to run fn once the transaction carried by the context is committed, or right away when there is none.
+// fn is dropped when the transaction is rolled back.
+func AfterCommit(ctx context.Context, fn func()) {
+	t, ok := ctx.Value(transactionContextKey{}).(*transaction)
+	if !ok {
+		fn()
+
+		return
+	}
+
+	t.afterCommit = append(t.afterCommit, fn)
+}
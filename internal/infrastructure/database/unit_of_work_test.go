@@ -0,0 +1,125 @@
+package database_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+func signup(ctx context.Context, userRepo *repository.UserRepositoryImpl, profileRepo *repository.ProfileRepositoryImpl) error {
+	currentTime := time.Now()
+	user := entity.NewUser("user@email.com", "password", "User", "2000-01-01", "MALE", "Indonesia", "", currentTime, currentTime)
+	id, err := userRepo.Insert(ctx, user)
+	if err != nil {
+		return err
+	}
+
+	return profileRepo.Insert(ctx, entity.NewProfile(id, "", "", false, currentTime, currentTime))
+}
+
+func TestUnitOfWorkImpl_Do_Rollback(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM \"users\"").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("INSERT INTO \"users\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM \"profiles\"").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("INSERT INTO \"profiles\" (.+) VALUES (.+)").WillReturnError(schema.ErrUnsupportedDataType)
+	mock.ExpectRollback()
+
+	unitOfWork := database.NewUnitOfWork(gormDB)
+	userRepo := repository.NewUserRepository(gormDB)
+	profileRepo := repository.NewProfileRepository(gormDB)
+	err := unitOfWork.Do(context.TODO(), func(ctx context.Context) error {
+		return signup(ctx, userRepo, profileRepo)
+	})
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWorkImpl_Do_Commit(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT (.+) FROM \"users\"").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("INSERT INTO \"users\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectQuery("SELECT (.+) FROM \"profiles\"").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectQuery("INSERT INTO \"profiles\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	unitOfWork := database.NewUnitOfWork(gormDB)
+	userRepo := repository.NewUserRepository(gormDB)
+	profileRepo := repository.NewProfileRepository(gormDB)
+	err := unitOfWork.Do(context.TODO(), func(ctx context.Context) error {
+		return signup(ctx, userRepo, profileRepo)
+	})
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWorkImpl_Do_AfterCommit(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("SAVEPOINT").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	unitOfWork := database.NewUnitOfWork(gormDB)
+	committed := false
+	err := unitOfWork.Do(context.TODO(), func(ctx context.Context) error {
+		err := unitOfWork.Do(ctx, func(ctx context.Context) error {
+			database.AfterCommit(ctx, func() {
+				committed = true
+			})
+
+			return nil
+		})
+		assert.False(t, committed)
+
+		return err
+	})
+	require.NoError(t, err)
+	assert.True(t, committed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUnitOfWorkImpl_Do_AfterCommit_Rollback(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+
+	unitOfWork := database.NewUnitOfWork(gormDB)
+	committed := false
+	err := unitOfWork.Do(context.TODO(), func(ctx context.Context) error {
+		database.AfterCommit(ctx, func() {
+			committed = true
+		})
+
+		return schema.ErrUnsupportedDataType
+	})
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.False(t, committed)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
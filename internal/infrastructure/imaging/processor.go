@@ -0,0 +1,225 @@
+// Package imaging contains the implementation of the image processor interface defined in the domain package.
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image"
+	"image/draw"
+	"image/jpeg"
+
+	// PNG images are decoded through image.Decode.
+	_ "image/png"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+const (
+	// maxPixels is the largest number of pixels of an image that is decoded, since a small file can declare a huge image.
+	maxPixels = 50_000_000
+	// jpegQuality is the quality of the JPEG images the processor encodes.
+	jpegQuality = 85
+	// orientationTag is the EXIF tag of the orientation of the camera relative to the scene.
+	orientationTag = 0x0112
+)
+
+// Processor is a struct used to implement the image processor interface with the standard library.
+type Processor struct{}
+
+// NewProcessor is a function used to initialize the image processor.
+func NewProcessor() *Processor {
+	return &Processor{}
+}
+
+// Resize is a method for decoding a JPEG or PNG image and encoding an upright JPEG copy of it, without metadata, for each size.
+// Images are scaled down to fit in a square of the size and never scaled up.
+func (p *Processor) Resize(data []byte, sizes []int) ([][]byte, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, invalidPhoto("photo must be a JPEG or PNG image")
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > maxPixels {
+		return nil, invalidPhoto("photo must be at most 50 megapixels")
+	}
+
+	decoded, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, invalidPhoto("photo must be a JPEG or PNG image")
+	}
+
+	src := orient(toRGBA(decoded), exifOrientation(data))
+	images := make([][]byte, len(sizes))
+	for i, size := range sizes {
+		var buffer bytes.Buffer
+		err = jpeg.Encode(&buffer, fit(src, size), &jpeg.Options{Quality: jpegQuality})
+		if err != nil {
+			return nil, err
+		}
+
+		images[i] = buffer.Bytes()
+	}
+
+	return images, nil
+}
+
+// invalidPhoto is a function to get the validation error of an uploaded photo that cannot be processed.
+func invalidPhoto(message string) error {
+	return apperror.Validation(constant.InvalidRequestBody, apperror.Field("photo", message))
+}
+
+// toRGBA is a function to convert an image to RGBA with its top left corner at the origin.
+func toRGBA(img image.Image) *image.RGBA {
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+
+	return rgba
+}
+
+// fit is a function to scale an image down to fit in a square of the size, averaging the source pixels covered by each pixel.
+func fit(src *image.RGBA, size int) *image.RGBA {
+	srcWidth, srcHeight := src.Rect.Dx(), src.Rect.Dy()
+	if srcWidth <= size && srcHeight <= size {
+		return src
+	}
+
+	width, height := size, size
+	if srcWidth > srcHeight {
+		height = max(1, srcHeight*size/srcWidth)
+	} else {
+		width = max(1, srcWidth*size/srcHeight)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		y0, y1 := y*srcHeight/height, max((y+1)*srcHeight/height, y*srcHeight/height+1)
+		for x := 0; x < width; x++ {
+			x0, x1 := x*srcWidth/width, max((x+1)*srcWidth/width, x*srcWidth/width+1)
+
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				row := src.Pix[sy*src.Stride+x0*4 : sy*src.Stride+x1*4]
+				for i := 0; i < len(row); i += 4 {
+					sum[0] += int(row[i])
+					sum[1] += int(row[i+1])
+					sum[2] += int(row[i+2])
+					sum[3] += int(row[i+3])
+				}
+			}
+
+			count := (x1 - x0) * (y1 - y0)
+			offset := y*dst.Stride + x*4
+			for i := range sum {
+				dst.Pix[offset+i] = uint8(sum[i] / count)
+			}
+		}
+	}
+
+	return dst
+}
+
+// orient is a function to turn an image upright according to its EXIF orientation, from 1 (already upright) to 8.
+func orient(src *image.RGBA, orientation int) *image.RGBA {
+	if orientation < 2 || orientation > 8 {
+		return src
+	}
+
+	width, height := src.Rect.Dx(), src.Rect.Dy()
+	dstWidth, dstHeight := width, height
+	if orientation >= 5 {
+		dstWidth, dstHeight = height, width
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstWidth, dstHeight))
+	for y := 0; y < dstHeight; y++ {
+		for x := 0; x < dstWidth; x++ {
+			var sx, sy int
+			switch orientation {
+			case 2:
+				sx, sy = width-1-x, y
+			case 3:
+				sx, sy = width-1-x, height-1-y
+			case 4:
+				sx, sy = x, height-1-y
+			case 5:
+				sx, sy = y, x
+			case 6:
+				sx, sy = y, height-1-x
+			case 7:
+				sx, sy = width-1-y, height-1-x
+			case 8:
+				sx, sy = width-1-y, x
+			}
+
+			copy(dst.Pix[y*dst.Stride+x*4:y*dst.Stride+x*4+4], src.Pix[sy*src.Stride+sx*4:sy*src.Stride+sx*4+4])
+		}
+	}
+
+	return dst
+}
+
+// exifOrientation is a function to read the orientation in the EXIF metadata of a JPEG image, which is 1 when there is none.
+func exifOrientation(data []byte) int {
+	if len(data) < 4 || data[0] != 0xFF || data[1] != 0xD8 {
+		return 1
+	}
+
+	// The metadata is in an APP1 segment before the start of the scan, and every segment before it starts with its length.
+	for i := 2; i+4 <= len(data) && data[i] == 0xFF; {
+		marker := data[i+1]
+		if marker == 0xDA || marker == 0xD9 {
+			break
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		if length < 2 || i+2+length > len(data) {
+			break
+		}
+
+		segment := data[i+4 : i+2+length]
+		if marker == 0xE1 && bytes.HasPrefix(segment, []byte("Exif\x00\x00")) {
+			return tiffOrientation(segment[6:])
+		}
+
+		i += 2 + length
+	}
+
+	return 1
+}
+
+// tiffOrientation is a function to read the orientation tag in the first directory of the TIFF structure of EXIF metadata.
+func tiffOrientation(tiff []byte) int {
+	if len(tiff) < 8 {
+		return 1
+	}
+
+	var order binary.ByteOrder
+	switch string(tiff[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return 1
+	}
+
+	offset := int(order.Uint32(tiff[4:]))
+	if offset < 8 || offset+2 > len(tiff) {
+		return 1
+	}
+
+	count := int(order.Uint16(tiff[offset:]))
+	for i := 0; i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > len(tiff) {
+			break
+		}
+
+		if order.Uint16(tiff[entry:]) == orientationTag {
+			return int(order.Uint16(tiff[entry+8:]))
+		}
+	}
+
+	return 1
+}
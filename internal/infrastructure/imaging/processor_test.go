@@ -0,0 +1,133 @@
+package imaging_test
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/infrastructure/imaging"
+)
+
+// testImage is a function to get an image that is red on its left half and blue on its right half.
+func testImage(width, height int) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			c := color.RGBA{R: 255, A: 255}
+			if x >= width/2 {
+				c = color.RGBA{B: 255, A: 255}
+			}
+
+			img.Set(x, y, c)
+		}
+	}
+
+	return img
+}
+
+// withExif is a function to insert an APP1 segment with the orientation and a GPS directory pointer after the start of a JPEG image.
+func withExif(t *testing.T, data []byte, orientation uint16) []byte {
+	t.Helper()
+
+	tiff := new(bytes.Buffer)
+	tiff.WriteString("MM")
+	binary.Write(tiff, binary.BigEndian, uint16(42))
+	binary.Write(tiff, binary.BigEndian, uint32(8))
+	binary.Write(tiff, binary.BigEndian, uint16(2))
+	binary.Write(tiff, binary.BigEndian, []uint16{0x0112, 3})
+	binary.Write(tiff, binary.BigEndian, uint32(1))
+	binary.Write(tiff, binary.BigEndian, []uint16{orientation, 0})
+	binary.Write(tiff, binary.BigEndian, []uint16{0x8825, 4})
+	binary.Write(tiff, binary.BigEndian, uint32(1))
+	binary.Write(tiff, binary.BigEndian, uint32(0))
+	binary.Write(tiff, binary.BigEndian, uint32(0))
+
+	segment := append([]byte("Exif\x00\x00"), tiff.Bytes()...)
+	app1 := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(app1[2:], uint16(len(segment)+2))
+
+	result := append([]byte{}, data[:2]...)
+	result = append(result, app1...)
+	result = append(result, segment...)
+
+	return append(result, data[2:]...)
+}
+
+func encodeJPEG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+
+	var buffer bytes.Buffer
+	if err := jpeg.Encode(&buffer, img, nil); err != nil {
+		t.Fatalf("jpeg.Encode() error = %v", err)
+	}
+
+	return buffer.Bytes()
+}
+
+func decode(t *testing.T, data []byte) image.Image {
+	t.Helper()
+
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("jpeg.Decode() error = %v", err)
+	}
+
+	return img
+}
+
+func TestProcessor_Resize_Failed_Not_An_Image(t *testing.T) {
+	_, err := imaging.NewProcessor().Resize([]byte("not an image"), []int{100})
+	if !errors.Is(err, apperror.ErrValidation) {
+		t.Errorf("Processor.Resize() error = %v, want a validation error", err)
+	}
+}
+
+func TestProcessor_Resize_Success_Fits_Sizes(t *testing.T) {
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, testImage(400, 200)); err != nil {
+		t.Fatalf("png.Encode() error = %v", err)
+	}
+
+	images, err := imaging.NewProcessor().Resize(buffer.Bytes(), []int{1000, 100})
+	if err != nil {
+		t.Fatalf("Processor.Resize() error = %v", err)
+	}
+
+	if bounds := decode(t, images[0]).Bounds(); bounds.Dx() != 400 || bounds.Dy() != 200 {
+		t.Errorf("Processor.Resize() large size = %v, want the image not scaled up", bounds)
+	}
+	if bounds := decode(t, images[1]).Bounds(); bounds.Dx() != 100 || bounds.Dy() != 50 {
+		t.Errorf("Processor.Resize() small size = %v, want 100x50", bounds)
+	}
+}
+
+func TestProcessor_Resize_Success_Upright_Without_Exif(t *testing.T) {
+	data := withExif(t, encodeJPEG(t, testImage(200, 100)), 6)
+
+	images, err := imaging.NewProcessor().Resize(data, []int{200})
+	if err != nil {
+		t.Fatalf("Processor.Resize() error = %v", err)
+	}
+
+	if bytes.Contains(images[0], []byte("Exif")) {
+		t.Errorf("Processor.Resize() kept the EXIF metadata")
+	}
+
+	// Turned a quarter clockwise, the red left half of the image ends up at the top.
+	img := decode(t, images[0])
+	if bounds := img.Bounds(); bounds.Dx() != 100 || bounds.Dy() != 200 {
+		t.Fatalf("Processor.Resize() size = %v, want 100x200", bounds)
+	}
+	if r, _, b, _ := img.At(50, 20).RGBA(); r < b {
+		t.Errorf("Processor.Resize() top is not red")
+	}
+	if r, _, b, _ := img.At(50, 180).RGBA(); b < r {
+		t.Errorf("Processor.Resize() bottom is not blue")
+	}
+}
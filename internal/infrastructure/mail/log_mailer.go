@@ -0,0 +1,39 @@
+package mail
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+const logMailSeparator = "\r\n--------\r\n"
+
+// LogMailer is a struct used to implement the mailer interface by writing emails to a file or the log instead of sending them, for local runs.
+type LogMailer struct {
+	mu   sync.Mutex
+	w    io.Writer
+	from string
+}
+
+// NewLogMailer is a function used to initialize the log mailer that writes emails to the writer.
+func NewLogMailer(w io.Writer, from string) *LogMailer {
+	return &LogMailer{
+		w:    w,
+		from: from,
+	}
+}
+
+// Send is a method for writing a mail followed by a separator.
+func (l *LogMailer) Send(_ context.Context, mail *entity.Mail) error {
+	message := append(compose(l.from, mail, time.Now()), logMailSeparator...)
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err := l.w.Write(message)
+
+	return err
+}
@@ -0,0 +1,45 @@
+package mail_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/mail"
+)
+
+func TestLogMailer_Send(t *testing.T) {
+	var buffer bytes.Buffer
+	m := mail.NewLogMailer(&buffer, "no-reply@dating.local")
+	err := m.Send(context.Background(), entity.NewMail("user@email.com", "Hello", "Line 1\nLine 2"))
+	if err != nil {
+		t.Fatalf("LogMailer.Send() error = %v", err)
+	}
+
+	got := buffer.String()
+	for _, want := range []string{
+		"From: no-reply@dating.local\r\n",
+		"To: user@email.com\r\n",
+		"Subject: Hello\r\n",
+		"Content-Type: text/plain; charset=utf-8\r\n",
+		"\r\n\r\nLine 1\r\nLine 2",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("LogMailer.Send() wrote %q, want it to contain %q", got, want)
+		}
+	}
+}
+
+func TestLogMailer_Send_Header_Injection(t *testing.T) {
+	var buffer bytes.Buffer
+	m := mail.NewLogMailer(&buffer, "no-reply@dating.local")
+	err := m.Send(context.Background(), entity.NewMail("user@email.com\r\nBcc: other@email.com", "Hello", "Body"))
+	if err != nil {
+		t.Fatalf("LogMailer.Send() error = %v", err)
+	}
+	if strings.Contains(buffer.String(), "\r\nBcc:") {
+		t.Errorf("LogMailer.Send() wrote an injected header: %q", buffer.String())
+	}
+}
@@ -0,0 +1,29 @@
+package mail
+
+import (
+	"os"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/infrastructure/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewMailer is a function used to initialize the mailer selected by the configuration.
+// The log mailer appends to MAIL_LOG_FILE when it is set, and writes to the log otherwise.
+func NewMailer(cfg *config.Config) (domain.Mailer, error) {
+	if cfg.Mailer == config.MailerSMTP {
+		return NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom), nil
+	}
+
+	if cfg.MailLogFile == "" {
+		return NewLogMailer(logrus.StandardLogger().WriterLevel(logrus.InfoLevel), cfg.MailFrom), nil
+	}
+
+	file, err := os.OpenFile(cfg.MailLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLogMailer(file, cfg.MailFrom), nil
+}
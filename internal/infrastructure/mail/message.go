@@ -0,0 +1,30 @@
+// Package mail contains implementations of the mailer interface defined in the domain package.
+package mail
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"strings"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// headerReplacer removes line breaks from header values, so that a value cannot add headers of its own.
+var headerReplacer = strings.NewReplacer("\r", "", "\n", "")
+
+// compose is a function to compose the plain text message of a mail as it is sent over SMTP.
+func compose(from string, mail *entity.Mail, date time.Time) []byte {
+	var message bytes.Buffer
+	fmt.Fprintf(&message, "From: %s\r\n", headerReplacer.Replace(from))
+	fmt.Fprintf(&message, "To: %s\r\n", headerReplacer.Replace(mail.To))
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", headerReplacer.Replace(mail.Subject)))
+	fmt.Fprintf(&message, "Date: %s\r\n", date.Format(time.RFC1123Z))
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	message.WriteString("\r\n")
+	message.WriteString(strings.ReplaceAll(strings.ReplaceAll(mail.Body, "\r\n", "\n"), "\n", "\r\n"))
+
+	return message.Bytes()
+}
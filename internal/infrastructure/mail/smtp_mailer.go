@@ -0,0 +1,37 @@
+package mail
+
+import (
+	"context"
+	"net"
+	"net/smtp"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// SMTPMailer is a struct used to implement the mailer interface by sending emails through an SMTP server.
+type SMTPMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer is a function used to initialize the SMTP mailer.
+// The server is authenticated to only when a username is given.
+func NewSMTPMailer(host, port, username, password, from string) *SMTPMailer {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &SMTPMailer{
+		addr: net.JoinHostPort(host, port),
+		from: from,
+		auth: auth,
+	}
+}
+
+// Send is a method for sending a mail, upgrading the connection with STARTTLS when the server supports it.
+func (s *SMTPMailer) Send(_ context.Context, mail *entity.Mail) error {
+	return smtp.SendMail(s.addr, s.auth, s.from, []string{mail.To}, compose(s.from, mail, time.Now()))
+}
@@ -0,0 +1,106 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+)
+
+const (
+	argon2idPrefix     = "$argon2id$"
+	argon2idSaltLength = 16
+	argon2idKeyLength  = 32
+)
+
+// Argon2idParams is a struct that represents the cost parameters of Argon2id, the memory being in KiB.
+type Argon2idParams struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// Argon2idHasher is a struct used to hash passwords with Argon2id into PHC strings, such as $argon2id$v=19$m=19456,t=2,p=1$<salt>$<hash>.
+type Argon2idHasher struct {
+	params Argon2idParams
+}
+
+// NewArgon2idHasher is a function used to initialize the Argon2id password hasher.
+func NewArgon2idHasher(params Argon2idParams) *Argon2idHasher {
+	return &Argon2idHasher{
+		params: params,
+	}
+}
+
+// Hash is a method for hashing a password with a random salt.
+func (a *Argon2idHasher) Hash(password string) (string, error) {
+	salt := make([]byte, argon2idSaltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	key := argon2.IDKey([]byte(password), salt, a.params.Iterations, a.params.Memory, a.params.Parallelism, argon2idKeyLength)
+
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2idPrefix, argon2.Version, a.params.Memory, a.params.Iterations, a.params.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify is a method for checking a password against a hash, using the parameters the hash was made with.
+func (a *Argon2idHasher) Verify(password, hashedPassword string) bool {
+	params, salt, key, err := parseArgon2idHash(hashedPassword)
+	if err != nil {
+		return false
+	}
+
+	got := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(got, key) == 1
+}
+
+// NeedsRehash is a method for checking whether a hash is not an Argon2id hash made with the current parameters.
+func (a *Argon2idHasher) NeedsRehash(hashedPassword string) bool {
+	params, _, _, err := parseArgon2idHash(hashedPassword)
+
+	return err != nil || params != a.params
+}
+
+// Recognizes is a method for checking whether a hash is an Argon2id hash.
+func (a *Argon2idHasher) Recognizes(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, argon2idPrefix)
+}
+
+// parseArgon2idHash is a function to get the parameters, the salt and the key of an Argon2id PHC string.
+func parseArgon2idHash(hashedPassword string) (Argon2idParams, []byte, []byte, error) {
+	params := Argon2idParams{}
+	parts := strings.Split(hashedPassword, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return params, nil, nil, errInvalidHash
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return params, nil, nil, errInvalidHash
+	}
+
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism)
+	if err != nil || params.Iterations == 0 || params.Parallelism == 0 {
+		return params, nil, nil, errInvalidHash
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return params, nil, nil, errInvalidHash
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return params, nil, nil, errInvalidHash
+	}
+
+	return params, salt, key, nil
+}
@@ -0,0 +1,45 @@
+package password
+
+import (
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// BcryptHasher is a struct used to hash passwords with bcrypt, whose modular crypt format hashes start with $2a$, $2b$ or $2y$.
+type BcryptHasher struct {
+	cost int
+}
+
+// NewBcryptHasher is a function used to initialize the bcrypt password hasher.
+func NewBcryptHasher(cost int) *BcryptHasher {
+	return &BcryptHasher{
+		cost: cost,
+	}
+}
+
+// Hash is a method for hashing a password.
+func (b *BcryptHasher) Hash(password string) (string, error) {
+	bytes, err := bcrypt.GenerateFromPassword([]byte(password), b.cost)
+
+	return string(bytes), err
+}
+
+// Verify is a method for checking a password against a hash.
+func (b *BcryptHasher) Verify(password, hashedPassword string) bool {
+	err := bcrypt.CompareHashAndPassword([]byte(hashedPassword), []byte(password))
+
+	return err == nil
+}
+
+// NeedsRehash is a method for checking whether a hash is not a bcrypt hash made with the current cost.
+func (b *BcryptHasher) NeedsRehash(hashedPassword string) bool {
+	cost, err := bcrypt.Cost([]byte(hashedPassword))
+
+	return err != nil || cost != b.cost
+}
+
+// Recognizes is a method for checking whether a hash is a bcrypt hash.
+func (b *BcryptHasher) Recognizes(hashedPassword string) bool {
+	return strings.HasPrefix(hashedPassword, "$2a$") || strings.HasPrefix(hashedPassword, "$2b$") || strings.HasPrefix(hashedPassword, "$2y$")
+}
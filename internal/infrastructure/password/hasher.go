@@ -0,0 +1,69 @@
+// Package password contains implementations of the password hasher interface defined in the domain package.
+package password
+
+import (
+	"errors"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/infrastructure/config"
+)
+
+// errInvalidHash is the error returned when a password hash cannot be parsed.
+var errInvalidHash = errors.New("invalid password hash")
+
+// Algorithm is the interface used for a password hashing algorithm that recognizes its own hashes.
+type Algorithm interface {
+	domain.PasswordHasher
+	Recognizes(hashedPassword string) bool
+}
+
+// Hasher is a struct used to hash new passwords with one algorithm while still verifying the hashes of the others.
+type Hasher struct {
+	current    Algorithm
+	algorithms []Algorithm
+}
+
+// NewHasher is a function used to initialize the password hasher that hashes with the current algorithm and verifies with any of them.
+func NewHasher(current Algorithm, others ...Algorithm) *Hasher {
+	return &Hasher{
+		current:    current,
+		algorithms: append([]Algorithm{current}, others...),
+	}
+}
+
+// NewPasswordHasher is a function used to initialize the password hasher selected by the configuration.
+// Hashes of the other algorithm keep being verified, so that switching algorithms does not lock anyone out.
+func NewPasswordHasher(cfg *config.Config) domain.PasswordHasher {
+	argon2id := NewArgon2idHasher(Argon2idParams{
+		Memory:      uint32(cfg.Argon2idMemory),
+		Iterations:  uint32(cfg.Argon2idIterations),
+		Parallelism: uint8(cfg.Argon2idParallelism),
+	})
+	bcrypt := NewBcryptHasher(cfg.BcryptCost)
+	if cfg.PasswordHashAlgorithm == config.PasswordHashAlgorithmBcrypt {
+		return NewHasher(bcrypt, argon2id)
+	}
+
+	return NewHasher(argon2id, bcrypt)
+}
+
+// Hash is a method for hashing a password with the current algorithm.
+func (h *Hasher) Hash(password string) (string, error) {
+	return h.current.Hash(password)
+}
+
+// Verify is a method for checking a password against a hash with the algorithm that made it.
+func (h *Hasher) Verify(password, hashedPassword string) bool {
+	for _, algorithm := range h.algorithms {
+		if algorithm.Recognizes(hashedPassword) {
+			return algorithm.Verify(password, hashedPassword)
+		}
+	}
+
+	return false
+}
+
+// NeedsRehash is a method for checking whether a hash was not made with the current algorithm and its current parameters.
+func (h *Hasher) NeedsRehash(hashedPassword string) bool {
+	return !h.current.Recognizes(hashedPassword) || h.current.NeedsRehash(hashedPassword)
+}
@@ -0,0 +1,149 @@
+package password_test
+
+import (
+	"strings"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/infrastructure/password"
+)
+
+var testArgon2idParams = password.Argon2idParams{Memory: 64, Iterations: 1, Parallelism: 1}
+
+func TestArgon2idHasher_Hash(t *testing.T) {
+	hasher := password.NewArgon2idHasher(testArgon2idParams)
+	hash, err := hasher.Hash("password")
+	if err != nil {
+		t.Fatalf("Argon2idHasher.Hash() error = %v", err)
+	}
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("Argon2idHasher.Hash() = %q, want a PHC string with the parameters", hash)
+	}
+
+	other, _ := hasher.Hash("password")
+	if other == hash {
+		t.Errorf("Argon2idHasher.Hash() made the same hash twice, want a random salt")
+	}
+}
+
+func TestArgon2idHasher_Verify(t *testing.T) {
+	hasher := password.NewArgon2idHasher(testArgon2idParams)
+	hash, _ := hasher.Hash("password")
+	tests := []struct {
+		name           string
+		password       string
+		hashedPassword string
+		want           bool
+	}{
+		{
+			name:           "Failed: Wrong password",
+			password:       "passwodr",
+			hashedPassword: hash,
+			want:           false,
+		},
+		{
+			name:           "Failed: Malformed hash",
+			password:       "password",
+			hashedPassword: "$argon2id$v=19$m=64$salt$key",
+			want:           false,
+		},
+		{
+			name:           "Success",
+			password:       "password",
+			hashedPassword: hash,
+			want:           true,
+		},
+		{
+			name:           "Success: Hash made with other parameters",
+			password:       "password",
+			hashedPassword: "$argon2id$v=19$m=32,t=2,p=1$8oFIgwWKKpPL3nNFO2hJ3w$TTbzDjJqA5eAQFCNcYW+CGnQyxOZIQ1sR28MIQM1KWg",
+			want:           true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasher.Verify(tt.password, tt.hashedPassword); got != tt.want {
+				t.Errorf("Argon2idHasher.Verify() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBcryptHasher_Hash(t *testing.T) {
+	hasher := password.NewBcryptHasher(4)
+	_, err := hasher.Hash("CbcDRihwZ3EICkMw0FejKb9qyICcK6T0CbcDRihwZ3EICkMw0FejKb9qyICcK6T0123456789")
+	if err == nil {
+		t.Errorf("BcryptHasher.Hash() error = nil for a password too long")
+	}
+
+	hash, err := hasher.Hash("password")
+	if err != nil || !hasher.Verify("password", hash) {
+		t.Errorf("BcryptHasher.Hash() = %q, %v, want a hash of the password", hash, err)
+	}
+}
+
+func TestBcryptHasher_Verify(t *testing.T) {
+	hasher := password.NewBcryptHasher(10)
+	if hasher.Verify("password", "password") {
+		t.Errorf("BcryptHasher.Verify() = true for an invalid hash")
+	}
+	if !hasher.Verify("password", "$2a$10$JuRPQgR8fD07tm8z0frLoOKI0TyptJCjEG0R.xIAJmwwxjsBUzaqW") {
+		t.Errorf("BcryptHasher.Verify() = false for a hash of the password")
+	}
+}
+
+func TestHasher_NeedsRehash(t *testing.T) {
+	bcryptHash := "$2a$10$JuRPQgR8fD07tm8z0frLoOKI0TyptJCjEG0R.xIAJmwwxjsBUzaqW"
+	argon2idHash, _ := password.NewArgon2idHasher(testArgon2idParams).Hash("password")
+	tests := []struct {
+		name           string
+		hasher         *password.Hasher
+		hashedPassword string
+		want           bool
+	}{
+		{
+			name:           "Other algorithm",
+			hasher:         password.NewHasher(password.NewArgon2idHasher(testArgon2idParams), password.NewBcryptHasher(10)),
+			hashedPassword: bcryptHash,
+			want:           true,
+		},
+		{
+			name:           "Other parameters",
+			hasher:         password.NewHasher(password.NewArgon2idHasher(password.Argon2idParams{Memory: 128, Iterations: 1, Parallelism: 1})),
+			hashedPassword: argon2idHash,
+			want:           true,
+		},
+		{
+			name:           "Other cost",
+			hasher:         password.NewHasher(password.NewBcryptHasher(12)),
+			hashedPassword: bcryptHash,
+			want:           true,
+		},
+		{
+			name:           "Current algorithm and parameters",
+			hasher:         password.NewHasher(password.NewArgon2idHasher(testArgon2idParams), password.NewBcryptHasher(10)),
+			hashedPassword: argon2idHash,
+			want:           false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hasher.NeedsRehash(tt.hashedPassword); got != tt.want {
+				t.Errorf("Hasher.NeedsRehash() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHasher_Verify(t *testing.T) {
+	hasher := password.NewHasher(password.NewArgon2idHasher(testArgon2idParams), password.NewBcryptHasher(10))
+	argon2idHash, _ := hasher.Hash("password")
+	if !hasher.Verify("password", argon2idHash) {
+		t.Errorf("Hasher.Verify() = false for a hash of the current algorithm")
+	}
+	if !hasher.Verify("password", "$2a$10$JuRPQgR8fD07tm8z0frLoOKI0TyptJCjEG0R.xIAJmwwxjsBUzaqW") {
+		t.Errorf("Hasher.Verify() = false for a hash of another algorithm")
+	}
+	if hasher.Verify("password", "$1$unknown") {
+		t.Errorf("Hasher.Verify() = true for a hash of an unknown algorithm")
+	}
+}
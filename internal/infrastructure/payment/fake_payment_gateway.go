@@ -0,0 +1,39 @@
+// Package payment contains implementation of the payment gateway interface defined in the domain package.
+package payment
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/pkg/constant"
+)
+
+// DeclinedPaymentToken is the payment token that the fake payment gateway always declines.
+const DeclinedPaymentToken = "tok_declined"
+
+// FakePaymentGateway is a struct used to implement a deterministic payment gateway for local runs and tests.
+type FakePaymentGateway struct{}
+
+// NewFakePaymentGateway is a function used to initialize the fake payment gateway.
+func NewFakePaymentGateway() *FakePaymentGateway {
+	return &FakePaymentGateway{}
+}
+
+// Charge is a method for charging a user, returning a reference derived from the charge attributes.
+func (f *FakePaymentGateway) Charge(_ context.Context, userID int, amount int64, paymentToken string) (string, error) {
+	if paymentToken == DeclinedPaymentToken {
+		return "", apperror.New(apperror.KindPaymentRequired, constant.PaymentDeclined)
+	}
+
+	hash := sha256.Sum256([]byte(fmt.Sprintf("%d:%d:%s", userID, amount, paymentToken)))
+
+	return "fake_" + hex.EncodeToString(hash[:8]), nil
+}
+
+// Refund is a method for refunding a charge.
+func (f *FakePaymentGateway) Refund(context.Context, string) error {
+	return nil
+}
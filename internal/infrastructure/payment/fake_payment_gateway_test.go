@@ -0,0 +1,53 @@
+package payment_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/infrastructure/payment"
+)
+
+func TestFakePaymentGateway_Charge(t *testing.T) {
+	type args struct {
+		paymentToken string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr bool
+	}{
+		{
+			name: "Failed: Declined",
+			args: args{
+				paymentToken: payment.DeclinedPaymentToken,
+			},
+			wantErr: true,
+		},
+		{
+			name: "Success",
+			args: args{
+				paymentToken: "tok_visa",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := payment.NewFakePaymentGateway()
+			got, err := f.Charge(context.Background(), 1, 99000, tt.args.paymentToken)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("FakePaymentGateway.Charge() error = %v, wantErr %v", err, tt.wantErr)
+
+				return
+			}
+			if tt.wantErr {
+				return
+			}
+
+			again, _ := f.Charge(context.Background(), 1, 99000, tt.args.paymentToken)
+			if got == "" || got != again {
+				t.Errorf("FakePaymentGateway.Charge() = %v, want the same non-empty reference as %v", got, again)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+const (
+	incrementActivityCounterQuery = `INSERT INTO activity_counters (user_id, count, date) SELECT ?, 1, ? WHERE ? > 0
+ON CONFLICT (user_id, date) DO UPDATE SET count = activity_counters.count + 1, updated_at = current_timestamp WHERE activity_counters.count < ?
+RETURNING id, user_id, count, date, created_at, updated_at`
+)
+
+// ActivityCounterRepositoryImpl is a struct used to implement the activity counter repository interface defined in the domain.
+type ActivityCounterRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewActivityCounterRepository is a function used to initialize the activity counter repository implementation.
+func NewActivityCounterRepository(db *gorm.DB) *ActivityCounterRepositoryImpl {
+	return &ActivityCounterRepositoryImpl{
+		db: db,
+	}
+}
+
+// Increment is a method for atomically incrementing the counter of a user on a date as long as it is below the limit.
+// The counter row is created on the first increment of the date, and a quota exceeded error is returned once the limit is reached.
+func (a *ActivityCounterRepositoryImpl) Increment(ctx context.Context, userID int, date time.Time, limit int) (*entity.ActivityCounter, error) {
+	counter := &entity.ActivityCounter{}
+	result := database.Conn(ctx, a.db).Raw(incrementActivityCounterQuery, userID, date, limit, limit).Scan(counter)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, apperror.New(apperror.KindQuotaExceeded, "Activity counter limit reached")
+	}
+
+	return counter, nil
+}
@@ -0,0 +1,66 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+var activityCounterColumns = []string{"id", "user_id", "count", "date", "created_at", "updated_at"}
+
+func TestActivityCounterRepositoryImpl_Increment_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO activity_counters (.+) ON CONFLICT (.+)").
+		WithArgs(1, currentTime, 10, 10).
+		WillReturnError(schema.ErrUnsupportedDataType)
+
+	repo := repository.NewActivityCounterRepository(gormDB)
+	_, err := repo.Increment(context.TODO(), 1, currentTime, 10)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityCounterRepositoryImpl_Increment_Failed_Limit_Reached(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO activity_counters (.+) ON CONFLICT (.+)").
+		WithArgs(1, currentTime, 10, 10).
+		WillReturnRows(sqlmock.NewRows(activityCounterColumns))
+
+	repo := repository.NewActivityCounterRepository(gormDB)
+	_, err := repo.Increment(context.TODO(), 1, currentTime, 10)
+	require.ErrorIs(t, err, apperror.ErrQuotaExceeded)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityCounterRepositoryImpl_Increment_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("INSERT INTO activity_counters (.+) ON CONFLICT (.+)").
+		WithArgs(1, currentTime, 10, 10).
+		WillReturnRows(sqlmock.NewRows(activityCounterColumns).AddRow(1, 1, 3, currentTime, currentTime, currentTime))
+
+	repo := repository.NewActivityCounterRepository(gormDB)
+	counter, err := repo.Increment(context.TODO(), 1, currentTime, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 3, counter.Count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
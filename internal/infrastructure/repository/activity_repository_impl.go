@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ActivityRepositoryImpl is a struct used to implement the activity repository interface defined in the domain.
+type ActivityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewActivityRepository is a function used to initialize the activity repository implementation.
+func NewActivityRepository(db *gorm.DB) *ActivityRepositoryImpl {
+	return &ActivityRepositoryImpl{
+		db: db,
+	}
+}
+
+// Insert is a method for inserting activity data in the activities table.
+// A second activity of the user on the profile on the same activity date is a conflict.
+func (a *ActivityRepositoryImpl) Insert(ctx context.Context, activity *entity.Activity) error {
+	return translateError(database.Conn(ctx, a.db).Create(activity).Error, "Activity")
+}
+
+// InsertLike is a method for inserting a like in the activities table and, when the liked user has liked back,
+// inserting the match between both users in the same transaction. The match is nil when no new match is formed.
+func (a *ActivityRepositoryImpl) InsertLike(ctx context.Context, activity *entity.Activity, likedUserID int) (*entity.Match, error) {
+	var match *entity.Match
+	err := database.Conn(ctx, a.db).Transaction(func(tx *gorm.DB) error {
+		// Likes between the same pair of users are serialized so that two simultaneous likes still see each other.
+		err := tx.Exec("SELECT pg_advisory_xact_lock(?, ?)", min(activity.UserID, likedUserID), max(activity.UserID, likedUserID)).Error
+		if err != nil {
+			return err
+		}
+
+		err = tx.Create(activity).Error
+		if err != nil {
+			return translateError(err, "Activity")
+		}
+
+		// Only the latest swipe of the liked user on the user's profile counts, and only when it was made
+		// after the pair last unmatched, so that a later pass or an earlier match never forms a new match.
+		var action string
+		err = tx.Model(&entity.Activity{}).
+			Select("activities.action").
+			Joins("JOIN profiles ON profiles.id = activities.profile_id").
+			Where("activities.user_id = ? AND profiles.user_id = ?", likedUserID, activity.UserID).
+			Where("NOT EXISTS (SELECT 1 FROM matches WHERE matches.user_one_id = ? AND matches.user_two_id = ? AND matches.unmatched_at >= activities.created_at)",
+				min(activity.UserID, likedUserID), max(activity.UserID, likedUserID)).
+			Order("activities.created_at DESC, activities.id DESC").
+			Limit(1).
+			Scan(&action).Error
+		if err != nil || action != entity.ActionLike {
+			return err
+		}
+
+		// A pair of users has at most one current match, so liking back while already matched forms no new match.
+		newMatch := entity.NewMatch(activity.UserID, likedUserID, activity.CreatedAt)
+		result := tx.Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "user_one_id"}, {Name: "user_two_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "unmatched_at IS NULL"}}},
+			DoNothing:   true,
+		}).Create(newMatch)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected > 0 {
+			match = newMatch
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return match, nil
+}
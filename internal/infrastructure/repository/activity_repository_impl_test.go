@@ -0,0 +1,181 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+func TestActivityRepositoryImpl_Insert_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	activity := entity.NewActivity(1, 2, entity.ActionLike, currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"activities\" (.+) VALUES (.+)").WillReturnError(schema.ErrUnsupportedDataType)
+	mock.ExpectRollback()
+
+	repo := repository.NewActivityRepository(gormDB)
+	err := repo.Insert(context.TODO(), activity)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityRepositoryImpl_Insert_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	activity := entity.NewActivity(1, 2, entity.ActionLike, currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"activities\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectCommit()
+
+	repo := repository.NewActivityRepository(gormDB)
+	err := repo.Insert(context.TODO(), activity)
+	require.NoError(t, err)
+	assert.Equal(t, 1, activity.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityRepositoryImpl_Insert_Failed_Already_Swiped(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	activity := entity.NewActivity(1, 2, entity.ActionPass, currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"activities\" (.+) VALUES (.+)").WillReturnError(gorm.ErrDuplicatedKey)
+	mock.ExpectRollback()
+
+	repo := repository.NewActivityRepository(gormDB)
+	err := repo.Insert(context.TODO(), activity)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityRepositoryImpl_InsertLike_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	activity := entity.NewActivity(2, 1, entity.ActionLike, currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("INSERT INTO \"activities\" (.+) VALUES (.+)").WillReturnError(schema.ErrUnsupportedDataType)
+	mock.ExpectRollback()
+
+	repo := repository.NewActivityRepository(gormDB)
+	_, err := repo.InsertLike(context.TODO(), activity, 1)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityRepositoryImpl_InsertLike_Success_Not_Matched(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	activity := entity.NewActivity(2, 1, entity.ActionLike, currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("INSERT INTO \"activities\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("SELECT activities.action FROM \"activities\" JOIN profiles (.+)NOT EXISTS (.+) ORDER BY activities.created_at DESC, activities.id DESC LIMIT").
+		WithArgs(1, 2, 1, 2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"action"}))
+	mock.ExpectCommit()
+
+	repo := repository.NewActivityRepository(gormDB)
+	match, err := repo.InsertLike(context.TODO(), activity, 1)
+	require.NoError(t, err)
+	assert.Nil(t, match)
+	assert.Equal(t, 1, activity.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityRepositoryImpl_InsertLike_Success_Passed_Back(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	activity := entity.NewActivity(2, 1, entity.ActionLike, currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("INSERT INTO \"activities\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("SELECT activities.action FROM \"activities\" JOIN profiles (.+)NOT EXISTS (.+) ORDER BY activities.created_at DESC, activities.id DESC LIMIT").
+		WithArgs(1, 2, 1, 2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"action"}).AddRow(entity.ActionPass))
+	mock.ExpectCommit()
+
+	repo := repository.NewActivityRepository(gormDB)
+	match, err := repo.InsertLike(context.TODO(), activity, 1)
+	require.NoError(t, err)
+	assert.Nil(t, match)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityRepositoryImpl_InsertLike_Success_Already_Matched(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	activity := entity.NewActivity(2, 1, entity.ActionLike, currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("INSERT INTO \"activities\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("SELECT activities.action FROM \"activities\" JOIN profiles (.+)NOT EXISTS (.+) ORDER BY activities.created_at DESC, activities.id DESC LIMIT").
+		WithArgs(1, 2, 1, 2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"action"}).AddRow(entity.ActionLike))
+	mock.ExpectQuery("INSERT INTO \"matches\" (.+) VALUES (.+) ON CONFLICT \\(\"user_one_id\",\"user_two_id\"\\) WHERE unmatched_at IS NULL DO NOTHING").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	mock.ExpectCommit()
+
+	repo := repository.NewActivityRepository(gormDB)
+	match, err := repo.InsertLike(context.TODO(), activity, 1)
+	require.NoError(t, err)
+	assert.Nil(t, match)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestActivityRepositoryImpl_InsertLike_Success_Matched(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	activity := entity.NewActivity(2, 1, entity.ActionLike, currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectExec("SELECT pg_advisory_xact_lock").WithArgs(1, 2).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery("INSERT INTO \"activities\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectQuery("SELECT activities.action FROM \"activities\" JOIN profiles (.+)NOT EXISTS (.+) ORDER BY activities.created_at DESC, activities.id DESC LIMIT").
+		WithArgs(1, 2, 1, 2, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"action"}).AddRow(entity.ActionLike))
+	mock.ExpectQuery("INSERT INTO \"matches\" (.+) VALUES (.+) ON CONFLICT \\(\"user_one_id\",\"user_two_id\"\\) WHERE unmatched_at IS NULL DO NOTHING").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectCommit()
+
+	repo := repository.NewActivityRepository(gormDB)
+	match, err := repo.InsertLike(context.TODO(), activity, 1)
+	require.NoError(t, err)
+	require.NotNil(t, match)
+	assert.Equal(t, 1, match.ID)
+	assert.Equal(t, 1, match.UserOneID)
+	assert.Equal(t, 2, match.UserTwoID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
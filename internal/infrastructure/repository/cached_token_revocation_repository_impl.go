@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/repository"
+	"dealls-technical-test-dating-service/internal/infrastructure/cache"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+)
+
+// CachedTokenRevocationRepositoryImpl is a struct used to implement the token revocation repository interface with an in-memory cache in front of another implementation.
+// Revocations made through this instance are visible once committed, while revocations made by other instances are seen once the cached answer expires.
+// Answers read inside a transaction are only cached once it is committed, so that a rolled back write is never cached,
+// and an answer is not cached when a revocation was cached since it was read, so that a read racing a logout never hides it.
+type CachedTokenRevocationRepositoryImpl struct {
+	repo           repository.TokenRevocationRepository
+	revokedTokens  *cache.TTLCache[string, bool]
+	revokedBefores *cache.TTLCache[int, time.Time]
+}
+
+// NewCachedTokenRevocationRepository is a function used to initialize the cached token revocation repository implementation.
+func NewCachedTokenRevocationRepository(repo repository.TokenRevocationRepository, ttl time.Duration) *CachedTokenRevocationRepositoryImpl {
+	return &CachedTokenRevocationRepositoryImpl{
+		repo:           repo,
+		revokedTokens:  cache.NewTTLCache[string, bool](ttl),
+		revokedBefores: cache.NewTTLCache[int, time.Time](ttl),
+	}
+}
+
+// InsertRevokedToken is a method for inserting revoked token data and caching the revocation once it is committed.
+func (c *CachedTokenRevocationRepositoryImpl) InsertRevokedToken(ctx context.Context, revokedToken *entity.RevokedToken) error {
+	err := c.repo.InsertRevokedToken(ctx, revokedToken)
+	if err != nil {
+		return err
+	}
+
+	database.AfterCommit(ctx, func() {
+		c.revokedTokens.Set(revokedToken.ID, true)
+	})
+
+	return nil
+}
+
+// ExistsRevokedToken is a method for checking whether a token ID is revoked, using the cached answer when there is one.
+func (c *CachedTokenRevocationRepositoryImpl) ExistsRevokedToken(ctx context.Context, id string) (bool, error) {
+	if revoked, ok := c.revokedTokens.Get(id); ok {
+		return revoked, nil
+	}
+
+	version := c.revokedTokens.Version()
+	revoked, err := c.repo.ExistsRevokedToken(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	database.AfterCommit(ctx, func() {
+		c.revokedTokens.SetIfUnchanged(id, revoked, version)
+	})
+
+	return revoked, nil
+}
+
+// UpsertUserTokenRevocation is a method for inserting or moving forward the time before which every token of a user is revoked
+// and caching the new time once it is committed.
+func (c *CachedTokenRevocationRepositoryImpl) UpsertUserTokenRevocation(ctx context.Context, revocation *entity.UserTokenRevocation) error {
+	err := c.repo.UpsertUserTokenRevocation(ctx, revocation)
+	if err != nil {
+		return err
+	}
+
+	database.AfterCommit(ctx, func() {
+		c.revokedBefores.Set(revocation.UserID, revocation.RevokedBefore)
+	})
+
+	return nil
+}
+
+// FindRevokedBefore is a method for finding the time before which every token of a user is revoked, using the cached time when there is one.
+func (c *CachedTokenRevocationRepositoryImpl) FindRevokedBefore(ctx context.Context, userID int) (time.Time, error) {
+	if revokedBefore, ok := c.revokedBefores.Get(userID); ok {
+		return revokedBefore, nil
+	}
+
+	version := c.revokedBefores.Version()
+	revokedBefore, err := c.repo.FindRevokedBefore(ctx, userID)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	database.AfterCommit(ctx, func() {
+		c.revokedBefores.SetIfUnchanged(userID, revokedBefore, version)
+	})
+
+	return revokedBefore, nil
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// EntitlementRepositoryImpl is a struct used to implement the entitlement repository interface defined in the domain.
+type EntitlementRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewEntitlementRepository is a function used to initialize the entitlement repository implementation.
+func NewEntitlementRepository(db *gorm.DB) *EntitlementRepositoryImpl {
+	return &EntitlementRepositoryImpl{
+		db: db,
+	}
+}
+
+// activeEntitlements is a method for building the query of the entitlements granted by the subscriptions active on the date.
+func (e *EntitlementRepositoryImpl) activeEntitlements(ctx context.Context, feature string, date time.Time) *gorm.DB {
+	return database.Conn(ctx, e.db).
+		Table("entitlements").
+		Joins("JOIN subscriptions ON subscriptions.subscription_package_id = entitlements.subscription_package_id").
+		Where("entitlements.feature = ?", feature).
+		Where("subscriptions.active AND subscriptions.start_date <= ? AND subscriptions.end_date > ?", date, date)
+}
+
+// ExistsByUserIDAndFeature is a method for checking whether a user has a feature through the subscriptions active on the date.
+func (e *EntitlementRepositoryImpl) ExistsByUserIDAndFeature(ctx context.Context, userID int, feature string, date time.Time) (bool, error) {
+	var count int64
+	err := e.activeEntitlements(ctx, feature, date).
+		Where("subscriptions.user_id = ?", userID).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// FindUserIDsByFeature is a method for finding which of the users have a feature through the subscriptions active on the date.
+func (e *EntitlementRepositoryImpl) FindUserIDsByFeature(ctx context.Context, userIDs []int, feature string, date time.Time) ([]int, error) {
+	entitledUserIDs := []int{}
+	if len(userIDs) == 0 {
+		return entitledUserIDs, nil
+	}
+
+	err := e.activeEntitlements(ctx, feature, date).
+		Where("subscriptions.user_id IN ?", userIDs).
+		Distinct("subscriptions.user_id").
+		Pluck("subscriptions.user_id", &entitledUserIDs).Error
+
+	return entitledUserIDs, err
+}
@@ -0,0 +1,76 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+func TestEntitlementRepositoryImpl_ExistsByUserIDAndFeature_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"entitlements\" JOIN subscriptions (.+)").WillReturnError(schema.ErrUnsupportedDataType)
+
+	repo := repository.NewEntitlementRepository(gormDB)
+	_, err := repo.ExistsByUserIDAndFeature(context.TODO(), 1, entity.FeatureNoSwipeQuota, currentTime)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEntitlementRepositoryImpl_ExistsByUserIDAndFeature_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"entitlements\" JOIN subscriptions (.+)").
+		WithArgs(entity.FeatureNoSwipeQuota, currentTime, currentTime, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	repo := repository.NewEntitlementRepository(gormDB)
+	exists, err := repo.ExistsByUserIDAndFeature(context.TODO(), 1, entity.FeatureNoSwipeQuota, currentTime)
+	require.NoError(t, err)
+	assert.True(t, exists)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEntitlementRepositoryImpl_FindUserIDsByFeature_Success_Empty_User_IDs(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	repo := repository.NewEntitlementRepository(gormDB)
+	userIDs, err := repo.FindUserIDsByFeature(context.TODO(), nil, entity.FeatureVerifiedLabel, currentTime)
+	require.NoError(t, err)
+	assert.Empty(t, userIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestEntitlementRepositoryImpl_FindUserIDsByFeature_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT DISTINCT subscriptions.user_id FROM \"entitlements\" JOIN subscriptions (.+) IN (.+)").
+		WithArgs(entity.FeatureVerifiedLabel, currentTime, currentTime, 2, 3).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(3))
+
+	repo := repository.NewEntitlementRepository(gormDB)
+	userIDs, err := repo.FindUserIDsByFeature(context.TODO(), []int{2, 3}, entity.FeatureVerifiedLabel, currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, []int{3}, userIDs)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"errors"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+
+	"gorm.io/gorm"
+)
+
+// translateError is a function to translate the GORM errors about a resource into the typed errors defined in the domain.
+func translateError(err error, resource string) error {
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return apperror.Wrap(apperror.KindNotFound, resource+" not found", err)
+	case errors.Is(err, gorm.ErrDuplicatedKey):
+		return apperror.Wrap(apperror.KindConflict, resource+" already exists", err)
+	default:
+		return err
+	}
+}
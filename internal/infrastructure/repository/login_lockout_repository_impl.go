@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// LoginLockoutRepositoryImpl is a struct used to implement the login lockout repository interface defined in the domain.
+type LoginLockoutRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewLoginLockoutRepository is a function used to initialize the login lockout repository implementation.
+func NewLoginLockoutRepository(db *gorm.DB) *LoginLockoutRepositoryImpl {
+	return &LoginLockoutRepositoryImpl{
+		db: db,
+	}
+}
+
+// Insert is a method for inserting login lockout data in the login_lockouts table.
+func (l *LoginLockoutRepositoryImpl) Insert(ctx context.Context, lockout *entity.LoginLockout) error {
+	return database.Conn(ctx, l.db).Create(lockout).Error
+}
@@ -0,0 +1,34 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoginLockoutRepositoryImpl_Insert_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	userID := 1
+	throttle := &entity.LoginThrottle{Scope: entity.LoginThrottleScopeAccount, Subject: "user@email.com", FailedAttempts: 5, LockedUntil: &currentTime}
+	lockout := entity.NewLoginLockout(throttle, &userID, "192.0.2.1", currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"login_lockouts\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectCommit()
+
+	repo := repository.NewLoginLockoutRepository(gormDB)
+	err := repo.Insert(context.TODO(), lockout)
+	require.NoError(t, err)
+	assert.Equal(t, 1, lockout.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
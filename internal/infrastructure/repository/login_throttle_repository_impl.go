@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+const recordLoginFailureQuery = `INSERT INTO login_throttles (scope, subject, failed_attempts, last_failed_at) VALUES (?, ?, 1, ?)
+ON CONFLICT (scope, subject) DO UPDATE SET
+failed_attempts = CASE WHEN login_throttles.last_failed_at < ? THEN 1 ELSE login_throttles.failed_attempts + 1 END,
+locked_until = CASE WHEN login_throttles.last_failed_at < ? THEN NULL ELSE login_throttles.locked_until END,
+last_failed_at = excluded.last_failed_at, updated_at = current_timestamp
+RETURNING id, scope, subject, failed_attempts, last_failed_at, locked_until, created_at, updated_at`
+
+// LoginThrottleRepositoryImpl is a struct used to implement the login throttle repository interface defined in the domain.
+type LoginThrottleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewLoginThrottleRepository is a function used to initialize the login throttle repository implementation.
+func NewLoginThrottleRepository(db *gorm.DB) *LoginThrottleRepositoryImpl {
+	return &LoginThrottleRepositoryImpl{
+		db: db,
+	}
+}
+
+// Find is a method for finding the login throttle data of an email address or an IP address.
+func (l *LoginThrottleRepositoryImpl) Find(ctx context.Context, scope, subject string) (*entity.LoginThrottle, error) {
+	throttle := &entity.LoginThrottle{}
+	err := database.Conn(ctx, l.db).First(throttle, "scope = ? AND subject = ?", scope, subject).Error
+
+	return throttle, translateError(err, "Login throttle")
+}
+
+// RecordFailure is a method for atomically counting one more failed login attempt and returning the updated login throttle data.
+// The count starts over when the previous failed attempt happened before resetBefore, which also lifts an expired lockout.
+func (l *LoginThrottleRepositoryImpl) RecordFailure(ctx context.Context, scope, subject string, failedAt, resetBefore time.Time) (*entity.LoginThrottle, error) {
+	throttle := &entity.LoginThrottle{}
+	err := database.Conn(ctx, l.db).Raw(recordLoginFailureQuery, scope, subject, failedAt, resetBefore, resetBefore).Scan(throttle).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return throttle, nil
+}
+
+// Lock is a method for refusing logins of the login throttle until the given time.
+func (l *LoginThrottleRepositoryImpl) Lock(ctx context.Context, id int, lockedUntil time.Time) error {
+	return database.Conn(ctx, l.db).
+		Model(&entity.LoginThrottle{}).
+		Where("id = ?", id).
+		Update("locked_until", lockedUntil).Error
+}
+
+// Delete is a method for forgetting the failed login attempts of an email address or an IP address.
+func (l *LoginThrottleRepositoryImpl) Delete(ctx context.Context, scope, subject string) error {
+	return database.Conn(ctx, l.db).Delete(&entity.LoginThrottle{}, "scope = ? AND subject = ?", scope, subject).Error
+}
@@ -0,0 +1,108 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var loginThrottleColumns = []string{"id", "scope", "subject", "failed_attempts", "last_failed_at", "locked_until", "created_at", "updated_at"}
+
+func TestLoginThrottleRepositoryImpl_Find_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"login_throttles\" WHERE scope = (.+) AND subject = (.+)").
+		WithArgs(entity.LoginThrottleScopeAccount, "user@email.com", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	repo := repository.NewLoginThrottleRepository(gormDB)
+	_, err := repo.Find(context.TODO(), entity.LoginThrottleScopeAccount, "user@email.com")
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoginThrottleRepositoryImpl_RecordFailure_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	resetBefore := currentTime.Add(-time.Hour)
+	mock.ExpectQuery("INSERT INTO login_throttles (.+) ON CONFLICT (.+)").
+		WithArgs(entity.LoginThrottleScopeIP, "192.0.2.1", currentTime, resetBefore, resetBefore).
+		WillReturnError(schema.ErrUnsupportedDataType)
+
+	repo := repository.NewLoginThrottleRepository(gormDB)
+	_, err := repo.RecordFailure(context.TODO(), entity.LoginThrottleScopeIP, "192.0.2.1", currentTime, resetBefore)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoginThrottleRepositoryImpl_RecordFailure_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	resetBefore := currentTime.Add(-time.Hour)
+	mock.ExpectQuery("INSERT INTO login_throttles (.+) ON CONFLICT (.+)").
+		WithArgs(entity.LoginThrottleScopeAccount, "user@email.com", currentTime, resetBefore, resetBefore).
+		WillReturnRows(sqlmock.NewRows(loginThrottleColumns).
+			AddRow(1, entity.LoginThrottleScopeAccount, "user@email.com", 3, currentTime, nil, currentTime, currentTime))
+
+	repo := repository.NewLoginThrottleRepository(gormDB)
+	throttle, err := repo.RecordFailure(context.TODO(), entity.LoginThrottleScopeAccount, "user@email.com", currentTime, resetBefore)
+	require.NoError(t, err)
+	assert.Equal(t, 3, throttle.FailedAttempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoginThrottleRepositoryImpl_Lock_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"login_throttles\" SET \"locked_until\"=(.+),\"updated_at\"=(.+) WHERE id = (.+)").
+		WithArgs(currentTime, sqlmock.AnyArg(), 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewLoginThrottleRepository(gormDB)
+	err := repo.Lock(context.TODO(), 1, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestLoginThrottleRepositoryImpl_Delete_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM \"login_throttles\" WHERE scope = (.+) AND subject = (.+)").
+		WithArgs(entity.LoginThrottleScopeAccount, "user@email.com").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewLoginThrottleRepository(gormDB)
+	err := repo.Delete(context.TODO(), entity.LoginThrottleScopeAccount, "user@email.com")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
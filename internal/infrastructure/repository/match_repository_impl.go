@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+const matchDetailColumns = "matches.id, users.id AS user_id, profiles.id AS profile_id, users.name, " +
+	"COALESCE(users.profile_picture_key, '') AS profile_picture_key, matches.created_at"
+
+// MatchRepositoryImpl is a struct used to implement the match repository interface defined in the domain.
+type MatchRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewMatchRepository is a function used to initialize the match repository implementation.
+func NewMatchRepository(db *gorm.DB) *MatchRepositoryImpl {
+	return &MatchRepositoryImpl{
+		db: db,
+	}
+}
+
+// FindByUserID is a method for finding the current matches of a user before the cursor, newest first.
+func (m *MatchRepositoryImpl) FindByUserID(ctx context.Context, userID, cursor, limit int) ([]*entity.MatchDetail, error) {
+	matches := []*entity.MatchDetail{}
+	query := database.Conn(ctx, m.db).
+		Table("matches").
+		Select(matchDetailColumns).
+		Joins("JOIN users ON users.id = CASE WHEN matches.user_one_id = ? THEN matches.user_two_id ELSE matches.user_one_id END", userID).
+		Joins("JOIN profiles ON profiles.user_id = users.id").
+		Where("(matches.user_one_id = ? OR matches.user_two_id = ?) AND matches.unmatched_at IS NULL", userID, userID)
+	if cursor > 0 {
+		query = query.Where("matches.id < ?", cursor)
+	}
+	err := query.
+		Order("matches.id DESC").
+		Limit(limit).
+		Scan(&matches).Error
+
+	return matches, err
+}
+
+// Unmatch is a method for marking a current match of a user as unmatched.
+func (m *MatchRepositoryImpl) Unmatch(ctx context.Context, id, userID int, unmatchedAt time.Time) error {
+	result := database.Conn(ctx, m.db).
+		Model(&entity.Match{}).
+		Where("id = ? AND (user_one_id = ? OR user_two_id = ?) AND unmatched_at IS NULL", id, userID, userID).
+		Update("unmatched_at", unmatchedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.Wrap(apperror.KindNotFound, "Match not found", gorm.ErrRecordNotFound)
+	}
+
+	return nil
+}
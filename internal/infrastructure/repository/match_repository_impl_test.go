@@ -0,0 +1,107 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+var matchDetailColumns = []string{"id", "user_id", "profile_id", "name", "profile_picture_key", "created_at"}
+
+func TestMatchRepositoryImpl_FindByUserID_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"matches\" JOIN users (.+) JOIN profiles (.+) WHERE (.+)").
+		WithArgs(1, 1, 1, 10).
+		WillReturnError(schema.ErrUnsupportedDataType)
+
+	repo := repository.NewMatchRepository(gormDB)
+	_, err := repo.FindByUserID(context.TODO(), 1, 0, 10)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMatchRepositoryImpl_FindByUserID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"matches\" JOIN users (.+) JOIN profiles (.+) WHERE (.+) AND matches.id < (.+) ORDER BY matches.id DESC LIMIT (.+)").
+		WithArgs(1, 1, 1, 5, 10).
+		WillReturnRows(sqlmock.NewRows(matchDetailColumns).AddRow(4, 2, 2, "Jane", "", currentTime))
+
+	repo := repository.NewMatchRepository(gormDB)
+	matches, err := repo.FindByUserID(context.TODO(), 1, 5, 10)
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, 4, matches[0].ID)
+	assert.Equal(t, 2, matches[0].UserID)
+	assert.Equal(t, "Jane", matches[0].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMatchRepositoryImpl_Unmatch_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"matches\" SET \"unmatched_at\"=(.+) WHERE (.+)").
+		WithArgs(currentTime, 1, 2, 2).
+		WillReturnError(schema.ErrUnsupportedDataType)
+	mock.ExpectRollback()
+
+	repo := repository.NewMatchRepository(gormDB)
+	err := repo.Unmatch(context.TODO(), 1, 2, currentTime)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMatchRepositoryImpl_Unmatch_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"matches\" SET \"unmatched_at\"=(.+) WHERE (.+)").
+		WithArgs(currentTime, 1, 2, 2).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewMatchRepository(gormDB)
+	err := repo.Unmatch(context.TODO(), 1, 2, currentTime)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestMatchRepositoryImpl_Unmatch_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"matches\" SET \"unmatched_at\"=(.+) WHERE (.+)").
+		WithArgs(currentTime, 1, 2, 2).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewMatchRepository(gormDB)
+	err := repo.Unmatch(context.TODO(), 1, 2, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
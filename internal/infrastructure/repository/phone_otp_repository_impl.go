@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// PhoneOTPRepositoryImpl is a struct used to implement the phone OTP repository interface defined in the domain.
+type PhoneOTPRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewPhoneOTPRepository is a function used to initialize the phone OTP repository implementation.
+func NewPhoneOTPRepository(db *gorm.DB) *PhoneOTPRepositoryImpl {
+	return &PhoneOTPRepositoryImpl{
+		db: db,
+	}
+}
+
+// Insert is a method for inserting phone OTP data in the phone_otps table.
+func (p *PhoneOTPRepositoryImpl) Insert(ctx context.Context, phoneOTP *entity.PhoneOTP) error {
+	err := database.Conn(ctx, p.db).Create(phoneOTP).Error
+
+	return translateError(err, "Phone OTP")
+}
+
+// Lock is a method for serializing the phone OTPs issued for a phone number and from an IP address until the end of the transaction,
+// so that concurrent requests see each other's phone OTPs when checking how recently and how often they were sent.
+// The IP address is not locked when it is empty.
+func (p *PhoneOTPRepositoryImpl) Lock(ctx context.Context, phone, ipAddress string) error {
+	subjects := []string{"phone_otps:phone:" + phone}
+	if ipAddress != "" {
+		subjects = append(subjects, "phone_otps:ip:"+ipAddress)
+	}
+
+	// The phone number is always locked before the IP address, so that two requests cannot wait for each other.
+	for _, subject := range subjects {
+		err := database.Conn(ctx, p.db).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", subject).Error
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindLatest is a method for finding the phone OTP data that was most recently sent to a phone number.
+func (p *PhoneOTPRepositoryImpl) FindLatest(ctx context.Context, phone string) (*entity.PhoneOTP, error) {
+	phoneOTP := &entity.PhoneOTP{}
+	err := database.Conn(ctx, p.db).
+		Where("phone = ?", phone).
+		Order("created_at DESC").
+		First(phoneOTP).Error
+
+	return phoneOTP, translateError(err, "Phone OTP")
+}
+
+// CountByPhoneSince is a method for counting the phone OTPs sent to a phone number since the given time.
+func (p *PhoneOTPRepositoryImpl) CountByPhoneSince(ctx context.Context, phone string, since time.Time) (int, error) {
+	var count int64
+	err := database.Conn(ctx, p.db).
+		Model(&entity.PhoneOTP{}).
+		Where("phone = ? AND created_at > ?", phone, since).
+		Count(&count).Error
+
+	return int(count), err
+}
+
+// CountByIPAddressSince is a method for counting the phone OTPs requested from an IP address since the given time.
+func (p *PhoneOTPRepositoryImpl) CountByIPAddressSince(ctx context.Context, ipAddress string, since time.Time) (int, error) {
+	var count int64
+	err := database.Conn(ctx, p.db).
+		Model(&entity.PhoneOTP{}).
+		Where("ip_address = ? AND created_at > ?", ipAddress, since).
+		Count(&count).Error
+
+	return int(count), err
+}
+
+// IncrementAttempts is a method for counting a code given for a phone OTP that is not used yet.
+// Only a phone OTP with attempts left is counted, so that concurrent requests cannot give more codes than allowed.
+func (p *PhoneOTPRepositoryImpl) IncrementAttempts(ctx context.Context, id, maxAttempts int) error {
+	result := database.Conn(ctx, p.db).
+		Model(&entity.PhoneOTP{}).
+		Where("id = ? AND attempts < ? AND used_at IS NULL", id, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.New(apperror.KindConflict, "Phone OTP has no attempts left")
+	}
+
+	return nil
+}
+
+// MarkUsed is a method for marking a phone OTP as used.
+// Only a phone OTP that is not used yet is marked, so that a code cannot be used twice by concurrent requests.
+func (p *PhoneOTPRepositoryImpl) MarkUsed(ctx context.Context, id int, usedAt time.Time) error {
+	result := database.Conn(ctx, p.db).
+		Model(&entity.PhoneOTP{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.New(apperror.KindConflict, "Phone OTP already used")
+	}
+
+	return nil
+}
@@ -0,0 +1,205 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var phoneOTPColumns = []string{"id", "phone", "ip_address", "code_hash", "attempts", "expires_at", "created_at", "used_at"}
+
+func TestPhoneOTPRepositoryImpl_Insert_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	phoneOTP := entity.NewPhoneOTP("+6281234567890", "203.0.113.1", "hash", currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"phone_otps\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectCommit()
+
+	repo := repository.NewPhoneOTPRepository(gormDB)
+	err := repo.Insert(context.TODO(), phoneOTP)
+	require.NoError(t, err)
+	assert.Equal(t, 1, phoneOTP.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPhoneOTPRepositoryImpl_Lock_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectExec("SELECT pg_advisory_xact_lock\\(hashtext\\((.+)\\)\\)").WithArgs("phone_otps:phone:+6281234567890").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("SELECT pg_advisory_xact_lock\\(hashtext\\((.+)\\)\\)").WithArgs("phone_otps:ip:203.0.113.1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repository.NewPhoneOTPRepository(gormDB)
+	err := repo.Lock(context.TODO(), "+6281234567890", "203.0.113.1")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPhoneOTPRepositoryImpl_Lock_Success_Without_IP_Address(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectExec("SELECT pg_advisory_xact_lock\\(hashtext\\((.+)\\)\\)").WithArgs("phone_otps:phone:+6281234567890").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repository.NewPhoneOTPRepository(gormDB)
+	err := repo.Lock(context.TODO(), "+6281234567890", "")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPhoneOTPRepositoryImpl_FindLatest_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"phone_otps\" WHERE phone = (.+) ORDER BY created_at DESC(.+)").
+		WithArgs("+6281234567890", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	repo := repository.NewPhoneOTPRepository(gormDB)
+	_, err := repo.FindLatest(context.TODO(), "+6281234567890")
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPhoneOTPRepositoryImpl_FindLatest_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"phone_otps\" WHERE phone = (.+) ORDER BY created_at DESC(.+)").
+		WithArgs("+6281234567890", 1).
+		WillReturnRows(sqlmock.NewRows(phoneOTPColumns).AddRow(1, "+6281234567890", "203.0.113.1", "hash", 2, currentTime, currentTime, nil))
+
+	repo := repository.NewPhoneOTPRepository(gormDB)
+	phoneOTP, err := repo.FindLatest(context.TODO(), "+6281234567890")
+	require.NoError(t, err)
+	assert.Equal(t, 1, phoneOTP.ID)
+	assert.Equal(t, 2, phoneOTP.Attempts)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPhoneOTPRepositoryImpl_IncrementAttempts_Failed_No_Attempts_Left(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"phone_otps\" SET \"attempts\"=attempts \\+ 1 WHERE id = (.+) AND attempts < (.+) AND used_at IS NULL").
+		WithArgs(1, 5).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewPhoneOTPRepository(gormDB)
+	err := repo.IncrementAttempts(context.TODO(), 1, 5)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPhoneOTPRepositoryImpl_IncrementAttempts_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"phone_otps\" SET \"attempts\"=attempts \\+ 1 WHERE id = (.+) AND attempts < (.+) AND used_at IS NULL").
+		WithArgs(1, 5).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewPhoneOTPRepository(gormDB)
+	err := repo.IncrementAttempts(context.TODO(), 1, 5)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPhoneOTPRepositoryImpl_MarkUsed_Failed_Already_Used(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"phone_otps\" SET \"used_at\"=(.+) WHERE (.+) AND used_at IS NULL").
+		WithArgs(currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewPhoneOTPRepository(gormDB)
+	err := repo.MarkUsed(context.TODO(), 1, currentTime)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPhoneOTPRepositoryImpl_MarkUsed_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"phone_otps\" SET \"used_at\"=(.+) WHERE (.+) AND used_at IS NULL").
+		WithArgs(currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewPhoneOTPRepository(gormDB)
+	err := repo.MarkUsed(context.TODO(), 1, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPhoneOTPRepositoryImpl_CountByPhoneSince_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"phone_otps\" WHERE phone = (.+) AND created_at > (.+)").
+		WithArgs("+6281234567890", currentTime).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	repo := repository.NewPhoneOTPRepository(gormDB)
+	count, err := repo.CountByPhoneSince(context.TODO(), "+6281234567890", currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPhoneOTPRepositoryImpl_CountByIPAddressSince_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"phone_otps\" WHERE ip_address = (.+) AND created_at > (.+)").
+		WithArgs("203.0.113.1", currentTime).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+
+	repo := repository.NewPhoneOTPRepository(gormDB)
+	count, err := repo.CountByIPAddressSince(context.TODO(), "203.0.113.1", currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, 7, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -3,12 +3,18 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"dealls-technical-test-dating-service/internal/domain/apperror"
 	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
 
 	"gorm.io/gorm"
 )
 
+const profileCandidateColumns = "profiles.id AS profile_id, profiles.user_id, users.name, users.birth_date, users.gender, users.location, " +
+	"COALESCE(users.profile_picture_key, '') AS profile_picture_key, COALESCE(profiles.bio, '') AS bio, COALESCE(profiles.interests, '') AS interests, profiles.verified"
+
 // ProfileRepositoryImpl is a struct used to implement the profile repository interface defined in the domain.
 type ProfileRepositoryImpl struct {
 	db *gorm.DB
@@ -23,13 +29,84 @@ func NewProfileRepository(db *gorm.DB) *ProfileRepositoryImpl {
 
 // Insert is a method for inserting profile data in the profiles table.
 func (p *ProfileRepositoryImpl) Insert(ctx context.Context, profile *entity.Profile) error {
-	result := p.db.WithContext(ctx).Where(entity.Profile{UserID: profile.UserID}).FirstOrCreate(profile)
+	result := database.Conn(ctx, p.db).Where(entity.Profile{UserID: profile.UserID}).FirstOrCreate(profile)
+	if result.Error != nil {
+		return translateError(result.Error, "Profile")
+	}
+	if result.RowsAffected == 0 {
+		return apperror.Wrap(apperror.KindConflict, "Profile already exists", gorm.ErrDuplicatedKey)
+	}
+
+	return nil
+}
+
+// FindByID is a method for finding profile data based on ID.
+func (p *ProfileRepositoryImpl) FindByID(ctx context.Context, id int) (*entity.Profile, error) {
+	profile := &entity.Profile{}
+	err := database.Conn(ctx, p.db).First(profile, "id = ?", id).Error
+
+	return profile, translateError(err, "Profile")
+}
+
+// FindByUserID is a method for finding the profile data of a user.
+func (p *ProfileRepositoryImpl) FindByUserID(ctx context.Context, userID int) (*entity.Profile, error) {
+	profile := &entity.Profile{}
+	err := database.Conn(ctx, p.db).First(profile, "user_id = ?", userID).Error
+
+	return profile, translateError(err, "Profile")
+}
+
+// FindCandidateByID is a method for finding a profile together with the attributes of its user that other users may see.
+func (p *ProfileRepositoryImpl) FindCandidateByID(ctx context.Context, id int) (*entity.ProfileCandidate, error) {
+	candidate := &entity.ProfileCandidate{}
+	err := database.Conn(ctx, p.db).
+		Table("profiles").
+		Select(profileCandidateColumns).
+		Joins("JOIN users ON users.id = profiles.user_id").
+		Where("profiles.id = ?", id).
+		Take(candidate).Error
+
+	return candidate, translateError(err, "Profile")
+}
+
+// Update is a method for updating the attributes of a profile that its user writes.
+func (p *ProfileRepositoryImpl) Update(ctx context.Context, id int, bio, interests string, updatedAt time.Time) error {
+	result := database.Conn(ctx, p.db).
+		Model(&entity.Profile{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"bio": bio, "interests": interests, "updated_at": updatedAt})
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return gorm.ErrDuplicatedKey
+		return apperror.New(apperror.KindNotFound, "Profile not found")
 	}
 
 	return nil
 }
+
+// FindDiscoverable is a method for finding the profiles after the cursor that a user has not liked or passed on the swipe date.
+// When verifiedEmailOnly is true, the profiles of users who have verified neither their email address nor their phone number are left out.
+func (p *ProfileRepositoryImpl) FindDiscoverable(ctx context.Context, userID int, swipeDate time.Time, cursor, limit int, verifiedEmailOnly bool) ([]*entity.ProfileCandidate, error) {
+	candidates := []*entity.ProfileCandidate{}
+	swiped := p.db.
+		Table("activities").
+		Select("1").
+		Where("activities.profile_id = profiles.id AND activities.user_id = ? AND activities.activity_date = ?", userID, swipeDate)
+	query := database.Conn(ctx, p.db).
+		Table("profiles").
+		Select(profileCandidateColumns).
+		Joins("JOIN users ON users.id = profiles.user_id").
+		Where("profiles.user_id <> ? AND profiles.id > ?", userID, cursor).
+		Where("NOT EXISTS (?)", swiped)
+	if verifiedEmailOnly {
+		query = query.Where("users.email_verified_at IS NOT NULL OR users.phone_verified_at IS NOT NULL")
+	}
+
+	err := query.
+		Order("profiles.id").
+		Limit(limit).
+		Scan(&candidates).Error
+
+	return candidates, err
+}
@@ -5,6 +5,7 @@ import (
 	"regexp"
 	"testing"
 
+	"dealls-technical-test-dating-service/internal/domain/apperror"
 	"dealls-technical-test-dating-service/internal/domain/entity"
 	"dealls-technical-test-dating-service/internal/infrastructure/database"
 	"dealls-technical-test-dating-service/internal/infrastructure/repository"
@@ -12,7 +13,6 @@ import (
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
-	"gorm.io/gorm"
 	"gorm.io/gorm/schema"
 )
 
@@ -51,7 +51,7 @@ func TestProfileRepositoryImpl_Insert_Failed_Email_Already_Exists(t *testing.T)
 	repo := repository.NewProfileRepository(gormDB)
 	err := repo.Insert(context.TODO(), profile)
 	require.Error(t, err)
-	assert.Equal(t, gorm.ErrDuplicatedKey, err)
+	require.ErrorIs(t, err, apperror.ErrConflict)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -77,3 +77,201 @@ func TestProfileRepositoryImpl_Insert_Success(t *testing.T) {
 	assert.Equal(t, 1, profile.ID)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestProfileRepositoryImpl_FindByID_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	expectedSQL := "SELECT (.+) FROM \"profiles\" WHERE id = (.+)"
+	profileRows := sqlmock.NewRows(profileColumns)
+	mock.ExpectQuery(expectedSQL).WillReturnRows(profileRows)
+
+	repo := repository.NewProfileRepository(gormDB)
+	_, err := repo.FindByID(context.TODO(), 1)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProfileRepositoryImpl_FindByID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	expectedSQL := "SELECT (.+) FROM \"profiles\" WHERE id = (.+)"
+	profileRows := sqlmock.NewRows(profileColumns).AddRow(1, 1, "Bio", "Interests", false, currentTime, currentTime)
+	mock.ExpectQuery(expectedSQL).WillReturnRows(profileRows)
+
+	repo := repository.NewProfileRepository(gormDB)
+	profile, err := repo.FindByID(context.TODO(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 1, profile.UserID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProfileRepositoryImpl_FindByUserID_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	expectedSQL := "SELECT (.+) FROM \"profiles\" WHERE user_id = (.+)"
+	mock.ExpectQuery(expectedSQL).WillReturnRows(sqlmock.NewRows(profileColumns))
+
+	repo := repository.NewProfileRepository(gormDB)
+	_, err := repo.FindByUserID(context.TODO(), 1)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProfileRepositoryImpl_FindByUserID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	expectedSQL := "SELECT (.+) FROM \"profiles\" WHERE user_id = (.+)"
+	profileRows := sqlmock.NewRows(profileColumns).AddRow(2, 1, "Bio", "Interests", false, currentTime, currentTime)
+	mock.ExpectQuery(expectedSQL).
+		WithArgs(1, 1).
+		WillReturnRows(profileRows)
+
+	repo := repository.NewProfileRepository(gormDB)
+	profile, err := repo.FindByUserID(context.TODO(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 2, profile.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProfileRepositoryImpl_FindCandidateByID_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	candidateColumns := []string{"profile_id", "user_id", "name", "birth_date", "gender", "location", "profile_picture_key", "bio", "interests", "verified"}
+	expectedSQL := "SELECT (.+) FROM \"profiles\" JOIN users ON users.id = profiles.user_id WHERE profiles.id = (.+) LIMIT (.+)"
+	mock.ExpectQuery(expectedSQL).WillReturnRows(sqlmock.NewRows(candidateColumns))
+
+	repo := repository.NewProfileRepository(gormDB)
+	_, err := repo.FindCandidateByID(context.TODO(), 2)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProfileRepositoryImpl_FindCandidateByID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	candidateColumns := []string{"profile_id", "user_id", "name", "birth_date", "gender", "location", "profile_picture_key", "bio", "interests", "verified"}
+	candidateRows := sqlmock.NewRows(candidateColumns).AddRow(2, 2, "User 2", currentTime, "FEMALE", "Indonesia", "", "Bio", "Interests", false)
+	expectedSQL := "SELECT (.+) FROM \"profiles\" JOIN users ON users.id = profiles.user_id WHERE profiles.id = (.+) LIMIT (.+)"
+	mock.ExpectQuery(expectedSQL).
+		WithArgs(2, 1).
+		WillReturnRows(candidateRows)
+
+	repo := repository.NewProfileRepository(gormDB)
+	candidate, err := repo.FindCandidateByID(context.TODO(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, "User 2", candidate.Name)
+	assert.Equal(t, "Bio", candidate.Bio)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProfileRepositoryImpl_Update_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"profiles\" SET \"bio\"=(.+),\"interests\"=(.+),\"updated_at\"=(.+) WHERE id = (.+)").
+		WithArgs("Bio", "Interests", currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewProfileRepository(gormDB)
+	err := repo.Update(context.TODO(), 1, "Bio", "Interests", currentTime)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProfileRepositoryImpl_Update_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"profiles\" SET \"bio\"=(.+),\"interests\"=(.+),\"updated_at\"=(.+) WHERE id = (.+)").
+		WithArgs("Bio", "Interests", currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewProfileRepository(gormDB)
+	err := repo.Update(context.TODO(), 1, "Bio", "Interests", currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProfileRepositoryImpl_FindDiscoverable_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	expectedSQL := "SELECT (.+) FROM \"profiles\" JOIN users ON users.id = profiles.user_id WHERE (.+) NOT EXISTS (.+) ORDER BY profiles.id LIMIT (.+)"
+	mock.ExpectQuery(expectedSQL).WillReturnError(schema.ErrUnsupportedDataType)
+
+	repo := repository.NewProfileRepository(gormDB)
+	_, err := repo.FindDiscoverable(context.TODO(), 1, currentTime, 0, 10, false)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProfileRepositoryImpl_FindDiscoverable_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	candidateColumns := []string{"profile_id", "user_id", "name", "birth_date", "gender", "location", "profile_picture_key", "bio", "interests", "verified"}
+	candidateRows := sqlmock.NewRows(candidateColumns).
+		AddRow(2, 2, "User 2", currentTime, "FEMALE", "Indonesia", "", "Bio", "Interests", false).
+		AddRow(3, 3, "User 3", currentTime, "MALE", "Indonesia", "", "", "", true)
+	expectedSQL := "SELECT (.+) FROM \"profiles\" JOIN users ON users.id = profiles.user_id WHERE (.+) NOT EXISTS (.+) ORDER BY profiles.id LIMIT (.+)"
+	mock.ExpectQuery(expectedSQL).
+		WithArgs(1, 0, 1, currentTime, 10).
+		WillReturnRows(candidateRows)
+
+	repo := repository.NewProfileRepository(gormDB)
+	candidates, err := repo.FindDiscoverable(context.TODO(), 1, currentTime, 0, 10, false)
+	require.NoError(t, err)
+	require.Len(t, candidates, 2)
+	assert.Equal(t, 2, candidates[0].ProfileID)
+	assert.Equal(t, "User 3", candidates[1].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestProfileRepositoryImpl_FindDiscoverable_Success_Verified_Email_Only(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	candidateColumns := []string{"profile_id", "user_id", "name", "birth_date", "gender", "location", "profile_picture_key", "bio", "interests", "verified"}
+	expectedSQL := "SELECT (.+) FROM \"profiles\" JOIN users ON users.id = profiles.user_id WHERE (.+) NOT EXISTS (.+) AND \\(users.email_verified_at IS NOT NULL OR users.phone_verified_at IS NOT NULL\\) ORDER BY profiles.id LIMIT (.+)"
+	mock.ExpectQuery(expectedSQL).
+		WithArgs(1, 0, 1, currentTime, 10).
+		WillReturnRows(sqlmock.NewRows(candidateColumns).AddRow(2, 2, "User 2", currentTime, "FEMALE", "Indonesia", "", "", "", false))
+
+	repo := repository.NewProfileRepository(gormDB)
+	candidates, err := repo.FindDiscoverable(context.TODO(), 1, currentTime, 0, 10, true)
+	require.NoError(t, err)
+	require.Len(t, candidates, 1)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// RecoveryCodeRepositoryImpl is a struct used to implement the recovery code repository interface defined in the domain.
+type RecoveryCodeRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRecoveryCodeRepository is a function used to initialize the recovery code repository implementation.
+func NewRecoveryCodeRepository(db *gorm.DB) *RecoveryCodeRepositoryImpl {
+	return &RecoveryCodeRepositoryImpl{
+		db: db,
+	}
+}
+
+// InsertBatch is a method for inserting recovery code data in the recovery_codes table.
+func (r *RecoveryCodeRepositoryImpl) InsertBatch(ctx context.Context, recoveryCodes []*entity.RecoveryCode) error {
+	err := database.Conn(ctx, r.db).Create(&recoveryCodes).Error
+
+	return translateError(err, "Recovery code")
+}
+
+// DeleteByUserID is a method for deleting every recovery code of a user.
+func (r *RecoveryCodeRepositoryImpl) DeleteByUserID(ctx context.Context, userID int) error {
+	return database.Conn(ctx, r.db).Delete(&entity.RecoveryCode{}, "user_id = ?", userID).Error
+}
+
+// MarkUsed is a method for marking the recovery code of a user with the hash as used.
+// Only a code that is not used yet is marked, so that a code cannot be used twice by concurrent requests.
+func (r *RecoveryCodeRepositoryImpl) MarkUsed(ctx context.Context, userID int, codeHash string, usedAt time.Time) error {
+	result := database.Conn(ctx, r.db).
+		Model(&entity.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.New(apperror.KindNotFound, "Recovery code not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecoveryCodeRepositoryImpl_InsertBatch_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	recoveryCodes := []*entity.RecoveryCode{
+		entity.NewRecoveryCode(1, "hash1", currentTime),
+		entity.NewRecoveryCode(1, "hash2", currentTime),
+	}
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"recovery_codes\" (.+) VALUES (.+),(.+)").
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1).AddRow(2))
+	mock.ExpectCommit()
+
+	repo := repository.NewRecoveryCodeRepository(gormDB)
+	err := repo.InsertBatch(context.TODO(), recoveryCodes)
+	require.NoError(t, err)
+	assert.Equal(t, 2, recoveryCodes[1].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecoveryCodeRepositoryImpl_DeleteByUserID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM \"recovery_codes\" WHERE user_id = (.+)").
+		WithArgs(1).
+		WillReturnResult(sqlmock.NewResult(0, 10))
+	mock.ExpectCommit()
+
+	repo := repository.NewRecoveryCodeRepository(gormDB)
+	err := repo.DeleteByUserID(context.TODO(), 1)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecoveryCodeRepositoryImpl_MarkUsed_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"recovery_codes\" SET \"used_at\"=(.+) WHERE user_id = (.+) AND code_hash = (.+) AND used_at IS NULL").
+		WithArgs(currentTime, 1, "hash").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewRecoveryCodeRepository(gormDB)
+	err := repo.MarkUsed(context.TODO(), 1, "hash", currentTime)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRecoveryCodeRepositoryImpl_MarkUsed_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"recovery_codes\" SET \"used_at\"=(.+) WHERE user_id = (.+) AND code_hash = (.+) AND used_at IS NULL").
+		WithArgs(currentTime, 1, "hash").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewRecoveryCodeRepository(gormDB)
+	err := repo.MarkUsed(context.TODO(), 1, "hash", currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
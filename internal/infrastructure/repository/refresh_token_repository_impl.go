@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// RefreshTokenRepositoryImpl is a struct used to implement the refresh token repository interface defined in the domain.
+type RefreshTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRefreshTokenRepository is a function used to initialize the refresh token repository implementation.
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepositoryImpl {
+	return &RefreshTokenRepositoryImpl{
+		db: db,
+	}
+}
+
+// Insert is a method for inserting refresh token data in the refresh_tokens table.
+func (r *RefreshTokenRepositoryImpl) Insert(ctx context.Context, refreshToken *entity.RefreshToken) error {
+	err := database.Conn(ctx, r.db).Create(refreshToken).Error
+
+	return translateError(err, "Refresh token")
+}
+
+// FindByTokenHash is a method for finding refresh token data based on the token hash.
+func (r *RefreshTokenRepositoryImpl) FindByTokenHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	refreshToken := &entity.RefreshToken{}
+	err := database.Conn(ctx, r.db).First(refreshToken, "token_hash = ?", tokenHash).Error
+
+	return refreshToken, translateError(err, "Refresh token")
+}
+
+// MarkRotated is a method for marking a refresh token as rotated.
+// Only a token that is neither rotated nor revoked is marked, so that two concurrent rotations of the same token cannot both succeed.
+func (r *RefreshTokenRepositoryImpl) MarkRotated(ctx context.Context, id int, rotatedAt time.Time) error {
+	result := database.Conn(ctx, r.db).
+		Model(&entity.RefreshToken{}).
+		Where("id = ? AND rotated_at IS NULL AND revoked_at IS NULL", id).
+		Update("rotated_at", rotatedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.New(apperror.KindConflict, "Refresh token already rotated")
+	}
+
+	return nil
+}
+
+// RevokeFamily is a method for revoking every refresh token of a family that is not revoked yet.
+func (r *RefreshTokenRepositoryImpl) RevokeFamily(ctx context.Context, familyID string, revokedAt time.Time) error {
+	return database.Conn(ctx, r.db).
+		Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).Error
+}
+
+// RevokeByUserID is a method for revoking every refresh token of a user that is not revoked yet.
+func (r *RefreshTokenRepositoryImpl) RevokeByUserID(ctx context.Context, userID int, revokedAt time.Time) error {
+	return database.Conn(ctx, r.db).
+		Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
@@ -0,0 +1,179 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var refreshTokenColumns = []string{"id", "user_id", "family_id", "token_hash", "expires_at", "created_at", "rotated_at", "revoked_at"}
+
+func TestRefreshTokenRepositoryImpl_Insert_Failed_Token_Exists(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	refreshToken := entity.NewRefreshToken(1, "family", "hash", currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"refresh_tokens\" (.+) VALUES (.+)").WillReturnError(gorm.ErrDuplicatedKey)
+	mock.ExpectRollback()
+
+	repo := repository.NewRefreshTokenRepository(gormDB)
+	err := repo.Insert(context.TODO(), refreshToken)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepositoryImpl_Insert_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	refreshToken := entity.NewRefreshToken(1, "family", "hash", currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"refresh_tokens\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectCommit()
+
+	repo := repository.NewRefreshTokenRepository(gormDB)
+	err := repo.Insert(context.TODO(), refreshToken)
+	require.NoError(t, err)
+	assert.Equal(t, 1, refreshToken.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepositoryImpl_FindByTokenHash_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"refresh_tokens\" WHERE token_hash = (.+)").
+		WithArgs("hash", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	repo := repository.NewRefreshTokenRepository(gormDB)
+	_, err := repo.FindByTokenHash(context.TODO(), "hash")
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepositoryImpl_FindByTokenHash_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"refresh_tokens\" WHERE token_hash = (.+)").
+		WithArgs("hash", 1).
+		WillReturnRows(sqlmock.NewRows(refreshTokenColumns).AddRow(1, 2, "family", "hash", currentTime, currentTime, nil, nil))
+
+	repo := repository.NewRefreshTokenRepository(gormDB)
+	refreshToken, err := repo.FindByTokenHash(context.TODO(), "hash")
+	require.NoError(t, err)
+	assert.Equal(t, 2, refreshToken.UserID)
+	assert.Equal(t, "family", refreshToken.FamilyID)
+	assert.Nil(t, refreshToken.RotatedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepositoryImpl_MarkRotated_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"refresh_tokens\" SET \"rotated_at\"=(.+) WHERE (.+)").
+		WithArgs(currentTime, 1).
+		WillReturnError(schema.ErrUnsupportedDataType)
+	mock.ExpectRollback()
+
+	repo := repository.NewRefreshTokenRepository(gormDB)
+	err := repo.MarkRotated(context.TODO(), 1, currentTime)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepositoryImpl_MarkRotated_Failed_Already_Rotated(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"refresh_tokens\" SET \"rotated_at\"=(.+) WHERE (.+) AND rotated_at IS NULL AND revoked_at IS NULL").
+		WithArgs(currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewRefreshTokenRepository(gormDB)
+	err := repo.MarkRotated(context.TODO(), 1, currentTime)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepositoryImpl_MarkRotated_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"refresh_tokens\" SET \"rotated_at\"=(.+) WHERE (.+)").
+		WithArgs(currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewRefreshTokenRepository(gormDB)
+	err := repo.MarkRotated(context.TODO(), 1, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepositoryImpl_RevokeFamily_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"refresh_tokens\" SET \"revoked_at\"=(.+) WHERE family_id = (.+) AND revoked_at IS NULL").
+		WithArgs(currentTime, "family").
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	repo := repository.NewRefreshTokenRepository(gormDB)
+	err := repo.RevokeFamily(context.TODO(), "family", currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRefreshTokenRepositoryImpl_RevokeByUserID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"refresh_tokens\" SET \"revoked_at\"=(.+) WHERE user_id = (.+) AND revoked_at IS NULL").
+		WithArgs(currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	repo := repository.NewRefreshTokenRepository(gormDB)
+	err := repo.RevokeByUserID(context.TODO(), 1, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
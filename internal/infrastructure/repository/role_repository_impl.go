@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// RoleRepositoryImpl is a struct used to implement the role repository interface defined in the domain.
+type RoleRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewRoleRepository is a function used to initialize the role repository implementation.
+func NewRoleRepository(db *gorm.DB) *RoleRepositoryImpl {
+	return &RoleRepositoryImpl{
+		db: db,
+	}
+}
+
+// FindPermissions is a method for finding the permissions granted to a role in the role_permissions table.
+func (r *RoleRepositoryImpl) FindPermissions(ctx context.Context, role string) ([]string, error) {
+	permissions := []string{}
+	err := database.Conn(ctx, r.db).
+		Model(&entity.RolePermission{}).
+		Where("role = ?", role).
+		Order("permission").
+		Pluck("permission", &permissions).Error
+
+	return permissions, err
+}
@@ -0,0 +1,47 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+func TestRoleRepositoryImpl_FindPermissions_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT \"permission\" FROM \"role_permissions\" WHERE role = (.+)").
+		WithArgs("admin").
+		WillReturnError(schema.ErrUnsupportedDataType)
+
+	repo := repository.NewRoleRepository(gormDB)
+	_, err := repo.FindPermissions(context.TODO(), "admin")
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRoleRepositoryImpl_FindPermissions_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT \"permission\" FROM \"role_permissions\" WHERE role = (.+) ORDER BY permission").
+		WithArgs("admin").
+		WillReturnRows(sqlmock.NewRows([]string{"permission"}).AddRow("users:manage_roles").AddRow("users:read"))
+
+	repo := repository.NewRoleRepository(gormDB)
+	permissions, err := repo.FindPermissions(context.TODO(), "admin")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"users:manage_roles", "users:read"}, permissions)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
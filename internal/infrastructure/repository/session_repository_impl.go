@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// SessionRepositoryImpl is a struct used to implement the session repository interface defined in the domain.
+type SessionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSessionRepository is a function used to initialize the session repository implementation.
+func NewSessionRepository(db *gorm.DB) *SessionRepositoryImpl {
+	return &SessionRepositoryImpl{
+		db: db,
+	}
+}
+
+// Insert is a method for inserting session data in the sessions table.
+func (s *SessionRepositoryImpl) Insert(ctx context.Context, session *entity.Session) error {
+	err := database.Conn(ctx, s.db).Create(session).Error
+
+	return translateError(err, "Session")
+}
+
+// FindByTokenID is a method for finding session data based on the ID of its current access token.
+func (s *SessionRepositoryImpl) FindByTokenID(ctx context.Context, tokenID string) (*entity.Session, error) {
+	session := &entity.Session{}
+	err := database.Conn(ctx, s.db).First(session, "token_id = ?", tokenID).Error
+
+	return session, translateError(err, "Session")
+}
+
+// FindByUserID is a method for finding the sessions of a user seen since the given time, most recently seen first.
+func (s *SessionRepositoryImpl) FindByUserID(ctx context.Context, userID int, seenSince time.Time) ([]*entity.Session, error) {
+	sessions := []*entity.Session{}
+	err := database.Conn(ctx, s.db).
+		Where("user_id = ? AND last_seen_at >= ?", userID, seenSince).
+		Order("last_seen_at DESC, id DESC").
+		Find(&sessions).Error
+
+	return sessions, err
+}
+
+// UpdateTokenID is a method for replacing the access token of the session of a refresh token family, marking the session as seen.
+func (s *SessionRepositoryImpl) UpdateTokenID(ctx context.Context, refreshFamilyID, tokenID string, seenAt time.Time) error {
+	result := database.Conn(ctx, s.db).
+		Model(&entity.Session{}).
+		Where("refresh_family_id = ?", refreshFamilyID).
+		Updates(map[string]interface{}{"token_id": tokenID, "last_seen_at": seenAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.Wrap(apperror.KindNotFound, "Session not found", gorm.ErrRecordNotFound)
+	}
+
+	return nil
+}
+
+// UpdateLastSeen is a method for updating the time a session was last seen.
+func (s *SessionRepositoryImpl) UpdateLastSeen(ctx context.Context, id int, seenAt time.Time) error {
+	return database.Conn(ctx, s.db).
+		Model(&entity.Session{}).
+		Where("id = ?", id).
+		Update("last_seen_at", seenAt).Error
+}
+
+// Delete is a method for deleting a session of a user and returning it.
+func (s *SessionRepositoryImpl) Delete(ctx context.Context, id, userID int) (*entity.Session, error) {
+	sessions := []*entity.Session{}
+	err := database.Conn(ctx, s.db).
+		Clauses(clause.Returning{}).
+		Where("id = ? AND user_id = ?", id, userID).
+		Delete(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, apperror.Wrap(apperror.KindNotFound, "Session not found", gorm.ErrRecordNotFound)
+	}
+
+	return sessions[0], nil
+}
+
+// DeleteByTokenID is a method for deleting the session of an access token.
+func (s *SessionRepositoryImpl) DeleteByTokenID(ctx context.Context, tokenID string) error {
+	return database.Conn(ctx, s.db).
+		Where("token_id = ?", tokenID).
+		Delete(&entity.Session{}).Error
+}
+
+// DeleteByUserID is a method for deleting every session of a user.
+func (s *SessionRepositoryImpl) DeleteByUserID(ctx context.Context, userID int) error {
+	return database.Conn(ctx, s.db).
+		Where("user_id = ?", userID).
+		Delete(&entity.Session{}).Error
+}
@@ -0,0 +1,235 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var sessionColumns = []string{"id", "user_id", "token_id", "refresh_family_id", "device_name", "ip_address", "created_at", "last_seen_at"}
+
+func TestSessionRepositoryImpl_Insert_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	session := entity.NewSession(1, "token", "family", "Chrome on Windows", "127.0.0.1", currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"sessions\" (.+) VALUES (.+)").WillReturnError(schema.ErrUnsupportedDataType)
+	mock.ExpectRollback()
+
+	repo := repository.NewSessionRepository(gormDB)
+	err := repo.Insert(context.TODO(), session)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_Insert_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	session := entity.NewSession(1, "token", "family", "Chrome on Windows", "127.0.0.1", currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"sessions\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectCommit()
+
+	repo := repository.NewSessionRepository(gormDB)
+	err := repo.Insert(context.TODO(), session)
+	require.NoError(t, err)
+	assert.Equal(t, 1, session.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_FindByTokenID_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"sessions\" WHERE token_id = (.+)").
+		WithArgs("token", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	repo := repository.NewSessionRepository(gormDB)
+	_, err := repo.FindByTokenID(context.TODO(), "token")
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_FindByTokenID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"sessions\" WHERE token_id = (.+)").
+		WithArgs("token", 1).
+		WillReturnRows(sqlmock.NewRows(sessionColumns).AddRow(1, 2, "token", "family", "Chrome on Windows", "127.0.0.1", currentTime, currentTime))
+
+	repo := repository.NewSessionRepository(gormDB)
+	session, err := repo.FindByTokenID(context.TODO(), "token")
+	require.NoError(t, err)
+	assert.Equal(t, 2, session.UserID)
+	assert.Equal(t, "family", session.RefreshFamilyID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_FindByUserID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"sessions\" WHERE (.+) ORDER BY last_seen_at DESC, id DESC").
+		WithArgs(1, currentTime).
+		WillReturnRows(sqlmock.NewRows(sessionColumns).
+			AddRow(2, 1, "token", "family", "Chrome on Windows", "127.0.0.1", currentTime, currentTime).
+			AddRow(1, 1, "other", "other", "Safari on iPhone", "127.0.0.2", currentTime, currentTime))
+
+	repo := repository.NewSessionRepository(gormDB)
+	sessions, err := repo.FindByUserID(context.TODO(), 1, currentTime)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	assert.Equal(t, 2, sessions[0].ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_UpdateTokenID_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"sessions\" SET (.+) WHERE refresh_family_id = (.+)").
+		WithArgs(currentTime, "token", "family").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewSessionRepository(gormDB)
+	err := repo.UpdateTokenID(context.TODO(), "family", "token", currentTime)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_UpdateTokenID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"sessions\" SET (.+) WHERE refresh_family_id = (.+)").
+		WithArgs(currentTime, "token", "family").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewSessionRepository(gormDB)
+	err := repo.UpdateTokenID(context.TODO(), "family", "token", currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_UpdateLastSeen_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"sessions\" SET \"last_seen_at\"=(.+) WHERE id = (.+)").
+		WithArgs(currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewSessionRepository(gormDB)
+	err := repo.UpdateLastSeen(context.TODO(), 1, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_Delete_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM \"sessions\" WHERE (.+) RETURNING (.+)").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows(sessionColumns))
+	mock.ExpectCommit()
+
+	repo := repository.NewSessionRepository(gormDB)
+	_, err := repo.Delete(context.TODO(), 1, 2)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_Delete_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("DELETE FROM \"sessions\" WHERE (.+) RETURNING (.+)").
+		WithArgs(1, 2).
+		WillReturnRows(sqlmock.NewRows(sessionColumns).AddRow(1, 2, "token", "family", "Chrome on Windows", "127.0.0.1", currentTime, currentTime))
+	mock.ExpectCommit()
+
+	repo := repository.NewSessionRepository(gormDB)
+	session, err := repo.Delete(context.TODO(), 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "family", session.RefreshFamilyID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_DeleteByTokenID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM \"sessions\" WHERE token_id = (.+)").
+		WithArgs("token").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewSessionRepository(gormDB)
+	err := repo.DeleteByTokenID(context.TODO(), "token")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSessionRepositoryImpl_DeleteByUserID_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM \"sessions\" WHERE user_id = (.+)").
+		WithArgs(1).
+		WillReturnError(schema.ErrUnsupportedDataType)
+	mock.ExpectRollback()
+
+	repo := repository.NewSessionRepository(gormDB)
+	err := repo.DeleteByUserID(context.TODO(), 1)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
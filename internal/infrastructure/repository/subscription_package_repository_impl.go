@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// SubscriptionPackageRepositoryImpl is a struct used to implement the subscription package repository interface defined in the domain.
+type SubscriptionPackageRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSubscriptionPackageRepository is a function used to initialize the subscription package repository implementation.
+func NewSubscriptionPackageRepository(db *gorm.DB) *SubscriptionPackageRepositoryImpl {
+	return &SubscriptionPackageRepositoryImpl{
+		db: db,
+	}
+}
+
+// FindAll is a method for finding all subscription package data.
+func (s *SubscriptionPackageRepositoryImpl) FindAll(ctx context.Context) ([]*entity.SubscriptionPackage, error) {
+	subscriptionPackages := []*entity.SubscriptionPackage{}
+	err := database.Conn(ctx, s.db).Order("id").Find(&subscriptionPackages).Error
+
+	return subscriptionPackages, err
+}
+
+// FindByID is a method for finding subscription package data based on ID.
+func (s *SubscriptionPackageRepositoryImpl) FindByID(ctx context.Context, id int) (*entity.SubscriptionPackage, error) {
+	subscriptionPackage := &entity.SubscriptionPackage{}
+	err := database.Conn(ctx, s.db).First(subscriptionPackage, "id = ?", id).Error
+
+	return subscriptionPackage, translateError(err, "Subscription package")
+}
@@ -0,0 +1,80 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+var subscriptionPackageColumns = []string{"id", "name", "lifetime_days", "price"}
+
+func TestSubscriptionPackageRepositoryImpl_FindAll_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"subscription_packages\" ORDER BY id").WillReturnError(schema.ErrUnsupportedDataType)
+
+	repo := repository.NewSubscriptionPackageRepository(gormDB)
+	_, err := repo.FindAll(context.TODO())
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionPackageRepositoryImpl_FindAll_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	packageRows := sqlmock.NewRows(subscriptionPackageColumns).
+		AddRow(1, "PREMIUM_MONTHLY", 30, 99000).
+		AddRow(2, "PREMIUM_YEARLY", 365, 899000)
+	mock.ExpectQuery("SELECT (.+) FROM \"subscription_packages\" ORDER BY id").WillReturnRows(packageRows)
+
+	repo := repository.NewSubscriptionPackageRepository(gormDB)
+	subscriptionPackages, err := repo.FindAll(context.TODO())
+	require.NoError(t, err)
+	require.Len(t, subscriptionPackages, 2)
+	assert.Equal(t, "PREMIUM_YEARLY", subscriptionPackages[1].Name)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionPackageRepositoryImpl_FindByID_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"subscription_packages\" WHERE id = (.+)").WillReturnRows(sqlmock.NewRows(subscriptionPackageColumns))
+
+	repo := repository.NewSubscriptionPackageRepository(gormDB)
+	_, err := repo.FindByID(context.TODO(), 1)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionPackageRepositoryImpl_FindByID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	packageRows := sqlmock.NewRows(subscriptionPackageColumns).AddRow(1, "PREMIUM_MONTHLY", 30, 99000)
+	mock.ExpectQuery("SELECT (.+) FROM \"subscription_packages\" WHERE id = (.+)").WillReturnRows(packageRows)
+
+	repo := repository.NewSubscriptionPackageRepository(gormDB)
+	subscriptionPackage, err := repo.FindByID(context.TODO(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, 30, subscriptionPackage.LifetimeDays)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/pkg/constant"
+
+	"gorm.io/gorm"
+)
+
+// SubscriptionRepositoryImpl is a struct used to implement the subscription repository interface defined in the domain.
+type SubscriptionRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewSubscriptionRepository is a function used to initialize the subscription repository implementation.
+func NewSubscriptionRepository(db *gorm.DB) *SubscriptionRepositoryImpl {
+	return &SubscriptionRepositoryImpl{
+		db: db,
+	}
+}
+
+// Insert is a method for inserting subscription data in the subscriptions table.
+func (s *SubscriptionRepositoryImpl) Insert(ctx context.Context, subscription *entity.Subscription) error {
+	// Only one active subscription per user is allowed by a partial unique index.
+	err := database.Conn(ctx, s.db).Create(subscription).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return apperror.Wrap(apperror.KindConflict, constant.SubscriptionExists, err)
+	}
+
+	return err
+}
+
+// FindActiveByUserID is a method for finding the active subscription of a user that has not ended on the date.
+func (s *SubscriptionRepositoryImpl) FindActiveByUserID(ctx context.Context, userID int, date time.Time) (*entity.Subscription, error) {
+	subscription := &entity.Subscription{}
+	err := database.Conn(ctx, s.db).First(subscription, "user_id = ? AND active AND end_date > ?", userID, date).Error
+
+	return subscription, translateError(err, "Subscription")
+}
+
+// DeactivateExpired is a method for deactivating the subscriptions of a user that have ended on the date.
+func (s *SubscriptionRepositoryImpl) DeactivateExpired(ctx context.Context, userID int, date time.Time) error {
+	return database.Conn(ctx, s.db).
+		Model(&entity.Subscription{}).
+		Where("user_id = ? AND active AND end_date <= ?", userID, date).
+		Update("active", false).Error
+}
+
+// Deactivate is a method for deactivating a subscription.
+func (s *SubscriptionRepositoryImpl) Deactivate(ctx context.Context, id int) error {
+	result := database.Conn(ctx, s.db).
+		Model(&entity.Subscription{}).
+		Where("id = ? AND active", id).
+		Update("active", false)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.Wrap(apperror.KindNotFound, "Subscription not found", gorm.ErrRecordNotFound)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+var subscriptionColumns = []string{"id", "subscription_package_id", "user_id", "start_date", "end_date", "active", "payment_reference"}
+
+func TestSubscriptionRepositoryImpl_Insert_Failed_Active_Subscription_Exists(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	subscription := entity.NewSubscription(&entity.SubscriptionPackage{ID: 1, LifetimeDays: 30}, 1, currentTime, "reference")
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"subscriptions\" (.+) VALUES (.+)").WillReturnError(gorm.ErrDuplicatedKey)
+	mock.ExpectRollback()
+
+	repo := repository.NewSubscriptionRepository(gormDB)
+	err := repo.Insert(context.TODO(), subscription)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionRepositoryImpl_Insert_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	subscription := entity.NewSubscription(&entity.SubscriptionPackage{ID: 1, LifetimeDays: 30}, 1, currentTime, "reference")
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"subscriptions\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectCommit()
+
+	repo := repository.NewSubscriptionRepository(gormDB)
+	err := repo.Insert(context.TODO(), subscription)
+	require.NoError(t, err)
+	assert.Equal(t, 1, subscription.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionRepositoryImpl_FindActiveByUserID_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"subscriptions\" WHERE user_id = (.+) AND active AND end_date > (.+)").
+		WithArgs(1, currentTime, 1).
+		WillReturnRows(sqlmock.NewRows(subscriptionColumns))
+
+	repo := repository.NewSubscriptionRepository(gormDB)
+	_, err := repo.FindActiveByUserID(context.TODO(), 1, currentTime)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionRepositoryImpl_FindActiveByUserID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	subscriptionRows := sqlmock.NewRows(subscriptionColumns).AddRow(1, 1, 1, currentTime, currentTime.AddDate(0, 0, 30), true, "reference")
+	mock.ExpectQuery("SELECT (.+) FROM \"subscriptions\" WHERE user_id = (.+) AND active AND end_date > (.+)").
+		WithArgs(1, currentTime, 1).
+		WillReturnRows(subscriptionRows)
+
+	repo := repository.NewSubscriptionRepository(gormDB)
+	subscription, err := repo.FindActiveByUserID(context.TODO(), 1, currentTime)
+	require.NoError(t, err)
+	assert.True(t, subscription.Active)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionRepositoryImpl_DeactivateExpired_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"subscriptions\" SET \"active\"=(.+) WHERE user_id = (.+) AND active AND end_date <= (.+)").
+		WithArgs(false, 1, currentTime).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewSubscriptionRepository(gormDB)
+	err := repo.DeactivateExpired(context.TODO(), 1, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionRepositoryImpl_Deactivate_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"subscriptions\" SET \"active\"=(.+) WHERE id = (.+) AND active").
+		WithArgs(false, 1).
+		WillReturnError(schema.ErrUnsupportedDataType)
+	mock.ExpectRollback()
+
+	repo := repository.NewSubscriptionRepository(gormDB)
+	err := repo.Deactivate(context.TODO(), 1)
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionRepositoryImpl_Deactivate_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"subscriptions\" SET \"active\"=(.+) WHERE id = (.+) AND active").
+		WithArgs(false, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewSubscriptionRepository(gormDB)
+	err := repo.Deactivate(context.TODO(), 1)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSubscriptionRepositoryImpl_Deactivate_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"subscriptions\" SET \"active\"=(.+) WHERE id = (.+) AND active").
+		WithArgs(false, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewSubscriptionRepository(gormDB)
+	err := repo.Deactivate(context.TODO(), 1)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TokenRevocationRepositoryImpl is a struct used to implement the token revocation repository interface defined in the domain.
+type TokenRevocationRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTokenRevocationRepository is a function used to initialize the token revocation repository implementation.
+func NewTokenRevocationRepository(db *gorm.DB) *TokenRevocationRepositoryImpl {
+	return &TokenRevocationRepositoryImpl{
+		db: db,
+	}
+}
+
+// InsertRevokedToken is a method for inserting revoked token data in the revoked_tokens table.
+// Revoking a token twice is not an error.
+func (t *TokenRevocationRepositoryImpl) InsertRevokedToken(ctx context.Context, revokedToken *entity.RevokedToken) error {
+	return database.Conn(ctx, t.db).Clauses(clause.OnConflict{DoNothing: true}).Create(revokedToken).Error
+}
+
+// ExistsRevokedToken is a method for checking whether a token ID is in the revoked_tokens table.
+func (t *TokenRevocationRepositoryImpl) ExistsRevokedToken(ctx context.Context, id string) (bool, error) {
+	var count int64
+	err := database.Conn(ctx, t.db).
+		Model(&entity.RevokedToken{}).
+		Where("id = ?", id).
+		Count(&count).Error
+
+	return count > 0, err
+}
+
+// UpsertUserTokenRevocation is a method for inserting or moving forward the time before which every token of a user is revoked.
+func (t *TokenRevocationRepositoryImpl) UpsertUserTokenRevocation(ctx context.Context, revocation *entity.UserTokenRevocation) error {
+	return database.Conn(ctx, t.db).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.Set{{
+				Column: clause.Column{Name: "revoked_before"},
+				Value:  gorm.Expr("GREATEST(user_token_revocations.revoked_before, EXCLUDED.revoked_before)"),
+			}},
+		}).
+		Create(revocation).Error
+}
+
+// FindRevokedBefore is a method for finding the time before which every token of a user is revoked.
+// The zero time is returned when the tokens of the user were never revoked at once.
+func (t *TokenRevocationRepositoryImpl) FindRevokedBefore(ctx context.Context, userID int) (time.Time, error) {
+	revocations := []*entity.UserTokenRevocation{}
+	err := database.Conn(ctx, t.db).
+		Where("user_id = ?", userID).
+		Limit(1).
+		Find(&revocations).Error
+	if err != nil || len(revocations) == 0 {
+		return time.Time{}, err
+	}
+
+	return revocations[0].RevokedBefore, nil
+}
@@ -0,0 +1,262 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm/schema"
+)
+
+func TestTokenRevocationRepositoryImpl_InsertRevokedToken_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	revokedToken := entity.NewRevokedToken(1, &entity.TokenClaims{ID: "jti", ExpiresAt: currentTime}, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO \"revoked_tokens\" (.+) VALUES (.+) ON CONFLICT DO NOTHING").
+		WithArgs("jti", 1, currentTime, currentTime).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewTokenRevocationRepository(gormDB)
+	err := repo.InsertRevokedToken(context.TODO(), revokedToken)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTokenRevocationRepositoryImpl_ExistsRevokedToken_Failed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT count(.+) FROM \"revoked_tokens\" WHERE id = (.+)").
+		WithArgs("jti").
+		WillReturnError(schema.ErrUnsupportedDataType)
+
+	repo := repository.NewTokenRevocationRepository(gormDB)
+	_, err := repo.ExistsRevokedToken(context.TODO(), "jti")
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTokenRevocationRepositoryImpl_ExistsRevokedToken_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT count(.+) FROM \"revoked_tokens\" WHERE id = (.+)").
+		WithArgs("jti").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	repo := repository.NewTokenRevocationRepository(gormDB)
+	revoked, err := repo.ExistsRevokedToken(context.TODO(), "jti")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTokenRevocationRepositoryImpl_UpsertUserTokenRevocation_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"user_token_revocations\" (.+) VALUES (.+) ON CONFLICT \\(\"user_id\"\\) DO UPDATE SET \"revoked_before\"=GREATEST(.+)").
+		WithArgs(currentTime, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	repo := repository.NewTokenRevocationRepository(gormDB)
+	err := repo.UpsertUserTokenRevocation(context.TODO(), entity.NewUserTokenRevocation(1, currentTime))
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTokenRevocationRepositoryImpl_FindRevokedBefore_Never_Revoked(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"user_token_revocations\" WHERE user_id = (.+) LIMIT (.+)").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "revoked_before"}))
+
+	repo := repository.NewTokenRevocationRepository(gormDB)
+	revokedBefore, err := repo.FindRevokedBefore(context.TODO(), 1)
+	require.NoError(t, err)
+	assert.True(t, revokedBefore.IsZero())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTokenRevocationRepositoryImpl_FindRevokedBefore_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"user_token_revocations\" WHERE user_id = (.+) LIMIT (.+)").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "revoked_before"}).AddRow(1, currentTime))
+
+	repo := repository.NewTokenRevocationRepository(gormDB)
+	revokedBefore, err := repo.FindRevokedBefore(context.TODO(), 1)
+	require.NoError(t, err)
+	assert.True(t, revokedBefore.Equal(currentTime))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCachedTokenRevocationRepositoryImpl_Caches_Lookups(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT count(.+) FROM \"revoked_tokens\" WHERE id = (.+)").
+		WithArgs("jti").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT (.+) FROM \"user_token_revocations\" WHERE user_id = (.+) LIMIT (.+)").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "revoked_before"}))
+
+	repo := repository.NewCachedTokenRevocationRepository(repository.NewTokenRevocationRepository(gormDB), time.Minute)
+	for range 2 {
+		revoked, err := repo.ExistsRevokedToken(context.TODO(), "jti")
+		require.NoError(t, err)
+		assert.False(t, revoked)
+
+		revokedBefore, err := repo.FindRevokedBefore(context.TODO(), 1)
+		require.NoError(t, err)
+		assert.True(t, revokedBefore.IsZero())
+	}
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCachedTokenRevocationRepositoryImpl_Revocations_Apply_Once_Committed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT count(.+) FROM \"revoked_tokens\" WHERE id = (.+)").
+		WithArgs("jti").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO \"revoked_tokens\" (.+)").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"user_token_revocations\" (.+)").WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	repo := repository.NewCachedTokenRevocationRepository(repository.NewTokenRevocationRepository(gormDB), time.Minute)
+	revoked, err := repo.ExistsRevokedToken(context.TODO(), "jti")
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	err = repo.InsertRevokedToken(context.TODO(), entity.NewRevokedToken(1, &entity.TokenClaims{ID: "jti", ExpiresAt: currentTime}, currentTime))
+	require.NoError(t, err)
+	revoked, err = repo.ExistsRevokedToken(context.TODO(), "jti")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	err = repo.UpsertUserTokenRevocation(context.TODO(), entity.NewUserTokenRevocation(1, currentTime))
+	require.NoError(t, err)
+	revokedBefore, err := repo.FindRevokedBefore(context.TODO(), 1)
+	require.NoError(t, err)
+	assert.True(t, revokedBefore.Equal(currentTime))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCachedTokenRevocationRepositoryImpl_Rolled_Back_Revocations_Not_Cached(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO \"revoked_tokens\" (.+)").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+	mock.ExpectQuery("SELECT count(.+) FROM \"revoked_tokens\" WHERE id = (.+)").
+		WithArgs("jti").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+
+	unitOfWork := database.NewUnitOfWork(gormDB)
+	repo := repository.NewCachedTokenRevocationRepository(repository.NewTokenRevocationRepository(gormDB), time.Minute)
+	err := unitOfWork.Do(context.TODO(), func(ctx context.Context) error {
+		err := repo.InsertRevokedToken(ctx, entity.NewRevokedToken(1, &entity.TokenClaims{ID: "jti", ExpiresAt: currentTime}, currentTime))
+		if err != nil {
+			return err
+		}
+
+		return schema.ErrUnsupportedDataType
+	})
+	require.ErrorIs(t, err, schema.ErrUnsupportedDataType)
+
+	revoked, err := repo.ExistsRevokedToken(context.TODO(), "jti")
+	require.NoError(t, err)
+	assert.False(t, revoked)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestCachedTokenRevocationRepositoryImpl_Stale_Lookups_Not_Cached(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("SELECT count(.+) FROM \"revoked_tokens\" WHERE id = (.+)").
+		WithArgs("jti").
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+	mock.ExpectQuery("SELECT (.+) FROM \"user_token_revocations\" WHERE user_id = (.+) LIMIT (.+)").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "revoked_before"}))
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO \"revoked_tokens\" (.+)").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"user_token_revocations\" (.+)").WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+	mock.ExpectCommit()
+	mock.ExpectCommit()
+
+	// The lookups read the revocations before the logouts are cached, and are only cached after them.
+	unitOfWork := database.NewUnitOfWork(gormDB)
+	repo := repository.NewCachedTokenRevocationRepository(repository.NewTokenRevocationRepository(gormDB), time.Minute)
+	err := unitOfWork.Do(context.TODO(), func(ctx context.Context) error {
+		revoked, err := repo.ExistsRevokedToken(ctx, "jti")
+		require.NoError(t, err)
+		require.False(t, revoked)
+
+		revokedBefore, err := repo.FindRevokedBefore(ctx, 1)
+		require.NoError(t, err)
+		require.True(t, revokedBefore.IsZero())
+
+		err = repo.InsertRevokedToken(context.TODO(), entity.NewRevokedToken(1, &entity.TokenClaims{ID: "jti", ExpiresAt: currentTime}, currentTime))
+		require.NoError(t, err)
+
+		return repo.UpsertUserTokenRevocation(context.TODO(), entity.NewUserTokenRevocation(1, currentTime))
+	})
+	require.NoError(t, err)
+
+	revoked, err := repo.ExistsRevokedToken(context.TODO(), "jti")
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revokedBefore, err := repo.FindRevokedBefore(context.TODO(), 1)
+	require.NoError(t, err)
+	assert.True(t, revokedBefore.Equal(currentTime))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// TwoFactorRepositoryImpl is a struct used to implement the two-factor repository interface defined in the domain.
+type TwoFactorRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewTwoFactorRepository is a function used to initialize the two-factor repository implementation.
+func NewTwoFactorRepository(db *gorm.DB) *TwoFactorRepositoryImpl {
+	return &TwoFactorRepositoryImpl{
+		db: db,
+	}
+}
+
+// Find is a method for finding the two-factor data of a user.
+func (t *TwoFactorRepositoryImpl) Find(ctx context.Context, userID int) (*entity.TwoFactor, error) {
+	twoFactor := &entity.TwoFactor{}
+	err := database.Conn(ctx, t.db).First(twoFactor, "user_id = ?", userID).Error
+
+	return twoFactor, translateError(err, "Two-factor")
+}
+
+// Upsert is a method for inserting two-factor data in the two_factors table, or replacing the secret of an enrollment that is not confirmed yet.
+// A confirmed enrollment is left as it is.
+func (t *TwoFactorRepositoryImpl) Upsert(ctx context.Context, twoFactor *entity.TwoFactor) error {
+	result := database.Conn(ctx, t.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"secret", "last_used_step", "created_at", "updated_at"}),
+			Where:     clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "two_factors.confirmed_at IS NULL"}}},
+		}).
+		Create(twoFactor)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.New(apperror.KindConflict, "Two-factor already confirmed")
+	}
+
+	return nil
+}
+
+// Confirm is a method for enabling the two-factor authentication of a user with the time step of the code that confirmed it.
+// Only an enrollment that is not confirmed yet is confirmed, so that concurrent requests cannot both confirm it.
+func (t *TwoFactorRepositoryImpl) Confirm(ctx context.Context, userID int, step int64, confirmedAt time.Time) error {
+	result := database.Conn(ctx, t.db).
+		Model(&entity.TwoFactor{}).
+		Where("user_id = ? AND confirmed_at IS NULL", userID).
+		Updates(map[string]interface{}{"last_used_step": step, "confirmed_at": confirmedAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.New(apperror.KindConflict, "Two-factor already confirmed")
+	}
+
+	return nil
+}
+
+// UseStep is a method for recording that a code of the time step was used.
+// Only a time step after the last used one is recorded, so that a code cannot be used twice by concurrent requests.
+func (t *TwoFactorRepositoryImpl) UseStep(ctx context.Context, userID int, step int64) error {
+	result := database.Conn(ctx, t.db).
+		Model(&entity.TwoFactor{}).
+		Where("user_id = ? AND last_used_step < ?", userID, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.New(apperror.KindConflict, "Two-factor code already used")
+	}
+
+	return nil
+}
@@ -0,0 +1,155 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var twoFactorColumns = []string{"user_id", "secret", "last_used_step", "confirmed_at", "created_at", "updated_at"}
+
+func TestTwoFactorRepositoryImpl_Find_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"two_factors\" WHERE user_id = (.+)").
+		WithArgs(1, 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	repo := repository.NewTwoFactorRepository(gormDB)
+	_, err := repo.Find(context.TODO(), 1)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorRepositoryImpl_Find_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"two_factors\" WHERE user_id = (.+)").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows(twoFactorColumns).AddRow(1, "SECRET", 100, currentTime, currentTime, currentTime))
+
+	repo := repository.NewTwoFactorRepository(gormDB)
+	twoFactor, err := repo.Find(context.TODO(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "SECRET", twoFactor.Secret)
+	assert.Equal(t, int64(100), twoFactor.LastUsedStep)
+	assert.True(t, twoFactor.IsEnabled())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorRepositoryImpl_Upsert_Failed_Confirmed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"two_factors\" (.+) ON CONFLICT (.+) DO UPDATE SET (.+) WHERE two_factors.confirmed_at IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}))
+	mock.ExpectCommit()
+
+	repo := repository.NewTwoFactorRepository(gormDB)
+	err := repo.Upsert(context.TODO(), entity.NewTwoFactor(1, "SECRET", currentTime, currentTime))
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorRepositoryImpl_Upsert_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"two_factors\" (.+) ON CONFLICT (.+) DO UPDATE SET (.+) WHERE two_factors.confirmed_at IS NULL").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(1))
+	mock.ExpectCommit()
+
+	repo := repository.NewTwoFactorRepository(gormDB)
+	err := repo.Upsert(context.TODO(), entity.NewTwoFactor(1, "SECRET", currentTime, currentTime))
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorRepositoryImpl_Confirm_Failed_Already_Confirmed(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"two_factors\" SET (.+) WHERE user_id = (.+) AND confirmed_at IS NULL").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewTwoFactorRepository(gormDB)
+	err := repo.Confirm(context.TODO(), 1, 100, currentTime)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorRepositoryImpl_Confirm_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"two_factors\" SET (.+) WHERE user_id = (.+) AND confirmed_at IS NULL").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewTwoFactorRepository(gormDB)
+	err := repo.Confirm(context.TODO(), 1, 100, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorRepositoryImpl_UseStep_Failed_Already_Used(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"two_factors\" SET \"last_used_step\"=(.+) WHERE user_id = (.+) AND last_used_step < (.+)").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewTwoFactorRepository(gormDB)
+	err := repo.UseStep(context.TODO(), 1, 100)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTwoFactorRepositoryImpl_UseStep_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"two_factors\" SET \"last_used_step\"=(.+) WHERE user_id = (.+) AND last_used_step < (.+)").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewTwoFactorRepository(gormDB)
+	err := repo.UseStep(context.TODO(), 1, 100)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// UserIdentityRepositoryImpl is a struct used to implement the user identity repository interface defined in the domain.
+type UserIdentityRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewUserIdentityRepository is a function used to initialize the user identity repository implementation.
+func NewUserIdentityRepository(db *gorm.DB) *UserIdentityRepositoryImpl {
+	return &UserIdentityRepositoryImpl{
+		db: db,
+	}
+}
+
+// Insert is a method for inserting user identity data in the user_identities table.
+// An account of a provider can be linked to a single user, so linking it again is a conflict.
+func (u *UserIdentityRepositoryImpl) Insert(ctx context.Context, userIdentity *entity.UserIdentity) error {
+	err := database.Conn(ctx, u.db).Create(userIdentity).Error
+
+	return translateError(err, "User identity")
+}
+
+// FindByProviderSubject is a method for finding user identity data by the provider and the subject of the account at the provider.
+func (u *UserIdentityRepositoryImpl) FindByProviderSubject(ctx context.Context, provider, subject string) (*entity.UserIdentity, error) {
+	userIdentity := &entity.UserIdentity{}
+	err := database.Conn(ctx, u.db).
+		Where("provider = ? AND subject = ?", provider, subject).
+		First(userIdentity).Error
+
+	return userIdentity, translateError(err, "User identity")
+}
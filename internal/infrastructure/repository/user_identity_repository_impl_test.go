@@ -0,0 +1,88 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var userIdentityColumns = []string{"id", "user_id", "provider", "subject", "email", "created_at"}
+
+func TestUserIdentityRepositoryImpl_Insert_Failed_Conflict(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	userIdentity := entity.NewUserIdentity(1, "google", "subject", "user@email.com", currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"user_identities\" (.+) VALUES (.+)").WillReturnError(gorm.ErrDuplicatedKey)
+	mock.ExpectRollback()
+
+	repo := repository.NewUserIdentityRepository(gormDB)
+	err := repo.Insert(context.TODO(), userIdentity)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserIdentityRepositoryImpl_Insert_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	userIdentity := entity.NewUserIdentity(1, "google", "subject", "user@email.com", currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"user_identities\" (.+) VALUES (.+)").
+		WithArgs(1, "google", "subject", "user@email.com", currentTime).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserIdentityRepository(gormDB)
+	err := repo.Insert(context.TODO(), userIdentity)
+	require.NoError(t, err)
+	assert.Equal(t, 1, userIdentity.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserIdentityRepositoryImpl_FindByProviderSubject_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"user_identities\" WHERE provider = (.+) AND subject = (.+)").
+		WithArgs("google", "subject", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	repo := repository.NewUserIdentityRepository(gormDB)
+	_, err := repo.FindByProviderSubject(context.TODO(), "google", "subject")
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserIdentityRepositoryImpl_FindByProviderSubject_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"user_identities\" WHERE provider = (.+) AND subject = (.+)").
+		WithArgs("google", "subject", 1).
+		WillReturnRows(sqlmock.NewRows(userIdentityColumns).AddRow(1, 2, "google", "subject", "user@email.com", currentTime))
+
+	repo := repository.NewUserIdentityRepository(gormDB)
+	userIdentity, err := repo.FindByProviderSubject(context.TODO(), "google", "subject")
+	require.NoError(t, err)
+	assert.Equal(t, 2, userIdentity.UserID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
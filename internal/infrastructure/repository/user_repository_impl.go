@@ -2,12 +2,22 @@ package repository
 
 import (
 	"context"
+	"time"
 
+	"dealls-technical-test-dating-service/internal/domain/apperror"
 	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
 
 	"gorm.io/gorm"
 )
 
+const (
+	updateProfilePictureKeyQuery = `UPDATE users SET profile_picture_key = ?, updated_at = ?
+FROM (SELECT id, profile_picture_key FROM users WHERE id = ? FOR UPDATE) AS previous
+WHERE users.id = previous.id
+RETURNING COALESCE(previous.profile_picture_key, '')`
+)
+
 // UserRepositoryImpl is a struct used to implement the user repository interface defined in the domain.
 type UserRepositoryImpl struct {
 	db *gorm.DB
@@ -21,13 +31,18 @@ func NewUserRepository(db *gorm.DB) *UserRepositoryImpl {
 }
 
 // Insert is a method for inserting user data in the users table.
+// The user is looked up by the email address or the phone number it signs up with, the empty one being left out of the lookup.
 func (u *UserRepositoryImpl) Insert(ctx context.Context, user *entity.User) (int, error) {
-	result := u.db.WithContext(ctx).Where(entity.User{Email: user.Email}).FirstOrCreate(user)
+	result := database.Conn(ctx, u.db).Where(entity.User{Email: user.Email, Phone: user.Phone}).FirstOrCreate(user)
 	if result.Error != nil {
-		return 0, result.Error
+		return 0, translateError(result.Error, "User")
 	}
 	if result.RowsAffected == 0 {
-		return 0, gorm.ErrDuplicatedKey
+		if user.Email == "" {
+			return 0, apperror.Wrap(apperror.KindConflict, "Phone number already exists", gorm.ErrDuplicatedKey)
+		}
+
+		return 0, apperror.Wrap(apperror.KindConflict, "Email already exists", gorm.ErrDuplicatedKey)
 	}
 
 	return user.ID, nil
@@ -36,7 +51,100 @@ func (u *UserRepositoryImpl) Insert(ctx context.Context, user *entity.User) (int
 // FindByEmail is a method for finding user data based on email.
 func (u *UserRepositoryImpl) FindByEmail(ctx context.Context, email string) (*entity.User, error) {
 	user := &entity.User{}
-	err := u.db.WithContext(ctx).First(user, "email = ?", email).Error
+	err := database.Conn(ctx, u.db).First(user, "email = ?", email).Error
+
+	return user, translateError(err, "User")
+}
+
+// FindByPhone is a method for finding user data based on phone number.
+func (u *UserRepositoryImpl) FindByPhone(ctx context.Context, phone string) (*entity.User, error) {
+	user := &entity.User{}
+	err := database.Conn(ctx, u.db).First(user, "phone = ?", phone).Error
+
+	return user, translateError(err, "User")
+}
+
+// FindByID is a method for finding user data based on ID.
+func (u *UserRepositoryImpl) FindByID(ctx context.Context, id int) (*entity.User, error) {
+	user := &entity.User{}
+	err := database.Conn(ctx, u.db).First(user, "id = ?", id).Error
+
+	return user, translateError(err, "User")
+}
+
+// UpdateEmailVerifiedAt is a method for updating the time a user verified the email address.
+func (u *UserRepositoryImpl) UpdateEmailVerifiedAt(ctx context.Context, id int, verifiedAt time.Time) error {
+	err := database.Conn(ctx, u.db).
+		Model(&entity.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"email_verified_at": verifiedAt, "updated_at": verifiedAt}).Error
+
+	return translateError(err, "User")
+}
+
+// UpdatePassword is a method for updating the hashed password of a user.
+func (u *UserRepositoryImpl) UpdatePassword(ctx context.Context, id int, password string, updatedAt time.Time) error {
+	err := database.Conn(ctx, u.db).
+		Model(&entity.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"password": password, "updated_at": updatedAt}).Error
+
+	return translateError(err, "User")
+}
+
+// UpdateEmail is a method for replacing the email address of a user with one that is verified at the given time.
+func (u *UserRepositoryImpl) UpdateEmail(ctx context.Context, id int, email string, verifiedAt time.Time) error {
+	err := database.Conn(ctx, u.db).
+		Model(&entity.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"email": email, "email_verified_at": verifiedAt, "updated_at": verifiedAt}).Error
+
+	return translateError(err, "User")
+}
+
+// UpdateRole is a method for updating the role of a user.
+func (u *UserRepositoryImpl) UpdateRole(ctx context.Context, id int, role string, updatedAt time.Time) error {
+	result := database.Conn(ctx, u.db).
+		Model(&entity.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"role": role, "updated_at": updatedAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.New(apperror.KindNotFound, "User not found")
+	}
+
+	return nil
+}
+
+// UpdateProfile is a method for updating the attributes of a user shown on the profile of the user.
+func (u *UserRepositoryImpl) UpdateProfile(ctx context.Context, id int, name, location string, updatedAt time.Time) error {
+	result := database.Conn(ctx, u.db).
+		Model(&entity.User{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{"name": name, "location": location, "updated_at": updatedAt})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.New(apperror.KindNotFound, "User not found")
+	}
+
+	return nil
+}
+
+// UpdateProfilePictureKey is a method for updating the key the profile picture of a user is stored under, returning the key it replaced.
+// The row is locked while it is read, so that concurrent updates each return the key the other one stored rather than the same one.
+func (u *UserRepositoryImpl) UpdateProfilePictureKey(ctx context.Context, id int, key string, updatedAt time.Time) (string, error) {
+	var previousKey string
+	result := database.Conn(ctx, u.db).Raw(updateProfilePictureKeyQuery, key, updatedAt, id).Scan(&previousKey)
+	if result.Error != nil {
+		return "", result.Error
+	}
+	if result.RowsAffected == 0 {
+		return "", apperror.New(apperror.KindNotFound, "User not found")
+	}
 
-	return user, err
+	return previousKey, nil
 }
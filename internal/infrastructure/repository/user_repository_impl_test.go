@@ -6,11 +6,13 @@ import (
 	"testing"
 	"time"
 
+	"dealls-technical-test-dating-service/internal/domain/apperror"
 	"dealls-technical-test-dating-service/internal/domain/entity"
 	"dealls-technical-test-dating-service/internal/infrastructure/database"
 	"dealls-technical-test-dating-service/internal/infrastructure/repository"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"gorm.io/gorm"
@@ -21,7 +23,7 @@ var (
 	currentTime     = time.Now()
 	birthDateFormat = "2006-01-02"
 	birthDate       = currentTime.Format(birthDateFormat)
-	userColumns     = []string{"id", "email", "password", "name", "birth_date", "gender", "location", "profile_picture_url", "created_at", "updated_at"}
+	userColumns     = []string{"id", "email", "password", "name", "birth_date", "gender", "location", "profile_picture_key", "created_at", "updated_at"}
 )
 
 func TestUserRepositoryImpl_Insert_Failed_Find_First_Error(t *testing.T) {
@@ -59,7 +61,29 @@ func TestUserRepositoryImpl_Insert_Failed_Email_Already_Exists(t *testing.T) {
 	id, err := repo.Insert(context.TODO(), user)
 	require.Error(t, err)
 	assert.Equal(t, 0, id)
-	assert.Equal(t, gorm.ErrDuplicatedKey, err)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_Insert_Failed_Email_Taken_Concurrently(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	// Another signup with the email address inserts its user between the lookup and the insert, which the unique constraint rejects.
+	user := entity.NewUser("user@email.com", "password", "User", birthDate, "MALE", "Indonesia", "", currentTime, currentTime)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."email" = $1 ORDER BY "users"."id" LIMIT $2`)).
+		WithArgs(user.Email, 1).
+		WillReturnRows(sqlmock.NewRows(userColumns))
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"users\" (.+) VALUES (.+)").WillReturnError(&pgconn.PgError{Code: "23505"})
+	mock.ExpectRollback()
+
+	repo := repository.NewUserRepository(gormDB)
+	id, err := repo.Insert(context.TODO(), user)
+	assert.Equal(t, 0, id)
+	require.ErrorIs(t, err, apperror.ErrConflict)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -86,6 +110,49 @@ func TestUserRepositoryImpl_Insert_Success(t *testing.T) {
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
+func TestUserRepositoryImpl_Insert_Failed_Phone_Already_Exists(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	user := entity.NewPhoneUser("+6281234567890", "User", birthDate, "MALE", "Indonesia", currentTime)
+	birthDate, _ := time.Parse(birthDateFormat, birthDate)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."phone" = $1 ORDER BY "users"."id" LIMIT $2`)).
+		WithArgs(user.Phone, 1).
+		WillReturnRows(sqlmock.NewRows(userColumns).AddRow(1, nil, "", "User", birthDate, "MALE", "Indonesia", "", currentTime, currentTime))
+
+	repo := repository.NewUserRepository(gormDB)
+	_, err := repo.Insert(context.TODO(), user)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.Equal(t, "Phone number already exists", err.Error())
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_Insert_Phone_User_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	user := entity.NewPhoneUser("+6281234567890", "User", birthDate, "MALE", "Indonesia", currentTime)
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "users" WHERE "users"."phone" = $1 ORDER BY "users"."id" LIMIT $2`)).
+		WithArgs(user.Phone, 1).
+		WillReturnRows(sqlmock.NewRows(userColumns))
+
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"users\" \\(\"password\",(.+),\"updated_at\",\"phone\"\\) VALUES (.+) RETURNING \"email\",\"phone\",\"id\"").
+		WillReturnRows(sqlmock.NewRows([]string{"email", "phone", "id"}).AddRow(nil, "+6281234567890", "1"))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserRepository(gormDB)
+	id, err := repo.Insert(context.TODO(), user)
+	require.NoError(t, err)
+	assert.Equal(t, 1, id)
+	assert.Empty(t, user.Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
 func TestUserRepositoryImpl_FindByEmail_Failed_Not_Found(t *testing.T) {
 	t.Parallel()
 
@@ -98,7 +165,7 @@ func TestUserRepositoryImpl_FindByEmail_Failed_Not_Found(t *testing.T) {
 
 	repo := repository.NewUserRepository(gormDB)
 	_, err := repo.FindByEmail(context.TODO(), "user@email.com")
-	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
 
@@ -119,3 +186,233 @@ func TestUserRepositoryImpl_FindByEmail_Success(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NoError(t, mock.ExpectationsWereMet())
 }
+
+func TestUserRepositoryImpl_FindByPhone_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	birthDate, _ := time.Parse(birthDateFormat, birthDate)
+	columns := append(append([]string{}, userColumns...), "phone")
+	mock.ExpectQuery("SELECT (.+) FROM \"users\" WHERE phone = (.+)").
+		WithArgs("+6281234567890", 1).
+		WillReturnRows(sqlmock.NewRows(columns).AddRow(1, nil, "", "User", birthDate, "MALE", "Indonesia", "", currentTime, currentTime, "+6281234567890"))
+
+	repo := repository.NewUserRepository(gormDB)
+	user, err := repo.FindByPhone(context.TODO(), "+6281234567890")
+	require.NoError(t, err)
+	assert.Equal(t, "+6281234567890", user.Phone)
+	assert.Empty(t, user.Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_FindByID_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"users\" WHERE id = (.+)").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows(userColumns))
+
+	repo := repository.NewUserRepository(gormDB)
+	_, err := repo.FindByID(context.TODO(), 1)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_FindByID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	birthDate, _ := time.Parse(birthDateFormat, birthDate)
+	mock.ExpectQuery("SELECT (.+) FROM \"users\" WHERE id = (.+)").
+		WithArgs(1, 1).
+		WillReturnRows(sqlmock.NewRows(userColumns).AddRow(1, "user@email.com", "password", "User", birthDate, "MALE", "Indonesia", "", currentTime, currentTime))
+
+	repo := repository.NewUserRepository(gormDB)
+	user, err := repo.FindByID(context.TODO(), 1)
+	require.NoError(t, err)
+	assert.Equal(t, "user@email.com", user.Email)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_UpdateEmailVerifiedAt_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"users\" SET \"email_verified_at\"=(.+),\"updated_at\"=(.+) WHERE id = (.+)").
+		WithArgs(currentTime, currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserRepository(gormDB)
+	err := repo.UpdateEmailVerifiedAt(context.TODO(), 1, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_UpdatePassword_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"users\" SET \"password\"=(.+),\"updated_at\"=(.+) WHERE id = (.+)").
+		WithArgs("hashed-password", currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserRepository(gormDB)
+	err := repo.UpdatePassword(context.TODO(), 1, "hashed-password", currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_UpdateEmail_Failed_Duplicated(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"users\" SET (.+) WHERE id = (.+)").
+		WillReturnError(gorm.ErrDuplicatedKey)
+	mock.ExpectRollback()
+
+	repo := repository.NewUserRepository(gormDB)
+	err := repo.UpdateEmail(context.TODO(), 1, "new@email.com", currentTime)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_UpdateEmail_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"users\" SET \"email\"=(.+),\"email_verified_at\"=(.+),\"updated_at\"=(.+) WHERE id = (.+)").
+		WithArgs("new@email.com", currentTime, currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserRepository(gormDB)
+	err := repo.UpdateEmail(context.TODO(), 1, "new@email.com", currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_UpdateRole_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"users\" SET \"role\"=(.+),\"updated_at\"=(.+) WHERE id = (.+)").
+		WithArgs("admin", currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserRepository(gormDB)
+	err := repo.UpdateRole(context.TODO(), 1, "admin", currentTime)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_UpdateRole_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"users\" SET \"role\"=(.+),\"updated_at\"=(.+) WHERE id = (.+)").
+		WithArgs("admin", currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserRepository(gormDB)
+	err := repo.UpdateRole(context.TODO(), 1, "admin", currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_UpdateProfile_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"users\" SET \"location\"=(.+),\"name\"=(.+),\"updated_at\"=(.+) WHERE id = (.+)").
+		WithArgs("Indonesia", "User", currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserRepository(gormDB)
+	err := repo.UpdateProfile(context.TODO(), 1, "User", "Indonesia", currentTime)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_UpdateProfile_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"users\" SET \"location\"=(.+),\"name\"=(.+),\"updated_at\"=(.+) WHERE id = (.+)").
+		WithArgs("Indonesia", "User", currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserRepository(gormDB)
+	err := repo.UpdateProfile(context.TODO(), 1, "User", "Indonesia", currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_UpdateProfilePictureKey_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("UPDATE users SET profile_picture_key = (.+) FROM \\(SELECT (.+) FOR UPDATE\\) AS previous (.+) RETURNING").
+		WithArgs("photos/1/key", currentTime, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"profile_picture_key"}))
+
+	repo := repository.NewUserRepository(gormDB)
+	_, err := repo.UpdateProfilePictureKey(context.TODO(), 1, "photos/1/key", currentTime)
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserRepositoryImpl_UpdateProfilePictureKey_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("UPDATE users SET profile_picture_key = (.+) FROM \\(SELECT (.+) FOR UPDATE\\) AS previous (.+) RETURNING").
+		WithArgs("photos/1/key", currentTime, 1).
+		WillReturnRows(sqlmock.NewRows([]string{"profile_picture_key"}).AddRow("photos/1/old"))
+
+	repo := repository.NewUserRepository(gormDB)
+	previousKey, err := repo.UpdateProfilePictureKey(context.TODO(), 1, "photos/1/key", currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, "photos/1/old", previousKey)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
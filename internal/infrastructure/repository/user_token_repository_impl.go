@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+
+	"gorm.io/gorm"
+)
+
+// UserTokenRepositoryImpl is a struct used to implement the user token repository interface defined in the domain.
+type UserTokenRepositoryImpl struct {
+	db *gorm.DB
+}
+
+// NewUserTokenRepository is a function used to initialize the user token repository implementation.
+func NewUserTokenRepository(db *gorm.DB) *UserTokenRepositoryImpl {
+	return &UserTokenRepositoryImpl{
+		db: db,
+	}
+}
+
+// Insert is a method for inserting user token data in the user_tokens table.
+func (u *UserTokenRepositoryImpl) Insert(ctx context.Context, userToken *entity.UserToken) error {
+	err := database.Conn(ctx, u.db).Create(userToken).Error
+
+	return translateError(err, "User token")
+}
+
+// FindByTokenHash is a method for finding user token data of a purpose based on the token hash.
+func (u *UserTokenRepositoryImpl) FindByTokenHash(ctx context.Context, purpose, tokenHash string) (*entity.UserToken, error) {
+	userToken := &entity.UserToken{}
+	err := database.Conn(ctx, u.db).First(userToken, "purpose = ? AND token_hash = ?", purpose, tokenHash).Error
+
+	return userToken, translateError(err, "User token")
+}
+
+// FindLatest is a method for finding the user token data of a purpose that was most recently issued to a user.
+func (u *UserTokenRepositoryImpl) FindLatest(ctx context.Context, userID int, purpose string) (*entity.UserToken, error) {
+	userToken := &entity.UserToken{}
+	err := database.Conn(ctx, u.db).
+		Where("user_id = ? AND purpose = ?", userID, purpose).
+		Order("created_at DESC").
+		First(userToken).Error
+
+	return userToken, translateError(err, "User token")
+}
+
+// MarkUsed is a method for marking a user token as used.
+// Only a token that is not used yet is marked, so that a token cannot be used twice by concurrent requests.
+func (u *UserTokenRepositoryImpl) MarkUsed(ctx context.Context, id int, usedAt time.Time) error {
+	result := database.Conn(ctx, u.db).
+		Model(&entity.UserToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return apperror.New(apperror.KindConflict, "User token already used")
+	}
+
+	return nil
+}
+
+// MarkUsedByUserID is a method for marking every user token of a purpose issued to a user that is not used yet as used.
+func (u *UserTokenRepositoryImpl) MarkUsedByUserID(ctx context.Context, userID int, purpose string, usedAt time.Time) error {
+	return database.Conn(ctx, u.db).
+		Model(&entity.UserToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", usedAt).Error
+}
+
+// LockIPAddress is a method for serializing the user tokens of a purpose issued to an IP address until the end of the transaction,
+// so that concurrent requests see each other's tokens when counting them.
+func (u *UserTokenRepositoryImpl) LockIPAddress(ctx context.Context, purpose, ipAddress string) error {
+	return database.Conn(ctx, u.db).Exec("SELECT pg_advisory_xact_lock(hashtext(?))", "user_tokens:"+purpose+":ip:"+ipAddress).Error
+}
+
+// CountUsableByIPAddress is a method for counting the user tokens of a purpose issued to an IP address that can still be used at the given time.
+func (u *UserTokenRepositoryImpl) CountUsableByIPAddress(ctx context.Context, purpose, ipAddress string, now time.Time) (int, error) {
+	var count int64
+	err := database.Conn(ctx, u.db).
+		Model(&entity.UserToken{}).
+		Where("purpose = ? AND ip_address = ? AND used_at IS NULL AND expires_at > ?", purpose, ipAddress, now).
+		Count(&count).Error
+
+	return int(count), err
+}
+
+// DeleteUnusable is a method for deleting the user tokens of a purpose that are used or expired at the given time.
+func (u *UserTokenRepositoryImpl) DeleteUnusable(ctx context.Context, purpose string, now time.Time) error {
+	return database.Conn(ctx, u.db).
+		Where("purpose = ? AND (used_at IS NOT NULL OR expires_at <= ?)", purpose, now).
+		Delete(&entity.UserToken{}).Error
+}
@@ -0,0 +1,213 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+var userTokenColumns = []string{"id", "user_id", "purpose", "token_hash", "email", "expires_at", "created_at", "used_at"}
+
+func TestUserTokenRepositoryImpl_Insert_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	userToken := entity.NewUserToken(1, entity.UserTokenPurposeEmailVerification, "hash", "user@email.com", currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"user_tokens\" (.+) VALUES (.+)").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserTokenRepository(gormDB)
+	err := repo.Insert(context.TODO(), userToken)
+	require.NoError(t, err)
+	assert.Equal(t, 1, userToken.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryImpl_Insert_Success_Without_User(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	userToken := entity.NewUserToken(0, entity.UserTokenPurposeOIDCNonce, "hash", "", currentTime, currentTime)
+	mock.ExpectBegin()
+	mock.ExpectQuery("INSERT INTO \"user_tokens\" \\(\"purpose\",(.+)\\) VALUES (.+) RETURNING \"user_id\",\"ip_address\",\"id\"").
+		WillReturnRows(sqlmock.NewRows([]string{"user_id", "ip_address", "id"}).AddRow(nil, nil, "1"))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserTokenRepository(gormDB)
+	err := repo.Insert(context.TODO(), userToken)
+	require.NoError(t, err)
+	assert.Equal(t, 1, userToken.ID)
+	assert.Zero(t, userToken.UserID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryImpl_FindByTokenHash_Failed_Not_Found(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"user_tokens\" WHERE purpose = (.+) AND token_hash = (.+)").
+		WithArgs(entity.UserTokenPurposeEmailVerification, "hash", 1).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	repo := repository.NewUserTokenRepository(gormDB)
+	_, err := repo.FindByTokenHash(context.TODO(), entity.UserTokenPurposeEmailVerification, "hash")
+	require.ErrorIs(t, err, apperror.ErrNotFound)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryImpl_FindByTokenHash_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"user_tokens\" WHERE purpose = (.+) AND token_hash = (.+)").
+		WithArgs(entity.UserTokenPurposeEmailVerification, "hash", 1).
+		WillReturnRows(sqlmock.NewRows(userTokenColumns).AddRow(1, 2, entity.UserTokenPurposeEmailVerification, "hash", "user@email.com", currentTime, currentTime, nil))
+
+	repo := repository.NewUserTokenRepository(gormDB)
+	userToken, err := repo.FindByTokenHash(context.TODO(), entity.UserTokenPurposeEmailVerification, "hash")
+	require.NoError(t, err)
+	assert.Equal(t, 2, userToken.UserID)
+	assert.Equal(t, "user@email.com", userToken.Email)
+	assert.Nil(t, userToken.UsedAt)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryImpl_FindLatest_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT (.+) FROM \"user_tokens\" WHERE user_id = (.+) AND purpose = (.+) ORDER BY created_at DESC(.+)").
+		WithArgs(2, entity.UserTokenPurposeEmailVerification, 1).
+		WillReturnRows(sqlmock.NewRows(userTokenColumns).AddRow(1, 2, entity.UserTokenPurposeEmailVerification, "hash", "user@email.com", currentTime, currentTime, nil))
+
+	repo := repository.NewUserTokenRepository(gormDB)
+	userToken, err := repo.FindLatest(context.TODO(), 2, entity.UserTokenPurposeEmailVerification)
+	require.NoError(t, err)
+	assert.Equal(t, 1, userToken.ID)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryImpl_MarkUsed_Failed_Already_Used(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"user_tokens\" SET \"used_at\"=(.+) WHERE (.+) AND used_at IS NULL").
+		WithArgs(currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserTokenRepository(gormDB)
+	err := repo.MarkUsed(context.TODO(), 1, currentTime)
+	require.ErrorIs(t, err, apperror.ErrConflict)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryImpl_MarkUsed_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"user_tokens\" SET \"used_at\"=(.+) WHERE (.+) AND used_at IS NULL").
+		WithArgs(currentTime, 1).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserTokenRepository(gormDB)
+	err := repo.MarkUsed(context.TODO(), 1, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryImpl_MarkUsedByUserID_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("UPDATE \"user_tokens\" SET \"used_at\"=(.+) WHERE user_id = (.+) AND purpose = (.+) AND used_at IS NULL").
+		WithArgs(currentTime, 1, entity.UserTokenPurposePasswordReset).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserTokenRepository(gormDB)
+	err := repo.MarkUsedByUserID(context.TODO(), 1, entity.UserTokenPurposePasswordReset, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryImpl_LockIPAddress_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectExec("SELECT pg_advisory_xact_lock\\(hashtext\\((.+)\\)\\)").
+		WithArgs("user_tokens:" + entity.UserTokenPurposeOIDCNonce + ":ip:203.0.113.1").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	repo := repository.NewUserTokenRepository(gormDB)
+	err := repo.LockIPAddress(context.TODO(), entity.UserTokenPurposeOIDCNonce, "203.0.113.1")
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryImpl_CountUsableByIPAddress_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT count\\(\\*\\) FROM \"user_tokens\" WHERE purpose = (.+) AND ip_address = (.+) AND used_at IS NULL AND expires_at > (.+)").
+		WithArgs(entity.UserTokenPurposeOIDCNonce, "203.0.113.1", currentTime).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(4))
+
+	repo := repository.NewUserTokenRepository(gormDB)
+	count, err := repo.CountUsableByIPAddress(context.TODO(), entity.UserTokenPurposeOIDCNonce, "203.0.113.1", currentTime)
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserTokenRepositoryImpl_DeleteUnusable_Success(t *testing.T) {
+	t.Parallel()
+
+	db, gormDB, mock := database.DBMock(t)
+	defer db.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectExec("DELETE FROM \"user_tokens\" WHERE purpose = (.+) AND \\(used_at IS NOT NULL OR expires_at <= (.+)\\)").
+		WithArgs(entity.UserTokenPurposeOIDCNonce, currentTime).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	mock.ExpectCommit()
+
+	repo := repository.NewUserTokenRepository(gormDB)
+	err := repo.DeleteUnusable(context.TODO(), entity.UserTokenPurposeOIDCNonce, currentTime)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
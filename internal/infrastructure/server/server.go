@@ -5,6 +5,8 @@ import (
 	"net"
 	"net/http"
 
+	"dealls-technical-test-dating-service/pkg/constant"
+
 	"github.com/emicklei/go-restful/v3"
 	"github.com/sirupsen/logrus"
 )
@@ -33,7 +35,7 @@ func NewServer(port string) *Server {
 	server.Container.Filter(server.Container.OPTIONSFilter)
 
 	cors := restful.CrossOriginResourceSharing{
-		ExposeHeaders:  []string{},
+		ExposeHeaders:  []string{constant.RequestIDHeader},
 		AllowedMethods: []string{http.MethodPost, http.MethodGet, http.MethodPut, http.MethodPatch, http.MethodDelete},
 		AllowedHeaders: []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Methods", "Authorization", "Content-Type", "Accept"},
 		CookiesAllowed: true,
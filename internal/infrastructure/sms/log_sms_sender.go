@@ -0,0 +1,42 @@
+// Package sms contains implementations of the SMS sender interface defined in the domain package.
+package sms
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+// lineReplacer removes line breaks from the recipient and the body, so that every message takes a single line.
+var lineReplacer = strings.NewReplacer("\r", " ", "\n", " ")
+
+// LogSMSSender is a struct used to implement the SMS sender interface by writing text messages to a file or the log instead of sending them,
+// as a stand-in until an SMS gateway is set up.
+type LogSMSSender struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewLogSMSSender is a function used to initialize the log SMS sender that writes text messages to the writer.
+func NewLogSMSSender(w io.Writer) *LogSMSSender {
+	return &LogSMSSender{
+		w: w,
+	}
+}
+
+// Send is a method for writing a text message as a line with the time and the recipient.
+func (l *LogSMSSender) Send(_ context.Context, sms *entity.SMS) error {
+	line := fmt.Sprintf("%s To: %s Body: %s\n", time.Now().UTC().Format(time.RFC3339), lineReplacer.Replace(sms.To), lineReplacer.Replace(sms.Body))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	_, err := io.WriteString(l.w, line)
+
+	return err
+}
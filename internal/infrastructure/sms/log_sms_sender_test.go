@@ -0,0 +1,28 @@
+package sms_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/sms"
+)
+
+func TestLogSMSSender_Send(t *testing.T) {
+	var buffer bytes.Buffer
+	s := sms.NewLogSMSSender(&buffer)
+	err := s.Send(context.Background(), entity.NewSMS("+6281234567890", "Line 1\nLine 2"))
+	if err != nil {
+		t.Fatalf("LogSMSSender.Send() error = %v", err)
+	}
+
+	got := buffer.String()
+	if !strings.Contains(got, " To: +6281234567890 Body: Line 1 Line 2\n") {
+		t.Errorf("LogSMSSender.Send() wrote %q, want the recipient and the body on one line", got)
+	}
+	if strings.Count(got, "\n") != 1 {
+		t.Errorf("LogSMSSender.Send() wrote %q, want a single line", got)
+	}
+}
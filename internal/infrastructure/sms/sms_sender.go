@@ -0,0 +1,25 @@
+package sms
+
+import (
+	"os"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/infrastructure/config"
+
+	"github.com/sirupsen/logrus"
+)
+
+// NewSMSSender is a function used to initialize the SMS sender selected by the configuration.
+// Text messages are appended to SMS_LOG_FILE when it is set, and written to the log otherwise.
+func NewSMSSender(cfg *config.Config) (domain.SMSSender, error) {
+	if cfg.SMSLogFile == "" {
+		return NewLogSMSSender(logrus.StandardLogger().WriterLevel(logrus.InfoLevel)), nil
+	}
+
+	file, err := os.OpenFile(cfg.SMSLogFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewLogSMSSender(file), nil
+}
@@ -0,0 +1,161 @@
+// Package storage contains implementations of the blob store interface defined in the domain package.
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+
+	"github.com/sirupsen/logrus"
+)
+
+// keyPattern matches the keys files may be stored under, which are relative slash-separated paths of URL-safe characters.
+var keyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+(\.[A-Za-z0-9]+)?(/[A-Za-z0-9_-]+(\.[A-Za-z0-9]+)?)*$`)
+
+// LocalBlobStore is a struct used to implement the blob store interface by keeping files in a directory of the local filesystem.
+// The service serves the files itself, at the base URL, to whoever has a URL signed with the signing key.
+type LocalBlobStore struct {
+	dir        string
+	baseURL    string
+	signingKey []byte
+}
+
+// NewLocalBlobStore is a function used to initialize the local blob store.
+// Without a signing key, an ephemeral key is generated when it is allowed so that the service can run locally,
+// but its URLs do not survive a restart.
+func NewLocalBlobStore(dir, baseURL, signingKey string, allowEphemeralKey bool) (*LocalBlobStore, error) {
+	key := []byte(signingKey)
+	if signingKey == "" {
+		if !allowEphemeralKey {
+			return nil, errors.New("no blob URL signing key is configured, set BLOB_URL_SIGNING_KEY or BLOB_ALLOW_EPHEMERAL_KEY=true")
+		}
+
+		logrus.Warn("No blob URL signing key is configured, signing URLs with an ephemeral key")
+
+		key = make([]byte, sha256.Size)
+		_, err := rand.Read(key)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &LocalBlobStore{
+		dir:        dir,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		signingKey: key,
+	}, nil
+}
+
+// Put is a method for writing a file, which replaces the file stored under the key at once so that it is never served half written.
+func (l *LocalBlobStore) Put(_ context.Context, key, _ string, data []byte) error {
+	filePath, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(filePath), 0o750)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(filePath), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err != nil {
+		file.Close()
+
+		return err
+	}
+
+	err = file.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(file.Name(), filePath)
+}
+
+// Get is a method for reading a file.
+func (l *LocalBlobStore) Get(_ context.Context, key string) ([]byte, error) {
+	filePath, err := l.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, apperror.Wrap(apperror.KindNotFound, "File not found", err)
+	}
+
+	return data, err
+}
+
+// Delete is a method for deleting a file, which succeeds when there is no file under the key.
+func (l *LocalBlobStore) Delete(_ context.Context, key string) error {
+	filePath, err := l.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(filePath)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+
+	return err
+}
+
+// SignedURL is a method for getting the URL the service serves a file at until the expiry time.
+func (l *LocalBlobStore) SignedURL(_ context.Context, key string, expiresAt time.Time) (string, error) {
+	if !keyPattern.MatchString(key) {
+		return "", fmt.Errorf("invalid blob key: %q", key)
+	}
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expiresAt.Unix(), 10))
+	query.Set("signature", l.signature(key, expiresAt))
+
+	return l.baseURL + "/" + key + "?" + query.Encode(), nil
+}
+
+// VerifySignedURL is a method for checking that the signature of the URL of a file was made with the signing key for the key and the expiry time.
+// The expiry time itself is checked by the caller.
+func (l *LocalBlobStore) VerifySignedURL(key string, expiresAt time.Time, signature string) bool {
+	return hmac.Equal([]byte(signature), []byte(l.signature(key, expiresAt)))
+}
+
+// signature is a method for signing the key and the expiry time of the URL of a file.
+func (l *LocalBlobStore) signature(key string, expiresAt time.Time) string {
+	mac := hmac.New(sha256.New, l.signingKey)
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expiresAt.Unix(), 10)))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// path is a method for getting the path of the file stored under the key, which cannot leave the directory of the store.
+func (l *LocalBlobStore) path(key string) (string, error) {
+	if !keyPattern.MatchString(key) || path.Clean(key) != key {
+		return "", apperror.New(apperror.KindNotFound, "File not found")
+	}
+
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
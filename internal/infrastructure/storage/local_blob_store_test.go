@@ -0,0 +1,105 @@
+package storage_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/infrastructure/storage"
+)
+
+func TestLocalBlobStore_Put_Get_Delete(t *testing.T) {
+	store, err := storage.NewLocalBlobStore(t.TempDir(), "http://localhost/blobs", "secret", false)
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore() error = %v", err)
+	}
+
+	ctx := context.Background()
+	key := "photos/1/abc/large.jpg"
+	if err := store.Put(ctx, key, "image/jpeg", []byte("photo")); err != nil {
+		t.Fatalf("LocalBlobStore.Put() error = %v", err)
+	}
+
+	data, err := store.Get(ctx, key)
+	if err != nil || string(data) != "photo" {
+		t.Fatalf("LocalBlobStore.Get() = %q, %v, want the stored file", data, err)
+	}
+
+	if err := store.Delete(ctx, key); err != nil {
+		t.Fatalf("LocalBlobStore.Delete() error = %v", err)
+	}
+	if err := store.Delete(ctx, key); err != nil {
+		t.Errorf("LocalBlobStore.Delete() error = %v for a missing file, want nil", err)
+	}
+
+	if _, err := store.Get(ctx, key); !errors.Is(err, apperror.ErrNotFound) {
+		t.Errorf("LocalBlobStore.Get() error = %v after delete, want not found", err)
+	}
+}
+
+func TestLocalBlobStore_Put_Failed_Invalid_Key(t *testing.T) {
+	store, err := storage.NewLocalBlobStore(t.TempDir(), "http://localhost/blobs", "secret", false)
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore() error = %v", err)
+	}
+
+	for _, key := range []string{"../outside.jpg", "/absolute.jpg", "photos/../x.jpg", "photos//x.jpg", ""} {
+		if err := store.Put(context.Background(), key, "image/jpeg", []byte("photo")); err == nil {
+			t.Errorf("LocalBlobStore.Put(%q) error = nil, want an error", key)
+		}
+	}
+}
+
+func TestLocalBlobStore_SignedURL(t *testing.T) {
+	store, err := storage.NewLocalBlobStore(t.TempDir(), "http://localhost/blobs/", "secret", false)
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore() error = %v", err)
+	}
+
+	key := "photos/1/abc/small.jpg"
+	expiresAt := time.Now().Add(time.Hour)
+	signedURL, err := store.SignedURL(context.Background(), key, expiresAt)
+	if err != nil {
+		t.Fatalf("LocalBlobStore.SignedURL() error = %v", err)
+	}
+	if !strings.HasPrefix(signedURL, "http://localhost/blobs/"+key+"?") {
+		t.Fatalf("LocalBlobStore.SignedURL() = %q", signedURL)
+	}
+
+	parsed, err := url.Parse(signedURL)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+
+	expires, _ := strconv.ParseInt(parsed.Query().Get("expires"), 10, 64)
+	signature := parsed.Query().Get("signature")
+	if !store.VerifySignedURL(key, time.Unix(expires, 0), signature) {
+		t.Errorf("LocalBlobStore.VerifySignedURL() = false for the signed URL")
+	}
+	if store.VerifySignedURL("photos/1/abc/large.jpg", time.Unix(expires, 0), signature) {
+		t.Errorf("LocalBlobStore.VerifySignedURL() = true for another key")
+	}
+	if store.VerifySignedURL(key, time.Unix(expires+3600, 0), signature) {
+		t.Errorf("LocalBlobStore.VerifySignedURL() = true for a later expiry time")
+	}
+
+	other, err := storage.NewLocalBlobStore(t.TempDir(), "http://localhost/blobs", "", true)
+	if err != nil {
+		t.Fatalf("NewLocalBlobStore() error = %v", err)
+	}
+	if other.VerifySignedURL(key, time.Unix(expires, 0), signature) {
+		t.Errorf("LocalBlobStore.VerifySignedURL() = true with another signing key")
+	}
+}
+
+func TestNewLocalBlobStore_Failed_No_Signing_Key(t *testing.T) {
+	_, err := storage.NewLocalBlobStore(t.TempDir(), "http://localhost/blobs", "", false)
+	if err == nil {
+		t.Errorf("NewLocalBlobStore() error = nil, want an error without a signing key")
+	}
+}
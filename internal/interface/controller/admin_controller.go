@@ -0,0 +1,76 @@
+package controller
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/usecase"
+	"dealls-technical-test-dating-service/internal/interface/response"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// AdminController is a struct for handling HTTP requests and responses and mapping to use cases.
+type AdminController struct {
+	adminUsecase usecase.AdminUsecase
+}
+
+// NewAdminController is a function used to initialize the admin controller.
+func NewAdminController(au usecase.AdminUsecase) *AdminController {
+	return &AdminController{
+		adminUsecase: au,
+	}
+}
+
+// GetUser is a method for getting the attributes of any user.
+func (a *AdminController) GetUser(req *restful.Request, resp *restful.Response) {
+	id, err := readPathParameterInt(req, "id")
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	userResp, err := a.adminUsecase.GetUser(req.Request.Context(), id)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, userResp)
+}
+
+// ChangeUserRole is a method for changing the role of another user.
+func (a *AdminController) ChangeUserRole(req *restful.Request, resp *restful.Response) {
+	admin, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	id, err := readPathParameterInt(req, "id")
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	changeRoleReq := &entity.ChangeRoleRequest{}
+	err = readEntity(req, changeRoleReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	userResp, err := a.adminUsecase.ChangeUserRole(req.Request.Context(), admin, id, changeRoleReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, userResp)
+}
@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/usecase"
+	"dealls-technical-test-dating-service/internal/interface/response"
+	"dealls-technical-test-dating-service/pkg/constant"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// BlobController is a struct for handling HTTP requests and responses and mapping to use cases.
+type BlobController struct {
+	blobUsecase usecase.BlobUsecase
+}
+
+// NewBlobController is a function used to initialize the blob controller.
+func NewBlobController(bu usecase.BlobUsecase) *BlobController {
+	return &BlobController{
+		blobUsecase: bu,
+	}
+}
+
+// GetBlob is a method for downloading a stored file through a signed URL.
+// The file may be cached only by the client and only until the URL expires.
+func (b *BlobController) GetBlob(req *restful.Request, resp *restful.Response) {
+	expires, err := strconv.ParseInt(req.QueryParameter("expires"), 10, 64)
+	if err != nil {
+		response.WriteError(req, resp, apperror.Validation(constant.InvalidRequestBody, apperror.Field("expires", "expires must be an integer")))
+
+		return
+	}
+
+	blob, err := b.blobUsecase.GetBlob(req.Request.Context(), req.PathParameter("key"), expires, req.QueryParameter("signature"))
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.AddHeader("Content-Type", blob.ContentType)
+	resp.AddHeader("Cache-Control", fmt.Sprintf("private, max-age=%d", int(time.Until(blob.ExpiresAt).Seconds())))
+	resp.AddHeader("X-Content-Type-Options", "nosniff")
+	resp.WriteHeader(http.StatusOK)
+	_, _ = resp.Write(blob.Data)
+}
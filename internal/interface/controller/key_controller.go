@@ -0,0 +1,30 @@
+package controller
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/usecase"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// jwksCacheControl lets verifiers cache the key set for a while, which is short enough for a new key to be picked up before it starts signing.
+const jwksCacheControl = "public, max-age=300"
+
+// KeyController is a struct for handling HTTP requests and responses and mapping to use cases.
+type KeyController struct {
+	keyUsecase usecase.KeyUsecase
+}
+
+// NewKeyController is a function used to initialize the key controller.
+func NewKeyController(ku usecase.KeyUsecase) *KeyController {
+	return &KeyController{
+		keyUsecase: ku,
+	}
+}
+
+// GetJWKS is a method for getting the public keys that access tokens are verified with.
+func (k *KeyController) GetJWKS(req *restful.Request, resp *restful.Response) {
+	resp.AddHeader("Cache-Control", jwksCacheControl)
+	resp.WriteHeaderAndEntity(http.StatusOK, k.keyUsecase.GetJWKS(req.Request.Context()))
+}
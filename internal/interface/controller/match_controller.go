@@ -0,0 +1,74 @@
+package controller
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/usecase"
+	"dealls-technical-test-dating-service/internal/interface/response"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// MatchController is a struct for handling HTTP requests and responses and mapping to use cases.
+type MatchController struct {
+	matchUsecase usecase.MatchUsecase
+}
+
+// NewMatchController is a function used to initialize the match controller.
+func NewMatchController(mu usecase.MatchUsecase) *MatchController {
+	return &MatchController{
+		matchUsecase: mu,
+	}
+}
+
+// GetMatches is a method for getting the current matches of the user, newest first.
+func (m *MatchController) GetMatches(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	pageReq, err := readPageRequest(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	matchesResp, err := m.matchUsecase.GetMatches(req.Request.Context(), user, pageReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, matchesResp)
+}
+
+// Unmatch is a method for unmatching a current match of the user.
+func (m *MatchController) Unmatch(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	id, err := readPathParameterInt(req, "id")
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = m.matchUsecase.Unmatch(req.Request.Context(), user, id)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
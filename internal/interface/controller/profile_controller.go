@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/usecase"
+	"dealls-technical-test-dating-service/internal/interface/response"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// ProfileController is a struct for handling HTTP requests and responses and mapping to use cases.
+type ProfileController struct {
+	discoveryUsecase usecase.DiscoveryUsecase
+	profileUsecase   usecase.ProfileUsecase
+}
+
+// NewProfileController is a function used to initialize the profile controller.
+func NewProfileController(du usecase.DiscoveryUsecase, pu usecase.ProfileUsecase) *ProfileController {
+	return &ProfileController{
+		discoveryUsecase: du,
+		profileUsecase:   pu,
+	}
+}
+
+// Discover is a method for getting the profiles the user can swipe on.
+func (p *ProfileController) Discover(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	pageReq, err := readPageRequest(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	discoverResp, err := p.discoveryUsecase.Discover(req.Request.Context(), user, pageReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, discoverResp)
+}
+
+// GetMyProfile is a method for getting the profile of the current user.
+func (p *ProfileController) GetMyProfile(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	profileResp, err := p.profileUsecase.GetMyProfile(req.Request.Context(), user)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, profileResp)
+}
+
+// UpdateMyProfile is a method for updating the attributes in the request body on the profile of the current user.
+func (p *ProfileController) UpdateMyProfile(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	updateProfileReq := &entity.UpdateProfileRequest{}
+	err = readEntity(req, updateProfileReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	profileResp, err := p.profileUsecase.UpdateMyProfile(req.Request.Context(), user, updateProfileReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, profileResp)
+}
+
+// GetProfile is a method for getting the profile of another user.
+func (p *ProfileController) GetProfile(req *restful.Request, resp *restful.Response) {
+	id, err := readPathParameterInt(req, "id")
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	profileResp, err := p.profileUsecase.GetProfile(req.Request.Context(), id)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, profileResp)
+}
+
+// UploadPhoto is a method for replacing the profile picture of the current user with the photo in the multipart form.
+func (p *ProfileController) UploadPhoto(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	upload, err := readPhotoUpload(req, "photo")
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	profileResp, err := p.profileUsecase.UploadPhoto(req.Request.Context(), user, upload)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, profileResp)
+}
+
+// DeletePhoto is a method for removing the profile picture of the current user.
+func (p *ProfileController) DeletePhoto(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = p.profileUsecase.DeletePhoto(req.Request.Context(), user)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,120 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/pkg/constant"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// authenticatedUser is a function to get the user stored in the request context by the auth filter.
+func authenticatedUser(req *restful.Request) (*entity.User, error) {
+	user, ok := domain.UserFromContext(req.Request.Context())
+	if !ok {
+		return nil, apperror.New(apperror.KindUnauthorized, constant.InvalidToken)
+	}
+
+	return user, nil
+}
+
+// authenticatedTokenClaims is a function to get the access token claims stored in the request context by the auth filter.
+func authenticatedTokenClaims(req *restful.Request) (*entity.TokenClaims, error) {
+	claims, ok := domain.TokenClaimsFromContext(req.Request.Context())
+	if !ok {
+		return nil, apperror.New(apperror.KindUnauthorized, constant.InvalidToken)
+	}
+
+	return claims, nil
+}
+
+// readEntity is a function to read the request body into the entity.
+func readEntity(req *restful.Request, entity any) error {
+	err := req.ReadEntity(entity)
+	if err != nil {
+		return apperror.Validation(constant.InvalidRequestBody, apperror.Field("body", err.Error()))
+	}
+
+	return nil
+}
+
+// readOptionalEntity is a function to read the request body into the entity, leaving the entity untouched when there is no body.
+func readOptionalEntity(req *restful.Request, entity any) error {
+	if req.Request.ContentLength == 0 {
+		return nil
+	}
+
+	return readEntity(req, entity)
+}
+
+// readQueryParameterInt is a function to read an optional integer query parameter, defaulting to zero when it is absent.
+func readQueryParameterInt(req *restful.Request, name string) (int, error) {
+	value := req.QueryParameter(name)
+	if value == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, apperror.Validation(constant.InvalidRequestBody, apperror.Field(name, fmt.Sprintf("%s must be an integer", name)))
+	}
+
+	return parsed, nil
+}
+
+// readPathParameterInt is a function to read a positive integer path parameter.
+func readPathParameterInt(req *restful.Request, name string) (int, error) {
+	parsed, err := strconv.Atoi(req.PathParameter(name))
+	if err != nil || parsed <= 0 {
+		return 0, apperror.Validation(constant.InvalidRequestBody, apperror.Field(name, fmt.Sprintf("%s must be a positive integer", name)))
+	}
+
+	return parsed, nil
+}
+
+// readPageRequest is a function to read the cursor and limit query parameters.
+func readPageRequest(req *restful.Request) (*entity.PageRequest, error) {
+	cursor, err := readQueryParameterInt(req, "cursor")
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := readQueryParameterInt(req, "limit")
+	if err != nil {
+		return nil, err
+	}
+
+	return &entity.PageRequest{
+		Cursor: cursor,
+		Limit:  limit,
+	}, nil
+}
+
+// readPhotoUpload is a function to read the photo in the multipart form field of the request.
+// The photo is streamed from the request body, so it has to be read before the handler returns.
+func readPhotoUpload(req *restful.Request, name string) (*entity.PhotoUpload, error) {
+	reader, err := req.Request.MultipartReader()
+	if err != nil {
+		return nil, apperror.Validation(constant.InvalidRequestBody, apperror.Field(name, err.Error()))
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, apperror.Validation(constant.InvalidRequestBody, apperror.Field(name, fmt.Sprintf("%s is required", name)))
+		}
+		if err != nil {
+			return nil, apperror.Validation(constant.InvalidRequestBody, apperror.Field(name, err.Error()))
+		}
+
+		if part.FormName() == name {
+			return entity.NewPhotoUpload(part.Header.Get("Content-Type"), part), nil
+		}
+	}
+}
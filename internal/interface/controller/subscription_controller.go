@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/usecase"
+	"dealls-technical-test-dating-service/internal/interface/response"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// SubscriptionController is a struct for handling HTTP requests and responses and mapping to use cases.
+type SubscriptionController struct {
+	subscriptionUsecase usecase.SubscriptionUsecase
+}
+
+// NewSubscriptionController is a function used to initialize the subscription controller.
+func NewSubscriptionController(su usecase.SubscriptionUsecase) *SubscriptionController {
+	return &SubscriptionController{
+		subscriptionUsecase: su,
+	}
+}
+
+// GetPackages is a method for getting the subscription packages.
+func (s *SubscriptionController) GetPackages(req *restful.Request, resp *restful.Response) {
+	subscriptionPackages, err := s.subscriptionUsecase.GetSubscriptionPackages(req.Request.Context())
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, subscriptionPackages)
+}
+
+// Purchase is a method for purchasing a subscription package.
+func (s *SubscriptionController) Purchase(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	purchaseReq := &entity.PurchaseSubscriptionRequest{}
+	err = readEntity(req, purchaseReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	subscriptionResp, err := s.subscriptionUsecase.Purchase(req.Request.Context(), user, purchaseReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusCreated, subscriptionResp)
+}
+
+// GetCurrent is a method for getting the active subscription of the user.
+func (s *SubscriptionController) GetCurrent(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	subscriptionResp, err := s.subscriptionUsecase.GetCurrentSubscription(req.Request.Context(), user)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, subscriptionResp)
+}
+
+// Cancel is a method for cancelling the active subscription of the user.
+func (s *SubscriptionController) Cancel(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = s.subscriptionUsecase.CancelSubscription(req.Request.Context(), user)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
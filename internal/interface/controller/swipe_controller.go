@@ -0,0 +1,50 @@
+package controller
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/usecase"
+	"dealls-technical-test-dating-service/internal/interface/response"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// SwipeController is a struct for handling HTTP requests and responses and mapping to use cases.
+type SwipeController struct {
+	swipeUsecase usecase.SwipeUsecase
+}
+
+// NewSwipeController is a function used to initialize the swipe controller.
+func NewSwipeController(su usecase.SwipeUsecase) *SwipeController {
+	return &SwipeController{
+		swipeUsecase: su,
+	}
+}
+
+// Swipe is a method for liking or passing a profile.
+func (s *SwipeController) Swipe(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	swipeReq := &entity.SwipeRequest{}
+	err = readEntity(req, swipeReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	swipeResp, err := s.swipeUsecase.Swipe(req.Request.Context(), user, swipeReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusCreated, swipeResp)
+}
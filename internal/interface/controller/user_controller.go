@@ -2,17 +2,13 @@
 package controller
 
 import (
-	"errors"
-	"fmt"
 	"net/http"
-	"strings"
 
 	"dealls-technical-test-dating-service/internal/domain/entity"
 	"dealls-technical-test-dating-service/internal/domain/usecase"
-	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/internal/interface/response"
 
 	"github.com/emicklei/go-restful/v3"
-	"gorm.io/gorm"
 )
 
 // UserController is a struct for handling HTTP requests and responses and mapping to use cases.
@@ -30,27 +26,16 @@ func NewUserController(uu usecase.UserUsecase) *UserController {
 // Signup is a method for signing up a user.
 func (u *UserController) Signup(req *restful.Request, resp *restful.Response) {
 	signupReq := &entity.UserSignupRequest{}
-	err := req.ReadEntity(signupReq)
+	err := readEntity(req, signupReq)
 	if err != nil {
-		resp.WriteError(http.StatusBadRequest, err)
+		response.WriteError(req, resp, err)
 
 		return
 	}
 
 	err = u.userUsecase.Signup(req.Request.Context(), signupReq)
-	if errors.Is(err, gorm.ErrDuplicatedKey) {
-		resp.WriteError(http.StatusConflict, errors.New("Email already exists"))
-
-		return
-	}
 	if err != nil {
-		if strings.Contains(err.Error(), constant.InvalidRequestBody) {
-			resp.WriteError(http.StatusBadRequest, err)
-
-			return
-		}
-
-		resp.WriteError(http.StatusInternalServerError, fmt.Errorf("Failed to sign up user: %s", err.Error()))
+		response.WriteError(req, resp, err)
 
 		return
 	}
@@ -61,30 +46,482 @@ func (u *UserController) Signup(req *restful.Request, resp *restful.Response) {
 // Login is a method for logging in a user.
 func (u *UserController) Login(req *restful.Request, resp *restful.Response) {
 	loginReq := &entity.UserLoginRequest{}
-	err := req.ReadEntity(loginReq)
+	err := readEntity(req, loginReq)
 	if err != nil {
-		resp.WriteError(http.StatusBadRequest, err)
+		response.WriteError(req, resp, err)
 
 		return
 	}
 
 	loginResp, err := u.userUsecase.Login(req.Request.Context(), loginReq)
-	if errors.Is(err, gorm.ErrRecordNotFound) {
-		resp.WriteError(http.StatusNotFound, errors.New("User not found"))
+	if err != nil {
+		response.WriteError(req, resp, err)
 
 		return
 	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, loginResp)
+}
+
+// RequestPhoneOTP is a method for sending a one-time password by SMS to a phone number to sign up or log in with.
+func (u *UserController) RequestPhoneOTP(req *restful.Request, resp *restful.Response) {
+	otpReq := &entity.PhoneOTPRequest{}
+	err := readEntity(req, otpReq)
 	if err != nil {
-		if strings.Contains(err.Error(), constant.InvalidRequestBody) || err.Error() == constant.InvalidEmailPassword {
-			resp.WriteError(http.StatusBadRequest, err)
+		response.WriteError(req, resp, err)
 
-			return
-		}
+		return
+	}
+
+	err = u.userUsecase.RequestPhoneOTP(req.Request.Context(), otpReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeader(http.StatusAccepted)
+}
 
-		resp.WriteError(http.StatusInternalServerError, fmt.Errorf("Failed to logging in user: %s", err.Error()))
+// SignupWithPhone is a method for signing up a user with a phone number and the one-time password sent to it.
+func (u *UserController) SignupWithPhone(req *restful.Request, resp *restful.Response) {
+	signupReq := &entity.PhoneSignupRequest{}
+	err := readEntity(req, signupReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	loginResp, err := u.userUsecase.SignupWithPhone(req.Request.Context(), signupReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusCreated, loginResp)
+}
+
+// LoginWithPhone is a method for logging in a user with a phone number and the one-time password sent to it.
+func (u *UserController) LoginWithPhone(req *restful.Request, resp *restful.Response) {
+	loginReq := &entity.PhoneLoginRequest{}
+	err := readEntity(req, loginReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	loginResp, err := u.userUsecase.LoginWithPhone(req.Request.Context(), loginReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
 
 		return
 	}
 
 	resp.WriteHeaderAndEntity(http.StatusOK, loginResp)
 }
+
+// IssueIdentityProviderNonce is a method for issuing a nonce for a login with an OpenID Connect provider.
+func (u *UserController) IssueIdentityProviderNonce(req *restful.Request, resp *restful.Response) {
+	nonceResp, err := u.userUsecase.IssueIdentityProviderNonce(req.Request.Context(), req.PathParameter("provider"))
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusCreated, nonceResp)
+}
+
+// LoginWithIdentityProvider is a method for logging in a user with an ID token of an OpenID Connect provider, signing the user up on the first login.
+func (u *UserController) LoginWithIdentityProvider(req *restful.Request, resp *restful.Response) {
+	loginReq := &entity.IdentityProviderLoginRequest{}
+	err := readEntity(req, loginReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	loginResp, err := u.userUsecase.LoginWithIdentityProvider(req.Request.Context(), loginReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, loginResp)
+}
+
+// LoginTwoFactor is a method for completing the login of a user with two-factor authentication with a TOTP code or a recovery code.
+func (u *UserController) LoginTwoFactor(req *restful.Request, resp *restful.Response) {
+	loginReq := &entity.TwoFactorLoginRequest{}
+	err := readEntity(req, loginReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	loginResp, err := u.userUsecase.LoginTwoFactor(req.Request.Context(), loginReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, loginResp)
+}
+
+// RefreshToken is a method for exchanging a refresh token for a new access token and refresh token.
+func (u *UserController) RefreshToken(req *restful.Request, resp *restful.Response) {
+	refreshReq := &entity.RefreshTokenRequest{}
+	err := readEntity(req, refreshReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	refreshResp, err := u.userUsecase.RefreshToken(req.Request.Context(), refreshReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, refreshResp)
+}
+
+// Logout is a method for revoking the access token of the current session and, when it is given, its refresh token.
+func (u *UserController) Logout(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	claims, err := authenticatedTokenClaims(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	logoutReq := &entity.LogoutRequest{}
+	err = readOptionalEntity(req, logoutReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = u.userUsecase.Logout(req.Request.Context(), user, claims, logoutReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+// LogoutAll is a method for revoking every token issued to the user.
+func (u *UserController) LogoutAll(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	claims, err := authenticatedTokenClaims(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = u.userUsecase.LogoutAll(req.Request.Context(), user, claims)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
+
+// VerifyEmail is a method for verifying the email address of a user with the token sent to it.
+func (u *UserController) VerifyEmail(req *restful.Request, resp *restful.Response) {
+	verifyReq := &entity.VerifyEmailRequest{}
+	err := readEntity(req, verifyReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = u.userUsecase.VerifyEmail(req.Request.Context(), verifyReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, "Email verified")
+}
+
+// ResendVerificationEmail is a method for sending the verification email again.
+func (u *UserController) ResendVerificationEmail(req *restful.Request, resp *restful.Response) {
+	resendReq := &entity.ResendVerificationEmailRequest{}
+	err := readEntity(req, resendReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = u.userUsecase.ResendVerificationEmail(req.Request.Context(), resendReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeader(http.StatusAccepted)
+}
+
+// ForgotPassword is a method for sending a password reset email.
+func (u *UserController) ForgotPassword(req *restful.Request, resp *restful.Response) {
+	forgotReq := &entity.ForgotPasswordRequest{}
+	err := readEntity(req, forgotReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = u.userUsecase.ForgotPassword(req.Request.Context(), forgotReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeader(http.StatusAccepted)
+}
+
+// ResetPassword is a method for resetting the password with a password reset token.
+func (u *UserController) ResetPassword(req *restful.Request, resp *restful.Response) {
+	resetReq := &entity.ResetPasswordRequest{}
+	err := readEntity(req, resetReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = u.userUsecase.ResetPassword(req.Request.Context(), resetReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, "Password reset")
+}
+
+// ChangePassword is a method for changing the password of the authenticated user.
+func (u *UserController) ChangePassword(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	changeReq := &entity.ChangePasswordRequest{}
+	err = readEntity(req, changeReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	changeResp, err := u.userUsecase.ChangePassword(req.Request.Context(), user, changeReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, changeResp)
+}
+
+// ChangeEmail is a method for sending a link that confirms the new email address of the authenticated user.
+func (u *UserController) ChangeEmail(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	changeReq := &entity.ChangeEmailRequest{}
+	err = readEntity(req, changeReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = u.userUsecase.ChangeEmail(req.Request.Context(), user, changeReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeader(http.StatusAccepted)
+}
+
+// ConfirmEmailChange is a method for replacing the email address of a user with the one the token was sent to.
+func (u *UserController) ConfirmEmailChange(req *restful.Request, resp *restful.Response) {
+	confirmReq := &entity.ConfirmEmailChangeRequest{}
+	err := readEntity(req, confirmReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = u.userUsecase.ConfirmEmailChange(req.Request.Context(), confirmReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, "Email changed")
+}
+
+// Me is a method for getting the authenticated user.
+func (u *UserController) Me(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	userResp, err := u.userUsecase.GetMe(req.Request.Context(), user)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, userResp)
+}
+
+// EnrollTwoFactor is a method for starting the enrollment of the authenticated user in two-factor authentication.
+func (u *UserController) EnrollTwoFactor(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	enrollResp, err := u.userUsecase.EnrollTwoFactor(req.Request.Context(), user)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, enrollResp)
+}
+
+// ConfirmTwoFactor is a method for enabling the two-factor authentication of the authenticated user with a first code.
+func (u *UserController) ConfirmTwoFactor(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	confirmReq := &entity.ConfirmTwoFactorRequest{}
+	err = readEntity(req, confirmReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	confirmResp, err := u.userUsecase.ConfirmTwoFactor(req.Request.Context(), user, confirmReq)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, confirmResp)
+}
+
+// GetSessions is a method for getting the sessions of the authenticated user, marking the one of the current access token.
+func (u *UserController) GetSessions(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	claims, err := authenticatedTokenClaims(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	sessionsResp, err := u.userUsecase.GetSessions(req.Request.Context(), user, claims)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeaderAndEntity(http.StatusOK, sessionsResp)
+}
+
+// DeleteSession is a method for logging the authenticated user out of one of their sessions.
+func (u *UserController) DeleteSession(req *restful.Request, resp *restful.Response) {
+	user, err := authenticatedUser(req)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	id, err := readPathParameterInt(req, "id")
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	err = u.userUsecase.DeleteSession(req.Request.Context(), user, id)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	resp.WriteHeader(http.StatusNoContent)
+}
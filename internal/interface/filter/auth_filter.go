@@ -0,0 +1,87 @@
+// Package filter contains the filters applied to web service routes before they reach the controllers.
+package filter
+
+import (
+	"errors"
+	"strings"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/service"
+	"dealls-technical-test-dating-service/internal/interface/response"
+	"dealls-technical-test-dating-service/pkg/constant"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// AuthFilter is a struct for authenticating requests using the bearer token in the authorization header.
+type AuthFilter struct {
+	auth                   domain.Auth
+	userService            service.UserService
+	tokenRevocationService service.TokenRevocationService
+	sessionService         service.SessionService
+}
+
+// NewAuthFilter is a function used to initialize the auth filter.
+func NewAuthFilter(a domain.Auth, us service.UserService, trs service.TokenRevocationService, ss service.SessionService) *AuthFilter {
+	return &AuthFilter{
+		auth:                   a,
+		userService:            us,
+		tokenRevocationService: trs,
+		sessionService:         ss,
+	}
+}
+
+// Authenticate is a method for validating the bearer token and storing the authenticated user and the token claims in the request context.
+// A token that was revoked, or whose session was deleted, is rejected like an expired one, and the session of any other token is marked as seen.
+func (a *AuthFilter) Authenticate(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+	token, found := strings.CutPrefix(req.HeaderParameter(constant.AuthorizationHeader), constant.BearerPrefix)
+	if !found || token == "" {
+		response.WriteError(req, resp, apperror.New(apperror.KindUnauthorized, constant.MissingBearerToken))
+
+		return
+	}
+
+	claims, err := a.auth.ValidateToken(token)
+	if err != nil {
+		response.WriteError(req, resp, apperror.Wrap(apperror.KindUnauthorized, constant.InvalidToken, err))
+
+		return
+	}
+
+	ctx := req.Request.Context()
+	user, err := a.userService.GetUserByID(ctx, claims.UserID)
+	if errors.Is(err, apperror.ErrNotFound) {
+		response.WriteError(req, resp, apperror.Wrap(apperror.KindUnauthorized, constant.InvalidToken, err))
+
+		return
+	}
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	revoked, err := a.tokenRevocationService.IsRevoked(ctx, user.ID, claims)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+	if revoked {
+		response.WriteError(req, resp, apperror.New(apperror.KindUnauthorized, constant.InvalidToken))
+
+		return
+	}
+
+	err = a.sessionService.TouchSession(ctx, user.ID, claims.ID)
+	if err != nil {
+		response.WriteError(req, resp, err)
+
+		return
+	}
+
+	ctx = domain.WithTokenClaims(domain.WithUser(ctx, user), claims)
+	req.Request = req.Request.WithContext(ctx)
+	chain.ProcessFilter(req, resp)
+}
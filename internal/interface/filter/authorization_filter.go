@@ -0,0 +1,51 @@
+package filter
+
+import (
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/domain/service"
+	"dealls-technical-test-dating-service/internal/interface/response"
+	"dealls-technical-test-dating-service/pkg/constant"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// AuthorizationFilter is a struct for authorizing requests of the authenticated user with the permissions of the role of the user.
+type AuthorizationFilter struct {
+	roleService service.RoleService
+}
+
+// NewAuthorizationFilter is a function used to initialize the authorization filter.
+func NewAuthorizationFilter(rs service.RoleService) *AuthorizationFilter {
+	return &AuthorizationFilter{
+		roleService: rs,
+	}
+}
+
+// Require is a method for getting a route filter that only lets users whose role grants the permission through, and must follow the auth filter.
+// The role is the one stored for the user rather than the one in the access token, so that a changed role applies at once.
+func (a *AuthorizationFilter) Require(permission string) restful.FilterFunction {
+	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		ctx := req.Request.Context()
+		user, ok := domain.UserFromContext(ctx)
+		if !ok {
+			response.WriteError(req, resp, apperror.New(apperror.KindUnauthorized, constant.InvalidToken))
+
+			return
+		}
+
+		allowed, err := a.roleService.HasPermission(ctx, user.Role, permission)
+		if err != nil {
+			response.WriteError(req, resp, err)
+
+			return
+		}
+		if !allowed {
+			response.WriteError(req, resp, apperror.New(apperror.KindForbidden, constant.PermissionDenied))
+
+			return
+		}
+
+		chain.ProcessFilter(req, resp)
+	}
+}
@@ -0,0 +1,32 @@
+package filter
+
+import (
+	"net"
+	"strings"
+
+	"dealls-technical-test-dating-service/internal/domain"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// NewClientIPFilter is a function used to initialize the container filter that stores the IP address of the client in the request context.
+// The address is read from the trusted header when it is set, and from the connection otherwise. A header that lists several addresses,
+// such as X-Forwarded-For, has its last one used, since that one was added by the reverse proxy while the others come from the client.
+func NewClientIPFilter(trustedHeader string) restful.FilterFunction {
+	return func(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+		clientIP := ""
+		if trustedHeader != "" {
+			addresses := strings.Split(req.HeaderParameter(trustedHeader), ",")
+			clientIP = strings.TrimSpace(addresses[len(addresses)-1])
+		}
+		if clientIP == "" {
+			clientIP = req.Request.RemoteAddr
+			if host, _, err := net.SplitHostPort(clientIP); err == nil {
+				clientIP = host
+			}
+		}
+
+		req.Request = req.Request.WithContext(domain.WithClientIP(req.Request.Context(), clientIP))
+		chain.ProcessFilter(req, resp)
+	}
+}
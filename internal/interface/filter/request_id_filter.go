@@ -0,0 +1,32 @@
+package filter
+
+import (
+	"regexp"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/pkg/constant"
+	"dealls-technical-test-dating-service/pkg/util"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+const requestIDSize = 16
+
+var requestIDPattern = regexp.MustCompile(`^[A-Za-z0-9._-]{1,128}$`)
+
+// RequestID is a container filter that assigns an ID to every request, echoes it in the response header, and stores it in the request context.
+// A well-formed ID sent by the client is kept so that a request can be traced across services.
+func RequestID(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+	requestID := req.HeaderParameter(constant.RequestIDHeader)
+	if !requestIDPattern.MatchString(requestID) {
+		var err error
+		requestID, err = util.RandomToken(requestIDSize)
+		if err != nil {
+			requestID = util.RandomString(requestIDSize)
+		}
+	}
+
+	resp.AddHeader(constant.RequestIDHeader, requestID)
+	req.Request = req.Request.WithContext(domain.WithRequestID(req.Request.Context(), requestID))
+	chain.ProcessFilter(req, resp)
+}
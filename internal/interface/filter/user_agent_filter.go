@@ -0,0 +1,13 @@
+package filter
+
+import (
+	"dealls-technical-test-dating-service/internal/domain"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// UserAgent is a container filter that stores the User-Agent header of the request in the request context, so that a login can name its device.
+func UserAgent(req *restful.Request, resp *restful.Response, chain *restful.FilterChain) {
+	req.Request = req.Request.WithContext(domain.WithUserAgent(req.Request.Context(), req.Request.UserAgent()))
+	chain.ProcessFilter(req, resp)
+}
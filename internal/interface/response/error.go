@@ -0,0 +1,66 @@
+// Package response contains the helpers used to write HTTP responses consistently across controllers and filters.
+package response
+
+import (
+	"errors"
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/sirupsen/logrus"
+)
+
+var statuses = map[apperror.Kind]int{
+	apperror.KindValidation:      http.StatusBadRequest,
+	apperror.KindUnauthorized:    http.StatusUnauthorized,
+	apperror.KindForbidden:       http.StatusForbidden,
+	apperror.KindNotFound:        http.StatusNotFound,
+	apperror.KindConflict:        http.StatusConflict,
+	apperror.KindQuotaExceeded:   http.StatusTooManyRequests,
+	apperror.KindPaymentRequired: http.StatusPaymentRequired,
+}
+
+// Status is a function to get the HTTP status of an error based on its domain error kind.
+// Errors that are not domain errors are internal server errors.
+func Status(err error) int {
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) {
+		return http.StatusInternalServerError
+	}
+
+	status, ok := statuses[appErr.Kind]
+	if !ok {
+		return http.StatusInternalServerError
+	}
+
+	return status
+}
+
+// WriteError is a function to write an error as a problem with the HTTP status mapped from its domain error kind.
+// Internal server errors are logged and their details are never written to the response.
+func WriteError(req *restful.Request, resp *restful.Response, err error) {
+	status := Status(err)
+	if status == http.StatusInternalServerError {
+		logrus.Errorf("Internal server error on request %s: %s", domain.RequestIDFromContext(req.Request.Context()), err.Error())
+		writeProblem(resp, NewProblem(req, status, "", http.StatusText(status), nil))
+
+		return
+	}
+
+	var appErr *apperror.Error
+	errors.As(err, &appErr)
+	writeProblem(resp, NewProblem(req, status, appErr.Kind, appErr.Message, appErr.Fields))
+}
+
+// WriteServiceError is a function used as the container service error handler to write routing errors, such as an unknown route or an unsupported media type, as problems.
+func WriteServiceError(serviceErr restful.ServiceError, req *restful.Request, resp *restful.Response) {
+	for header, values := range serviceErr.Header {
+		for _, value := range values {
+			resp.AddHeader(header, value)
+		}
+	}
+
+	writeProblem(resp, NewProblem(req, serviceErr.Code, "", serviceErr.Message, nil))
+}
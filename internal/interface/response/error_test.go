@@ -0,0 +1,145 @@
+package response_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+	"dealls-technical-test-dating-service/internal/interface/response"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{
+			name: "Validation",
+			err:  apperror.Validation("Invalid request body", apperror.Field("email", "email is required")),
+			want: http.StatusBadRequest,
+		},
+		{
+			name: "Unauthorized",
+			err:  apperror.New(apperror.KindUnauthorized, "Invalid or expired token"),
+			want: http.StatusUnauthorized,
+		},
+		{
+			name: "Forbidden",
+			err:  apperror.New(apperror.KindForbidden, "Forbidden"),
+			want: http.StatusForbidden,
+		},
+		{
+			name: "Not found",
+			err:  apperror.New(apperror.KindNotFound, "User not found"),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "Conflict",
+			err:  apperror.New(apperror.KindConflict, "Email already exists"),
+			want: http.StatusConflict,
+		},
+		{
+			name: "Quota exceeded",
+			err:  apperror.New(apperror.KindQuotaExceeded, "Daily swipe quota exceeded"),
+			want: http.StatusTooManyRequests,
+		},
+		{
+			name: "Payment required",
+			err:  apperror.New(apperror.KindPaymentRequired, "Payment declined"),
+			want: http.StatusPaymentRequired,
+		},
+		{
+			name: "Wrapped",
+			err:  fmt.Errorf("wrapped: %w", apperror.New(apperror.KindNotFound, "User not found")),
+			want: http.StatusNotFound,
+		},
+		{
+			name: "Unknown",
+			err:  errors.New("unknown"),
+			want: http.StatusInternalServerError,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := response.Status(tt.err); got != tt.want {
+				t.Errorf("Status() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+		wantType   string
+		wantDetail string
+		wantFields []apperror.FieldError
+	}{
+		{
+			name: "Validation",
+			err: apperror.Validation("Invalid request body",
+				apperror.Field("email", "email is required"),
+				apperror.Field("password", "password is required"),
+			),
+			wantStatus: http.StatusBadRequest,
+			wantType:   "urn:dating-service:problem:validation",
+			wantDetail: "Invalid request body",
+			wantFields: []apperror.FieldError{
+				{Field: "email", Message: "email is required"},
+				{Field: "password", Message: "password is required"},
+			},
+		},
+		{
+			name:       "Quota exceeded",
+			err:        apperror.New(apperror.KindQuotaExceeded, "Daily swipe quota exceeded"),
+			wantStatus: http.StatusTooManyRequests,
+			wantType:   "urn:dating-service:problem:quota-exceeded",
+			wantDetail: "Daily swipe quota exceeded",
+			wantFields: []apperror.FieldError{},
+		},
+		{
+			name:       "Internal server error",
+			err:        errors.New("connection refused"),
+			wantStatus: http.StatusInternalServerError,
+			wantType:   "about:blank",
+			wantDetail: "Internal Server Error",
+			wantFields: []apperror.FieldError{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			httpReq := httptest.NewRequest(http.MethodPost, "/v1/users/signup", nil)
+			httpReq = httpReq.WithContext(domain.WithRequestID(httpReq.Context(), "request-id"))
+			recorder := httptest.NewRecorder()
+
+			response.WriteError(restful.NewRequest(httpReq), restful.NewResponse(recorder), tt.err)
+
+			assert.Equal(t, tt.wantStatus, recorder.Code)
+			assert.Equal(t, response.MIMEProblemJSON, recorder.Header().Get("Content-Type"))
+
+			var problem response.Problem
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+			assert.Equal(t, response.Problem{
+				Type:      tt.wantType,
+				Title:     http.StatusText(tt.wantStatus),
+				Status:    tt.wantStatus,
+				Detail:    tt.wantDetail,
+				Instance:  "/v1/users/signup",
+				RequestID: "request-id",
+				Errors:    tt.wantFields,
+			}, problem)
+		})
+	}
+}
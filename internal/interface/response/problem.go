@@ -0,0 +1,58 @@
+package response
+
+import (
+	"net/http"
+	"strings"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/apperror"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// MIMEProblemJSON is the media type of problem details responses.
+const MIMEProblemJSON = "application/problem+json"
+
+const (
+	problemTypePrefix = "urn:dating-service:problem:"
+	blankProblemType  = "about:blank"
+)
+
+// Problem is a struct that represents an RFC 7807 problem details response body.
+type Problem struct {
+	Type      string                `json:"type"`
+	Title     string                `json:"title"`
+	Status    int                   `json:"status"`
+	Detail    string                `json:"detail"`
+	Instance  string                `json:"instance"`
+	RequestID string                `json:"request_id"`
+	Errors    []apperror.FieldError `json:"errors"`
+}
+
+// NewProblem is a function used to initialize the problem struct of a request.
+// The type identifies the domain error kind, and is about:blank when the problem has no domain meaning.
+func NewProblem(req *restful.Request, status int, kind apperror.Kind, detail string, fields []apperror.FieldError) *Problem {
+	problemType := blankProblemType
+	if kind != "" {
+		problemType = problemTypePrefix + strings.ToLower(strings.ReplaceAll(string(kind), "_", "-"))
+	}
+
+	if fields == nil {
+		fields = []apperror.FieldError{}
+	}
+
+	return &Problem{
+		Type:      problemType,
+		Title:     http.StatusText(status),
+		Status:    status,
+		Detail:    detail,
+		Instance:  req.Request.URL.Path,
+		RequestID: domain.RequestIDFromContext(req.Request.Context()),
+		Errors:    fields,
+	}
+}
+
+// writeProblem is a function to write the problem as the response body with its status.
+func writeProblem(resp *restful.Response, problem *Problem) {
+	resp.WriteHeaderAndJson(problem.Status, problem, MIMEProblemJSON)
+}
@@ -0,0 +1,44 @@
+package routes
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/interface/controller"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// RegisterAdminRoutes is a function to register routes for admin and moderation APIs.
+// Each route declares the permission it requires with the filter that the require function returns for it.
+func RegisterAdminRoutes(container *restful.Container, basePath string, authFilter restful.FilterFunction, require func(permission string) restful.FilterFunction, controller *controller.AdminController) {
+	webService := newProtectedWebService(basePath+"/v1/admin", authFilter)
+	webService.Route(webService.
+		GET("/users/{id}").
+		Filter(require(entity.PermissionReadUsers)).
+		Param(webService.PathParameter("id", "User ID").DataType("integer")).
+		Produces(restful.MIME_JSON).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.UserResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusForbidden, http.StatusText(http.StatusForbidden), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.GetUser))
+	webService.Route(webService.
+		PUT("/users/{id}/role").
+		Filter(require(entity.PermissionManageRoles)).
+		Param(webService.PathParameter("id", "User ID").DataType("integer")).
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.ChangeRoleRequest{}).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.UserResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusForbidden, http.StatusText(http.StatusForbidden), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.ChangeUserRole))
+
+	container.Add(webService)
+}
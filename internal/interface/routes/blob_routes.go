@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/interface/controller"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// RegisterBlobRoutes is a function to register routes for blob APIs.
+// The routes are not protected by the auth filter, since the signature in a URL is what grants access to the file.
+// Photos are stored as JPEG, and image/* is listed as well since image loaders may accept any image without naming a type.
+func RegisterBlobRoutes(container *restful.Container, basePath string, controller *controller.BlobController) {
+	webService := new(restful.WebService).Path(basePath + "/v1/blobs")
+	webService.Route(webService.
+		GET("/{key:*}").
+		Param(webService.PathParameter("key", "Key of the file")).
+		Param(webService.QueryParameter("expires", "Unix time at which the URL expires").DataType("integer")).
+		Param(webService.QueryParameter("signature", "Signature of the URL")).
+		Produces("image/jpeg", "image/*").
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), nil).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusForbidden, http.StatusText(http.StatusForbidden), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.GetBlob))
+
+	container.Add(webService)
+}
@@ -0,0 +1,23 @@
+package routes
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/interface/controller"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// RegisterKeyRoutes is a function to register routes for key APIs.
+// The key set is served at the well-known path of the host rather than under the base path, where verifiers look for it.
+func RegisterKeyRoutes(container *restful.Container, controller *controller.KeyController) {
+	webService := new(restful.WebService).Path("/.well-known")
+	webService.Route(webService.
+		GET("/jwks.json").
+		Produces(restful.MIME_JSON).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.JSONWebKeySet{}).
+		To(controller.GetJWKS))
+
+	container.Add(webService)
+}
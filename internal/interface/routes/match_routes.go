@@ -0,0 +1,36 @@
+package routes
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/interface/controller"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// RegisterMatchRoutes is a function to register routes for match APIs.
+func RegisterMatchRoutes(container *restful.Container, basePath string, authFilter restful.FilterFunction, controller *controller.MatchController) {
+	webService := newProtectedWebService(basePath+"/v1/matches", authFilter)
+	webService.Route(webService.
+		GET("").
+		Produces(restful.MIME_JSON).
+		Param(webService.QueryParameter("cursor", "Match ID after which the next page starts").DataType("integer")).
+		Param(webService.QueryParameter("limit", "Number of matches per page").DataType("integer")).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.MatchesResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.GetMatches))
+	webService.Route(webService.
+		DELETE("/{id}").
+		Param(webService.PathParameter("id", "Match ID").DataType("integer")).
+		Returns(http.StatusNoContent, http.StatusText(http.StatusNoContent), nil).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.Unmatch))
+
+	container.Add(webService)
+}
@@ -0,0 +1,73 @@
+package routes
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/interface/controller"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// RegisterProfileRoutes is a function to register routes for profile APIs.
+func RegisterProfileRoutes(container *restful.Container, basePath string, authFilter restful.FilterFunction, controller *controller.ProfileController) {
+	webService := newProtectedWebService(basePath+"/v1/profiles", authFilter)
+	webService.Route(webService.
+		GET("/discover").
+		Produces(restful.MIME_JSON).
+		Param(webService.QueryParameter("cursor", "Profile ID after which the next page starts").DataType("integer")).
+		Param(webService.QueryParameter("limit", "Number of profiles per page").DataType("integer")).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.DiscoverResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.Discover))
+	webService.Route(webService.
+		GET("/me").
+		Produces(restful.MIME_JSON).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.ProfileResponse{}).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.GetMyProfile))
+	webService.Route(webService.
+		PATCH("/me").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.UpdateProfileRequest{}).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.ProfileResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.UpdateMyProfile))
+	webService.Route(webService.
+		PUT("/me/photo").
+		Consumes("multipart/form-data").
+		Produces(restful.MIME_JSON).
+		Param(webService.MultiPartFormParameter("photo", "JPEG or PNG photo").DataType("file")).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.ProfileResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.UploadPhoto))
+	webService.Route(webService.
+		DELETE("/me/photo").
+		Returns(http.StatusNoContent, http.StatusText(http.StatusNoContent), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.DeletePhoto))
+	webService.Route(webService.
+		GET("/{id}").
+		Param(webService.PathParameter("id", "Profile ID").DataType("integer")).
+		Produces(restful.MIME_JSON).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.ProfileCandidate{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.GetProfile))
+
+	container.Add(webService)
+}
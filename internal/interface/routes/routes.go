@@ -0,0 +1,8 @@
+package routes
+
+import "github.com/emicklei/go-restful/v3"
+
+// newProtectedWebService is a function used to initialize a web service whose routes require an authenticated user.
+func newProtectedWebService(path string, authFilter restful.FilterFunction) *restful.WebService {
+	return new(restful.WebService).Path(path).Filter(authFilter)
+}
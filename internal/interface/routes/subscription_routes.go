@@ -0,0 +1,52 @@
+package routes
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/interface/controller"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// RegisterSubscriptionRoutes is a function to register routes for subscription APIs.
+func RegisterSubscriptionRoutes(container *restful.Container, basePath string, authFilter restful.FilterFunction, controller *controller.SubscriptionController) {
+	webService := newProtectedWebService(basePath+"/v1/subscriptions", authFilter)
+	webService.Route(webService.
+		GET("/packages").
+		Produces(restful.MIME_JSON).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), []entity.SubscriptionPackage{}).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.GetPackages))
+	webService.Route(webService.
+		POST("").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.PurchaseSubscriptionRequest{}).
+		Returns(http.StatusCreated, http.StatusText(http.StatusCreated), entity.SubscriptionResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusPaymentRequired, http.StatusText(http.StatusPaymentRequired), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusConflict, http.StatusText(http.StatusConflict), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.Purchase))
+	webService.Route(webService.
+		GET("/current").
+		Produces(restful.MIME_JSON).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.SubscriptionResponse{}).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.GetCurrent))
+	webService.Route(webService.
+		DELETE("/current").
+		Returns(http.StatusNoContent, http.StatusText(http.StatusNoContent), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.Cancel))
+
+	container.Add(webService)
+}
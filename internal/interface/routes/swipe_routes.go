@@ -0,0 +1,30 @@
+package routes
+
+import (
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/interface/controller"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+// RegisterSwipeRoutes is a function to register routes for swipe APIs.
+func RegisterSwipeRoutes(container *restful.Container, basePath string, authFilter restful.FilterFunction, controller *controller.SwipeController) {
+	webService := newProtectedWebService(basePath+"/v1/swipes", authFilter)
+	webService.Route(webService.
+		POST("").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.SwipeRequest{}).
+		Returns(http.StatusCreated, http.StatusText(http.StatusCreated), entity.SwipeResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusConflict, http.StatusText(http.StatusConflict), nil).
+		Returns(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.Swipe))
+
+	container.Add(webService)
+}
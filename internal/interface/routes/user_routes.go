@@ -11,7 +11,7 @@ import (
 )
 
 // RegisterUserRoutes is a function to register routes for user APIs.
-func RegisterUserRoutes(container *restful.Container, basePath string, controller *controller.UserController) {
+func RegisterUserRoutes(container *restful.Container, basePath string, authFilter restful.FilterFunction, controller *controller.UserController) {
 	webService := new(restful.WebService).Path(basePath)
 	webService.Route(webService.
 		POST("/v1/users/signup").
@@ -30,9 +30,210 @@ func RegisterUserRoutes(container *restful.Container, basePath string, controlle
 		Reads(entity.UserLoginRequest{}).
 		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.UserLoginResponse{}).
 		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusForbidden, http.StatusText(http.StatusForbidden), nil).
 		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
 		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
 		To(controller.Login))
+	webService.Route(webService.
+		POST("/v1/users/login/2fa").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.TwoFactorLoginRequest{}).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.UserLoginResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.LoginTwoFactor))
+	webService.Route(webService.
+		POST("/v1/users/phone/otp").
+		Consumes(restful.MIME_JSON).
+		Reads(entity.PhoneOTPRequest{}).
+		Returns(http.StatusAccepted, http.StatusText(http.StatusAccepted), nil).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.RequestPhoneOTP))
+	webService.Route(webService.
+		POST("/v1/users/phone/signup").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.PhoneSignupRequest{}).
+		Returns(http.StatusCreated, http.StatusText(http.StatusCreated), entity.UserLoginResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusConflict, http.StatusText(http.StatusConflict), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.SignupWithPhone))
+	webService.Route(webService.
+		POST("/v1/users/phone/login").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.PhoneLoginRequest{}).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.UserLoginResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusTooManyRequests, http.StatusText(http.StatusTooManyRequests), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.LoginWithPhone))
+	webService.Route(webService.
+		POST("/v1/users/login/oidc/{provider}/nonce").
+		Produces(restful.MIME_JSON).
+		Param(webService.PathParameter("provider", "Name of the identity provider in OIDC_PROVIDERS").DataType("string")).
+		Returns(http.StatusCreated, http.StatusText(http.StatusCreated), entity.IdentityProviderNonceResponse{}).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.IssueIdentityProviderNonce))
+	webService.Route(webService.
+		POST("/v1/users/login/oidc").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.IdentityProviderLoginRequest{}).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.UserLoginResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusForbidden, http.StatusText(http.StatusForbidden), nil).
+		Returns(http.StatusConflict, http.StatusText(http.StatusConflict), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.LoginWithIdentityProvider))
+	webService.Route(webService.
+		POST("/v1/users/verify-email").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.VerifyEmailRequest{}).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), nil).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.VerifyEmail))
+	webService.Route(webService.
+		POST("/v1/users/verify-email/resend").
+		Consumes(restful.MIME_JSON).
+		Reads(entity.ResendVerificationEmailRequest{}).
+		Returns(http.StatusAccepted, http.StatusText(http.StatusAccepted), nil).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.ResendVerificationEmail))
+	webService.Route(webService.
+		POST("/v1/users/password/forgot").
+		Consumes(restful.MIME_JSON).
+		Reads(entity.ForgotPasswordRequest{}).
+		Returns(http.StatusAccepted, http.StatusText(http.StatusAccepted), nil).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.ForgotPassword))
+	webService.Route(webService.
+		POST("/v1/users/password/reset").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.ResetPasswordRequest{}).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), nil).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.ResetPassword))
+	webService.Route(webService.
+		POST("/v1/users/email/confirm").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.ConfirmEmailChangeRequest{}).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), nil).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusConflict, http.StatusText(http.StatusConflict), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.ConfirmEmailChange))
+	webService.Route(webService.
+		POST("/v1/users/token/refresh").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.RefreshTokenRequest{}).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.UserLoginResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.RefreshToken))
+	webService.Route(webService.
+		POST("/v1/users/logout").
+		Filter(authFilter).
+		Consumes(restful.MIME_JSON).
+		AllowedMethodsWithoutContentType([]string{http.MethodPost}).
+		Reads(entity.LogoutRequest{}).
+		Returns(http.StatusNoContent, http.StatusText(http.StatusNoContent), nil).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.Logout))
+	webService.Route(webService.
+		POST("/v1/users/logout-all").
+		Filter(authFilter).
+		Returns(http.StatusNoContent, http.StatusText(http.StatusNoContent), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.LogoutAll))
+
+	protectedWebService := newProtectedWebService(basePath+"/v1/users/me", authFilter)
+	protectedWebService.Route(protectedWebService.
+		GET("").
+		Produces(restful.MIME_JSON).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.UserResponse{}).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.Me))
+	protectedWebService.Route(protectedWebService.
+		PUT("/password").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.ChangePasswordRequest{}).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.UserLoginResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.ChangePassword))
+	protectedWebService.Route(protectedWebService.
+		PUT("/email").
+		Consumes(restful.MIME_JSON).
+		Reads(entity.ChangeEmailRequest{}).
+		Returns(http.StatusAccepted, http.StatusText(http.StatusAccepted), nil).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusConflict, http.StatusText(http.StatusConflict), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.ChangeEmail))
+	protectedWebService.Route(protectedWebService.
+		POST("/2fa").
+		Produces(restful.MIME_JSON).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.TwoFactorEnrollmentResponse{}).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusConflict, http.StatusText(http.StatusConflict), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.EnrollTwoFactor))
+	protectedWebService.Route(protectedWebService.
+		POST("/2fa/confirm").
+		Consumes(restful.MIME_JSON).
+		Produces(restful.MIME_JSON).
+		Reads(entity.ConfirmTwoFactorRequest{}).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.RecoveryCodesResponse{}).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusConflict, http.StatusText(http.StatusConflict), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.ConfirmTwoFactor))
+	protectedWebService.Route(protectedWebService.
+		GET("/sessions").
+		Produces(restful.MIME_JSON).
+		Returns(http.StatusOK, http.StatusText(http.StatusOK), entity.SessionsResponse{}).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.GetSessions))
+	protectedWebService.Route(protectedWebService.
+		DELETE("/sessions/{id}").
+		Param(protectedWebService.PathParameter("id", "Session ID").DataType("integer")).
+		Returns(http.StatusNoContent, http.StatusText(http.StatusNoContent), nil).
+		Returns(http.StatusBadRequest, http.StatusText(http.StatusBadRequest), nil).
+		Returns(http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized), nil).
+		Returns(http.StatusNotFound, http.StatusText(http.StatusNotFound), nil).
+		Returns(http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError), nil).
+		To(controller.DeleteSession))
 
 	container.Add(webService)
+	container.Add(protectedWebService)
 }
@@ -3,6 +3,39 @@ package constant
 
 // Constants.
 const (
-	InvalidRequestBody   = "Invalid request body"
-	InvalidEmailPassword = "Invalid email or password"
+	InvalidRequestBody       = "Invalid request body"
+	InvalidEmailPassword     = "Invalid email or password"
+	PasswordNotSet           = "The account has no password, set one with a password reset"
+	InvalidToken             = "Invalid or expired token"
+	InvalidRefreshToken      = "Invalid or expired refresh token"
+	InvalidUserToken         = "Invalid, expired or already used token"
+	EmailNotVerified         = "Email is not verified"
+	EmailAlreadyUsed         = "Email is already used"
+	MissingBearerToken       = "Missing bearer token"
+	AuthorizationHeader      = "Authorization"
+	BearerPrefix             = "Bearer "
+	RequestIDHeader          = "X-Request-ID"
+	CannotSwipeOwnProfile    = "Cannot swipe on your own profile"
+	ProfileAlreadySwiped     = "Profile already swiped today"
+	SwipeQuotaExceeded       = "Daily swipe quota exceeded"
+	TooManyLoginAttempts     = "Too many failed login attempts, please try again later"
+	InvalidTwoFactorCode     = "Invalid two-factor authentication code"
+	InvalidChallenge         = "Invalid or expired two-factor authentication challenge"
+	TwoFactorEnabled         = "Two-factor authentication is already enabled"
+	TwoFactorNotEnrolled     = "Two-factor authentication is not enrolled"
+	InvalidOTP               = "Invalid or expired code"
+	OTPRecentlySent          = "A code was recently sent to this phone number, please try again later"
+	OTPDailyLimitReached     = "Too many codes were sent today, please try again later"
+	PhoneNotRegistered       = "Phone number is not registered"
+	PhoneAlreadyUsed         = "Phone number is already used"
+	InvalidIDToken           = "Invalid or expired ID token"
+	InvalidNonce             = "Invalid, expired or already used nonce"
+	TooManyNonces            = "Too many unused nonces were issued to this address, please try again later"
+	ProviderEmailNotVerified = "The identity provider has not verified the email address"
+	PermissionDenied         = "You do not have permission to perform this action"
+	CannotChangeOwnRole      = "Cannot change your own role"
+	EmailLinkNotVerified     = "Email is already used by an account that has not verified it, please verify it first"
+	PaymentDeclined          = "Payment declined"
+	SubscriptionExists       = "Active subscription already exists"
+	InvalidSignedURL         = "Invalid or expired URL"
 )
@@ -0,0 +1,25 @@
+package util
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	phoneSeparators = strings.NewReplacer(" ", "", "-", "", "(", "", ")", "", ".", "")
+	e164Regex       = regexp.MustCompile(`^\+[1-9][0-9]{7,14}$`)
+)
+
+// NormalizePhone is a function to turn a phone number with its country code into the E.164 format, such as +6281234567890.
+// Spaces, dashes, dots and parentheses are removed and a 00 prefix is taken for +, the number being reported invalid otherwise.
+func NormalizePhone(phone string) (string, bool) {
+	normalized := phoneSeparators.Replace(strings.TrimSpace(phone))
+	if rest, found := strings.CutPrefix(normalized, "00"); found {
+		normalized = "+" + rest
+	}
+	if !e164Regex.MatchString(normalized) {
+		return "", false
+	}
+
+	return normalized, true
+}
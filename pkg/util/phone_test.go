@@ -0,0 +1,63 @@
+package util_test
+
+import (
+	"testing"
+
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name   string
+		phone  string
+		want   string
+		wantOK bool
+	}{
+		{
+			name:   "Success",
+			phone:  "+6281234567890",
+			want:   "+6281234567890",
+			wantOK: true,
+		},
+		{
+			name:   "Success with separators",
+			phone:  " +62 (812) 3456-78.90 ",
+			want:   "+6281234567890",
+			wantOK: true,
+		},
+		{
+			name:   "Success with 00 prefix",
+			phone:  "006281234567890",
+			want:   "+6281234567890",
+			wantOK: true,
+		},
+		{
+			name:   "Failed: Missing country code",
+			phone:  "081234567890",
+			wantOK: false,
+		},
+		{
+			name:   "Failed: Too short",
+			phone:  "+6281234",
+			wantOK: false,
+		},
+		{
+			name:   "Failed: Too long",
+			phone:  "+6281234567890123",
+			wantOK: false,
+		},
+		{
+			name:   "Failed: Letters",
+			phone:  "+62812345678ab",
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := util.NormalizePhone(tt.phone)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("NormalizePhone() = %q, %v, want %q, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
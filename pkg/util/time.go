@@ -0,0 +1,25 @@
+package util
+
+import "time"
+
+// StartOfDay is a function to get the start of the UTC calendar date of a time.
+func StartOfDay(t time.Time) time.Time {
+	return DateIn(t, time.UTC)
+}
+
+// DateIn is a function to get the calendar date of a time in the location, at midnight UTC like the dates that are stored.
+func DateIn(t time.Time, loc *time.Location) time.Time {
+	year, month, day := t.In(loc).Date()
+
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// Age is a function to calculate the age in full years of someone born on the birth date at the given time.
+func Age(birthDate, now time.Time) int {
+	age := now.Year() - birthDate.Year()
+	if now.Month() < birthDate.Month() || (now.Month() == birthDate.Month() && now.Day() < birthDate.Day()) {
+		age--
+	}
+
+	return max(age, 0)
+}
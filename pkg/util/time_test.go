@@ -0,0 +1,113 @@
+package util_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+func TestStartOfDay(t *testing.T) {
+	type args struct {
+		t time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want time.Time
+	}{
+		{
+			name: "Success: UTC time",
+			args: args{
+				t: time.Date(2024, 5, 1, 13, 14, 15, 16, time.UTC),
+			},
+			want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Success: Non-UTC time",
+			args: args{
+				t: time.Date(2024, 5, 1, 1, 0, 0, 0, time.FixedZone("WIB", 7*60*60)),
+			},
+			want: time.Date(2024, 4, 30, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := util.StartOfDay(tt.args.t); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("StartOfDay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDateIn(t *testing.T) {
+	jakarta := time.FixedZone("WIB", 7*60*60)
+	tests := []struct {
+		name string
+		t    time.Time
+		loc  *time.Location
+		want time.Time
+	}{
+		{
+			name: "Success: Same date as UTC",
+			t:    time.Date(2024, 5, 1, 13, 0, 0, 0, time.UTC),
+			loc:  jakarta,
+			want: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "Success: Next date in the location",
+			t:    time.Date(2024, 5, 1, 18, 0, 0, 0, time.UTC),
+			loc:  jakarta,
+			want: time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := util.DateIn(tt.t, tt.loc); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DateIn() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAge(t *testing.T) {
+	now := time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	type args struct {
+		birthDate time.Time
+	}
+	tests := []struct {
+		name string
+		args args
+		want int
+	}{
+		{
+			name: "Success: Birthday not yet reached",
+			args: args{
+				birthDate: time.Date(2000, 5, 2, 0, 0, 0, 0, time.UTC),
+			},
+			want: 23,
+		},
+		{
+			name: "Success: Birthday today",
+			args: args{
+				birthDate: time.Date(2000, 5, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: 24,
+		},
+		{
+			name: "Success: Birth date in the future",
+			args: args{
+				birthDate: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := util.Age(tt.args.birthDate, now); got != tt.want {
+				t.Errorf("Age() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
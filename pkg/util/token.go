@@ -0,0 +1,27 @@
+package util
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+)
+
+// RandomToken is a function to generate a cryptographically secure, URL-safe random token from the given number of bytes.
+func RandomToken(size int) (string, error) {
+	bytes := make([]byte, size)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
+
+// HashToken is a function to hash a random token for storage, so that a leaked table cannot be used to authenticate.
+// A fast hash is enough because the token carries the full entropy of RandomToken, unlike a password.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,38 @@
+package util_test
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+func TestRandomToken(t *testing.T) {
+	token, err := util.RandomToken(32)
+	if err != nil {
+		t.Fatalf("RandomToken() error = %v", err)
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(decoded) != 32 {
+		t.Errorf("RandomToken() = %v, want 32 URL-safe encoded bytes", token)
+	}
+
+	other, _ := util.RandomToken(32)
+	if token == other {
+		t.Errorf("RandomToken() returned the same token twice")
+	}
+}
+
+func TestHashToken(t *testing.T) {
+	hash := util.HashToken("token")
+	if len(hash) != 64 {
+		t.Errorf("HashToken() = %v, want a hex encoded SHA-256 hash", hash)
+	}
+	if hash != util.HashToken("token") {
+		t.Errorf("HashToken() is not deterministic")
+	}
+	if hash == util.HashToken("other") {
+		t.Errorf("HashToken() returned the same hash for different tokens")
+	}
+}
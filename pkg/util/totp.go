@@ -0,0 +1,77 @@
+package util
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// TOTP parameters, the defaults of RFC 6238 that every authenticator app supports.
+const (
+	totpSecretSize = 20
+	totpDigits     = 6
+	totpPeriod     = 30
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// RandomTOTPSecret is a function to generate a cryptographically secure, base32 encoded TOTP secret.
+func RandomTOTPSecret() (string, error) {
+	bytes := make([]byte, totpSecretSize)
+	_, err := rand.Read(bytes)
+	if err != nil {
+		return "", err
+	}
+
+	return totpEncoding.EncodeToString(bytes), nil
+}
+
+// TOTPStep is a function to get the number of the TOTP time step a time falls in.
+func TOTPStep(t time.Time) int64 {
+	return t.Unix() / totpPeriod
+}
+
+// TOTPCode is a function to compute the TOTP code of a base32 encoded secret for a time step.
+func TOTPCode(secret string, step int64) (string, error) {
+	key, err := totpEncoding.DecodeString(strings.ToUpper(secret))
+	if err != nil {
+		return "", err
+	}
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation as described in RFC 4226.
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%0*d", totpDigits, value%1000000), nil
+}
+
+// TOTPURI is a function to build the otpauth URI that authenticator apps read, usually from a QR code, to add an account.
+func TOTPURI(issuer, account, secret string) string {
+	query := url.Values{}
+	query.Set("secret", secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+
+	uri := url.URL{
+		Scheme:   "otpauth",
+		Host:     "totp",
+		Path:     "/" + issuer + ":" + account,
+		RawQuery: query.Encode(),
+	}
+
+	return uri.String()
+}
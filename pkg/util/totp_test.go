@@ -0,0 +1,67 @@
+package util_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+func TestRandomTOTPSecret(t *testing.T) {
+	secret, err := util.RandomTOTPSecret()
+	if err != nil {
+		t.Fatalf("RandomTOTPSecret() error = %v", err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("RandomTOTPSecret() = %v, want 20 base32 encoded bytes", secret)
+	}
+
+	other, _ := util.RandomTOTPSecret()
+	if secret == other {
+		t.Errorf("RandomTOTPSecret() returned the same secret twice")
+	}
+}
+
+func TestTOTPCode(t *testing.T) {
+	// The SHA-1 test vectors of RFC 6238, truncated to six digits.
+	secret := "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+	tests := []struct {
+		time time.Time
+		want string
+	}{
+		{time: time.Unix(59, 0), want: "287082"},
+		{time: time.Unix(1111111109, 0), want: "081804"},
+		{time: time.Unix(1234567890, 0), want: "005924"},
+		{time: time.Unix(20000000000, 0), want: "353130"},
+	}
+	for _, test := range tests {
+		t.Run(test.want, func(t *testing.T) {
+			got, err := util.TOTPCode(secret, util.TOTPStep(test.time))
+			if err != nil {
+				t.Fatalf("TOTPCode() error = %v", err)
+			}
+			if got != test.want {
+				t.Errorf("TOTPCode() = %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	_, err := util.TOTPCode("not base32!", 1)
+	if err == nil {
+		t.Errorf("TOTPCode() error = nil for an invalid secret")
+	}
+}
+
+func TestTOTPURI(t *testing.T) {
+	uri, err := url.Parse(util.TOTPURI("Dating Service", "user@email.com", "SECRET"))
+	if err != nil {
+		t.Fatalf("TOTPURI() is not a valid URI: %v", err)
+	}
+	if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/Dating Service:user@email.com" {
+		t.Errorf("TOTPURI() = %v, want an otpauth URI labelled with the issuer and account", uri)
+	}
+	if uri.Query().Get("secret") != "SECRET" || uri.Query().Get("issuer") != "Dating Service" {
+		t.Errorf("TOTPURI() = %v, want the secret and issuer in the query", uri)
+	}
+}
@@ -0,0 +1,77 @@
+package util
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	unknownDevice       = "Unknown device"
+	maxDeviceNameLength = 255
+)
+
+// userAgentBrowsers are the markers of the browsers told apart by DeviceName, in the order they are looked for,
+// since most browsers also name the ones they are based on.
+var userAgentBrowsers = []struct{ marker, name string }{
+	{"Edg/", "Edge"},
+	{"OPR/", "Opera"},
+	{"SamsungBrowser/", "Samsung Internet"},
+	{"Firefox/", "Firefox"},
+	{"FxiOS/", "Firefox"},
+	{"CriOS/", "Chrome"},
+	{"Chrome/", "Chrome"},
+	{"Safari/", "Safari"},
+}
+
+// userAgentSystems are the markers of the operating systems told apart by DeviceName, in the order they are looked for.
+var userAgentSystems = []struct{ marker, name string }{
+	{"iPhone", "iPhone"},
+	{"iPad", "iPad"},
+	{"Android", "Android"},
+	{"Windows", "Windows"},
+	{"Mac OS X", "macOS"},
+	{"CrOS", "ChromeOS"},
+	{"Linux", "Linux"},
+}
+
+// DeviceName is a function to describe the device of a User-Agent header in a readable way, such as "Chrome on Windows".
+// A client that is not a known browser is described by its product name, such as "curl".
+// Invalid UTF-8 is dropped from the header first, since the name is stored as text.
+func DeviceName(userAgent string) string {
+	userAgent = strings.TrimSpace(strings.ToValidUTF8(userAgent, ""))
+	if userAgent == "" {
+		return unknownDevice
+	}
+
+	browser := findUserAgentMarker(userAgent, userAgentBrowsers)
+	system := findUserAgentMarker(userAgent, userAgentSystems)
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	}
+
+	product, _, _ := strings.Cut(strings.Fields(userAgent)[0], "/")
+	if product == "" {
+		return unknownDevice
+	}
+	if utf8.RuneCountInString(product) > maxDeviceNameLength {
+		product = string([]rune(product)[:maxDeviceNameLength])
+	}
+
+	return product
+}
+
+// findUserAgentMarker is a function to find the name of the first marker found in a User-Agent header.
+func findUserAgentMarker(userAgent string, markers []struct{ marker, name string }) string {
+	for _, m := range markers {
+		if strings.Contains(userAgent, m.marker) {
+			return m.name
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,74 @@
+package util_test
+
+import (
+	"strings"
+	"testing"
+
+	"dealls-technical-test-dating-service/pkg/util"
+)
+
+func TestDeviceName(t *testing.T) {
+	tests := []struct {
+		name      string
+		userAgent string
+		want      string
+	}{
+		{
+			name:      "Empty",
+			userAgent: "",
+			want:      "Unknown device",
+		},
+		{
+			name:      "Chrome on Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36",
+			want:      "Chrome on Windows",
+		},
+		{
+			name:      "Edge on Windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36 Edg/120.0.0.0",
+			want:      "Edge on Windows",
+		},
+		{
+			name:      "Safari on iPhone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_0 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.0 Mobile/15E148 Safari/604.1",
+			want:      "Safari on iPhone",
+		},
+		{
+			name:      "Firefox on macOS",
+			userAgent: "Mozilla/5.0 (Macintosh; Intel Mac OS X 14.0; rv:120.0) Gecko/20100101 Firefox/120.0",
+			want:      "Firefox on macOS",
+		},
+		{
+			name:      "Chrome on Android",
+			userAgent: "Mozilla/5.0 (Linux; Android 14) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Mobile Safari/537.36",
+			want:      "Chrome on Android",
+		},
+		{
+			name:      "Other client",
+			userAgent: "curl/8.4.0",
+			want:      "curl",
+		},
+		{
+			name:      "Invalid UTF-8",
+			userAgent: "cu\xffrl/8.4.0",
+			want:      "curl",
+		},
+		{
+			name:      "Only invalid UTF-8",
+			userAgent: "\xff\xfe/1.0",
+			want:      "Unknown device",
+		},
+		{
+			name:      "Long multi-byte product",
+			userAgent: strings.Repeat("é", 300) + "/1.0",
+			want:      strings.Repeat("é", 255),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := util.DeviceName(tt.userAgent); got != tt.want {
+				t.Errorf("DeviceName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
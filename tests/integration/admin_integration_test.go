@@ -0,0 +1,83 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+const adminUsersURL = "/dating/v1/admin/users"
+
+func (t *Test) Test_Admin_Get_User_Failed_Unauthorized() {
+	response, err := t.executeGet(adminUsersURL+"/1", "")
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Admin_Get_User_Failed_Forbidden() {
+	token := t.signupAndLogin()
+	user := t.me(token)
+	t.Require().Equal(entity.RoleUser, user.Role)
+
+	response, err := t.executeGet(fmt.Sprintf("%s/%d", adminUsersURL, user.ID), token)
+	t.Require().Equal(http.StatusForbidden, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Admin_Change_Role_Success() {
+	adminToken := t.signupAndLogin()
+	admin := t.me(adminToken)
+	t.promote(admin.ID, entity.RoleAdmin)
+
+	token := t.signupAndLogin()
+	user := t.me(token)
+
+	response, err := t.executeGet(fmt.Sprintf("%s/%d", adminUsersURL, user.ID), adminToken)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeAuthorizedPut(fmt.Sprintf("%s/%d/role", adminUsersURL, user.ID), adminToken, entity.ChangeRoleRequest{Role: entity.RoleModerator})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	userResp := entity.UserResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &userResp))
+	t.Require().Equal(entity.RoleModerator, userResp.Role)
+
+	// The new role applies to the access token the user already has, and moderators can read users but not change roles.
+	response, err = t.executeGet(fmt.Sprintf("%s/%d", adminUsersURL, admin.ID), token)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeAuthorizedPut(fmt.Sprintf("%s/%d/role", adminUsersURL, admin.ID), token, entity.ChangeRoleRequest{Role: entity.RoleUser})
+	t.Require().Equal(http.StatusForbidden, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Admin_Change_Role_Failed_Own_Role() {
+	adminToken := t.signupAndLogin()
+	admin := t.me(adminToken)
+	t.promote(admin.ID, entity.RoleAdmin)
+
+	response, err := t.executeAuthorizedPut(fmt.Sprintf("%s/%d/role", adminUsersURL, admin.ID), adminToken, entity.ChangeRoleRequest{Role: entity.RoleUser})
+	t.Require().Equal(http.StatusForbidden, response.Code)
+	t.Require().NoError(err)
+	t.Require().Equal(entity.RoleAdmin, t.me(adminToken).Role)
+}
+
+func (t *Test) Test_Admin_Change_Role_Failed_Invalid_Role() {
+	adminToken := t.signupAndLogin()
+	t.promote(t.me(adminToken).ID, entity.RoleAdmin)
+	user := t.me(t.signupAndLogin())
+
+	response, err := t.executeAuthorizedPut(fmt.Sprintf("%s/%d/role", adminUsersURL, user.ID), adminToken, entity.ChangeRoleRequest{Role: "owner"})
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+}
+
+// promote is a method for giving a user a role directly in the database, the same way the first admin of the service is made.
+func (t *Test) promote(userID int, role string) {
+	t.Require().NoError(t.db.Exec("UPDATE users SET role = ? WHERE id = ?", role, userID).Error)
+}
@@ -0,0 +1,109 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+const matchURL = "/dating/v1/matches"
+
+func (t *Test) Test_Matches_Failed_Unauthorized() {
+	response, err := t.executeGet(matchURL, "")
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Unmatch_Failed_Invalid_ID() {
+	token := t.signupAndLogin()
+
+	response, err := t.executeDelete(matchURL+"/id", token)
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Unmatch_Failed_Not_Found() {
+	token := t.signupAndLogin()
+
+	response, err := t.executeDelete(matchURL+"/2147483647", token)
+	t.Require().Equal(http.StatusNotFound, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Match_Mutual_Like_And_Unmatch_Success() {
+	firstToken := t.signupAndLogin()
+	secondToken := t.signupAndLogin()
+
+	swipeResp := t.swipe(firstToken, t.findProfileIDOf(firstToken, secondToken))
+	t.Require().False(swipeResp.Matched)
+
+	swipeResp = t.swipe(secondToken, t.findProfileIDOf(secondToken, firstToken))
+	t.Require().True(swipeResp.Matched)
+	t.Require().NotNil(swipeResp.Match)
+
+	for _, token := range []string{firstToken, secondToken} {
+		response, err := t.executeGet(matchURL, token)
+		t.Require().Equal(http.StatusOK, response.Code)
+		t.Require().NoError(err)
+
+		matchesResp := entity.MatchesResponse{}
+		err = json.Unmarshal(response.Body.Bytes(), &matchesResp)
+		t.Require().NoError(err)
+		t.Require().NotEmpty(matchesResp.Matches)
+		t.Require().Equal(swipeResp.Match.ID, matchesResp.Matches[0].ID)
+	}
+
+	response, err := t.executeDelete(fmt.Sprintf("%s/%d", matchURL, swipeResp.Match.ID), firstToken)
+	t.Require().Equal(http.StatusNoContent, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeDelete(fmt.Sprintf("%s/%d", matchURL, swipeResp.Match.ID), secondToken)
+	t.Require().Equal(http.StatusNotFound, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) swipe(token string, profileID int) entity.SwipeResponse {
+	request := entity.SwipeRequest{
+		ProfileID: profileID,
+		Action:    entity.ActionLike,
+	}
+	response, err := t.executeAuthorizedPost(swipeURL, token, request)
+	t.Require().Equal(http.StatusCreated, response.Code)
+	t.Require().NoError(err)
+
+	swipeResp := entity.SwipeResponse{}
+	err = json.Unmarshal(response.Body.Bytes(), &swipeResp)
+	t.Require().NoError(err)
+
+	return swipeResp
+}
+
+func (t *Test) findProfileIDOf(token, otherToken string) int {
+	response, err := t.executeGet(meURL, otherToken)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	other := entity.UserResponse{}
+	err = json.Unmarshal(response.Body.Bytes(), &other)
+	t.Require().NoError(err)
+
+	cursor := 0
+	for {
+		response, err = t.executeGet(fmt.Sprintf("%s?limit=%d&cursor=%d", discoverURL, entity.MaxPageLimit, cursor), token)
+		t.Require().Equal(http.StatusOK, response.Code)
+		t.Require().NoError(err)
+
+		discoverResp := entity.DiscoverResponse{}
+		err = json.Unmarshal(response.Body.Bytes(), &discoverResp)
+		t.Require().NoError(err)
+		for _, profile := range discoverResp.Profiles {
+			if profile.UserID == other.ID {
+				return profile.ProfileID
+			}
+		}
+		t.Require().NotNil(discoverResp.NextCursor)
+		cursor = *discoverResp.NextCursor
+	}
+}
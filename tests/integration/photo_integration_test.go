@@ -0,0 +1,127 @@
+package integration_test
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	_ "image/jpeg"
+	"image/png"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"net/textproto"
+	"net/url"
+
+	"github.com/emicklei/go-restful/v3"
+)
+
+const myPhotoURL = "/dating/v1/profiles/me/photo"
+
+func (t *Test) Test_Upload_Photo_Failed_Unauthorized() {
+	response, err := t.executeUploadPhoto("", "image/png", testPhoto())
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Upload_Photo_Failed_Not_An_Image() {
+	token := t.signupAndLogin()
+
+	response, err := t.executeUploadPhoto(token, "image/png", []byte("not an image"))
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+	t.Require().Nil(t.myProfile(token).ProfilePicture)
+}
+
+func (t *Test) Test_Upload_Photo_Success() {
+	token := t.signupAndLogin()
+
+	response, err := t.executeUploadPhoto(token, "image/png", testPhoto())
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	profile := t.myProfile(token)
+	t.Require().NotNil(profile.ProfilePicture)
+	t.Require().Equal(profile.ProfilePicture.Large, profile.ProfilePictureURL)
+	t.Require().Equal(profile.ProfilePicture.Large, t.me(token).ProfilePictureURL)
+
+	photoURL, err := url.Parse(profile.ProfilePicture.Small)
+	t.Require().NoError(err)
+
+	response, err = t.executeGet(photoURL.RequestURI(), "")
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+	t.Require().Equal("image/jpeg", response.Header().Get("Content-Type"))
+
+	thumbnail, _, err := image.Decode(bytes.NewReader(response.Body.Bytes()))
+	t.Require().NoError(err)
+	t.Require().LessOrEqual(thumbnail.Bounds().Dx(), 160)
+
+	query := photoURL.Query()
+	query.Set("signature", "tampered")
+	photoURL.RawQuery = query.Encode()
+	response, err = t.executeGet(photoURL.RequestURI(), "")
+	t.Require().Equal(http.StatusForbidden, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Delete_Photo_Success() {
+	token := t.signupAndLogin()
+
+	response, err := t.executeUploadPhoto(token, "image/png", testPhoto())
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	photoURL, err := url.Parse(t.myProfile(token).ProfilePicture.Large)
+	t.Require().NoError(err)
+
+	response, err = t.executeDelete(myPhotoURL, token)
+	t.Require().Equal(http.StatusNoContent, response.Code)
+	t.Require().NoError(err)
+
+	t.Require().Nil(t.myProfile(token).ProfilePicture)
+
+	response, err = t.executeGet(photoURL.RequestURI(), "")
+	t.Require().Equal(http.StatusNotFound, response.Code)
+	t.Require().NoError(err)
+}
+
+// executeUploadPhoto is a method for uploading the photo in a multipart form, as clients do.
+func (t *Test) executeUploadPhoto(token, contentType string, photo []byte) (*httptest.ResponseRecorder, error) {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+
+	header := textproto.MIMEHeader{}
+	header.Set("Content-Disposition", `form-data; name="photo"; filename="photo"`)
+	header.Set("Content-Type", contentType)
+	part, err := writer.CreatePart(header)
+	t.Require().NoError(err)
+
+	_, err = part.Write(photo)
+	t.Require().NoError(err)
+	t.Require().NoError(writer.Close())
+
+	request := httptest.NewRequest(http.MethodPut, myPhotoURL, body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	request.Header.Set("Accept", restful.MIME_JSON)
+	request.Header.Set(AuthorizationHeader, fmt.Sprintf("Bearer %s", token))
+
+	response, _, err := t.execute(request)
+
+	return response, err
+}
+
+// testPhoto is a function to generate a PNG photo larger than the largest size it is resized to.
+func testPhoto() []byte {
+	photo := image.NewRGBA(image.Rect(0, 0, 1600, 1200))
+	for y := 0; y < 1200; y++ {
+		for x := 0; x < 1600; x++ {
+			photo.Set(x, y, color.RGBA{R: uint8(x), G: uint8(y), B: 128, A: 255})
+		}
+	}
+
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, photo)
+
+	return buf.Bytes()
+}
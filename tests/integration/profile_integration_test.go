@@ -0,0 +1,132 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+const (
+	discoverURL  = "/dating/v1/profiles/discover"
+	profilesURL  = "/dating/v1/profiles"
+	myProfileURL = "/dating/v1/profiles/me"
+)
+
+func (t *Test) Test_Discover_Failed_Unauthorized() {
+	response, err := t.executeGet(discoverURL, "")
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Discover_Failed_Invalid_Limit() {
+	token := t.signupAndLogin()
+
+	response, err := t.executeGet(discoverURL+"?limit=limit", token)
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Discover_Success_Excludes_Swiped_Profiles() {
+	t.signupAndLogin()
+	token := t.signupAndLogin()
+
+	response, err := t.executeGet(discoverURL+"?limit=1", token)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	discoverResp := entity.DiscoverResponse{}
+	err = json.Unmarshal(response.Body.Bytes(), &discoverResp)
+	t.Require().NoError(err)
+	t.Require().Len(discoverResp.Profiles, 1)
+
+	swipeReq := entity.SwipeRequest{
+		ProfileID: discoverResp.Profiles[0].ProfileID,
+		Action:    entity.ActionPass,
+	}
+	response, err = t.executeAuthorizedPost(swipeURL, token, swipeReq)
+	t.Require().Equal(http.StatusCreated, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeGet(discoverURL+"?limit=1", token)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	err = json.Unmarshal(response.Body.Bytes(), &discoverResp)
+	t.Require().NoError(err)
+	for _, profile := range discoverResp.Profiles {
+		t.Require().NotEqual(swipeReq.ProfileID, profile.ProfileID)
+	}
+}
+
+func (t *Test) Test_Get_My_Profile_Failed_Unauthorized() {
+	response, err := t.executeGet(myProfileURL, "")
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Update_My_Profile_Failed_Invalid_Request() {
+	token := t.signupAndLogin()
+	bio := strings.Repeat("a", 501)
+	name := " "
+
+	response, err := t.executeAuthorizedPatch(myProfileURL, token, entity.UpdateProfileRequest{Name: &name, Bio: &bio})
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Update_My_Profile_Success() {
+	token := t.signupAndLogin()
+	before := t.myProfile(token)
+	t.Require().Empty(before.Bio)
+
+	name := "Updated Name"
+	bio := "Coffee first"
+	response, err := t.executeAuthorizedPatch(myProfileURL, token, entity.UpdateProfileRequest{Name: &name, Bio: &bio})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	after := t.myProfile(token)
+	t.Require().Equal(name, after.Name)
+	t.Require().Equal(bio, after.Bio)
+	t.Require().Equal(before.Location, after.Location)
+	t.Require().Equal(before.Email, after.Email)
+	t.Require().Equal(name, t.me(token).Name)
+}
+
+func (t *Test) Test_Get_Profile_Failed_Not_Found() {
+	token := t.signupAndLogin()
+
+	response, err := t.executeGet(profilesURL+"/2147483647", token)
+	t.Require().Equal(http.StatusNotFound, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Get_Profile_Success_Without_Private_Fields() {
+	otherProfile := t.myProfile(t.signupAndLogin())
+	token := t.signupAndLogin()
+
+	response, err := t.executeGet(fmt.Sprintf("%s/%d", profilesURL, otherProfile.ProfileID), token)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	body := map[string]interface{}{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &body))
+	t.Require().NotContains(body, "email")
+	t.Require().NotContains(body, "birth_date")
+	t.Require().Equal(otherProfile.Name, body["name"])
+	t.Require().EqualValues(otherProfile.Age, body["age"])
+}
+
+func (t *Test) myProfile(token string) entity.ProfileResponse {
+	response, err := t.executeGet(myProfileURL, token)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	profile := entity.ProfileResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &profile))
+
+	return profile
+}
@@ -0,0 +1,399 @@
+// Package integration_test contains setup code for your integration tests, such as initializing the database, starting the server, and the actual integration test cases.
+// The setup is a test file like the test cases, since a package named integration_test in a file that is not a test file
+// conflicts with the test files sorted before it, such as profile_integration_test.go.
+package integration_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+
+	"dealls-technical-test-dating-service/internal/domain"
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/domain/service"
+	"dealls-technical-test-dating-service/internal/domain/usecase"
+	"dealls-technical-test-dating-service/internal/infrastructure/auth"
+	"dealls-technical-test-dating-service/internal/infrastructure/auth/oidctest"
+	"dealls-technical-test-dating-service/internal/infrastructure/config"
+	"dealls-technical-test-dating-service/internal/infrastructure/database"
+	"dealls-technical-test-dating-service/internal/infrastructure/imaging"
+	"dealls-technical-test-dating-service/internal/infrastructure/password"
+	"dealls-technical-test-dating-service/internal/infrastructure/payment"
+	"dealls-technical-test-dating-service/internal/infrastructure/repository"
+	"dealls-technical-test-dating-service/internal/infrastructure/storage"
+	"dealls-technical-test-dating-service/internal/interface/controller"
+	"dealls-technical-test-dating-service/internal/interface/filter"
+	"dealls-technical-test-dating-service/internal/interface/response"
+	"dealls-technical-test-dating-service/internal/interface/routes"
+	"dealls-technical-test-dating-service/pkg/util"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/joho/godotenv"
+	"github.com/parnurzeal/gorequest"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+)
+
+// Constants.
+const (
+	BasePath            = "/e-wallet"
+	AuthorizationHeader = "Authorization"
+
+	oidcProviderName = "test"
+	oidcClientID     = "dating-service"
+)
+
+var once sync.Once
+
+// HTTPTestCaller is a struct that represents an HTTP call request.
+type HTTPTestCaller struct {
+	handler  http.Handler
+	request  *http.Request
+	response interface{}
+}
+
+func call(handler http.Handler) *HTTPTestCaller {
+	return &HTTPTestCaller{
+		handler: handler,
+	}
+}
+
+func (h *HTTPTestCaller) to(request *http.Request, _ ...interface{}) *HTTPTestCaller {
+	h.request = request
+
+	return h
+}
+
+func (h *HTTPTestCaller) execute() (*httptest.ResponseRecorder, interface{}, error) {
+	recorder := httptest.NewRecorder()
+	h.handler.ServeHTTP(recorder, h.request)
+
+	var err error
+	if h.response != nil {
+		err = json.Unmarshal(recorder.Body.Bytes(), &h.response)
+	}
+
+	return recorder, h.response, err
+}
+
+// mailbox is a struct used to implement the mailer interface by keeping the emails, so that tests can read the tokens sent to users.
+type mailbox struct {
+	mu    sync.Mutex
+	mails []*entity.Mail
+}
+
+// Send is a method for keeping a mail.
+func (m *mailbox) Send(_ context.Context, mail *entity.Mail) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.mails = append(m.mails, mail)
+
+	return nil
+}
+
+// lastTo is a method for getting the last mail sent to the email address.
+func (m *mailbox) lastTo(email string) *entity.Mail {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for i := len(m.mails) - 1; i >= 0; i-- {
+		if m.mails[i].To == email {
+			return m.mails[i]
+		}
+	}
+
+	return nil
+}
+
+// smsOutbox is a struct used to implement the SMS sender interface by keeping the text messages, so that tests can read the codes sent to users.
+type smsOutbox struct {
+	mu       sync.Mutex
+	messages []*entity.SMS
+}
+
+// Send is a method for keeping a text message.
+func (o *smsOutbox) Send(_ context.Context, sms *entity.SMS) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.messages = append(o.messages, sms)
+
+	return nil
+}
+
+// lastTo is a method for getting the last text message sent to the phone number.
+func (o *smsOutbox) lastTo(phone string) *entity.SMS {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	for i := len(o.messages) - 1; i >= 0; i-- {
+		if o.messages[i].To == phone {
+			return o.messages[i]
+		}
+	}
+
+	return nil
+}
+
+// Test is a struct that represents the integration tests suite.
+type Test struct {
+	suite.Suite
+	container *restful.Container
+	mailbox   *mailbox
+	smsOutbox *smsOutbox
+	// oidcIssuer is the OpenID Connect provider that users log in with in the tests.
+	oidcIssuer *oidctest.Issuer
+	// db is the database of the service, used to set up what has no API such as the first admin.
+	db *gorm.DB
+	// blobDir is the temporary directory that the files uploaded in the tests are stored in.
+	blobDir string
+}
+
+// SetupSuite is a method for setup the integration tests suite.
+func (t *Test) SetupSuite() {
+	logrus.SetOutput(os.Stdout)
+	logrus.SetLevel(logrus.DebugLevel)
+	logrus.SetReportCaller(true)
+
+	cfg, err := loadConfig()
+	if err != nil {
+		logrus.Fatalf("Failed to load configuration: %s", err.Error())
+	}
+	t.Require().NoError(err)
+	t.Require().NotNil(cfg)
+
+	// Every test request comes from the same address, so the per-IP lockout and limits are disabled to keep repeated runs independent.
+	cfg.LoginMaxFailedAttemptsPerIP = 0
+	cfg.PhoneOTPMaxDailySendsPerIP = 0
+	cfg.OIDCNonceMaxPerIP = 0
+	// Tests log in with a phone number right after signing up with it, which needs a second code within the resend interval.
+	cfg.PhoneOTPResendInterval = 0
+
+	postgres, err := database.NewPostgres(cfg)
+	if err != nil {
+		logrus.Fatalf("Failed to initialize PostgreSQL: %s", err.Error())
+	}
+	t.Require().NoError(err)
+	t.Require().NotNil(postgres)
+
+	err = postgres.Migrate(cfg.PostgresDBName)
+	if err != nil {
+		logrus.Fatalf("Failed to migrate PostgreSQL: %s", err.Error())
+	}
+	t.Require().NoError(err)
+	t.db = postgres.Client
+
+	container := restful.NewContainer()
+	container.Filter(filter.RequestID)
+	container.Filter(filter.NewClientIPFilter(cfg.TrustedClientIPHeader))
+	container.Filter(filter.UserAgent)
+	container.ServiceErrorHandler(response.WriteServiceError)
+
+	userRepo := repository.NewUserRepository(postgres.Client)
+	userService := service.NewUserService(userRepo)
+
+	profileRepo := repository.NewProfileRepository(postgres.Client)
+	profileService := service.NewProfileService(profileRepo)
+
+	refreshTokenRepo := repository.NewRefreshTokenRepository(postgres.Client)
+	refreshTokenService := service.NewRefreshTokenService(refreshTokenRepo)
+
+	tokenRevocationRepo := repository.NewCachedTokenRevocationRepository(repository.NewTokenRevocationRepository(postgres.Client), cfg.TokenRevocationCacheTTL)
+	tokenRevocationService := service.NewTokenRevocationService(tokenRevocationRepo)
+
+	userTokenRepo := repository.NewUserTokenRepository(postgres.Client)
+	userTokenService := service.NewUserTokenService(userTokenRepo)
+
+	loginThrottleRepo := repository.NewLoginThrottleRepository(postgres.Client)
+	loginLockoutRepo := repository.NewLoginLockoutRepository(postgres.Client)
+	loginThrottleService := service.NewLoginThrottleService(loginThrottleRepo, loginLockoutRepo, cfg.GetLoginAccountThrottlePolicy(), cfg.GetLoginIPThrottlePolicy())
+	twoFactorRepo := repository.NewTwoFactorRepository(postgres.Client)
+	recoveryCodeRepo := repository.NewRecoveryCodeRepository(postgres.Client)
+	twoFactorService := service.NewTwoFactorService(twoFactorRepo, recoveryCodeRepo)
+
+	sessionRepo := repository.NewSessionRepository(postgres.Client)
+	sessionService := service.NewSessionService(sessionRepo)
+
+	phoneOTPRepo := repository.NewPhoneOTPRepository(postgres.Client)
+	phoneOTPService := service.NewPhoneOTPService(phoneOTPRepo, cfg.GetPhoneOTPPolicy())
+
+	userIdentityRepo := repository.NewUserIdentityRepository(postgres.Client)
+	userIdentityService := service.NewUserIdentityService(userIdentityRepo)
+
+	unitOfWork := database.NewUnitOfWork(postgres.Client)
+	t.mailbox = &mailbox{}
+	t.smsOutbox = &smsOutbox{}
+	t.oidcIssuer = oidctest.NewIssuer()
+	identityProvider := auth.NewOIDCProvider(oidcProviderName, t.oidcIssuer.URL, oidcClientID, http.DefaultClient)
+
+	t.blobDir, err = os.MkdirTemp("", "blobs")
+	t.Require().NoError(err)
+
+	// URLs only need to be verified by the tests themselves, so an ephemeral key is enough when no signing key is configured.
+	blobStore, err := storage.NewLocalBlobStore(t.blobDir, cfg.BlobBaseURL, cfg.BlobURLSigningKey, true)
+	t.Require().NoError(err)
+
+	photoService := service.NewPhotoService(blobStore, imaging.NewProcessor(), cfg.GetPhotoPolicy())
+
+	// Tokens only need to be verified by the tests themselves, so an ephemeral key is enough when no key file is configured.
+	keySet, err := auth.LoadKeySet(cfg.JWTSigningKeyFile, cfg.JWTVerificationKeyFiles, true)
+	t.Require().NoError(err)
+
+	jwt := auth.NewJWTClaims(cfg.JWTExpiration, keySet)
+	passwordHasher := password.NewPasswordHasher(cfg)
+	userUsecase := usecase.NewUserUsecase(
+		userService, profileService, refreshTokenService, tokenRevocationService, userTokenService,
+		loginThrottleService, twoFactorService, sessionService, phoneOTPService, userIdentityService,
+		photoService, unitOfWork, cfg, jwt, t.mailbox, t.smsOutbox, passwordHasher, []domain.IdentityProvider{identityProvider},
+	)
+	userController := controller.NewUserController(userUsecase)
+	keyUsecase := usecase.NewKeyUsecase(jwt)
+	keyController := controller.NewKeyController(keyUsecase)
+	routes.RegisterKeyRoutes(container, keyController)
+	blobUsecase := usecase.NewBlobUsecase(blobStore, blobStore)
+	blobController := controller.NewBlobController(blobUsecase)
+	routes.RegisterBlobRoutes(container, cfg.BasePath, blobController)
+
+	authFilter := filter.NewAuthFilter(jwt, userService, tokenRevocationService, sessionService)
+	routes.RegisterUserRoutes(container, cfg.BasePath, authFilter.Authenticate, userController)
+
+	entitlementRepo := repository.NewEntitlementRepository(postgres.Client)
+	entitlementService := service.NewEntitlementService(entitlementRepo)
+
+	activityRepo := repository.NewActivityRepository(postgres.Client)
+	activityService := service.NewActivityService(activityRepo)
+	activityCounterRepo := repository.NewActivityCounterRepository(postgres.Client)
+	activityCounterService := service.NewActivityCounterService(activityCounterRepo)
+	swipeUsecase := usecase.NewSwipeUsecase(profileService, activityService, activityCounterService, entitlementService, unitOfWork, cfg)
+	swipeController := controller.NewSwipeController(swipeUsecase)
+	routes.RegisterSwipeRoutes(container, cfg.BasePath, authFilter.Authenticate, swipeController)
+
+	discoveryUsecase := usecase.NewDiscoveryUsecase(profileService, entitlementService, photoService, cfg)
+	profileUsecase := usecase.NewProfileUsecase(userService, profileService, entitlementService, photoService, unitOfWork)
+	profileController := controller.NewProfileController(discoveryUsecase, profileUsecase)
+	routes.RegisterProfileRoutes(container, cfg.BasePath, authFilter.Authenticate, profileController)
+
+	matchRepo := repository.NewMatchRepository(postgres.Client)
+	matchService := service.NewMatchService(matchRepo)
+	matchUsecase := usecase.NewMatchUsecase(matchService, photoService)
+	matchController := controller.NewMatchController(matchUsecase)
+	routes.RegisterMatchRoutes(container, cfg.BasePath, authFilter.Authenticate, matchController)
+
+	subscriptionPackageRepo := repository.NewSubscriptionPackageRepository(postgres.Client)
+	subscriptionPackageService := service.NewSubscriptionPackageService(subscriptionPackageRepo)
+
+	subscriptionRepo := repository.NewSubscriptionRepository(postgres.Client)
+	subscriptionService := service.NewSubscriptionService(subscriptionRepo)
+
+	paymentGateway := payment.NewFakePaymentGateway()
+	subscriptionUsecase := usecase.NewSubscriptionUsecase(subscriptionPackageService, subscriptionService, paymentGateway)
+	subscriptionController := controller.NewSubscriptionController(subscriptionUsecase)
+	routes.RegisterSubscriptionRoutes(container, cfg.BasePath, authFilter.Authenticate, subscriptionController)
+
+	roleRepo := repository.NewRoleRepository(postgres.Client)
+	roleService := service.NewRoleService(roleRepo)
+	authorizationFilter := filter.NewAuthorizationFilter(roleService)
+	adminUsecase := usecase.NewAdminUsecase(userService, photoService)
+	adminController := controller.NewAdminController(adminUsecase)
+	routes.RegisterAdminRoutes(container, cfg.BasePath, authFilter.Authenticate, authorizationFilter.Require, adminController)
+
+	t.container = container
+}
+
+// TearDownSuite is a method for shutting down what the integration tests suite started.
+func (t *Test) TearDownSuite() {
+	t.oidcIssuer.Close()
+	_ = os.RemoveAll(t.blobDir)
+}
+
+func (t *Test) execute(request *http.Request, _ ...interface{}) (*httptest.ResponseRecorder, interface{}, error) {
+	return call(t.container).to(request).execute()
+}
+
+func (t *Test) executePost(url string, request interface{}) (*httptest.ResponseRecorder, error) {
+	response, _, err := t.execute(gorequest.New().
+		Post(url).
+		Type(restful.MIME_JSON).
+		Send(request).
+		MakeRequest())
+
+	return response, err
+}
+
+func (t *Test) executeAuthorizedPost(url, token string, request interface{}) (*httptest.ResponseRecorder, error) {
+	response, _, err := t.execute(gorequest.New().
+		Post(url).
+		Type(restful.MIME_JSON).
+		Set(AuthorizationHeader, "Bearer "+token).
+		Send(request).
+		MakeRequest())
+
+	return response, err
+}
+
+func (t *Test) executeAuthorizedPut(url, token string, request interface{}) (*httptest.ResponseRecorder, error) {
+	response, _, err := t.execute(gorequest.New().
+		Put(url).
+		Type(restful.MIME_JSON).
+		Set(AuthorizationHeader, "Bearer "+token).
+		Send(request).
+		MakeRequest())
+
+	return response, err
+}
+
+func (t *Test) executeAuthorizedPatch(url, token string, request interface{}) (*httptest.ResponseRecorder, error) {
+	response, _, err := t.execute(gorequest.New().
+		Patch(url).
+		Type(restful.MIME_JSON).
+		Set(AuthorizationHeader, "Bearer "+token).
+		Send(request).
+		MakeRequest())
+
+	return response, err
+}
+
+func (t *Test) executeGet(url, token string) (*httptest.ResponseRecorder, error) {
+	response, _, err := t.execute(gorequest.New().
+		Get(url).
+		Set(AuthorizationHeader, "Bearer "+token).
+		MakeRequest())
+
+	return response, err
+}
+
+func (t *Test) executeDelete(url, token string) (*httptest.ResponseRecorder, error) {
+	response, _, err := t.execute(gorequest.New().
+		Delete(url).
+		Set(AuthorizationHeader, "Bearer "+token).
+		MakeRequest())
+
+	return response, err
+}
+
+func loadConfig() (*config.Config, error) {
+	var err error
+
+	once.Do(func() {
+		var filePath string
+
+		filePath, err = util.GetFile(".env")
+		if err == nil {
+			err = godotenv.Load(filePath)
+			if err != nil {
+				return
+			}
+		}
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return config.ParseConfig()
+}
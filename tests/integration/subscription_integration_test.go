@@ -0,0 +1,83 @@
+package integration_test
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+	"dealls-technical-test-dating-service/internal/infrastructure/payment"
+)
+
+const (
+	subscriptionURL         = "/dating/v1/subscriptions"
+	subscriptionPackagesURL = "/dating/v1/subscriptions/packages"
+	currentSubscriptionURL  = "/dating/v1/subscriptions/current"
+)
+
+func (t *Test) getSubscriptionPackages(token string) []entity.SubscriptionPackage {
+	response, err := t.executeGet(subscriptionPackagesURL, token)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	subscriptionPackages := []entity.SubscriptionPackage{}
+	err = json.Unmarshal(response.Body.Bytes(), &subscriptionPackages)
+	t.Require().NoError(err)
+	t.Require().NotEmpty(subscriptionPackages)
+
+	return subscriptionPackages
+}
+
+func (t *Test) Test_Subscription_Packages_Success() {
+	token := t.signupAndLogin()
+	t.getSubscriptionPackages(token)
+}
+
+func (t *Test) Test_Subscription_Purchase_Failed_Payment_Declined() {
+	token := t.signupAndLogin()
+	subscriptionPackages := t.getSubscriptionPackages(token)
+
+	request := entity.PurchaseSubscriptionRequest{
+		SubscriptionPackageID: subscriptionPackages[0].ID,
+		PaymentToken:          payment.DeclinedPaymentToken,
+	}
+	response, err := t.executeAuthorizedPost(subscriptionURL, token, request)
+	t.Require().Equal(http.StatusPaymentRequired, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Subscription_Current_Failed_Not_Found() {
+	token := t.signupAndLogin()
+
+	response, err := t.executeGet(currentSubscriptionURL, token)
+	t.Require().Equal(http.StatusNotFound, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Subscription_Purchase_And_Cancel_Success() {
+	token := t.signupAndLogin()
+	subscriptionPackages := t.getSubscriptionPackages(token)
+
+	request := entity.PurchaseSubscriptionRequest{
+		SubscriptionPackageID: subscriptionPackages[0].ID,
+		PaymentToken:          "tok_visa",
+	}
+	response, err := t.executeAuthorizedPost(subscriptionURL, token, request)
+	t.Require().Equal(http.StatusCreated, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeAuthorizedPost(subscriptionURL, token, request)
+	t.Require().Equal(http.StatusConflict, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeGet(currentSubscriptionURL, token)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeDelete(currentSubscriptionURL, token)
+	t.Require().Equal(http.StatusNoContent, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeGet(currentSubscriptionURL, token)
+	t.Require().Equal(http.StatusNotFound, response.Code)
+	t.Require().NoError(err)
+}
@@ -0,0 +1,40 @@
+package integration_test
+
+import (
+	"math"
+	"net/http"
+
+	"dealls-technical-test-dating-service/internal/domain/entity"
+)
+
+const swipeURL = "/dating/v1/swipes"
+
+func (t *Test) Test_Swipe_Failed_Unauthorized() {
+	response, err := t.executeAuthorizedPost(swipeURL, "", nil)
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Swipe_Failed_Invalid_Action() {
+	token := t.signupAndLogin()
+
+	request := entity.SwipeRequest{
+		ProfileID: 1,
+		Action:    "ACTION",
+	}
+	response, err := t.executeAuthorizedPost(swipeURL, token, request)
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Swipe_Failed_Profile_Not_Found() {
+	token := t.signupAndLogin()
+
+	request := entity.SwipeRequest{
+		ProfileID: math.MaxInt32,
+		Action:    entity.ActionLike,
+	}
+	response, err := t.executeAuthorizedPost(swipeURL, token, request)
+	t.Require().Equal(http.StatusNotFound, response.Code)
+	t.Require().NoError(err)
+}
@@ -1,18 +1,47 @@
 package integration_test
 
 import (
+	"encoding/json"
+	"fmt"
+
 	"dealls-technical-test-dating-service/internal/domain/entity"
+	problem "dealls-technical-test-dating-service/internal/interface/response"
+	"dealls-technical-test-dating-service/pkg/constant"
 	"dealls-technical-test-dating-service/pkg/util"
 	"net/http"
+	"net/url"
+	"regexp"
 	"testing"
 	"time"
 
+	"github.com/dgrijalva/jwt-go"
 	"github.com/stretchr/testify/suite"
 )
 
 const (
-	signupURL = "/dating/v1/users/signup"
-	loginURL  = "/dating/v1/users/login"
+	signupURL    = "/dating/v1/users/signup"
+	loginURL     = "/dating/v1/users/login"
+	meURL        = "/dating/v1/users/me"
+	refreshURL   = "/dating/v1/users/token/refresh"
+	logoutURL    = "/dating/v1/users/logout"
+	logoutAllURL = "/dating/v1/users/logout-all"
+	jwksURL      = "/.well-known/jwks.json"
+	verifyURL    = "/dating/v1/users/verify-email"
+	resendURL    = "/dating/v1/users/verify-email/resend"
+	forgotURL    = "/dating/v1/users/password/forgot"
+	resetURL     = "/dating/v1/users/password/reset"
+	passwordURL  = "/dating/v1/users/me/password"
+	emailURL     = "/dating/v1/users/me/email"
+	confirmURL   = "/dating/v1/users/email/confirm"
+	twoFactorURL = "/dating/v1/users/me/2fa"
+	enableURL    = "/dating/v1/users/me/2fa/confirm"
+	loginCodeURL = "/dating/v1/users/login/2fa"
+	sessionsURL  = "/dating/v1/users/me/sessions"
+	phoneOTPURL  = "/dating/v1/users/phone/otp"
+	phoneSignURL = "/dating/v1/users/phone/signup"
+	phoneLogURL  = "/dating/v1/users/phone/login"
+	oidcLoginURL = "/dating/v1/users/login/oidc"
+	oidcNonceURL = "/dating/v1/users/login/oidc/" + oidcProviderName + "/nonce"
 )
 
 func TestSuite(t *testing.T) {
@@ -26,6 +55,27 @@ func (t *Test) Test_Signup_Failed_Bad_Request() {
 	t.Require().NoError(err)
 }
 
+func (t *Test) Test_Signup_Failed_Every_Invalid_Field_Reported() {
+	response, err := t.executePost(signupURL, entity.UserSignupRequest{})
+	t.Require().NoError(err)
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().Equal(problem.MIMEProblemJSON, response.Header().Get("Content-Type"))
+	t.Require().NotEmpty(response.Header().Get(constant.RequestIDHeader))
+
+	var body problem.Problem
+	err = json.Unmarshal(response.Body.Bytes(), &body)
+	t.Require().NoError(err)
+	t.Require().Equal(http.StatusBadRequest, body.Status)
+	t.Require().Equal(signupURL, body.Instance)
+	t.Require().Equal(response.Header().Get(constant.RequestIDHeader), body.RequestID)
+
+	fields := make([]string, 0, len(body.Errors))
+	for _, fieldErr := range body.Errors {
+		fields = append(fields, fieldErr.Field)
+	}
+	t.Require().Equal([]string{"email", "password", "name", "birth_date", "gender", "location"}, fields)
+}
+
 func (t *Test) Test_Signup_Failed_Email_Empty() {
 	request := entity.UserSignupRequest{
 		Email: "",
@@ -217,8 +267,9 @@ func (t *Test) Test_Login_Failed_User_Not_Found() {
 		Password: "password",
 	}
 	response, err := t.executePost(loginURL, request)
-	t.Require().Equal(http.StatusNotFound, response.Code)
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
 	t.Require().NoError(err)
+	t.Require().Contains(response.Body.String(), constant.InvalidEmailPassword)
 }
 
 func (t *Test) Test_Login_Failed_Invalid_Password() {
@@ -241,7 +292,20 @@ func (t *Test) Test_Login_Failed_Invalid_Password() {
 		Password: "passwodr",
 	}
 	response, err = t.executePost(loginURL, loginReq)
-	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Login_Failed_Locked_Out() {
+	email := t.me(t.signupAndLogin()).Email
+	for i := 0; i < 5; i++ {
+		response, err := t.executePost(loginURL, entity.UserLoginRequest{Email: email, Password: "wrong-password"})
+		t.Require().Equal(http.StatusUnauthorized, response.Code)
+		t.Require().NoError(err)
+	}
+
+	response, err := t.executePost(loginURL, entity.UserLoginRequest{Email: email, Password: "password"})
+	t.Require().Equal(http.StatusTooManyRequests, response.Code)
 	t.Require().NoError(err)
 }
 
@@ -268,3 +332,686 @@ func (t *Test) Test_Login_Success() {
 	t.Require().Equal(http.StatusOK, response.Code)
 	t.Require().NoError(err)
 }
+
+func (t *Test) Test_Me_Failed_Missing_Token() {
+	response, err := t.executeGet(meURL, "")
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Me_Failed_Invalid_Token() {
+	response, err := t.executeGet(meURL, "token")
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Me_Success() {
+	token := t.signupAndLogin()
+
+	response, err := t.executeGet(meURL, token)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_JWKS_Success() {
+	response, err := t.executeGet(jwksURL, "")
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	var jwks entity.JSONWebKeySet
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &jwks))
+	t.Require().NotEmpty(jwks.Keys)
+	t.Require().NotEmpty(jwks.Keys[0].Kid)
+}
+
+func (t *Test) Test_Refresh_Token_Failed_Unknown_Token() {
+	response, err := t.executePost(refreshURL, entity.RefreshTokenRequest{RefreshToken: "token"})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Refresh_Token_Success() {
+	loginResp := t.signupAndLoginResponse()
+
+	refreshResp := t.refresh(loginResp.RefreshToken)
+	t.Require().NotEmpty(refreshResp.Token)
+	t.Require().NotEqual(loginResp.RefreshToken, refreshResp.RefreshToken)
+
+	response, err := t.executeGet(meURL, refreshResp.Token)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Refresh_Token_Ends_Previous_Access_Token() {
+	loginResp := t.signupAndLoginResponse()
+	t.refresh(loginResp.RefreshToken)
+
+	response, err := t.executeGet(meURL, loginResp.Token)
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Refresh_Token_Failed_Reused_Token_Revokes_Family() {
+	loginResp := t.signupAndLoginResponse()
+	refreshResp := t.refresh(loginResp.RefreshToken)
+
+	response, err := t.executePost(refreshURL, entity.RefreshTokenRequest{RefreshToken: loginResp.RefreshToken})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(refreshURL, entity.RefreshTokenRequest{RefreshToken: refreshResp.RefreshToken})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Logout_Failed_Missing_Token() {
+	response, err := t.executePost(logoutURL, nil)
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Logout_Success() {
+	loginResp := t.signupAndLoginResponse()
+
+	response, err := t.executeAuthorizedPost(logoutURL, loginResp.Token, entity.LogoutRequest{RefreshToken: loginResp.RefreshToken})
+	t.Require().Equal(http.StatusNoContent, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeGet(meURL, loginResp.Token)
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(refreshURL, entity.RefreshTokenRequest{RefreshToken: loginResp.RefreshToken})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Logout_All_Success() {
+	loginResp := t.signupAndLoginResponse()
+	refreshResp := t.refresh(loginResp.RefreshToken)
+
+	response, err := t.executeAuthorizedPost(logoutAllURL, refreshResp.Token, nil)
+	t.Require().Equal(http.StatusNoContent, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeGet(meURL, refreshResp.Token)
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(refreshURL, entity.RefreshTokenRequest{RefreshToken: refreshResp.RefreshToken})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Sessions_Success() {
+	loginResp := t.signupAndLoginResponse()
+	email := t.me(loginResp.Token).Email
+
+	response, err := t.executePost(loginURL, entity.UserLoginRequest{Email: email, Password: "password"})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	otherLoginResp := entity.UserLoginResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &otherLoginResp))
+
+	sessionsResp := t.sessions(loginResp.Token)
+	t.Require().Len(sessionsResp.Sessions, 2)
+
+	var otherSessionID int
+	for _, session := range sessionsResp.Sessions {
+		if !session.Current {
+			otherSessionID = session.ID
+		}
+	}
+	t.Require().NotZero(otherSessionID)
+
+	response, err = t.executeDelete(fmt.Sprintf("%s/%d", sessionsURL, otherSessionID), loginResp.Token)
+	t.Require().Equal(http.StatusNoContent, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeGet(meURL, otherLoginResp.Token)
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(refreshURL, entity.RefreshTokenRequest{RefreshToken: otherLoginResp.RefreshToken})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	t.Require().Len(t.sessions(loginResp.Token).Sessions, 1)
+}
+
+func (t *Test) Test_Delete_Session_Failed_Not_Found() {
+	token := t.signupAndLogin()
+	otherSessionID := t.sessions(t.signupAndLogin()).Sessions[0].ID
+
+	response, err := t.executeDelete(fmt.Sprintf("%s/%d", sessionsURL, otherSessionID), token)
+	t.Require().Equal(http.StatusNotFound, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) sessions(token string) entity.SessionsResponse {
+	response, err := t.executeGet(sessionsURL, token)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	sessionsResp := entity.SessionsResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &sessionsResp))
+
+	return sessionsResp
+}
+
+func (t *Test) refresh(refreshToken string) entity.UserLoginResponse {
+	response, err := t.executePost(refreshURL, entity.RefreshTokenRequest{RefreshToken: refreshToken})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	refreshResp := entity.UserLoginResponse{}
+	err = json.Unmarshal(response.Body.Bytes(), &refreshResp)
+	t.Require().NoError(err)
+
+	return refreshResp
+}
+
+func (t *Test) Test_Verify_Email_Failed_Invalid_Token() {
+	response, err := t.executePost(verifyURL, entity.VerifyEmailRequest{Token: "token"})
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Verify_Email_Success_Once() {
+	loginResp := t.signupAndLoginResponse()
+	user := t.me(loginResp.Token)
+	t.Require().False(user.EmailVerified)
+
+	token := t.mailedToken(user.Email)
+	response, err := t.executePost(verifyURL, entity.VerifyEmailRequest{Token: token})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+	t.Require().True(t.me(loginResp.Token).EmailVerified)
+
+	response, err = t.executePost(verifyURL, entity.VerifyEmailRequest{Token: token})
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Resend_Verification_Email_Accepted_For_Unknown_Email() {
+	response, err := t.executePost(resendURL, entity.ResendVerificationEmailRequest{Email: util.RandomString(6) + "@email.com"})
+	t.Require().Equal(http.StatusAccepted, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Resend_Verification_Email_Throttled() {
+	loginResp := t.signupAndLoginResponse()
+	user := t.me(loginResp.Token)
+	sent := t.mailbox.lastTo(user.Email)
+
+	response, err := t.executePost(resendURL, entity.ResendVerificationEmailRequest{Email: user.Email})
+	t.Require().Equal(http.StatusAccepted, response.Code)
+	t.Require().NoError(err)
+	t.Require().Same(sent, t.mailbox.lastTo(user.Email))
+}
+
+func (t *Test) Test_Forgot_Password_Accepted_For_Unknown_Email() {
+	response, err := t.executePost(forgotURL, entity.ForgotPasswordRequest{Email: util.RandomString(6) + "@email.com"})
+	t.Require().Equal(http.StatusAccepted, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Reset_Password_Failed_Invalid_Token() {
+	response, err := t.executePost(resetURL, entity.ResetPasswordRequest{Token: "token", Password: "new-password"})
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Reset_Password_Success_Once() {
+	loginResp := t.signupAndLoginResponse()
+	email := t.me(loginResp.Token).Email
+
+	response, err := t.executePost(forgotURL, entity.ForgotPasswordRequest{Email: email})
+	t.Require().Equal(http.StatusAccepted, response.Code)
+	t.Require().NoError(err)
+
+	token := t.mailedToken(email)
+	response, err = t.executePost(resetURL, entity.ResetPasswordRequest{Token: token, Password: "new-password"})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(resetURL, entity.ResetPasswordRequest{Token: token, Password: "other-password"})
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(refreshURL, entity.RefreshTokenRequest{RefreshToken: loginResp.RefreshToken})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(loginURL, entity.UserLoginRequest{Email: email, Password: "password"})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(loginURL, entity.UserLoginRequest{Email: email, Password: "new-password"})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Change_Password_Failed_Incorrect_Current_Password() {
+	token := t.signupAndLogin()
+	response, err := t.executeAuthorizedPut(passwordURL, token, entity.ChangePasswordRequest{CurrentPassword: "wrong-password", NewPassword: "new-password"})
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Change_Password_Success() {
+	loginResp := t.signupAndLoginResponse()
+	email := t.me(loginResp.Token).Email
+
+	response, err := t.executeAuthorizedPut(passwordURL, loginResp.Token, entity.ChangePasswordRequest{CurrentPassword: "password", NewPassword: "new-password"})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	changeResp := entity.UserLoginResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &changeResp))
+	t.me(changeResp.Token)
+
+	response, err = t.executePost(refreshURL, entity.RefreshTokenRequest{RefreshToken: loginResp.RefreshToken})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(loginURL, entity.UserLoginRequest{Email: email, Password: "new-password"})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Change_Email_Failed_Already_Used() {
+	token := t.signupAndLogin()
+	otherEmail := t.me(t.signupAndLogin()).Email
+	response, err := t.executeAuthorizedPut(emailURL, token, entity.ChangeEmailRequest{Email: otherEmail, Password: "password"})
+	t.Require().Equal(http.StatusConflict, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Change_Email_Success() {
+	token := t.signupAndLogin()
+	oldEmail := t.me(token).Email
+	newEmail := util.RandomString(6) + "@email.com"
+
+	response, err := t.executeAuthorizedPut(emailURL, token, entity.ChangeEmailRequest{Email: newEmail, Password: "password"})
+	t.Require().Equal(http.StatusAccepted, response.Code)
+	t.Require().NoError(err)
+
+	// The current email keeps working until the new one is confirmed.
+	response, err = t.executePost(loginURL, entity.UserLoginRequest{Email: oldEmail, Password: "password"})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(confirmURL, entity.ConfirmEmailChangeRequest{Token: t.mailedToken(newEmail)})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+	t.Require().Equal("Your email address was changed", t.mailbox.lastTo(oldEmail).Subject)
+
+	response, err = t.executePost(loginURL, entity.UserLoginRequest{Email: oldEmail, Password: "password"})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(loginURL, entity.UserLoginRequest{Email: newEmail, Password: "password"})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Confirm_Two_Factor_Failed_Wrong_Code() {
+	token := t.signupAndLogin()
+	response, err := t.executeAuthorizedPost(twoFactorURL, token, nil)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executeAuthorizedPost(enableURL, token, entity.ConfirmTwoFactorRequest{Code: "000000"})
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Two_Factor_Login_Success() {
+	token := t.signupAndLogin()
+	email := t.me(token).Email
+
+	response, err := t.executeAuthorizedPost(twoFactorURL, token, nil)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	enrollResp := entity.TwoFactorEnrollmentResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &enrollResp))
+	t.Require().Contains(enrollResp.OTPAuthURI, enrollResp.Secret)
+
+	step := util.TOTPStep(time.Now())
+	response, err = t.executeAuthorizedPost(enableURL, token, entity.ConfirmTwoFactorRequest{Code: t.totpCode(enrollResp.Secret, step)})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	confirmResp := entity.RecoveryCodesResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &confirmResp))
+	t.Require().Len(confirmResp.RecoveryCodes, 10)
+
+	response, err = t.executeAuthorizedPost(twoFactorURL, token, nil)
+	t.Require().Equal(http.StatusConflict, response.Code)
+	t.Require().NoError(err)
+
+	// The code that confirmed the enrollment cannot be used again, unlike the code of the next time step.
+	challenge := t.twoFactorChallenge(email)
+	response, err = t.executePost(loginCodeURL, entity.TwoFactorLoginRequest{ChallengeToken: challenge, Code: t.totpCode(enrollResp.Secret, step)})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(loginCodeURL, entity.TwoFactorLoginRequest{ChallengeToken: challenge, Code: t.totpCode(enrollResp.Secret, step+1)})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	loginResp := entity.UserLoginResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &loginResp))
+	t.me(loginResp.Token)
+
+	response, err = t.executePost(loginCodeURL, entity.TwoFactorLoginRequest{ChallengeToken: challenge, RecoveryCode: confirmResp.RecoveryCodes[0]})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(loginCodeURL, entity.TwoFactorLoginRequest{ChallengeToken: t.twoFactorChallenge(email), RecoveryCode: confirmResp.RecoveryCodes[0]})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(loginCodeURL, entity.TwoFactorLoginRequest{ChallengeToken: t.twoFactorChallenge(email), RecoveryCode: confirmResp.RecoveryCodes[0]})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Phone_Signup_Failed_Wrong_Code() {
+	phone := randomPhone()
+	t.requestPhoneOTP(phone)
+
+	response, err := t.executePost(phoneSignURL, t.phoneSignupRequest(phone, "000000"))
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Phone_Login_Failed_Not_Registered() {
+	phone := randomPhone()
+	code := t.requestPhoneOTP(phone)
+
+	// Without the code, an unregistered phone number looks like a wrong code.
+	response, err := t.executePost(phoneLogURL, entity.PhoneLoginRequest{Phone: phone, Code: "000000"})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(phoneLogURL, entity.PhoneLoginRequest{Phone: phone, Code: code})
+	t.Require().Equal(http.StatusNotFound, response.Code)
+	t.Require().NoError(err)
+
+	// The code is kept, so that it can sign up right away.
+	response, err = t.executePost(phoneSignURL, t.phoneSignupRequest(phone, code))
+	t.Require().Equal(http.StatusCreated, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Phone_Signup_And_Login_Success() {
+	phone := randomPhone()
+	signupCode := t.requestPhoneOTP(phone)
+	response, err := t.executePost(phoneSignURL, t.phoneSignupRequest(phone, signupCode))
+	t.Require().Equal(http.StatusCreated, response.Code)
+	t.Require().NoError(err)
+
+	signupResp := entity.UserLoginResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &signupResp))
+	user := t.me(signupResp.Token)
+	t.Require().Equal(phone, user.Phone)
+	t.Require().True(user.PhoneVerified)
+	t.Require().Empty(user.Email)
+
+	// Codes are accepted once, so the code of the sign up cannot log in.
+	response, err = t.executePost(phoneLogURL, entity.PhoneLoginRequest{Phone: phone, Code: signupCode})
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+
+	// A sign up refused for a phone number that has signed up keeps the code, so that it can log in.
+	code := t.requestPhoneOTP(phone)
+	response, err = t.executePost(phoneSignURL, t.phoneSignupRequest(phone, code))
+	t.Require().Equal(http.StatusConflict, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(phoneLogURL, entity.PhoneLoginRequest{Phone: phone, Code: code})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	loginResp := entity.UserLoginResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &loginResp))
+	t.Require().Equal(user.ID, t.me(loginResp.Token).ID)
+
+	// Users without a password can add an email address without one.
+	email := util.RandomString(6) + "@email.com"
+	response, err = t.executeAuthorizedPut(emailURL, loginResp.Token, entity.ChangeEmailRequest{Email: email})
+	t.Require().Equal(http.StatusAccepted, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(confirmURL, entity.ConfirmEmailChangeRequest{Token: t.mailedToken(email)})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+	t.Require().Equal(email, t.me(loginResp.Token).Email)
+}
+
+func (t *Test) Test_Identity_Provider_Nonce_Failed_Not_Found() {
+	response, err := t.executePost("/dating/v1/users/login/oidc/other/nonce", nil)
+	t.Require().Equal(http.StatusNotFound, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Identity_Provider_Login_Failed_Wrong_Nonce() {
+	nonce := t.oidcNonce()
+	idToken := t.oidcIssuer.IDToken(jwt.MapClaims{"aud": oidcClientID, "sub": util.RandomString(12), "nonce": "other-nonce", "email": "user@email.com", "email_verified": true})
+	req := entity.IdentityProviderLoginRequest{Provider: oidcProviderName, IDToken: idToken, Nonce: nonce}
+	response, err := t.executePost(oidcLoginURL, req)
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Identity_Provider_Login_Failed_Nonce_Not_Issued() {
+	idToken := t.oidcIssuer.IDToken(jwt.MapClaims{"aud": oidcClientID, "sub": util.RandomString(12), "nonce": "nonce", "email": "user@email.com", "email_verified": true})
+	req := entity.IdentityProviderLoginRequest{Provider: oidcProviderName, IDToken: idToken, Nonce: "nonce"}
+	response, err := t.executePost(oidcLoginURL, req)
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Identity_Provider_Login_Failed_Nonce_Replayed() {
+	nonce := t.oidcNonce()
+	email := util.RandomString(6) + "@email.com"
+	idToken := t.oidcIssuer.IDToken(jwt.MapClaims{"aud": oidcClientID, "sub": util.RandomString(12), "nonce": nonce, "email": email, "email_verified": true, "name": "Integration Test"})
+	req := entity.IdentityProviderLoginRequest{
+		Provider: oidcProviderName, IDToken: idToken, Nonce: nonce, BirthDate: time.Now().Format("2006-01-02"), Gender: "FEMALE", Location: "Indonesia",
+	}
+
+	response, err := t.executePost(oidcLoginURL, req)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(oidcLoginURL, req)
+	t.Require().Equal(http.StatusUnauthorized, response.Code)
+	t.Require().NoError(err)
+}
+
+func (t *Test) Test_Identity_Provider_Login_Signup_Success() {
+	subject := util.RandomString(12)
+	email := util.RandomString(6) + "@email.com"
+	nonce := t.oidcNonce()
+	idToken := t.oidcIssuer.IDToken(jwt.MapClaims{"aud": oidcClientID, "sub": subject, "nonce": nonce, "email": email, "email_verified": true, "name": "Integration Test"})
+	req := entity.IdentityProviderLoginRequest{Provider: oidcProviderName, IDToken: idToken, Nonce: nonce}
+
+	response, err := t.executePost(oidcLoginURL, req)
+	t.Require().Equal(http.StatusBadRequest, response.Code)
+	t.Require().NoError(err)
+
+	req.BirthDate = time.Now().Format("2006-01-02")
+	req.Gender = "FEMALE"
+	req.Location = "Indonesia"
+	response, err = t.executePost(oidcLoginURL, req)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	loginResp := entity.UserLoginResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &loginResp))
+	user := t.me(loginResp.Token)
+	t.Require().Equal(email, user.Email)
+	t.Require().Equal("Integration Test", user.Name)
+	t.Require().True(user.EmailVerified)
+
+	// The account stays linked to the user after its email address changes at the provider.
+	nonce = t.oidcNonce()
+	idToken = t.oidcIssuer.IDToken(jwt.MapClaims{"aud": oidcClientID, "sub": subject, "nonce": nonce, "email": "other-" + email, "email_verified": true})
+	response, err = t.executePost(oidcLoginURL, entity.IdentityProviderLoginRequest{Provider: oidcProviderName, IDToken: idToken, Nonce: nonce})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	loginResp = entity.UserLoginResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &loginResp))
+	t.Require().Equal(user.ID, t.me(loginResp.Token).ID)
+}
+
+func (t *Test) Test_Identity_Provider_Login_Link_Success() {
+	token := t.signupAndLogin()
+	user := t.me(token)
+	nonce := t.oidcNonce()
+	idToken := t.oidcIssuer.IDToken(jwt.MapClaims{"aud": oidcClientID, "sub": util.RandomString(12), "nonce": nonce, "email": user.Email, "email_verified": true})
+	req := entity.IdentityProviderLoginRequest{Provider: oidcProviderName, IDToken: idToken, Nonce: nonce}
+
+	// An account is only linked to a user who proved owning the email address too.
+	response, err := t.executePost(oidcLoginURL, req)
+	t.Require().Equal(http.StatusConflict, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(verifyURL, entity.VerifyEmailRequest{Token: t.mailedToken(user.Email)})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	response, err = t.executePost(oidcLoginURL, req)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	loginResp := entity.UserLoginResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &loginResp))
+	t.Require().Equal(user.ID, t.me(loginResp.Token).ID)
+}
+
+func (t *Test) oidcNonce() string {
+	response, err := t.executePost(oidcNonceURL, nil)
+	t.Require().Equal(http.StatusCreated, response.Code)
+	t.Require().NoError(err)
+
+	nonceResp := entity.IdentityProviderNonceResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &nonceResp))
+	t.Require().NotEmpty(nonceResp.Nonce)
+
+	return nonceResp.Nonce
+}
+
+func (t *Test) requestPhoneOTP(phone string) string {
+	response, err := t.executePost(phoneOTPURL, entity.PhoneOTPRequest{Phone: phone})
+	t.Require().Equal(http.StatusAccepted, response.Code)
+	t.Require().NoError(err)
+
+	return t.smsCode(phone)
+}
+
+func (t *Test) smsCode(phone string) string {
+	sms := t.smsOutbox.lastTo(phone)
+	t.Require().NotNil(sms)
+
+	code := regexp.MustCompile(`\b[0-9]{6}\b`).FindString(sms.Body)
+	t.Require().NotEmpty(code)
+
+	return code
+}
+
+func (t *Test) phoneSignupRequest(phone, code string) entity.PhoneSignupRequest {
+	return entity.PhoneSignupRequest{
+		Phone:     phone,
+		Code:      code,
+		Name:      "Integration Test",
+		BirthDate: time.Now().Format("2006-01-02"),
+		Gender:    "FEMALE",
+		Location:  "Indonesia",
+	}
+}
+
+func randomPhone() string {
+	return fmt.Sprintf("+62812%08d", time.Now().UnixNano()%100000000)
+}
+
+func (t *Test) twoFactorChallenge(email string) string {
+	response, err := t.executePost(loginURL, entity.UserLoginRequest{Email: email, Password: "password"})
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	loginResp := entity.UserLoginResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &loginResp))
+	t.Require().Empty(loginResp.Token)
+	t.Require().NotEmpty(loginResp.ChallengeToken)
+
+	return loginResp.ChallengeToken
+}
+
+func (t *Test) totpCode(secret string, step int64) string {
+	code, err := util.TOTPCode(secret, step)
+	t.Require().NoError(err)
+
+	return code
+}
+
+func (t *Test) me(token string) entity.UserResponse {
+	response, err := t.executeGet(meURL, token)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	user := entity.UserResponse{}
+	t.Require().NoError(json.Unmarshal(response.Body.Bytes(), &user))
+
+	return user
+}
+
+func (t *Test) mailedToken(email string) string {
+	mail := t.mailbox.lastTo(email)
+	t.Require().NotNil(mail)
+
+	match := regexp.MustCompile(`https?://\S+`).FindString(mail.Body)
+	link, err := url.Parse(match)
+	t.Require().NoError(err)
+
+	return link.Query().Get("token")
+}
+
+func (t *Test) signupAndLogin() string {
+	return t.signupAndLoginResponse().Token
+}
+
+func (t *Test) signupAndLoginResponse() entity.UserLoginResponse {
+	randomString := util.RandomString(6)
+	email := randomString + "@email.com"
+	signUpReq := entity.UserSignupRequest{
+		Email:     email,
+		Password:  "password",
+		Name:      "Integration Test",
+		BirthDate: time.Now().Format("2006-01-02"),
+		Gender:    "MALE",
+		Location:  "Indonesia",
+	}
+	response, err := t.executePost(signupURL, signUpReq)
+	t.Require().Equal(http.StatusCreated, response.Code)
+	t.Require().NoError(err)
+
+	loginReq := entity.UserLoginRequest{
+		Email:    signUpReq.Email,
+		Password: signUpReq.Password,
+	}
+	response, err = t.executePost(loginURL, loginReq)
+	t.Require().Equal(http.StatusOK, response.Code)
+	t.Require().NoError(err)
+
+	loginResp := entity.UserLoginResponse{}
+	err = json.Unmarshal(response.Body.Bytes(), &loginResp)
+	t.Require().NoError(err)
+
+	return loginResp
+}